@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"BanglaCode/src/Update"
+	"BanglaCode/src/checker"
 	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
@@ -44,6 +45,15 @@ func main() {
 		return
 	}
 
+	if os.Args[1] == "check" {
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: banglacode check <file>...")
+			os.Exit(1)
+		}
+		checkFiles(os.Args[2:])
+		return
+	}
+
 	// Execute file
 	filename := os.Args[1]
 	runFile(filename)
@@ -61,6 +71,7 @@ func printHelp() {
 	fmt.Println("\033[1;33m▸ Usage:\033[0m")
 	fmt.Println("  \033[1;32mbanglacode\033[0m                  Start interactive REPL")
 	fmt.Println("  \033[1;32mbanglacode <file>\033[0m           Execute a BanglaCode file")
+	fmt.Println("  \033[1;32mbanglacode check <file>\033[0m     Type-check annotated BanglaCode files")
 	fmt.Println("  \033[1;32mbanglacode update\033[0m           Update to the latest version")
 	fmt.Println("  \033[1;32mbanglacode --help, -h\033[0m       Show this help message")
 	fmt.Println("  \033[1;32mbanglacode --version, -v\033[0m    Show version information")
@@ -73,6 +84,7 @@ func printHelp() {
 	fmt.Println("  \033[0;34m$\033[0m banglacode hello.bang       \033[2m# Run hello.bang file\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode app.bangla       \033[2m# Run app.bangla file\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode server.bong      \033[2m# Run server.bong file\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode check app.bang   \033[2m# Type-check app.bang\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode update           \033[2m# Update to latest version\033[0m")
 	fmt.Println("")
 	fmt.Println("\033[1;36m╚══════════════════════════════════════════════════════════════════╝\033[0m")
@@ -137,4 +149,49 @@ func runFile(filename string) {
 		fmt.Fprintf(os.Stderr, "\033[31m%s\033[0m\n", result.Inspect())
 		os.Exit(1)
 	}
+	if exc, ok := result.(*object.Exception); ok && exc.Fatal {
+		fmt.Fprintf(os.Stderr, "\033[31mUncaught %s\033[0m\n", exc.Message)
+		os.Exit(1)
+	}
+
+	// Servers run in the background; keep serving until the script closes them
+	builtins.WaitForServers()
+}
+
+// checkFiles runs the static type checker over each file without executing it
+func checkFiles(filenames []string) {
+	failed := false
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			failed = true
+			continue
+		}
+
+		p := parser.New(lexer.New(string(content)))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			fmt.Fprintf(os.Stderr, "\033[31mParser errors in %s:\033[0m\n", filename)
+			for _, msg := range p.Errors() {
+				fmt.Fprintf(os.Stderr, "\t%s\n", msg)
+			}
+			failed = true
+			continue
+		}
+
+		for _, d := range checker.Check(program) {
+			if d.Warning {
+				fmt.Fprintf(os.Stderr, "\033[33m%s:%d:%d: warning: %s\033[0m\n", filename, d.Line, d.Column, d.Message)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "\033[31m%s:%d:%d: %s\033[0m\n", filename, d.Line, d.Column, d.Message)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Println("\033[32m✓ No type errors found\033[0m")
 }
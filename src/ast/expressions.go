@@ -12,6 +12,7 @@ import (
 type Identifier struct {
 	Token lexer.Token
 	Value string
+	Type  *TypeAnnotation // optional annotation on declarations and parameters
 }
 
 func (i *Identifier) expressionNode()      {}
@@ -189,3 +190,19 @@ func (ye *YieldExpression) String() string {
 	}
 	return out.String()
 }
+
+// SequenceExpression represents the comma operator in ghuriye headers: i = i + 1, j = j - 1
+type SequenceExpression struct {
+	Token       lexer.Token // the first ',' token
+	Expressions []Expression
+}
+
+func (se *SequenceExpression) expressionNode()      {}
+func (se *SequenceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SequenceExpression) String() string {
+	parts := make([]string, len(se.Expressions))
+	for i, e := range se.Expressions {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ", ")
+}
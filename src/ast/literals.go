@@ -79,8 +79,10 @@ func (al *ArrayLiteral) String() string {
 
 // MapLiteral represents {"key": "value"}
 type MapLiteral struct {
-	Token lexer.Token // the '{' token
-	Pairs map[Expression]Expression
+	Token   lexer.Token // the '{' token
+	Pairs   map[Expression]Expression
+	Getters map[string]*FunctionLiteral // pao name() { ... }
+	Setters map[string]*FunctionLiteral // set name(v) { ... }
 }
 
 func (ml *MapLiteral) expressionNode()      {}
@@ -91,6 +93,12 @@ func (ml *MapLiteral) String() string {
 	for key, value := range ml.Pairs {
 		pairs = append(pairs, key.String()+":"+value.String())
 	}
+	for name, fn := range ml.Getters {
+		pairs = append(pairs, "pao "+name+"() "+fn.Body.String())
+	}
+	for name, fn := range ml.Setters {
+		pairs = append(pairs, "set "+name+"("+fn.Parameters[0].String()+") "+fn.Body.String())
+	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
 	out.WriteString("}")
@@ -104,7 +112,8 @@ type FunctionLiteral struct {
 	Parameters    []*Identifier
 	RestParameter *Identifier // optional rest parameter (...args)
 	Body          *BlockStatement
-	IsGenerator   bool // true if generator function (kaj* or has yield)
+	IsGenerator   bool            // true if generator function (kaj* or has yield)
+	ReturnType    *TypeAnnotation // optional return annotation: kaj f(): number
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
@@ -113,10 +122,10 @@ func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 	params := []string{}
 	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+		params = append(params, annotatedName(p))
 	}
 	if fl.RestParameter != nil {
-		params = append(params, "..."+fl.RestParameter.String())
+		params = append(params, "..."+annotatedName(fl.RestParameter))
 	}
 	// Constructor shuru() is output without kaj prefix
 	if fl.Name != nil && fl.Name.Value == "shuru" {
@@ -133,7 +142,11 @@ func (fl *FunctionLiteral) String() string {
 		out.WriteString("(")
 	}
 	out.WriteString(strings.Join(params, ", "))
-	out.WriteString(") ")
+	out.WriteString(")")
+	if fl.ReturnType != nil {
+		out.WriteString(": " + fl.ReturnType.String())
+	}
+	out.WriteString(" ")
 	out.WriteString(fl.Body.String())
 	return out.String()
 }
@@ -145,6 +158,7 @@ type AsyncFunctionLiteral struct {
 	Parameters    []*Identifier
 	RestParameter *Identifier // optional rest parameter (...args)
 	Body          *BlockStatement
+	ReturnType    *TypeAnnotation // optional return annotation
 }
 
 func (afl *AsyncFunctionLiteral) expressionNode()      {}
@@ -153,10 +167,10 @@ func (afl *AsyncFunctionLiteral) String() string {
 	var out bytes.Buffer
 	params := []string{}
 	for _, p := range afl.Parameters {
-		params = append(params, p.String())
+		params = append(params, annotatedName(p))
 	}
 	if afl.RestParameter != nil {
-		params = append(params, "..."+afl.RestParameter.String())
+		params = append(params, "..."+annotatedName(afl.RestParameter))
 	}
 	out.WriteString("proyash kaj")
 	if afl.Name != nil {
@@ -164,7 +178,11 @@ func (afl *AsyncFunctionLiteral) String() string {
 	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
-	out.WriteString(") ")
+	out.WriteString(")")
+	if afl.ReturnType != nil {
+		out.WriteString(": " + afl.ReturnType.String())
+	}
+	out.WriteString(" ")
 	out.WriteString(afl.Body.String())
 	return out.String()
 }
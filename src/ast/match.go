@@ -0,0 +1,185 @@
+package ast
+
+import (
+	"BanglaCode/src/lexer"
+	"bytes"
+	"strings"
+)
+
+// ==================== Pattern Matching ====================
+
+// MatchExpression represents: milao (value) { khetre pattern jodi (guard) => result, ... }
+type MatchExpression struct {
+	Token   lexer.Token // the MILAO token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("milao (")
+	out.WriteString(me.Subject.String())
+	out.WriteString(") { ")
+	arms := make([]string, len(me.Arms))
+	for i, arm := range me.Arms {
+		arms[i] = arm.String()
+	}
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString(" }")
+	return out.String()
+}
+
+// MatchArm is one "khetre pattern [jodi (guard)] => result" arm.
+// Exactly one of Result and Block is set.
+type MatchArm struct {
+	Token   lexer.Token // the KHETRE token
+	Pattern Pattern
+	Guard   Expression
+	Result  Expression
+	Block   *BlockStatement
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+	out.WriteString("khetre ")
+	out.WriteString(ma.Pattern.String())
+	if ma.Guard != nil {
+		out.WriteString(" jodi (" + ma.Guard.String() + ")")
+	}
+	out.WriteString(" => ")
+	if ma.Block != nil {
+		out.WriteString(ma.Block.String())
+	} else {
+		out.WriteString(ma.Result.String())
+	}
+	return out.String()
+}
+
+// Pattern is a node that can appear after khetre in a milao expression
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// WildcardPattern matches anything without binding: _
+type WildcardPattern struct {
+	Token lexer.Token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) String() string       { return "_" }
+
+// BindingPattern matches anything and binds it to a name: x
+type BindingPattern struct {
+	Name *Identifier
+}
+
+func (bp *BindingPattern) patternNode()         {}
+func (bp *BindingPattern) TokenLiteral() string { return bp.Name.Token.Literal }
+func (bp *BindingPattern) String() string       { return bp.Name.Value }
+
+// LiteralPattern matches a number, string, boolean or khali by equality
+type LiteralPattern struct {
+	Token lexer.Token
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// ArrayPattern matches arrays element-wise: [a, 0, ...rest]
+type ArrayPattern struct {
+	Token    lexer.Token // the '[' token
+	Elements []Pattern
+	Rest     *Identifier // binds remaining elements; nil without a rest element
+	HasRest  bool        // true for both ...rest and a bare ...
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) String() string {
+	parts := make([]string, 0, len(ap.Elements)+1)
+	for _, el := range ap.Elements {
+		parts = append(parts, el.String())
+	}
+	if ap.HasRest {
+		rest := "..."
+		if ap.Rest != nil {
+			rest += ap.Rest.Value
+		}
+		parts = append(parts, rest)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// MapPattern matches maps (and instance properties) by key: {status: 200, body}
+type MapPattern struct {
+	Token  lexer.Token // the '{' token
+	Keys   []string
+	Values []Pattern
+	Rest   *Identifier // binds the unmatched keys as a new map
+}
+
+func (mp *MapPattern) patternNode()         {}
+func (mp *MapPattern) TokenLiteral() string { return mp.Token.Literal }
+func (mp *MapPattern) String() string {
+	parts := make([]string, 0, len(mp.Keys)+1)
+	for i, key := range mp.Keys {
+		if bp, ok := mp.Values[i].(*BindingPattern); ok && bp.Name.Value == key {
+			parts = append(parts, key)
+			continue
+		}
+		parts = append(parts, key+": "+mp.Values[i].String())
+	}
+	if mp.Rest != nil {
+		parts = append(parts, "..."+mp.Rest.Value)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// ClassPattern matches instances of a sreni, optionally destructuring properties: Person {naam}
+type ClassPattern struct {
+	Token  lexer.Token // the class name token
+	Class  *Identifier
+	Fields *MapPattern // nil for a bare type test
+}
+
+func (cp *ClassPattern) patternNode()         {}
+func (cp *ClassPattern) TokenLiteral() string { return cp.Token.Literal }
+func (cp *ClassPattern) String() string {
+	if cp.Fields == nil {
+		return cp.Class.Value + " {}"
+	}
+	return cp.Class.Value + " " + cp.Fields.String()
+}
+
+// OrPattern matches if any alternative matches: 1 | 2 | 3
+type OrPattern struct {
+	Token        lexer.Token
+	Alternatives []Pattern
+}
+
+func (op *OrPattern) patternNode()         {}
+func (op *OrPattern) TokenLiteral() string { return op.Token.Literal }
+func (op *OrPattern) String() string {
+	parts := make([]string, len(op.Alternatives))
+	for i, alt := range op.Alternatives {
+		parts[i] = alt.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
+// AsPattern binds the whole matched value after matching an inner pattern: Person {} hisabe p
+type AsPattern struct {
+	Token   lexer.Token // the HISABE token
+	Pattern Pattern
+	Name    *Identifier
+}
+
+func (ap *AsPattern) patternNode()         {}
+func (ap *AsPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *AsPattern) String() string       { return ap.Pattern.String() + " hisabe " + ap.Name.Value }
@@ -3,6 +3,7 @@ package ast
 import (
 	"BanglaCode/src/lexer"
 	"bytes"
+	"strings"
 )
 
 // ==================== Statement Nodes ====================
@@ -27,7 +28,7 @@ func (vd *VariableDeclaration) String() string {
 	} else {
 		out.WriteString("dhoro ")
 	}
-	out.WriteString(vd.Name.String())
+	out.WriteString(annotatedName(vd.Name))
 	out.WriteString(" = ")
 	if vd.Value != nil {
 		out.WriteString(vd.Value.String())
@@ -164,6 +165,7 @@ type ClassDeclaration struct {
 	Getters          map[string]*FunctionLiteral // getters: pao prop() { }
 	Setters          map[string]*FunctionLiteral // setters: set prop(val) { }
 	StaticProperties map[string]Expression       // static properties: sthir prop = value
+	Fields           []*FieldDeclaration         // annotated fields: naam: string;
 }
 
 func (cd *ClassDeclaration) statementNode()       {}
@@ -173,6 +175,9 @@ func (cd *ClassDeclaration) String() string {
 	out.WriteString("sreni ")
 	out.WriteString(cd.Name.String())
 	out.WriteString(" { ")
+	for _, field := range cd.Fields {
+		out.WriteString(field.String())
+	}
 	for _, method := range cd.Methods {
 		out.WriteString(method.String())
 	}
@@ -180,23 +185,65 @@ func (cd *ClassDeclaration) String() string {
 	return out.String()
 }
 
-// BreakStatement represents: thamo;
+// BreakStatement represents: thamo; or thamo label;
 type BreakStatement struct {
 	Token lexer.Token // the THAMO token
+	Label *Identifier // optional target label
 }
 
 func (bs *BreakStatement) statementNode()       {}
 func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
-func (bs *BreakStatement) String() string       { return "thamo;" }
+func (bs *BreakStatement) String() string {
+	if bs.Label != nil {
+		return "thamo " + bs.Label.Value + ";"
+	}
+	return "thamo;"
+}
 
-// ContinueStatement represents: chharo;
+// ContinueStatement represents: chharo; or chharo label;
 type ContinueStatement struct {
 	Token lexer.Token // the CHHARO token
+	Label *Identifier // optional target label
 }
 
 func (cs *ContinueStatement) statementNode()       {}
 func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
-func (cs *ContinueStatement) String() string       { return "chharo;" }
+func (cs *ContinueStatement) String() string {
+	if cs.Label != nil {
+		return "chharo " + cs.Label.Value + ";"
+	}
+	return "chharo;"
+}
+
+// LabeledStatement represents: outer: ghuriye (...) { ... }
+type LabeledStatement struct {
+	Token lexer.Token // the label token
+	Label *Identifier
+	Body  Statement
+}
+
+func (ls *LabeledStatement) statementNode()       {}
+func (ls *LabeledStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *LabeledStatement) String() string       { return ls.Label.Value + ": " + ls.Body.String() }
+
+// DeclarationList represents several declarators in one statement: dhoro i = 0, j = 10
+type DeclarationList struct {
+	Token        lexer.Token // the DHORO/STHIR/BISHWO token
+	Declarations []*VariableDeclaration
+}
+
+func (dl *DeclarationList) statementNode()       {}
+func (dl *DeclarationList) TokenLiteral() string { return dl.Token.Literal }
+func (dl *DeclarationList) String() string {
+	parts := make([]string, len(dl.Declarations))
+	for i, decl := range dl.Declarations {
+		parts[i] = strings.TrimSuffix(decl.String(), ";")
+		if i > 0 {
+			parts[i] = strings.TrimPrefix(parts[i], dl.Token.Literal+" ")
+		}
+	}
+	return strings.Join(parts, ", ") + ";"
+}
 
 // ImportStatement represents: ano "module.bang" hisabe alias;
 type ImportStatement struct {
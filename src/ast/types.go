@@ -0,0 +1,53 @@
+package ast
+
+import (
+	"BanglaCode/src/lexer"
+	"strings"
+)
+
+// ==================== Type Annotations ====================
+
+// TypeAnnotation represents an optional static type: number, string[], Person | khali.
+// Annotations are ignored by the evaluator and only consumed by `banglacode check`.
+type TypeAnnotation struct {
+	Token lexer.Token       // first token of the annotation
+	Name  string            // named type (number, string, ..., or a class name)
+	Elem  *TypeAnnotation   // element type for array types: T[]
+	Union []*TypeAnnotation // alternatives for union types: A | B
+}
+
+func (ta *TypeAnnotation) String() string {
+	if len(ta.Union) > 0 {
+		parts := make([]string, len(ta.Union))
+		for i, alt := range ta.Union {
+			parts[i] = alt.String()
+		}
+		return strings.Join(parts, " | ")
+	}
+	if ta.Elem != nil {
+		if len(ta.Elem.Union) > 0 {
+			return "(" + ta.Elem.String() + ")[]"
+		}
+		return ta.Elem.String() + "[]"
+	}
+	return ta.Name
+}
+
+// FieldDeclaration represents an annotated class field: naam: string;
+type FieldDeclaration struct {
+	Token lexer.Token // the field name token
+	Name  *Identifier
+	Type  *TypeAnnotation
+}
+
+func (fd *FieldDeclaration) String() string {
+	return fd.Name.Value + ": " + fd.Type.String() + ";"
+}
+
+// annotatedName renders a parameter or variable name with its optional annotation
+func annotatedName(ident *Identifier) string {
+	if ident.Type != nil {
+		return ident.Value + ": " + ident.Type.String()
+	}
+	return ident.Value
+}
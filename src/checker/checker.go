@@ -0,0 +1,466 @@
+// Package checker implements `banglacode check`: an optional static type checker
+// driven by type annotations on dhoro, kaj parameters/returns and sreni fields.
+// Unannotated code is inferred locally and treated as any wherever inference
+// runs out, so untyped programs check cleanly.
+package checker
+
+import (
+	"BanglaCode/src/ast"
+	"fmt"
+	"sort"
+)
+
+// Diagnostic is a type error (or warning) reported at a source position
+type Diagnostic struct {
+	Line    int
+	Column  int
+	Message string
+	Warning bool // warnings are reported but do not fail the check
+}
+
+func (d Diagnostic) String() string {
+	if d.Warning {
+		return fmt.Sprintf("line %d, column %d: warning: %s", d.Line, d.Column, d.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", d.Line, d.Column, d.Message)
+}
+
+// classInfo holds the static shape of a sreni declaration
+type classInfo struct {
+	name    string
+	fields  map[string]*Type
+	getters map[string]*Type
+	methods map[string]*Signature
+	ctor    *Signature
+}
+
+// variable is a binding in a checker scope. Only annotated bindings constrain assignments;
+// unannotated ones widen to include every value assigned to them.
+type variable struct {
+	typ       *Type
+	annotated bool
+}
+
+type scope struct {
+	vars   map[string]*variable
+	parent *scope
+}
+
+func (s *scope) lookup(name string) *variable {
+	for cur := s; cur != nil; cur = cur.parent {
+		if v, ok := cur.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// funcContext tracks the declared result of the function being checked
+type funcContext struct {
+	ret *Type // declared return type (resolved value type for async functions), nil if unannotated
+}
+
+// Checker walks a program and collects type diagnostics
+type Checker struct {
+	diagnostics []Diagnostic
+	classes     map[string]*classInfo
+	builtins    map[string][]*Signature
+	scope       *scope
+	fn          *funcContext
+	self        *Type // type of 'ei' inside class bodies
+}
+
+// New creates a checker that knows the shipped builtin declaration stubs
+func New() *Checker {
+	return &Checker{
+		classes:  make(map[string]*classInfo),
+		builtins: BuiltinStubs(),
+		scope:    &scope{vars: make(map[string]*variable)},
+	}
+}
+
+// Check type-checks a parsed program and returns diagnostics sorted by position
+func Check(program *ast.Program) []Diagnostic {
+	return New().CheckProgram(program)
+}
+
+// CheckProgram type-checks program with this checker
+func (c *Checker) CheckProgram(program *ast.Program) []Diagnostic {
+	c.checkStatements(program.Statements)
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		a, b := c.diagnostics[i], c.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return c.diagnostics
+}
+
+func (c *Checker) errorf(line, column int, format string, args ...interface{}) {
+	c.report(Diagnostic{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *Checker) warnf(line, column int, format string, args ...interface{}) {
+	c.report(Diagnostic{Line: line, Column: column, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+func (c *Checker) report(d Diagnostic) {
+	for _, existing := range c.diagnostics {
+		if existing == d {
+			return // annotations are resolved both when hoisting and when checking bodies
+		}
+	}
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+func (c *Checker) pushScope() {
+	c.scope = &scope{vars: make(map[string]*variable), parent: c.scope}
+}
+
+func (c *Checker) popScope() {
+	c.scope = c.scope.parent
+}
+
+func (c *Checker) declare(name string, typ *Type, annotated bool) {
+	c.scope.vars[name] = &variable{typ: typ, annotated: annotated}
+}
+
+// resolveType converts an annotation to a checker type, reporting unknown names
+func (c *Checker) resolveType(ta *ast.TypeAnnotation) *Type {
+	if ta == nil {
+		return Any
+	}
+	if len(ta.Union) > 0 {
+		alts := make([]*Type, len(ta.Union))
+		for i, alt := range ta.Union {
+			alts[i] = c.resolveType(alt)
+		}
+		return UnionOf(alts...)
+	}
+	if ta.Elem != nil {
+		return ArrayOf(c.resolveType(ta.Elem))
+	}
+	if t, ok := primitiveNames[ta.Name]; ok {
+		return t
+	}
+	if _, ok := c.classes[ta.Name]; ok {
+		return InstanceOf(ta.Name)
+	}
+	c.errorf(ta.Token.Line, ta.Token.Column, "unknown type '%s'", ta.Name)
+	return Any
+}
+
+// signatureOf builds a signature from (possibly partial) annotations
+func (c *Checker) signatureOf(params []*ast.Identifier, rest *ast.Identifier, ret *ast.TypeAnnotation, async bool) *Signature {
+	sig := &Signature{Params: make([]*Type, len(params)), Return: Any}
+	for i, p := range params {
+		sig.Params[i] = c.resolveType(p.Type)
+	}
+	if rest != nil {
+		sig.Rest = Any
+		if rest.Type != nil {
+			if restType := c.resolveType(rest.Type); restType.Kind == KindArray {
+				sig.Rest = restType.Elem
+			} else if restType.Kind != KindAny {
+				c.errorf(rest.Token.Line, rest.Token.Column, "rest parameter '%s' must have an array type, got %s", rest.Value, restType)
+			}
+		}
+	}
+	if ret != nil {
+		sig.Return = c.resolveType(ret)
+	}
+	if async {
+		sig.Return = PromiseOf(sig.Return)
+	}
+	return sig
+}
+
+// hoist declares classes and named functions of a statement list before checking it,
+// so calls may precede declarations as they can at runtime
+func (c *Checker) hoist(stmts []ast.Statement) {
+	var classes []*ast.ClassDeclaration
+	for _, stmt := range stmts {
+		if exp, ok := stmt.(*ast.ExportStatement); ok {
+			stmt = exp.Statement
+		}
+		if cls, ok := stmt.(*ast.ClassDeclaration); ok && cls.Name != nil {
+			c.classes[cls.Name.Value] = &classInfo{name: cls.Name.Value}
+			classes = append(classes, cls)
+		}
+	}
+	for _, cls := range classes {
+		c.collectClass(cls)
+	}
+
+	for _, stmt := range stmts {
+		if exp, ok := stmt.(*ast.ExportStatement); ok {
+			stmt = exp.Statement
+		}
+		es, ok := stmt.(*ast.ExpressionStatement)
+		if !ok {
+			continue
+		}
+		switch fn := es.Expression.(type) {
+		case *ast.FunctionLiteral:
+			if fn.Name != nil {
+				c.declare(fn.Name.Value, FuncOf(c.signatureOf(fn.Parameters, fn.RestParameter, fn.ReturnType, false)), true)
+			}
+		case *ast.AsyncFunctionLiteral:
+			if fn.Name != nil {
+				c.declare(fn.Name.Value, FuncOf(c.signatureOf(fn.Parameters, fn.RestParameter, fn.ReturnType, true)), true)
+			}
+		}
+	}
+}
+
+// collectClass records the fields, accessors, methods and constructor of a class
+func (c *Checker) collectClass(cls *ast.ClassDeclaration) {
+	info := c.classes[cls.Name.Value]
+	info.fields = make(map[string]*Type)
+	info.getters = make(map[string]*Type)
+	info.methods = make(map[string]*Signature)
+
+	for _, field := range cls.Fields {
+		info.fields[field.Name.Value] = c.resolveType(field.Type)
+	}
+	for name, getter := range cls.Getters {
+		info.getters[name] = c.resolveType(getter.ReturnType)
+	}
+	for _, method := range cls.Methods {
+		if method.Name == nil {
+			continue
+		}
+		sig := c.signatureOf(method.Parameters, method.RestParameter, method.ReturnType, false)
+		if method.Name.Value == "shuru" {
+			info.ctor = sig
+			continue
+		}
+		info.methods[method.Name.Value] = sig
+	}
+}
+
+// memberType returns the static type of a property on a class instance
+func (info *classInfo) memberType(name string) (*Type, bool) {
+	if t, ok := info.fields[name]; ok {
+		return t, true
+	}
+	if t, ok := info.getters[name]; ok {
+		return t, true
+	}
+	if sig, ok := info.methods[name]; ok {
+		return FuncOf(sig), true
+	}
+	return nil, false
+}
+
+func (c *Checker) checkStatements(stmts []ast.Statement) {
+	c.hoist(stmts)
+	for _, stmt := range stmts {
+		c.checkStatement(stmt)
+	}
+}
+
+func (c *Checker) checkBlock(block *ast.BlockStatement) {
+	if block == nil {
+		return
+	}
+	c.pushScope()
+	c.checkStatements(block.Statements)
+	c.popScope()
+}
+
+func (c *Checker) checkStatement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		c.checkVariableDeclaration(s)
+	case *ast.ExpressionStatement:
+		if s.Expression != nil {
+			c.infer(s.Expression)
+		}
+	case *ast.ReturnStatement:
+		c.checkReturn(s)
+	case *ast.BlockStatement:
+		c.checkBlock(s)
+	case *ast.IfStatement:
+		c.infer(s.Condition)
+		c.checkBlock(s.Consequence)
+		c.checkBlock(s.Alternative)
+	case *ast.WhileStatement:
+		c.infer(s.Condition)
+		c.checkBlock(s.Body)
+	case *ast.DoWhileStatement:
+		c.checkBlock(s.Body)
+		c.infer(s.Condition)
+	case *ast.ForStatement:
+		c.pushScope()
+		if s.Init != nil {
+			c.checkStatement(s.Init)
+		}
+		if s.Condition != nil {
+			c.infer(s.Condition)
+		}
+		if s.Update != nil {
+			c.infer(s.Update)
+		}
+		c.checkBlock(s.Body)
+		c.popScope()
+	case *ast.ForOfStatement:
+		c.checkForOf(s)
+	case *ast.ForInStatement:
+		c.infer(s.Object)
+		c.pushScope()
+		c.declare(s.VarName.Value, String, false)
+		c.checkBlock(s.Body)
+		c.popScope()
+	case *ast.SwitchStatement:
+		c.infer(s.Expr)
+		for _, cc := range s.Cases {
+			c.infer(cc.Value)
+			c.checkBlock(cc.Body)
+		}
+		c.checkBlock(s.Default)
+	case *ast.TryCatchStatement:
+		c.checkBlock(s.TryBlock)
+		if s.CatchBlock != nil {
+			c.pushScope()
+			if s.CatchParam != nil {
+				c.declare(s.CatchParam.Value, Any, false)
+			}
+			c.checkBlock(s.CatchBlock)
+			c.popScope()
+		}
+		c.checkBlock(s.FinallyBlock)
+	case *ast.ThrowStatement:
+		c.infer(s.Value)
+	case *ast.ExportStatement:
+		c.checkStatement(s.Statement)
+	case *ast.ClassDeclaration:
+		c.checkClass(s)
+	case *ast.ArrayDestructuringDeclaration, *ast.ObjectDestructuringDeclaration:
+		c.checkDestructuring(s)
+	case *ast.DeclarationList:
+		for _, decl := range s.Declarations {
+			c.checkVariableDeclaration(decl)
+		}
+	case *ast.LabeledStatement:
+		c.checkStatement(s.Body)
+	}
+}
+
+func (c *Checker) checkVariableDeclaration(s *ast.VariableDeclaration) {
+	valueType := c.infer(s.Value)
+	if s.Name.Type == nil {
+		if valueType.Kind == KindNull {
+			valueType = Any // khali placeholders are filled in later
+		}
+		c.declare(s.Name.Value, valueType, false)
+		return
+	}
+
+	declared := c.resolveType(s.Name.Type)
+	if !AssignableTo(valueType, declared) {
+		line, col := position(s.Value, s.Name.Token)
+		c.errorf(line, col, "cannot assign %s to '%s' of type %s", valueType, s.Name.Value, declared)
+	}
+	c.declare(s.Name.Value, declared, true)
+}
+
+func (c *Checker) checkReturn(s *ast.ReturnStatement) {
+	valueType := Null
+	if s.ReturnValue != nil {
+		valueType = c.infer(s.ReturnValue)
+	}
+	if c.fn == nil || c.fn.ret == nil {
+		return
+	}
+	if !AssignableTo(valueType, c.fn.ret) {
+		line, col := position(s.ReturnValue, s.Token)
+		c.errorf(line, col, "cannot return %s from a function declared to return %s", valueType, c.fn.ret)
+	}
+}
+
+func (c *Checker) checkForOf(s *ast.ForOfStatement) {
+	iterable := c.infer(s.Iterable)
+	elem := Any
+	switch iterable.Kind {
+	case KindArray:
+		elem = iterable.Elem
+	case KindString:
+		elem = String
+	}
+	c.pushScope()
+	c.declare(s.VarName.Value, elem, false)
+	c.checkBlock(s.Body)
+	c.popScope()
+}
+
+// checkDestructuring binds destructured names as any after checking the source value
+func (c *Checker) checkDestructuring(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.ArrayDestructuringDeclaration:
+		c.infer(s.Source)
+		for _, name := range s.Names {
+			c.declare(name.Value, Any, false)
+		}
+	case *ast.ObjectDestructuringDeclaration:
+		c.infer(s.Source)
+		for _, name := range s.Names {
+			c.declare(name.Value, Any, false)
+		}
+	}
+}
+
+func (c *Checker) checkClass(cls *ast.ClassDeclaration) {
+	if cls.Name == nil {
+		return
+	}
+	if _, ok := c.classes[cls.Name.Value]; !ok {
+		c.classes[cls.Name.Value] = &classInfo{name: cls.Name.Value}
+		c.collectClass(cls)
+	}
+
+	prevSelf := c.self
+	c.self = InstanceOf(cls.Name.Value)
+	for _, value := range cls.StaticProperties {
+		c.infer(value)
+	}
+	for _, method := range cls.Methods {
+		c.checkFunctionBody(method.Parameters, method.RestParameter, method.ReturnType, method.Body)
+	}
+	for _, getter := range cls.Getters {
+		c.checkFunctionBody(nil, nil, getter.ReturnType, getter.Body)
+	}
+	for _, setter := range cls.Setters {
+		c.checkFunctionBody(setter.Parameters, nil, nil, setter.Body)
+	}
+	c.self = prevSelf
+}
+
+// checkFunctionBody checks a function body in a fresh scope holding its parameters
+func (c *Checker) checkFunctionBody(params []*ast.Identifier, rest *ast.Identifier, ret *ast.TypeAnnotation, body *ast.BlockStatement) {
+	prevFn := c.fn
+	c.pushScope()
+
+	for _, p := range params {
+		c.declare(p.Value, c.resolveType(p.Type), p.Type != nil)
+	}
+	if rest != nil {
+		restType := ArrayOf(Any)
+		if rest.Type != nil {
+			restType = c.resolveType(rest.Type)
+		}
+		c.declare(rest.Value, restType, rest.Type != nil)
+	}
+
+	c.fn = &funcContext{}
+	if ret != nil {
+		c.fn.ret = c.resolveType(ret)
+	}
+	if body != nil {
+		c.checkStatements(body.Statements)
+	}
+
+	c.popScope()
+	c.fn = prevFn
+}
@@ -0,0 +1,414 @@
+package checker
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"strings"
+)
+
+// infer returns the static type of an expression, reporting errors found inside it
+func (c *Checker) infer(expr ast.Expression) *Type {
+	switch e := expr.(type) {
+	case nil:
+		return Any
+	case *ast.NumberLiteral:
+		return Number
+	case *ast.StringLiteral, *ast.TemplateLiteral:
+		return String
+	case *ast.BooleanLiteral:
+		return Boolean
+	case *ast.NullLiteral:
+		return Null
+	case *ast.Identifier:
+		return c.inferIdentifier(e)
+	case *ast.ArrayLiteral:
+		return c.inferArray(e)
+	case *ast.MapLiteral:
+		for key, value := range e.Pairs {
+			if _, ok := key.(*ast.Identifier); !ok {
+				c.infer(key)
+			}
+			c.infer(value)
+		}
+		for _, getter := range e.Getters {
+			c.checkFunctionBody(nil, nil, getter.ReturnType, getter.Body)
+		}
+		for _, setter := range e.Setters {
+			c.checkFunctionBody(setter.Parameters, nil, nil, setter.Body)
+		}
+		return AnyMap
+	case *ast.FunctionLiteral:
+		c.checkFunctionBody(e.Parameters, e.RestParameter, e.ReturnType, e.Body)
+		return FuncOf(c.signatureOf(e.Parameters, e.RestParameter, e.ReturnType, false))
+	case *ast.AsyncFunctionLiteral:
+		c.checkFunctionBody(e.Parameters, e.RestParameter, e.ReturnType, e.Body)
+		return FuncOf(c.signatureOf(e.Parameters, e.RestParameter, e.ReturnType, true))
+	case *ast.UnaryExpression:
+		return c.inferUnary(e)
+	case *ast.BinaryExpression:
+		return c.inferBinary(e)
+	case *ast.AssignmentExpression:
+		return c.inferAssignment(e)
+	case *ast.CallExpression:
+		return c.inferCall(e)
+	case *ast.MemberExpression:
+		return c.inferMember(e)
+	case *ast.NewExpression:
+		return c.inferNew(e)
+	case *ast.AwaitExpression:
+		t := c.infer(e.Expression)
+		if t.Kind == KindPromise {
+			return t.Elem
+		}
+		return t
+	case *ast.SpreadElement:
+		return c.infer(e.Argument)
+	case *ast.YieldExpression:
+		c.infer(e.Expression)
+		return Any
+	case *ast.DeleteExpression:
+		return Boolean
+	case *ast.MatchExpression:
+		return c.inferMatch(e)
+	case *ast.SequenceExpression:
+		last := Any
+		for _, expr := range e.Expressions {
+			last = c.infer(expr)
+		}
+		return last
+	}
+	return Any
+}
+
+func (c *Checker) inferIdentifier(ident *ast.Identifier) *Type {
+	if ident.Value == "ei" && c.self != nil {
+		return c.self
+	}
+	if v := c.scope.lookup(ident.Value); v != nil {
+		return v.typ
+	}
+	if overloads, ok := c.builtins[ident.Value]; ok && len(overloads) == 1 {
+		return FuncOf(overloads[0])
+	}
+	return Any
+}
+
+func (c *Checker) inferArray(arr *ast.ArrayLiteral) *Type {
+	if len(arr.Elements) == 0 {
+		return ArrayOf(Any)
+	}
+	elems := make([]*Type, 0, len(arr.Elements))
+	for _, el := range arr.Elements {
+		t := c.infer(el)
+		if _, spread := el.(*ast.SpreadElement); spread {
+			if t.Kind != KindArray {
+				return ArrayOf(Any)
+			}
+			t = t.Elem
+		}
+		elems = append(elems, t)
+	}
+	return ArrayOf(UnionOf(elems...))
+}
+
+func (c *Checker) inferUnary(e *ast.UnaryExpression) *Type {
+	operand := c.infer(e.Right)
+	switch e.Operator {
+	case "-":
+		if !operand.Includes(KindNumber) {
+			c.errorf(e.Token.Line, e.Token.Column, "operator '-' cannot be applied to %s", operand)
+		}
+		return Number
+	case "!", "na":
+		return Boolean
+	}
+	return Any
+}
+
+// inferBinary mirrors the evaluator's operand rules: arithmetic needs numbers,
+// '+' also joins strings (string + number is allowed, number + string is not)
+func (c *Checker) inferBinary(e *ast.BinaryExpression) *Type {
+	left := c.infer(e.Left)
+	right := c.infer(e.Right)
+
+	switch e.Operator {
+	case "==", "!=", "soman", "osoman", "in", "instanceof", "ebong", "ba":
+		return Boolean
+	case "<", ">", "<=", ">=":
+		c.requireOperands(e, left, right, KindNumber)
+		return Boolean
+	case "-", "*", "/", "%", "**":
+		c.requireOperands(e, left, right, KindNumber)
+		return Number
+	case "+":
+		return c.inferPlus(e, left, right)
+	}
+	return Any
+}
+
+func (c *Checker) requireOperands(e *ast.BinaryExpression, left, right *Type, kind Kind) {
+	if !left.Includes(kind) || !right.Includes(kind) {
+		c.errorf(e.Token.Line, e.Token.Column, "operator '%s' cannot be applied to %s and %s", e.Operator, left, right)
+	}
+}
+
+func (c *Checker) inferPlus(e *ast.BinaryExpression, left, right *Type) *Type {
+	switch {
+	case left.Kind == KindNumber && right.Kind == KindNumber:
+		return Number
+	case left.Kind == KindString && (right.Includes(KindString) || right.Includes(KindNumber)):
+		return String
+	case left.Kind == KindAny || right.Kind == KindAny:
+		if left.Kind == KindString || right.Kind == KindString {
+			return String
+		}
+		return Any
+	case left.Kind == KindUnion || right.Kind == KindUnion:
+		return Any
+	}
+	c.errorf(e.Token.Line, e.Token.Column, "operator '+' cannot be applied to %s and %s", left, right)
+	return Any
+}
+
+func (c *Checker) inferAssignment(e *ast.AssignmentExpression) *Type {
+	value := c.infer(e.Value)
+	if e.Operator != "=" {
+		op := strings.TrimSuffix(e.Operator, "=")
+		binary := &ast.BinaryExpression{Token: e.Token, Left: e.Name, Operator: op, Right: e.Value}
+		value = c.inferBinary(binary)
+	}
+
+	switch target := e.Name.(type) {
+	case *ast.Identifier:
+		v := c.scope.lookup(target.Value)
+		if v == nil {
+			return value
+		}
+		if v.annotated {
+			if !AssignableTo(value, v.typ) {
+				line, col := position(e.Value, e.Token)
+				c.errorf(line, col, "cannot assign %s to '%s' of type %s", value, target.Value, v.typ)
+			}
+		} else {
+			v.typ = UnionOf(v.typ, value)
+		}
+	case *ast.MemberExpression:
+		objType := c.infer(target.Object)
+		if objType.Kind != KindInstance || target.Computed {
+			return value
+		}
+		prop, ok := target.Property.(*ast.Identifier)
+		if !ok {
+			return value
+		}
+		if declared, ok := c.classes[objType.Class].fields[prop.Value]; ok && !AssignableTo(value, declared) {
+			line, col := position(e.Value, e.Token)
+			c.errorf(line, col, "cannot assign %s to field '%s.%s' of type %s", value, objType.Class, prop.Value, declared)
+		}
+	}
+	return value
+}
+
+func (c *Checker) inferCall(e *ast.CallExpression) *Type {
+	args := make([]*Type, len(e.Arguments))
+	for i, arg := range e.Arguments {
+		args[i] = c.infer(arg)
+	}
+
+	// Builtins are checked against their declaration stubs unless shadowed
+	if ident, ok := e.Function.(*ast.Identifier); ok && c.scope.lookup(ident.Value) == nil {
+		if overloads, ok := c.builtins[ident.Value]; ok {
+			return c.checkBuiltinCall(ident.Value, overloads, e, args)
+		}
+	}
+
+	callee := c.infer(e.Function)
+	switch callee.Kind {
+	case KindFunction:
+		if callee.Sig == nil {
+			return Any
+		}
+		c.checkArguments(calleeName(e.Function), callee.Sig, e, args, true)
+		return callee.Sig.Return
+	case KindAny, KindUnion, KindMap:
+		return Any
+	}
+	c.errorf(e.Token.Line, e.Token.Column, "%s is not callable", callee)
+	return Any
+}
+
+// checkArguments validates arity and argument types; report=false only tests compatibility
+func (c *Checker) checkArguments(name string, sig *Signature, e *ast.CallExpression, args []*Type, report bool) bool {
+	for _, arg := range e.Arguments {
+		if _, spread := arg.(*ast.SpreadElement); spread {
+			return true // arity and positions are unknown with spread arguments
+		}
+	}
+
+	if len(args) < len(sig.Params) || (sig.Rest == nil && len(args) > len(sig.Params)) {
+		if report {
+			want := len(sig.Params)
+			if sig.Rest != nil {
+				c.errorf(e.Token.Line, e.Token.Column, "function '%s' expects at least %d argument(s) but got %d", name, want, len(args))
+			} else {
+				c.errorf(e.Token.Line, e.Token.Column, "function '%s' expects %d argument(s) but got %d", name, want, len(args))
+			}
+		}
+		return false
+	}
+
+	ok := true
+	for i, arg := range args {
+		expected := sig.Rest
+		if i < len(sig.Params) {
+			expected = sig.Params[i]
+		}
+		if AssignableTo(arg, expected) {
+			continue
+		}
+		ok = false
+		if report {
+			line, col := position(e.Arguments[i], e.Token)
+			c.errorf(line, col, "argument %d to '%s' must be %s, got %s", i+1, name, expected, arg)
+		}
+	}
+	return ok
+}
+
+func (c *Checker) checkBuiltinCall(name string, overloads []*Signature, e *ast.CallExpression, args []*Type) *Type {
+	if len(overloads) == 1 {
+		c.checkArguments(name, overloads[0], e, args, true)
+		return overloads[0].Return
+	}
+	for _, sig := range overloads {
+		if c.checkArguments(name, sig, e, args, false) {
+			return sig.Return
+		}
+	}
+
+	got := make([]string, len(args))
+	for i, arg := range args {
+		got[i] = arg.String()
+	}
+	candidates := make([]string, len(overloads))
+	for i, sig := range overloads {
+		candidates[i] = strings.TrimPrefix(sig.String(), "kaj")
+	}
+	c.errorf(e.Token.Line, e.Token.Column, "no signature of '%s' accepts (%s); expected one of %s",
+		name, strings.Join(got, ", "), strings.Join(candidates, ", "))
+	return Any
+}
+
+func (c *Checker) inferMember(e *ast.MemberExpression) *Type {
+	obj := c.infer(e.Object)
+	if e.Computed {
+		c.infer(e.Property)
+		switch obj.Kind {
+		case KindArray:
+			return obj.Elem
+		case KindString:
+			return String
+		}
+		return Any
+	}
+
+	prop, ok := e.Property.(*ast.Identifier)
+	if !ok {
+		return Any
+	}
+	switch obj.Kind {
+	case KindArray, KindString:
+		if prop.Value == "length" {
+			return Number
+		}
+	case KindInstance:
+		if info, ok := c.classes[obj.Class]; ok {
+			if t, found := info.memberType(prop.Value); found {
+				return t
+			}
+		}
+	case KindNumber, KindBoolean, KindNull:
+		c.errorf(prop.Token.Line, prop.Token.Column, "property '%s' does not exist on %s", prop.Value, obj)
+	}
+	return Any
+}
+
+func (c *Checker) inferNew(e *ast.NewExpression) *Type {
+	args := make([]*Type, len(e.Arguments))
+	for i, arg := range e.Arguments {
+		args[i] = c.infer(arg)
+	}
+	ident, ok := e.Class.(*ast.Identifier)
+	if !ok {
+		return Any
+	}
+	info, ok := c.classes[ident.Value]
+	if !ok {
+		return Any
+	}
+	if info.ctor != nil {
+		call := &ast.CallExpression{Token: e.Token, Function: ident, Arguments: e.Arguments}
+		c.checkArguments(ident.Value, info.ctor, call, args, true)
+	}
+	return InstanceOf(ident.Value)
+}
+
+// calleeName renders the callee of a call for diagnostics
+func calleeName(fn ast.Expression) string {
+	switch f := fn.(type) {
+	case *ast.Identifier:
+		return f.Value
+	case *ast.MemberExpression:
+		if prop, ok := f.Property.(*ast.Identifier); ok && !f.Computed {
+			return calleeName(f.Object) + "." + prop.Value
+		}
+	case *ast.FunctionLiteral:
+		if f.Name != nil {
+			return f.Name.Value
+		}
+	}
+	return "anonymous function"
+}
+
+// position returns the source position of an expression, falling back to tok
+func position(expr ast.Expression, tok lexer.Token) (int, int) {
+	var t lexer.Token
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		t = e.Token
+	case *ast.NumberLiteral:
+		t = e.Token
+	case *ast.StringLiteral:
+		t = e.Token
+	case *ast.TemplateLiteral:
+		t = e.Token
+	case *ast.BooleanLiteral:
+		t = e.Token
+	case *ast.NullLiteral:
+		t = e.Token
+	case *ast.ArrayLiteral:
+		t = e.Token
+	case *ast.MapLiteral:
+		t = e.Token
+	case *ast.FunctionLiteral:
+		t = e.Token
+	case *ast.NewExpression:
+		t = e.Token
+	case *ast.UnaryExpression:
+		t = e.Token
+	case *ast.BinaryExpression:
+		return position(e.Left, e.Token)
+	case *ast.CallExpression:
+		return position(e.Function, e.Token)
+	case *ast.MemberExpression:
+		return position(e.Object, e.Token)
+	case *ast.AwaitExpression:
+		t = e.Token
+	case *ast.MatchExpression:
+		t = e.Token
+	}
+	if t.Line == 0 {
+		return tok.Line, tok.Column
+	}
+	return t.Line, t.Column
+}
@@ -0,0 +1,203 @@
+package checker
+
+import (
+	"BanglaCode/src/ast"
+	"strings"
+)
+
+// inferMatch checks each milao arm in its own scope and returns the union of arm results
+func (c *Checker) inferMatch(e *ast.MatchExpression) *Type {
+	subject := c.infer(e.Subject)
+
+	results := make([]*Type, 0, len(e.Arms))
+	for _, arm := range e.Arms {
+		c.pushScope()
+		c.bindPattern(arm.Pattern, subject)
+		if arm.Guard != nil {
+			c.infer(arm.Guard)
+		}
+		if arm.Block != nil {
+			c.checkStatements(arm.Block.Statements)
+			results = append(results, Any)
+		} else {
+			results = append(results, c.infer(arm.Result))
+		}
+		c.popScope()
+	}
+
+	c.checkExhaustive(e, subject)
+	return UnionOf(results...)
+}
+
+// bindPattern declares the names bound by a pattern, narrowing types where the pattern allows
+func (c *Checker) bindPattern(pattern ast.Pattern, subject *Type) {
+	switch pat := pattern.(type) {
+	case *ast.BindingPattern:
+		c.declare(pat.Name.Value, subject, false)
+	case *ast.AsPattern:
+		c.bindPattern(pat.Pattern, subject)
+		c.declare(pat.Name.Value, narrowByPattern(pat.Pattern, subject), false)
+	case *ast.OrPattern:
+		for _, alt := range pat.Alternatives {
+			c.bindPattern(alt, Any)
+		}
+	case *ast.ArrayPattern:
+		elem := Any
+		if subject.Kind == KindArray {
+			elem = subject.Elem
+		}
+		for _, el := range pat.Elements {
+			c.bindPattern(el, elem)
+		}
+		if pat.Rest != nil {
+			c.declare(pat.Rest.Value, ArrayOf(elem), false)
+		}
+	case *ast.MapPattern:
+		for _, value := range pat.Values {
+			c.bindPattern(value, Any)
+		}
+		if pat.Rest != nil {
+			c.declare(pat.Rest.Value, AnyMap, false)
+		}
+	case *ast.ClassPattern:
+		info, ok := c.classes[pat.Class.Value]
+		if !ok {
+			if c.scope.lookup(pat.Class.Value) == nil {
+				c.errorf(pat.Token.Line, pat.Token.Column, "unknown sreni '%s' in pattern", pat.Class.Value)
+			}
+		}
+		if pat.Fields == nil {
+			return
+		}
+		for i, key := range pat.Fields.Keys {
+			fieldType := Any
+			if info != nil {
+				if t, found := info.memberType(key); found {
+					fieldType = t
+				}
+			}
+			c.bindPattern(pat.Fields.Values[i], fieldType)
+		}
+		if pat.Fields.Rest != nil {
+			c.declare(pat.Fields.Rest.Value, AnyMap, false)
+		}
+	}
+}
+
+// narrowByPattern returns the type of a value known to match pattern
+func narrowByPattern(pattern ast.Pattern, subject *Type) *Type {
+	switch pat := pattern.(type) {
+	case *ast.ClassPattern:
+		return InstanceOf(pat.Class.Value)
+	case *ast.ArrayPattern:
+		if subject.Kind == KindArray {
+			return subject
+		}
+		return ArrayOf(Any)
+	case *ast.MapPattern:
+		return AnyMap
+	}
+	return subject
+}
+
+// checkExhaustive warns about arms after a catch-all and about literal-only matches
+// that leave values unhandled
+func (c *Checker) checkExhaustive(e *ast.MatchExpression, subject *Type) {
+	covered := make(map[string]bool)
+	literalOnly := true
+	for i, arm := range e.Arms {
+		if arm.Guard == nil && isCatchAll(arm.Pattern) {
+			for _, unreachable := range e.Arms[i+1:] {
+				c.warnf(unreachable.Token.Line, unreachable.Token.Column,
+					"unreachable khetre arm: an earlier arm matches every value")
+			}
+			return
+		}
+		if !collectLiterals(arm.Pattern, covered, arm.Guard == nil) {
+			literalOnly = false
+		}
+	}
+	if !literalOnly {
+		return
+	}
+
+	var missing []string
+	switch {
+	case finiteLiteralType(subject):
+		for _, lit := range []struct {
+			kind Kind
+			vals []string
+		}{{KindBoolean, []string{"sotti", "mittha"}}, {KindNull, []string{"khali"}}} {
+			if !subject.Includes(lit.kind) {
+				continue
+			}
+			for _, v := range lit.vals {
+				if !covered[v] {
+					missing = append(missing, v)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			return
+		}
+	default:
+		missing = []string{"other " + subject.String() + " values"}
+	}
+
+	c.warnf(e.Token.Line, e.Token.Column,
+		"milao is not exhaustive: %s not handled; add a khetre _ arm", strings.Join(missing, ", "))
+}
+
+// finiteLiteralType reports whether every value of t can be listed as literals (booleans and khali)
+func finiteLiteralType(t *Type) bool {
+	switch t.Kind {
+	case KindBoolean, KindNull:
+		return true
+	case KindUnion:
+		for _, alt := range t.Alts {
+			if !finiteLiteralType(alt) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isCatchAll(pattern ast.Pattern) bool {
+	switch pat := pattern.(type) {
+	case *ast.WildcardPattern, *ast.BindingPattern:
+		return true
+	case *ast.AsPattern:
+		return isCatchAll(pat.Pattern)
+	case *ast.OrPattern:
+		for _, alt := range pat.Alternatives {
+			if isCatchAll(alt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectLiterals records the literals a pattern matches (when unguarded) and
+// reports whether the pattern consists only of literals
+func collectLiterals(pattern ast.Pattern, covered map[string]bool, record bool) bool {
+	switch pat := pattern.(type) {
+	case *ast.LiteralPattern:
+		if record {
+			covered[pat.Value.String()] = true
+		}
+		return true
+	case *ast.OrPattern:
+		for _, alt := range pat.Alternatives {
+			if !collectLiterals(alt, covered, record) {
+				return false
+			}
+		}
+		return true
+	case *ast.AsPattern:
+		return collectLiterals(pat.Pattern, covered, record)
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package checker
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed builtins.d.bang
+var builtinDeclarations string
+
+// BuiltinStubs parses the shipped declaration file into builtin signatures.
+// A name declared more than once has one signature per overload.
+func BuiltinStubs() map[string][]*Signature {
+	stubs, err := ParseStubs(builtinDeclarations)
+	if err != nil {
+		panic("checker: invalid builtin declarations: " + err.Error())
+	}
+	return stubs
+}
+
+// ParseStubs parses declaration source made of annotated, body-less kaj declarations
+func ParseStubs(source string) (map[string][]*Signature, error) {
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	resolver := &Checker{classes: make(map[string]*classInfo)}
+	stubs := make(map[string][]*Signature)
+	for _, stmt := range program.Statements {
+		es, ok := stmt.(*ast.ExpressionStatement)
+		if !ok {
+			return nil, fmt.Errorf("unexpected statement %q in declarations", stmt.String())
+		}
+		fn, ok := es.Expression.(*ast.FunctionLiteral)
+		if !ok || fn.Name == nil {
+			return nil, fmt.Errorf("expected a named kaj declaration, got %q", es.String())
+		}
+		sig := resolver.signatureOf(fn.Parameters, fn.RestParameter, fn.ReturnType, false)
+		stubs[fn.Name.Value] = append(stubs[fn.Name.Value], sig)
+	}
+	if len(resolver.diagnostics) > 0 {
+		return nil, fmt.Errorf("%s", resolver.diagnostics[0])
+	}
+	return stubs, nil
+}
@@ -0,0 +1,236 @@
+package checker
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kind classifies a static type
+type Kind int
+
+const (
+	KindAny Kind = iota
+	KindNumber
+	KindString
+	KindBoolean
+	KindNull
+	KindArray
+	KindMap
+	KindFunction
+	KindPromise
+	KindInstance
+	KindUnion
+)
+
+// Type is a static type used by the checker.
+// Elem is the element type of arrays and the resolved type of promises,
+// Class names instances, Alts lists union members and Sig describes functions.
+type Type struct {
+	Kind  Kind
+	Elem  *Type
+	Class string
+	Alts  []*Type
+	Sig   *Signature
+}
+
+// Signature describes the parameters and result of a callable
+type Signature struct {
+	Params []*Type
+	Rest   *Type // element type of a rest parameter, nil if none
+	Return *Type
+}
+
+// Shared primitive types
+var (
+	Any     = &Type{Kind: KindAny}
+	Number  = &Type{Kind: KindNumber}
+	String  = &Type{Kind: KindString}
+	Boolean = &Type{Kind: KindBoolean}
+	Null    = &Type{Kind: KindNull}
+	AnyMap  = &Type{Kind: KindMap}
+	AnyFunc = &Type{Kind: KindFunction}
+)
+
+// primitiveNames maps annotation names (canonical and Banglish aliases) to types
+var primitiveNames = map[string]*Type{
+	"any":      Any,
+	"number":   Number,
+	"sonkha":   Number,
+	"string":   String,
+	"lekha":    String,
+	"boolean":  Boolean,
+	"bool":     Boolean,
+	"khali":    Null,
+	"null":     Null,
+	"void":     Null,
+	"map":      AnyMap,
+	"kaj":      AnyFunc,
+	"function": AnyFunc,
+	"array":    {Kind: KindArray, Elem: Any},
+	"promise":  {Kind: KindPromise, Elem: Any},
+}
+
+// ArrayOf returns the array type with the given element type
+func ArrayOf(elem *Type) *Type { return &Type{Kind: KindArray, Elem: elem} }
+
+// PromiseOf returns a promise resolving to elem
+func PromiseOf(elem *Type) *Type { return &Type{Kind: KindPromise, Elem: elem} }
+
+// InstanceOf returns the instance type of a class
+func InstanceOf(class string) *Type { return &Type{Kind: KindInstance, Class: class} }
+
+// FuncOf returns a function type with the given signature
+func FuncOf(sig *Signature) *Type { return &Type{Kind: KindFunction, Sig: sig} }
+
+// UnionOf builds a flattened, de-duplicated union (collapsing to any/single types)
+func UnionOf(types ...*Type) *Type {
+	var alts []*Type
+	for _, t := range types {
+		if t == nil {
+			continue
+		}
+		if t.Kind == KindAny {
+			return Any
+		}
+		members := []*Type{t}
+		if t.Kind == KindUnion {
+			members = t.Alts
+		}
+		for _, m := range members {
+			duplicate := false
+			for _, existing := range alts {
+				if Identical(existing, m) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				alts = append(alts, m)
+			}
+		}
+	}
+	switch len(alts) {
+	case 0:
+		return Any
+	case 1:
+		return alts[0]
+	}
+	return &Type{Kind: KindUnion, Alts: alts}
+}
+
+// Identical reports whether two types are structurally the same
+func Identical(a, b *Type) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case KindArray, KindPromise:
+		return Identical(a.Elem, b.Elem)
+	case KindInstance:
+		return a.Class == b.Class
+	case KindUnion:
+		return AssignableTo(a, b) && AssignableTo(b, a)
+	}
+	return true
+}
+
+// AssignableTo reports whether a value of type from may be stored where to is expected.
+// any is compatible in both directions so unannotated code never produces errors.
+func AssignableTo(from, to *Type) bool {
+	if from.Kind == KindAny || to.Kind == KindAny {
+		return true
+	}
+	if from.Kind == KindUnion {
+		for _, alt := range from.Alts {
+			if !AssignableTo(alt, to) {
+				return false
+			}
+		}
+		return true
+	}
+	if to.Kind == KindUnion {
+		for _, alt := range to.Alts {
+			if AssignableTo(from, alt) {
+				return true
+			}
+		}
+		return false
+	}
+	if from.Kind != to.Kind {
+		return false
+	}
+	switch to.Kind {
+	case KindArray, KindPromise:
+		return AssignableTo(from.Elem, to.Elem)
+	case KindInstance:
+		return from.Class == to.Class
+	}
+	return true
+}
+
+// Includes reports whether t may hold a value of kind k
+func (t *Type) Includes(k Kind) bool {
+	switch t.Kind {
+	case KindAny:
+		return true
+	case KindUnion:
+		for _, alt := range t.Alts {
+			if alt.Includes(k) {
+				return true
+			}
+		}
+		return false
+	}
+	return t.Kind == k
+}
+
+func (t *Type) String() string {
+	switch t.Kind {
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindBoolean:
+		return "boolean"
+	case KindNull:
+		return "khali"
+	case KindMap:
+		return "map"
+	case KindFunction:
+		if t.Sig == nil {
+			return "kaj"
+		}
+		return t.Sig.String()
+	case KindArray:
+		if t.Elem.Kind == KindUnion || t.Elem.Kind == KindFunction && t.Elem.Sig != nil {
+			return "(" + t.Elem.String() + ")[]"
+		}
+		return t.Elem.String() + "[]"
+	case KindPromise:
+		if t.Elem.Kind == KindAny {
+			return "promise"
+		}
+		return "promise(" + t.Elem.String() + ")"
+	case KindInstance:
+		return t.Class
+	case KindUnion:
+		parts := make([]string, len(t.Alts))
+		for i, alt := range t.Alts {
+			parts[i] = alt.String()
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, " | ")
+	}
+	return "any"
+}
+
+func (s *Signature) String() string {
+	params := make([]string, 0, len(s.Params)+1)
+	for _, p := range s.Params {
+		params = append(params, p.String())
+	}
+	if s.Rest != nil {
+		params = append(params, "..."+ArrayOf(s.Rest).String())
+	}
+	return "kaj(" + strings.Join(params, ", ") + "): " + s.Return.String()
+}
@@ -6,18 +6,11 @@ import (
 )
 
 // evalDoWhileStatement evaluates: do { ... } jotokkhon (condition);
-func evalDoWhileStatement(stmt *ast.DoWhileStatement, env *object.Environment) object.Object {
+func evalDoWhileStatement(stmt *ast.DoWhileStatement, env *object.Environment, label string) object.Object {
 	for {
 		result := Eval(stmt.Body, env)
-		if result != nil {
-			switch result.Type() {
-			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
-				return result
-			case object.BREAK_OBJ:
-				return object.NULL
-			case object.CONTINUE_OBJ:
-				// continue to condition check
-			}
+		if stop, out := loopControl(result, label); stop {
+			return out
 		}
 
 		condition := Eval(stmt.Condition, env)
@@ -45,15 +38,25 @@ func evalDeleteExpression(node *ast.DeleteExpression, env *object.Environment) o
 
 	switch o := obj.(type) {
 	case *object.Map:
-		key, ok := resolveMemberKey(member, env)
-		if !ok {
+		sym, keyObj := resolveSymbolKey(member, env)
+		if isError(keyObj) {
 			return object.FALSE
 		}
-		if _, exists := o.Pairs[key]; exists {
-			delete(o.Pairs, key)
+		if sym != nil {
+			if _, exists := o.Symbols[sym]; exists && (o.Frozen || o.Sealed) {
+				return newPropertyTypeError("cannot delete property '%s'", sym.Inspect())
+			}
+			delete(o.Symbols, sym)
 			return object.TRUE
 		}
-		return object.TRUE
+		if member.Computed && keyObj.Type() != object.STRING_OBJ && keyObj.Type() != object.NUMBER_OBJ {
+			return object.FALSE
+		}
+		key, errObj := mapKeyFor(member, keyObj)
+		if errObj != nil {
+			return object.FALSE
+		}
+		return deleteMapProperty(o, key)
 
 	case *object.Instance:
 		key, ok := resolveMemberKey(member, env)
@@ -85,12 +88,15 @@ func evalDeleteExpression(node *ast.DeleteExpression, env *object.Environment) o
 func evalInOperator(left, right object.Object) object.Object {
 	switch r := right.(type) {
 	case *object.Map:
+		if sym, ok := left.(*object.Symbol); ok {
+			_, exists := r.Symbols[sym]
+			return object.NativeBoolToBooleanObject(exists)
+		}
 		key := getMapKey(left)
 		if key == "" && left.Type() != object.STRING_OBJ && left.Type() != object.NUMBER_OBJ {
 			return object.FALSE
 		}
-		_, exists := r.Pairs[key]
-		return object.NativeBoolToBooleanObject(exists)
+		return object.NativeBoolToBooleanObject(r.HasOwn(key))
 
 	case *object.Array:
 		if left.Type() != object.NUMBER_OBJ {
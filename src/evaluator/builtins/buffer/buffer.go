@@ -2,6 +2,7 @@ package buffer
 
 import (
 	"BanglaCode/src/object"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -18,6 +19,31 @@ var Builtins = map[string]*object.Builtin{
 	"buffer_tulona": {Fn: compareBuffers},
 	"buffer_hex":    {Fn: bufferToHex},
 	"buffer_copy":   {Fn: copyBuffer},
+	"buffer_view":   {Fn: viewBuffer},
+
+	// Typed arrays and DataView (zero-copy views over a buffer)
+	"typed_array_banao":  {Fn: createTypedArray},
+	"typed_array_list":   {Fn: typedArrayToList},
+	"typed_array_angsho": {Fn: subTypedArray},
+	"dataview_banao":     {Fn: createDataView},
+	"dataview_poro":      {Fn: readDataView},
+	"dataview_lekho":     {Fn: writeDataView},
+}
+
+// decodeString converts an encoded string to bytes
+func decodeString(value, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "utf8", "utf-8":
+		return []byte(value), nil
+	case "hex":
+		return hex.DecodeString(value)
+	case "base64":
+		return base64.StdEncoding.DecodeString(value)
+	case "base64url":
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
 }
 
 // createBuffer creates a new buffer with specified size
@@ -40,10 +66,11 @@ func createBuffer(args ...object.Object) object.Object {
 	return object.CreateBuffer(int(size.Value))
 }
 
-// createBufferFrom creates a buffer from string, array, or another buffer
-// Usage: dhoro buf = buffer_theke("Hello");      // From string
+// createBufferFrom creates a buffer from string, array, another buffer, or a typed array
+// Usage: dhoro buf = buffer_theke("Hello");                // From string
 //
-//	dhoro buf = buffer_theke([72, 101]);     // From byte array
+//	dhoro buf = buffer_theke([72, 101]);               // From byte array
+//	dhoro buf = buffer_theke("SGVsbG8=", "base64");    // Decode base64/base64url/hex
 func createBufferFrom(args ...object.Object) object.Object {
 	if len(args) == 0 {
 		return &object.Error{Message: "buffer_theke() expects at least 1 argument"}
@@ -51,8 +78,19 @@ func createBufferFrom(args ...object.Object) object.Object {
 
 	switch arg := args[0].(type) {
 	case *object.String:
-		// Create buffer from string (UTF-8 encoding)
-		return object.CreateBufferFrom([]byte(arg.Value))
+		// Create buffer from string (UTF-8 encoding unless specified)
+		if len(args) < 2 {
+			return object.CreateBufferFrom([]byte(arg.Value))
+		}
+		enc, ok := args[1].(*object.String)
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("encoding must be string, got %s", args[1].Type())}
+		}
+		data, err := decodeString(arg.Value, enc.Value)
+		if err != nil {
+			return &object.Error{Message: fmt.Sprintf("buffer_theke: %s", err.Error())}
+		}
+		return object.NewBuffer(data)
 
 	case *object.Array:
 		// Create buffer from array of numbers (bytes)
@@ -75,6 +113,12 @@ func createBufferFrom(args ...object.Object) object.Object {
 		defer arg.Mu.RUnlock()
 		return object.CreateBufferFrom(arg.Data)
 
+	case *object.TypedArray:
+		// Copy the bytes covered by the typed array
+		arg.Buffer.Mu.RLock()
+		defer arg.Buffer.Mu.RUnlock()
+		return object.CreateBufferFrom(arg.Buffer.Data[arg.ByteOffset : arg.ByteOffset+arg.Length*arg.ElementSize])
+
 	default:
 		return &object.Error{Message: fmt.Sprintf("cannot create buffer from %s", arg.Type())}
 	}
@@ -121,6 +165,7 @@ func concatBuffers(args ...object.Object) object.Object {
 //	dhoro text = buffer_text(buf, "utf8");      // UTF-8
 //	dhoro text = buffer_text(buf, "hex");       // Hexadecimal
 //	dhoro text = buffer_text(buf, "base64");    // Base64
+//	dhoro text = buffer_text(buf, "base64url"); // URL-safe Base64 without padding
 func bufferToString(args ...object.Object) object.Object {
 	if len(args) < 1 || len(args) > 2 {
 		return &object.Error{Message: "buffer_text() expects 1 or 2 arguments (buffer, [encoding])"}
@@ -153,8 +198,10 @@ func bufferToString(args ...object.Object) object.Object {
 		return &object.String{Value: hex.EncodeToString(buf.Data)}
 
 	case "base64":
-		// Note: We already have base64_encode function, but include here for compatibility
-		return &object.String{Value: fmt.Sprintf("%x", buf.Data)}
+		return &object.String{Value: base64.StdEncoding.EncodeToString(buf.Data)}
+
+	case "base64url":
+		return &object.String{Value: base64.RawURLEncoding.EncodeToString(buf.Data)}
 
 	default:
 		return &object.Error{Message: fmt.Sprintf("unsupported encoding: %s", encoding)}
@@ -273,6 +320,44 @@ func sliceBuffer(args ...object.Object) object.Object {
 	return object.CreateBufferFrom(buf.Data[startIdx:endIdx])
 }
 
+// viewBuffer returns a buffer sharing memory and its lock with the original (writes are
+// visible in both)
+// Usage: dhoro header = buffer_view(packet, 0, 4);  // Bytes 0-3, no copy
+func viewBuffer(args ...object.Object) object.Object {
+	if len(args) < 2 || len(args) > 3 {
+		return &object.Error{Message: "buffer_view() expects 2 or 3 arguments (buffer, start, [end])"}
+	}
+
+	buf, ok := args[0].(*object.Buffer)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("first argument must be buffer, got %s", args[0].Type())}
+	}
+	start, ok := args[1].(*object.Number)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("start must be number, got %s", args[1].Type())}
+	}
+
+	buf.Mu.RLock()
+	defer buf.Mu.RUnlock()
+
+	end := float64(len(buf.Data))
+	if len(args) == 3 {
+		e, ok := args[2].(*object.Number)
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("end must be number, got %s", args[2].Type())}
+		}
+		end = e.Value
+	}
+
+	startIdx := clampIndex(int(start.Value), len(buf.Data))
+	endIdx := clampIndex(int(end), len(buf.Data))
+	if endIdx < startIdx {
+		endIdx = startIdx
+	}
+
+	return buf.View(startIdx, endIdx)
+}
+
 // compareBuffers compares two buffers
 // Usage: dhoro result = buffer_tulona(buf1, buf2);
 // Returns: -1 if buf1 < buf2, 0 if equal, 1 if buf1 > buf2
@@ -293,9 +378,11 @@ func compareBuffers(args ...object.Object) object.Object {
 	}
 
 	buf1.Mu.RLock()
-	buf2.Mu.RLock()
 	defer buf1.Mu.RUnlock()
-	defer buf2.Mu.RUnlock()
+	if buf2.Mu != buf1.Mu { // a view shares its parent's lock
+		buf2.Mu.RLock()
+		defer buf2.Mu.RUnlock()
+	}
 
 	// Compare byte by byte
 	minLen := len(buf1.Data)
@@ -394,9 +481,11 @@ func copyBuffer(args ...object.Object) object.Object {
 
 	// Copy data (thread-safe)
 	target.Mu.Lock()
-	source.Mu.RLock()
 	defer target.Mu.Unlock()
-	defer source.Mu.RUnlock()
+	if source.Mu != target.Mu { // copying within one buffer or between views of it
+		source.Mu.RLock()
+		defer source.Mu.RUnlock()
+	}
 
 	if targetStart < 0 || targetStart >= len(target.Data) {
 		return &object.Error{Message: "targetStart out of range"}
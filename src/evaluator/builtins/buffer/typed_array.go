@@ -0,0 +1,409 @@
+package buffer
+
+import (
+	"BanglaCode/src/object"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// elementSizes maps typed array kinds to their width in bytes
+var elementSizes = map[string]int{
+	"Int8":         1,
+	"Uint8":        1,
+	"Uint8Clamped": 1,
+	"Int16":        2,
+	"Uint16":       2,
+	"Int32":        4,
+	"Uint32":       4,
+	"Float32":      4,
+	"Float64":      8,
+}
+
+// normalizeKind accepts "Uint16", "uint16" or "Uint16Array" and returns the canonical kind
+func normalizeKind(name string) (string, bool) {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "Array"), "array")
+	for kind := range elementSizes {
+		if strings.EqualFold(kind, name) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// byteOrder returns the binary order for the optional littleEndian flag
+func byteOrder(littleEndian bool) binary.ByteOrder {
+	if littleEndian {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+// decodeValue reads a single value of the given kind from b
+func decodeValue(kind string, b []byte, order binary.ByteOrder) float64 {
+	switch kind {
+	case "Int8":
+		return float64(int8(b[0]))
+	case "Uint8", "Uint8Clamped":
+		return float64(b[0])
+	case "Int16":
+		return float64(int16(order.Uint16(b)))
+	case "Uint16":
+		return float64(order.Uint16(b))
+	case "Int32":
+		return float64(int32(order.Uint32(b)))
+	case "Uint32":
+		return float64(order.Uint32(b))
+	case "Float32":
+		return float64(math.Float32frombits(order.Uint32(b)))
+	case "Float64":
+		return math.Float64frombits(order.Uint64(b))
+	}
+	return 0
+}
+
+// toWrappedInt converts a number to an integer using JS modular semantics (NaN/Inf → 0)
+func toWrappedInt(v float64) int64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return int64(math.Trunc(math.Mod(v, 1<<32)))
+}
+
+// encodeValue writes a single value of the given kind into b
+func encodeValue(kind string, b []byte, v float64, order binary.ByteOrder) {
+	switch kind {
+	case "Int8", "Uint8":
+		b[0] = byte(toWrappedInt(v))
+	case "Uint8Clamped":
+		switch {
+		case math.IsNaN(v) || v <= 0:
+			b[0] = 0
+		case v >= 255:
+			b[0] = 255
+		default:
+			b[0] = byte(math.RoundToEven(v))
+		}
+	case "Int16", "Uint16":
+		order.PutUint16(b, uint16(toWrappedInt(v)))
+	case "Int32", "Uint32":
+		order.PutUint32(b, uint32(toWrappedInt(v)))
+	case "Float32":
+		order.PutUint32(b, math.Float32bits(float32(v)))
+	case "Float64":
+		order.PutUint64(b, math.Float64bits(v))
+	}
+}
+
+// ReadElement returns element idx of a typed array (typed arrays are little-endian)
+func ReadElement(ta *object.TypedArray, idx int) (float64, bool) {
+	if idx < 0 || idx >= ta.Length {
+		return 0, false
+	}
+	ta.Buffer.Mu.RLock()
+	defer ta.Buffer.Mu.RUnlock()
+	start := ta.ByteOffset + idx*ta.ElementSize
+	return decodeValue(ta.Kind, ta.Buffer.Data[start:start+ta.ElementSize], binary.LittleEndian), true
+}
+
+// WriteElement stores value at element idx of a typed array
+func WriteElement(ta *object.TypedArray, idx int, value float64) bool {
+	if idx < 0 || idx >= ta.Length {
+		return false
+	}
+	ta.Buffer.Mu.Lock()
+	defer ta.Buffer.Mu.Unlock()
+	start := ta.ByteOffset + idx*ta.ElementSize
+	encodeValue(ta.Kind, ta.Buffer.Data[start:start+ta.ElementSize], value, binary.LittleEndian)
+	return true
+}
+
+// Elements returns the typed array's values as BanglaCode numbers
+func Elements(ta *object.TypedArray) []object.Object {
+	elements := make([]object.Object, ta.Length)
+	for i := 0; i < ta.Length; i++ {
+		v, _ := ReadElement(ta, i)
+		elements[i] = &object.Number{Value: v}
+	}
+	return elements
+}
+
+// newTypedArray allocates a zeroed typed array with its own buffer
+func newTypedArray(kind string, length int) *object.TypedArray {
+	size := elementSizes[kind]
+	return &object.TypedArray{
+		Kind:        kind,
+		ElementSize: size,
+		Buffer:      object.CreateBuffer(length * size),
+		Length:      length,
+	}
+}
+
+// createTypedArray creates a typed array from a length, array, typed array or buffer
+// Usage: dhoro a = typed_array_banao("Uint8", 4);             // 4 zeroed bytes
+//
+//	dhoro b = typed_array_banao("Float32", [1.5, 2.5]);    // From numbers
+//	dhoro c = typed_array_banao("Uint16", buf, 2, 3);      // View over buf (no copy)
+func createTypedArray(args ...object.Object) object.Object {
+	if len(args) < 2 || len(args) > 4 {
+		return &object.Error{Message: "typed_array_banao() expects 2-4 arguments (kind, source, [byteOffset], [length])"}
+	}
+
+	kindStr, ok := args[0].(*object.String)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("kind must be string, got %s", args[0].Type())}
+	}
+	kind, ok := normalizeKind(kindStr.Value)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("unknown typed array kind: %s", kindStr.Value)}
+	}
+	size := elementSizes[kind]
+
+	switch src := args[1].(type) {
+	case *object.Number:
+		if src.Value < 0 {
+			return &object.Error{Message: "typed array length cannot be negative"}
+		}
+		return newTypedArray(kind, int(src.Value))
+
+	case *object.Array:
+		ta := newTypedArray(kind, len(src.Elements))
+		for i, elem := range src.Elements {
+			num, ok := elem.(*object.Number)
+			if !ok {
+				return &object.Error{Message: fmt.Sprintf("array element %d must be number, got %s", i, elem.Type())}
+			}
+			WriteElement(ta, i, num.Value)
+		}
+		return ta
+
+	case *object.TypedArray:
+		ta := newTypedArray(kind, src.Length)
+		for i := 0; i < src.Length; i++ {
+			v, _ := ReadElement(src, i)
+			WriteElement(ta, i, v)
+		}
+		return ta
+
+	case *object.Buffer:
+		src.Mu.RLock()
+		bufLen := len(src.Data)
+		src.Mu.RUnlock()
+
+		offset := 0
+		if len(args) >= 3 {
+			off, ok := args[2].(*object.Number)
+			if !ok {
+				return &object.Error{Message: fmt.Sprintf("byteOffset must be number, got %s", args[2].Type())}
+			}
+			offset = int(off.Value)
+		}
+		if offset < 0 || offset > bufLen {
+			return &object.Error{Message: fmt.Sprintf("byteOffset %d out of range [0, %d]", offset, bufLen)}
+		}
+		if offset%size != 0 {
+			return &object.Error{Message: fmt.Sprintf("byteOffset of %sArray must be a multiple of %d", kind, size)}
+		}
+
+		var length int
+		if len(args) == 4 {
+			l, ok := args[3].(*object.Number)
+			if !ok {
+				return &object.Error{Message: fmt.Sprintf("length must be number, got %s", args[3].Type())}
+			}
+			length = int(l.Value)
+			if length < 0 || offset+length*size > bufLen {
+				return &object.Error{Message: fmt.Sprintf("length %d out of range for buffer of %d bytes", length, bufLen)}
+			}
+		} else {
+			if (bufLen-offset)%size != 0 {
+				return &object.Error{Message: fmt.Sprintf("buffer length minus byteOffset must be a multiple of %d", size)}
+			}
+			length = (bufLen - offset) / size
+		}
+
+		return &object.TypedArray{Kind: kind, ElementSize: size, Buffer: src, ByteOffset: offset, Length: length}
+
+	default:
+		return &object.Error{Message: fmt.Sprintf("cannot create typed array from %s", src.Type())}
+	}
+}
+
+// typedArrayToList converts a typed array to a plain array of numbers
+// Usage: dhoro nums = typed_array_list(arr);
+func typedArrayToList(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return &object.Error{Message: "typed_array_list() expects 1 argument (typedArray)"}
+	}
+	ta, ok := args[0].(*object.TypedArray)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("argument must be typed array, got %s", args[0].Type())}
+	}
+	return &object.Array{Elements: Elements(ta)}
+}
+
+// subTypedArray returns a view over a range of elements sharing the same buffer
+// Usage: dhoro part = typed_array_angsho(arr, 1, 3);  // Elements 1-2
+func subTypedArray(args ...object.Object) object.Object {
+	if len(args) < 2 || len(args) > 3 {
+		return &object.Error{Message: "typed_array_angsho() expects 2 or 3 arguments (typedArray, start, [end])"}
+	}
+	ta, ok := args[0].(*object.TypedArray)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("first argument must be typed array, got %s", args[0].Type())}
+	}
+	start, ok := args[1].(*object.Number)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("start must be number, got %s", args[1].Type())}
+	}
+	end := float64(ta.Length)
+	if len(args) == 3 {
+		e, ok := args[2].(*object.Number)
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("end must be number, got %s", args[2].Type())}
+		}
+		end = e.Value
+	}
+
+	startIdx := clampIndex(int(start.Value), ta.Length)
+	endIdx := clampIndex(int(end), ta.Length)
+	if endIdx < startIdx {
+		endIdx = startIdx
+	}
+
+	return &object.TypedArray{
+		Kind:        ta.Kind,
+		ElementSize: ta.ElementSize,
+		Buffer:      ta.Buffer,
+		ByteOffset:  ta.ByteOffset + startIdx*ta.ElementSize,
+		Length:      endIdx - startIdx,
+	}
+}
+
+// clampIndex resolves negative indexes from the end and clamps to [0, length]
+func clampIndex(idx, length int) int {
+	if idx < 0 {
+		idx += length
+	}
+	if idx < 0 {
+		return 0
+	}
+	if idx > length {
+		return length
+	}
+	return idx
+}
+
+// createDataView creates an endian-aware view over a buffer
+// Usage: dhoro view = dataview_banao(buf);          // Whole buffer
+//
+//	dhoro view = dataview_banao(buf, 4, 8);    // 8 bytes starting at offset 4
+func createDataView(args ...object.Object) object.Object {
+	if len(args) < 1 || len(args) > 3 {
+		return &object.Error{Message: "dataview_banao() expects 1-3 arguments (buffer, [byteOffset], [byteLength])"}
+	}
+	buf, ok := args[0].(*object.Buffer)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("first argument must be buffer, got %s", args[0].Type())}
+	}
+
+	buf.Mu.RLock()
+	bufLen := len(buf.Data)
+	buf.Mu.RUnlock()
+
+	offset := 0
+	if len(args) >= 2 {
+		off, ok := args[1].(*object.Number)
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("byteOffset must be number, got %s", args[1].Type())}
+		}
+		offset = int(off.Value)
+	}
+	if offset < 0 || offset > bufLen {
+		return &object.Error{Message: fmt.Sprintf("byteOffset %d out of range [0, %d]", offset, bufLen)}
+	}
+
+	length := bufLen - offset
+	if len(args) == 3 {
+		l, ok := args[2].(*object.Number)
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("byteLength must be number, got %s", args[2].Type())}
+		}
+		length = int(l.Value)
+		if length < 0 || offset+length > bufLen {
+			return &object.Error{Message: fmt.Sprintf("byteLength %d out of range for buffer of %d bytes", length, bufLen)}
+		}
+	}
+
+	return &object.DataView{Buffer: buf, ByteOffset: offset, ByteLength: length}
+}
+
+// dataViewArgs validates the common (view, kind, offset) prefix of dataview_poro/dataview_lekho
+func dataViewArgs(name string, args []object.Object) (*object.DataView, string, int, *object.Error) {
+	view, ok := args[0].(*object.DataView)
+	if !ok {
+		return nil, "", 0, &object.Error{Message: fmt.Sprintf("%s: first argument must be DataView, got %s", name, args[0].Type())}
+	}
+	kindStr, ok := args[1].(*object.String)
+	if !ok {
+		return nil, "", 0, &object.Error{Message: fmt.Sprintf("%s: kind must be string, got %s", name, args[1].Type())}
+	}
+	kind, ok := normalizeKind(kindStr.Value)
+	if !ok {
+		return nil, "", 0, &object.Error{Message: fmt.Sprintf("%s: unknown kind %s", name, kindStr.Value)}
+	}
+	off, ok := args[2].(*object.Number)
+	if !ok {
+		return nil, "", 0, &object.Error{Message: fmt.Sprintf("%s: offset must be number, got %s", name, args[2].Type())}
+	}
+	offset := int(off.Value)
+	if offset < 0 || offset+elementSizes[kind] > view.ByteLength {
+		return nil, "", 0, &object.Error{Message: fmt.Sprintf("%s: offset %d out of range for %s in view of %d bytes", name, offset, kind, view.ByteLength)}
+	}
+	return view, kind, view.ByteOffset + offset, nil
+}
+
+// readDataView reads a number at a byte offset (big-endian unless littleEndian is sotti)
+// Usage: dhoro len = dataview_poro(view, "Uint16", 0);
+//
+//	dhoro x = dataview_poro(view, "Float32", 2, sotti);  // Little-endian
+func readDataView(args ...object.Object) object.Object {
+	if len(args) < 3 || len(args) > 4 {
+		return &object.Error{Message: "dataview_poro() expects 3 or 4 arguments (view, kind, offset, [littleEndian])"}
+	}
+	view, kind, start, err := dataViewArgs("dataview_poro", args)
+	if err != nil {
+		return err
+	}
+	littleEndian := len(args) == 4 && args[3] == object.TRUE
+
+	view.Buffer.Mu.RLock()
+	defer view.Buffer.Mu.RUnlock()
+	value := decodeValue(kind, view.Buffer.Data[start:start+elementSizes[kind]], byteOrder(littleEndian))
+	return &object.Number{Value: value}
+}
+
+// writeDataView writes a number at a byte offset (big-endian unless littleEndian is sotti)
+// Usage: dataview_lekho(view, "Uint32", 0, 3405691582);
+//
+//	dataview_lekho(view, "Int16", 4, -2, sotti);  // Little-endian
+func writeDataView(args ...object.Object) object.Object {
+	if len(args) < 4 || len(args) > 5 {
+		return &object.Error{Message: "dataview_lekho() expects 4 or 5 arguments (view, kind, offset, value, [littleEndian])"}
+	}
+	view, kind, start, err := dataViewArgs("dataview_lekho", args)
+	if err != nil {
+		return err
+	}
+	value, ok := args[3].(*object.Number)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("dataview_lekho: value must be number, got %s", args[3].Type())}
+	}
+	littleEndian := len(args) == 5 && args[4] == object.TRUE
+
+	view.Buffer.Mu.Lock()
+	defer view.Buffer.Mu.Unlock()
+	encodeValue(kind, view.Buffer.Data[start:start+elementSizes[kind]], value.Value, byteOrder(littleEndian))
+	return object.NULL
+}
@@ -46,6 +46,10 @@ func InitializeEnvironmentWithConstants(env *object.Environment) {
 		env.Set(name, numObj)
 		env.SetConstant(name, numObj) // Mark as constant
 	}
+
+	// Add well-known symbols
+	env.Set("CHIHNO_ITERATOR", object.SymbolIterator)
+	env.SetConstant("CHIHNO_ITERATOR", object.SymbolIterator)
 }
 
 func init() {
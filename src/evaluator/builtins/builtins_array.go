@@ -54,8 +54,9 @@ func init() {
 			}
 
 			mapObj := args[0].(*object.Map)
-			keys := make([]object.Object, 0, len(mapObj.Pairs))
-			for key := range mapObj.Pairs {
+			names := mapObj.EnumerableKeys()
+			keys := make([]object.Object, 0, len(names))
+			for _, key := range names {
 				keys = append(keys, &object.String{Value: key})
 			}
 			return &object.Array{Elements: keys}
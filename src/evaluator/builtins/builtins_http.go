@@ -4,26 +4,30 @@ import (
 	"BanglaCode/src/object"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
 )
 
 func init() {
 	// server_chalu (সার্ভার চালু - start server)
 	// Accepts a Router (MAP with __router_id__) or a plain function handler.
+	// Returns a server handle right away; the script keeps running until every
+	// server is closed with handle.bondho(ms).
+	// Optional third argument: {tls: {cert, key, min_version, client_ca, client_auth}} serves HTTPS.
 	Builtins["server_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
 			}
 			if args[0].Type() != object.NUMBER_OBJ {
 				return newError("first argument to `server_chalu` must be NUMBER (port), got %s", args[0].Type())
 			}
 			port := int(args[0].(*object.Number).Value)
 
-			// Router mode
-			if args[1].Type() == object.MAP_OBJ {
+			var handler http.Handler
+			mode := ""
+			switch args[1].Type() {
+			case object.MAP_OBJ:
+				// Router mode
 				routerMap := args[1].(*object.Map)
 				ridObj, ok := routerMap.Pairs["__router_id__"]
 				if !ok {
@@ -37,93 +41,80 @@ func init() {
 				if !found {
 					return newError("router not found — was it created with router_banao()?")
 				}
-				fmt.Printf("🚀 Server cholche http://localhost:%d e (Router mode)\n", port)
-				if err := http.ListenAndServe(fmt.Sprintf(":%d", port), router); err != nil {
-					return newError("server error: %s", err.Error())
-				}
-				return object.NULL
+				handler = router
+				mode = " (Router mode)"
+			case object.FUNCTION_OBJ:
+				// Function-based mode (backward compatible)
+				fn := args[1].(*object.Function)
+				mux := http.NewServeMux()
+				mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+					body, upload, err := readRequestBody(r, r.Body)
+					if err != nil {
+						writeBodyError(w, err)
+						return
+					}
+					reqMap := buildRequestMap(r, body, nil)
+					if upload != nil {
+						defer upload.cleanup()
+						upload.attach(reqMap)
+					}
+					resMap := buildResponseMap()
+					rs := attachResponseStream(w, r, resMap)
+					if EvalFunc != nil {
+						if failure := awaitHandler(r.Context(), EvalFunc(fn, []object.Object{reqMap, resMap})); failure != nil {
+							writeFailureResponse(resMap, failure)
+						}
+					}
+					if !rs.finish() {
+						writeHTTPResponse(w, resMap, false)
+					}
+				})
+				handler = mux
+			default:
+				return newError("second argument to `server_chalu` must be FUNCTION or ROUTER, got %s", args[1].Type())
 			}
 
-			// Function-based mode (backward compatible)
-			if args[1].Type() != object.FUNCTION_OBJ {
-				return newError("second argument to `server_chalu` must be FUNCTION or ROUTER, got %s", args[1].Type())
+			var options object.Object
+			if len(args) == 3 {
+				options = args[2]
 			}
-			handler := args[1].(*object.Function)
-			mux := http.NewServeMux()
-			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-				body, _ := io.ReadAll(r.Body)
-				reqMap := buildRequestMap(r, body, nil)
-				resMap := buildResponseMap()
-				if EvalFunc != nil {
-					EvalFunc(handler, []object.Object{reqMap, resMap})
-				}
-				writeHTTPResponse(w, resMap, false)
-			})
-			fmt.Printf("🚀 Server cholche http://localhost:%d e\n", port)
-			if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+			tlsConfig, errObj := serverTLSConfig("server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+			scheme := "http"
+			if tlsConfig != nil {
+				scheme = "https"
+			}
+
+			listener, err := listenTCP(port, tlsConfig)
+			if err != nil {
 				return newError("server error: %s", err.Error())
 			}
-			return object.NULL
+			server := newServerHandle("http", listener, &http.Server{Handler: handler})
+			fmt.Printf("🚀 Server cholche %s://localhost:%d e%s\n", scheme, server.port(), mode)
+			server.serve()
+			return server.toMap()
 		},
 	}
 
 	// anun (আনুন - HTTP client, backward compatible + extended with options)
 	// anun(url)                         → GET
-	// anun(url, {method, body, headers}) → any method
+	// anun(url, {method, body, json, query, headers, timeout, retry, redirect, stream, proxy, tls})
+	// → {status, ok, url, headers, body, json} (stream: sotti gives res.stream instead of body)
 	Builtins["anun"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) < 1 || len(args) > 2 {
-				return newError("wrong number of arguments. got=%d, want=1-2", len(args))
-			}
-			if args[0].Type() != object.STRING_OBJ {
-				return newError("first argument to `anun` must be STRING (url), got %s", args[0].Type())
-			}
-			resp, err := doHTTPRequest(args)
-			if err != nil {
-				return newError("HTTP error: %s", err.Error())
-			}
-			defer resp.Body.Close()
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return newError("error reading response: %s", err.Error())
-			}
-			result := &object.Map{Pairs: make(map[string]object.Object)}
-			result.Pairs["status"] = &object.Number{Value: float64(resp.StatusCode)}
-			result.Pairs["body"] = &object.String{Value: string(body)}
-			return result
+			return anunRequest("anun", args)
 		},
 	}
 
 	// anun_async (আনুন async - async HTTP client)
 	Builtins["anun_async"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) < 1 || len(args) > 2 {
-				return newError("wrong number of arguments. got=%d, want=1-2", len(args))
+			if _, _, errObj := httpRequestArgs("anun_async", args); errObj != nil {
+				return errObj
 			}
-			if args[0].Type() != object.STRING_OBJ {
-				return newError("first argument to `anun_async` must be STRING (url), got %s", args[0].Type())
-			}
-			promise := object.CreatePromise()
-			// Capture args slice for goroutine
-			capturedArgs := args
-			go func() {
-				resp, err := doHTTPRequest(capturedArgs)
-				if err != nil {
-					object.RejectPromise(promise, newError("HTTP error: %s", err.Error()))
-					return
-				}
-				defer resp.Body.Close()
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					object.RejectPromise(promise, newError("error reading response: %s", err.Error()))
-					return
-				}
-				result := &object.Map{Pairs: make(map[string]object.Object)}
-				result.Pairs["status"] = &object.Number{Value: float64(resp.StatusCode)}
-				result.Pairs["body"] = &object.String{Value: string(body)}
-				object.ResolvePromise(promise, result)
-			}()
-			return promise
+			return asyncRequest(func() object.Object { return anunRequest("anun_async", args) })
 		},
 	}
 
@@ -204,47 +195,17 @@ func init() {
 	}
 }
 
-// doHTTPRequest builds and executes an HTTP request from BanglaCode args.
-// args[0] = url STRING
-// args[1] = options MAP (optional): method, body, headers
-func doHTTPRequest(args []object.Object) (*http.Response, error) {
-	rawURL := args[0].(*object.String).Value
-	method := "GET"
-	bodyStr := ""
-	extraHeaders := map[string]string{}
-
-	if len(args) == 2 && args[1].Type() == object.MAP_OBJ {
-		opts := args[1].(*object.Map)
-		if m, ok := opts.Pairs["method"].(*object.String); ok {
-			method = strings.ToUpper(m.Value)
-		}
-		if b, ok := opts.Pairs["body"].(*object.String); ok {
-			bodyStr = b.Value
-		}
-		if h, ok := opts.Pairs["headers"].(*object.Map); ok {
-			for k, v := range h.Pairs {
-				if vs, ok := v.(*object.String); ok {
-					extraHeaders[k] = vs.Value
-				}
-			}
-		}
-	}
-
-	var bodyReader io.Reader
-	if bodyStr != "" {
-		bodyReader = strings.NewReader(bodyStr)
+// anunRequest sends a one-off request with a fresh client (no cookie jar)
+func anunRequest(name string, args []object.Object) object.Object {
+	rawURL, opts, errObj := httpRequestArgs(name, args)
+	if errObj != nil {
+		return errObj
 	}
-
-	req, err := http.NewRequest(method, rawURL, bodyReader)
-	if err != nil {
-		return nil, err
+	c, errObj := newHTTPClient(name, opts, false)
+	if errObj != nil {
+		return newError("HTTP error: %s", errObj.Message)
 	}
-	for k, v := range extraHeaders {
-		req.Header.Set(k, v)
-	}
-
-	client := &http.Client{}
-	return client.Do(req)
+	return c.do(name, rawURL, opts)
 }
 
 // parseJSON converts a JSON string to BanglaCode objects.
@@ -315,9 +276,10 @@ func objectToJSON(obj object.Object) interface{} {
 		}
 		return arr
 	case *object.Map:
-		m := make(map[string]interface{}, len(v.Pairs))
-		for key, val := range v.Pairs {
-			m[key] = objectToJSON(val)
+		keys := v.EnumerableKeys()
+		m := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			m[key] = objectToJSON(mapPropertyValue(v, key))
 		}
 		return m
 	default:
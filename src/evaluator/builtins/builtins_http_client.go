@@ -0,0 +1,615 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// httpClient backs anun/anun_async (one per call) and client_banao (shared, with a
+// cookie jar and interceptors). Per-request options override the client defaults.
+type httpClient struct {
+	client   *http.Client
+	baseURL  string
+	headers  map[string]string
+	timeout  time.Duration
+	retry    retryPolicy
+	redirect redirectPolicy
+
+	mu     sync.RWMutex
+	before []object.Object // request interceptors: kaj(req) → req
+	after  []object.Object // response interceptors: kaj(res) → res
+}
+
+// retryPolicy retries network errors and the listed statuses with exponential backoff
+type retryPolicy struct {
+	count    int
+	delay    time.Duration
+	statuses map[int]bool
+}
+
+// redirectPolicy is "follow" (up to max hops), "manual" (return the 3xx) or "error"
+type redirectPolicy struct {
+	mode string
+	max  int
+}
+
+// requestSettings are the per-request knobs resolved from client defaults and options
+type requestSettings struct {
+	timeout  time.Duration
+	retry    retryPolicy
+	redirect redirectPolicy
+	stream   bool
+}
+
+type redirectPolicyKey struct{}
+
+var errRedirectBlocked = errors.New("redirect not allowed")
+
+var defaultRetryStatuses = []int{408, 429, 500, 502, 503, 504}
+
+// newHTTPClient builds a client from {baseURL, headers, timeout, retry, redirect,
+// maxRedirects, proxy, tls}; withJar adds a cookie jar
+func newHTTPClient(name string, opts *object.Map, withJar bool) (*httpClient, *object.Error) {
+	c := &httpClient{
+		headers:  map[string]string{},
+		retry:    retryPolicy{delay: 100 * time.Millisecond, statuses: statusSet(defaultRetryStatuses)},
+		redirect: redirectPolicy{mode: "follow", max: 10},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts != nil {
+		if v, ok := opts.Pairs["baseURL"].(*object.String); ok {
+			c.baseURL = v.Value
+		}
+		if h, ok := opts.Pairs["headers"].(*object.Map); ok {
+			for k, v := range h.Pairs {
+				if vs, ok := v.(*object.String); ok {
+					c.headers[k] = vs.Value
+				}
+			}
+		}
+		settings, errObj := c.settings(name, opts)
+		if errObj != nil {
+			return nil, errObj
+		}
+		c.timeout, c.retry, c.redirect = settings.timeout, settings.retry, settings.redirect
+
+		if p, ok := opts.Pairs["proxy"]; ok {
+			ps, ok := p.(*object.String)
+			if !ok {
+				return nil, newError("`proxy` option to `%s` must be STRING (url), got %s", name, p.Type())
+			}
+			proxyURL, err := url.Parse(ps.Value)
+			if err != nil {
+				return nil, newError("%s: invalid proxy url: %s", name, err.Error())
+			}
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+		tlsConfig, errObj := clientTLSConfig(name, opts)
+		if errObj != nil {
+			return nil, errObj
+		}
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+		}
+	}
+
+	c.client = &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			policy, _ := req.Context().Value(redirectPolicyKey{}).(redirectPolicy)
+			switch policy.mode {
+			case "manual":
+				return http.ErrUseLastResponse
+			case "error":
+				return fmt.Errorf("%w (to %s)", errRedirectBlocked, req.URL)
+			}
+			if len(via) > policy.max {
+				return fmt.Errorf("stopped after %d redirects", policy.max)
+			}
+			return nil
+		},
+	}
+	if withJar {
+		c.client.Jar, _ = cookiejar.New(nil)
+	}
+	return c, nil
+}
+
+// settings overlays the timeout/retry/redirect/stream options on the client defaults
+func (c *httpClient) settings(name string, opts *object.Map) (requestSettings, *object.Error) {
+	s := requestSettings{timeout: c.timeout, retry: c.retry, redirect: c.redirect}
+	if opts == nil {
+		return s, nil
+	}
+	if v, ok := opts.Pairs["timeout"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value < 0 {
+			return s, newError("`timeout` option to `%s` must be a non-negative NUMBER (ms)", name)
+		}
+		s.timeout = time.Duration(n.Value * float64(time.Millisecond))
+	}
+	switch v := opts.Pairs["retry"].(type) {
+	case nil:
+	case *object.Number:
+		s.retry.count = int(v.Value)
+	case *object.Map:
+		if n, ok := v.Pairs["count"].(*object.Number); ok {
+			s.retry.count = int(n.Value)
+		}
+		if n, ok := v.Pairs["delay"].(*object.Number); ok {
+			s.retry.delay = time.Duration(n.Value * float64(time.Millisecond))
+		}
+		if statuses, ok := v.Pairs["statuses"].(*object.Array); ok {
+			s.retry.statuses = map[int]bool{}
+			for _, el := range statuses.Elements {
+				n, ok := el.(*object.Number)
+				if !ok {
+					return s, newError("`retry.statuses` option to `%s` must contain NUMBER values, got %s", name, el.Type())
+				}
+				s.retry.statuses[int(n.Value)] = true
+			}
+		}
+	default:
+		return s, newError("`retry` option to `%s` must be NUMBER (count) or MAP, got %s", name, v.Type())
+	}
+	if v, ok := opts.Pairs["redirect"]; ok {
+		mode, ok := v.(*object.String)
+		if !ok || (mode.Value != "follow" && mode.Value != "manual" && mode.Value != "error") {
+			return s, newError("`redirect` option to `%s` must be \"follow\", \"manual\" or \"error\"", name)
+		}
+		s.redirect.mode = mode.Value
+	}
+	if n, ok := opts.Pairs["maxRedirects"].(*object.Number); ok {
+		s.redirect.max = int(n.Value)
+	}
+	if b, ok := opts.Pairs["stream"].(*object.Boolean); ok {
+		s.stream = b.Value
+	}
+	return s, nil
+}
+
+// requestObject builds the {method, url, headers, body} map that request interceptors see
+func (c *httpClient) requestObject(rawURL string, opts *object.Map) (*object.Map, error) {
+	method := "GET"
+	headers := newObjectMap()
+	for k, v := range c.headers {
+		headers.Pairs[k] = &object.String{Value: v}
+	}
+	body := ""
+	if opts != nil {
+		if m, ok := opts.Pairs["method"].(*object.String); ok {
+			method = strings.ToUpper(m.Value)
+		}
+		if h, ok := opts.Pairs["headers"].(*object.Map); ok {
+			for k, v := range h.Pairs {
+				headers.Pairs[k] = v
+			}
+		}
+		if b, ok := opts.Pairs["body"].(*object.String); ok {
+			body = b.Value
+		}
+		if data, ok := opts.Pairs["json"]; ok {
+			body = stringifyJSON(data)
+			if _, ok := headers.Pairs["Content-Type"]; !ok {
+				headers.Pairs["Content-Type"] = &object.String{Value: "application/json"}
+			}
+		}
+		if fields, ok := opts.Pairs["multipart"].(*object.Map); ok {
+			reader, contentType, err := multipartRequestBody(fields)
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			body = string(data)
+			headers.Pairs["Content-Type"] = &object.String{Value: contentType}
+			if _, ok := opts.Pairs["method"]; !ok {
+				method = "POST"
+			}
+		}
+	}
+
+	full, err := c.resolveURL(rawURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	reqObj := newObjectMap()
+	reqObj.Pairs["method"] = &object.String{Value: method}
+	reqObj.Pairs["url"] = &object.String{Value: full}
+	reqObj.Pairs["headers"] = headers
+	reqObj.Pairs["body"] = &object.String{Value: body}
+	return reqObj, nil
+}
+
+// resolveURL joins relative paths onto baseURL and appends the `query` option
+func (c *httpClient) resolveURL(rawURL string, opts *object.Map) (string, error) {
+	if c.baseURL != "" && !strings.Contains(rawURL, "://") {
+		rawURL = strings.TrimRight(c.baseURL, "/") + "/" + strings.TrimLeft(rawURL, "/")
+	}
+	if opts == nil {
+		return rawURL, nil
+	}
+	query, ok := opts.Pairs["query"].(*object.Map)
+	if !ok {
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	values := u.Query()
+	for _, k := range sortedKeys(query) {
+		switch v := query.Pairs[k].(type) {
+		case *object.String:
+			values.Add(k, v.Value)
+		case *object.Array:
+			for _, el := range v.Elements {
+				values.Add(k, el.Inspect())
+			}
+		default:
+			values.Add(k, v.Inspect())
+		}
+	}
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
+
+// do runs one request through the interceptors, retries and response decoding
+func (c *httpClient) do(name, rawURL string, opts *object.Map) object.Object {
+	settings, errObj := c.settings(name, opts)
+	if errObj != nil {
+		return errObj
+	}
+	reqObj, err := c.requestObject(rawURL, opts)
+	if err != nil {
+		return newError("HTTP error: %s", err.Error())
+	}
+
+	c.mu.RLock()
+	before, after := c.before, c.after
+	c.mu.RUnlock()
+	for _, interceptor := range before {
+		result := awaitResult(callHandler(interceptor, []object.Object{reqObj}))
+		if isFailure(result) {
+			return result
+		}
+		if m, ok := result.(*object.Map); ok {
+			reqObj = m
+		}
+	}
+
+	resp, err := c.send(reqObj, settings)
+	if err != nil {
+		return newError("HTTP error: %s", err.Error())
+	}
+	var resObj object.Object
+	if resObj, err = responseObject(resp, settings.stream); err != nil {
+		return newError("error reading response: %s", err.Error())
+	}
+
+	for _, interceptor := range after {
+		result := awaitResult(callHandler(interceptor, []object.Object{resObj}))
+		if isFailure(result) {
+			return result
+		}
+		if m, ok := result.(*object.Map); ok {
+			resObj = m
+		}
+	}
+	return resObj
+}
+
+// send performs the request, retrying network errors and retry statuses with
+// exponential backoff (or the server's Retry-After)
+func (c *httpClient) send(reqObj *object.Map, s requestSettings) (*http.Response, error) {
+	method := strings.ToUpper(objectString(reqObj.Pairs["method"], "GET"))
+	target := objectString(reqObj.Pairs["url"], "")
+	body := objectString(reqObj.Pairs["body"], "")
+	headers, _ := reqObj.Pairs["headers"].(*object.Map)
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		if s.timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), s.timeout)
+		}
+		ctx = context.WithValue(ctx, redirectPolicyKey{}, s.redirect)
+
+		var bodyReader io.Reader
+		if body != "" {
+			bodyReader = bytes.NewReader([]byte(body))
+		}
+		req, err := http.NewRequestWithContext(ctx, method, target, bodyReader)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if headers != nil {
+			for k, v := range headers.Pairs {
+				req.Header.Set(k, v.Inspect())
+			}
+		}
+
+		resp, err := c.client.Do(req)
+		retry := attempt < s.retry.count
+		if err != nil {
+			retry = retry && !errors.Is(err, errRedirectBlocked)
+		} else {
+			retry = retry && s.retry.statuses[resp.StatusCode]
+		}
+		if !retry {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := s.retry.delay << attempt
+		if resp != nil {
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+				wait = time.Duration(secs) * time.Second
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+		time.Sleep(wait)
+	}
+}
+
+// responseObject converts a response to {status, ok, url, headers, body, json} or, for
+// streamed requests, {status, ok, url, headers, stream}
+func responseObject(resp *http.Response, stream bool) (*object.Map, error) {
+	result := newObjectMap()
+	result.Pairs["status"] = &object.Number{Value: float64(resp.StatusCode)}
+	result.Pairs["ok"] = object.NativeBoolToBooleanObject(resp.StatusCode >= 200 && resp.StatusCode < 300)
+	result.Pairs["url"] = &object.String{Value: resp.Request.URL.String()}
+	headers := newObjectMap()
+	for k, v := range resp.Header {
+		headers.Pairs[k] = &object.String{Value: strings.Join(v, ", ")}
+	}
+	result.Pairs["headers"] = headers
+
+	if stream {
+		result.Pairs["body"] = &object.String{Value: ""}
+		result.Pairs["json"] = object.NULL
+		result.Pairs["stream"] = requestBodyStream(nil, &closeAtEOF{ReadCloser: resp.Body})
+		return result, nil
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result.Pairs["body"] = &object.String{Value: string(body)}
+	result.Pairs["json"] = object.NULL
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") && len(body) > 0 {
+		if parsed := parseJSON(string(body)); parsed.Type() != object.ERROR_OBJ {
+			result.Pairs["json"] = parsed
+		}
+	}
+	return result, nil
+}
+
+// cancelOnClose releases the request's timeout context once the body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// closeAtEOF closes a streamed response body once it has been read to the end
+type closeAtEOF struct {
+	io.ReadCloser
+	err error
+}
+
+func (c *closeAtEOF) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.ReadCloser.Read(p)
+	if err != nil {
+		c.err = err
+		c.ReadCloser.Close()
+	}
+	return n, err
+}
+
+// awaitResult waits for a promise and returns its value, or the rejection/error
+func awaitResult(result object.Object) object.Object {
+	promise, ok := result.(*object.Promise)
+	if !ok {
+		return result
+	}
+	promise.Mu.RLock()
+	state, value, err := promise.State, promise.Value, promise.Error
+	promise.Mu.RUnlock()
+	switch state {
+	case object.PROMISE_RESOLVED:
+		return value
+	case object.PROMISE_REJECTED:
+		return err
+	}
+	select {
+	case value := <-promise.ResultChan:
+		return value
+	case err := <-promise.ErrorChan:
+		return err
+	}
+}
+
+func isFailure(obj object.Object) bool {
+	return obj != nil && (obj.Type() == object.ERROR_OBJ || obj.Type() == object.EXCEPTION_OBJ)
+}
+
+func objectString(obj object.Object, fallback string) string {
+	if s, ok := obj.(*object.String); ok {
+		return s.Value
+	}
+	return fallback
+}
+
+func statusSet(statuses []int) map[int]bool {
+	set := make(map[int]bool, len(statuses))
+	for _, s := range statuses {
+		set[s] = true
+	}
+	return set
+}
+
+// httpRequestArgs checks (url, [options]) for anun, anun_async and client methods
+func httpRequestArgs(name string, args []object.Object) (string, *object.Map, *object.Error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", nil, newError("wrong number of arguments. got=%d, want=1-2", len(args))
+	}
+	rawURL, ok := args[0].(*object.String)
+	if !ok {
+		return "", nil, newError("first argument to `%s` must be STRING (url), got %s", name, args[0].Type())
+	}
+	var opts *object.Map
+	if len(args) == 2 {
+		if opts, ok = args[1].(*object.Map); !ok {
+			return "", nil, newError("second argument to `%s` must be MAP (options), got %s", name, args[1].Type())
+		}
+	}
+	return rawURL.Value, opts, nil
+}
+
+// asyncRequest runs fn on a goroutine and returns a promise for its result
+func asyncRequest(fn func() object.Object) *object.Promise {
+	promise := object.CreatePromise()
+	go func() {
+		if result := fn(); isFailure(result) {
+			object.RejectPromise(promise, result)
+		} else {
+			object.ResolvePromise(promise, result)
+		}
+	}()
+	return promise
+}
+
+// clientMap exposes an httpClient to BanglaCode
+func (c *httpClient) clientMap() *object.Map {
+	m := newObjectMap()
+	request := func(name, method string) *object.Builtin {
+		return &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				rawURL, opts, errObj := httpRequestArgs(name, args)
+				if errObj != nil {
+					return errObj
+				}
+				if method != "" {
+					withMethod := newObjectMap()
+					if opts != nil {
+						for k, v := range opts.Pairs {
+							withMethod.Pairs[k] = v
+						}
+					}
+					withMethod.Pairs["method"] = &object.String{Value: method}
+					opts = withMethod
+				}
+				return c.do(name, rawURL, opts)
+			},
+		}
+	}
+
+	// anurodh (অনুরোধ - request) sends any method: client.anurodh(url, {method, ...})
+	m.Pairs["anurodh"] = request("client.anurodh", "")
+	for name, method := range routeMethodNames {
+		m.Pairs[name] = request("client."+name, method)
+	}
+	m.Pairs["anurodh_async"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			rawURL, opts, errObj := httpRequestArgs("client.anurodh_async", args)
+			if errObj != nil {
+				return errObj
+			}
+			return asyncRequest(func() object.Object { return c.do("client.anurodh_async", rawURL, opts) })
+		},
+	}
+
+	// age (আগে - before) / pore (পরে - after) add request / response interceptors
+	interceptor := func(name string, list *[]object.Object) *object.Builtin {
+		return &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 || (args[0].Type() != object.FUNCTION_OBJ && args[0].Type() != object.BUILTIN_OBJ) {
+					return newError("client.%s() takes exactly 1 FUNCTION argument", name)
+				}
+				c.mu.Lock()
+				*list = append(*list, args[0])
+				c.mu.Unlock()
+				return m
+			},
+		}
+	}
+	m.Pairs["age"] = interceptor("age", &c.before)
+	m.Pairs["pore"] = interceptor("pore", &c.after)
+
+	// kukis (কুকিস) returns the jar's cookies for a url as {name: value}
+	m.Pairs["kukis"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 || args[0].Type() != object.STRING_OBJ {
+				return newError("client.kukis() takes exactly 1 STRING argument (url)")
+			}
+			target, err := c.resolveURL(args[0].(*object.String).Value, nil)
+			if err != nil {
+				return newError("client.kukis(): %s", err.Error())
+			}
+			u, err := url.Parse(target)
+			if err != nil {
+				return newError("client.kukis(): %s", err.Error())
+			}
+			cookies := newObjectMap()
+			for _, cookie := range c.client.Jar.Cookies(u) {
+				cookies.Pairs[cookie.Name] = &object.String{Value: cookie.Value}
+			}
+			return cookies
+		},
+	}
+	return m
+}
+
+func init() {
+	// client_banao (ক্লায়েন্ট বানাও - create an HTTP client with defaults and a cookie jar)
+	// client_banao({baseURL, headers, timeout: ms, retry: {count, delay, statuses}, redirect, maxRedirects, proxy, tls})
+	// → {anurodh, ana, pathano, bodlano, songshodhon, mujhe_felo, matha, nirdharon, anurodh_async, age, pore, kukis}
+	Builtins["client_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments. got=%d, want=0-1 (options)", len(args))
+			}
+			var opts *object.Map
+			if len(args) == 1 {
+				var ok bool
+				if opts, ok = args[0].(*object.Map); !ok {
+					return newError("argument to `client_banao` must be MAP (options), got %s", args[0].Type())
+				}
+			}
+			c, errObj := newHTTPClient("client_banao", opts, true)
+			if errObj != nil {
+				return errObj
+			}
+			return c.clientMap()
+		},
+	}
+}
@@ -0,0 +1,338 @@
+package builtins
+
+import (
+	"BanglaCode/src/evaluator/builtins/graphql"
+	"BanglaCode/src/object"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A GraphQL schema is written in SDL and its resolvers are BanglaCode functions keyed by
+// type and field: {Query: {user: kaj(parent, args, context, info) {...}}}. Resolvers may
+// be proyash kaj; their promises are awaited. Fields without a resolver read the property
+// of the same name from the parent value. Interfaces and unions pick the concrete type
+// with a __resolveType resolver or a __typename key on the value.
+
+// Global schema registry — maps pointer string → *graphql.Schema.
+var (
+	graphqlRegistry   = make(map[string]*graphql.Schema)
+	graphqlRegistryMu sync.RWMutex
+)
+
+func extractGraphQLSchema(fn string, arg object.Object) (*graphql.Schema, object.Object) {
+	m, ok := arg.(*object.Map)
+	if !ok {
+		return nil, newError("argument to `%s` must be a SCHEMA (from graphql_banao()), got %s", fn, arg.Type())
+	}
+	id, ok := m.Pairs["__graphql_id__"].(*object.String)
+	if !ok {
+		return nil, newError("argument to `%s` is not a valid GraphQL schema", fn)
+	}
+	graphqlRegistryMu.RLock()
+	defer graphqlRegistryMu.RUnlock()
+	schema, found := graphqlRegistry[id.Value]
+	if !found {
+		return nil, newError("`%s`: schema not found — was it created with graphql_banao()?", fn)
+	}
+	return schema, nil
+}
+
+// setGraphQLResolvers attaches {Type: {field: kaj}} to the schema
+func setGraphQLResolvers(schema *graphql.Schema, resolvers *object.Map) object.Object {
+	typeNames := make([]string, 0, len(resolvers.Pairs))
+	for name := range resolvers.Pairs {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		if schema.Type(typeName) == nil {
+			return newError("graphql_banao: resolvers given for unknown type %q", typeName)
+		}
+		fields, ok := resolvers.Pairs[typeName].(*object.Map)
+		if !ok {
+			return newError("graphql_banao: resolvers for %s must be MAP, got %s", typeName, resolvers.Pairs[typeName].Type())
+		}
+		for fieldName, fn := range fields.Pairs {
+			if fn.Type() != object.FUNCTION_OBJ && fn.Type() != object.BUILTIN_OBJ {
+				return newError("graphql_banao: resolver %s.%s must be FUNCTION, got %s", typeName, fieldName, fn.Type())
+			}
+			if err := schema.SetResolver(typeName, fieldName, fn); err != nil {
+				return newError("graphql_banao: %s", err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// parseGraphQLOptions reads {maxDepth, maxComplexity, introspection}
+func parseGraphQLOptions(schema *graphql.Schema, opts *object.Map) object.Object {
+	schema.MaxDepth = 15
+	if opts == nil {
+		return nil
+	}
+	for _, key := range []string{"maxDepth", "maxComplexity"} {
+		v, ok := opts.Pairs[key]
+		if !ok || v == object.NULL {
+			continue
+		}
+		n, ok := v.(*object.Number)
+		if !ok || n.Value < 0 {
+			return newError("`%s` option to `graphql_banao` must be a non-negative NUMBER, got %s", key, v.Inspect())
+		}
+		if key == "maxDepth" {
+			schema.MaxDepth = int(n.Value)
+		} else {
+			schema.MaxComplexity = int(n.Value)
+		}
+	}
+	if v, ok := opts.Pairs["introspection"]; ok {
+		b, ok := v.(*object.Boolean)
+		if !ok {
+			return newError("`introspection` option to `graphql_banao` must be BOOLEAN, got %s", v.Type())
+		}
+		schema.Introspection = b.Value
+	}
+	return nil
+}
+
+// graphqlResultMap converts a result to {data, errors} for BanglaCode
+func graphqlResultMap(result *graphql.Result) *object.Map {
+	m := newObjectMap()
+	if result.Executed {
+		m.Pairs["data"] = graphql.ToObject(result.Data)
+	}
+	if len(result.Errors) > 0 {
+		m.Pairs["errors"] = graphql.ToObject(result.Errors)
+	}
+	return m
+}
+
+// graphqlHTTPParams reads a GraphQL-over-HTTP request: GET ?query=&variables=&operationName=,
+// or POST with a JSON body {query, variables, operationName} or an application/graphql body
+func graphqlHTTPParams(reqMap *object.Map) (graphql.Params, string) {
+	var p graphql.Params
+	method := objectString(reqMap.Pairs["method"], "")
+	var query, variables, operationName object.Object = object.NULL, object.NULL, object.NULL
+
+	switch method {
+	case "GET":
+		p.ReadOnly = true
+		q, _ := reqMap.Pairs["query"].(*object.Map)
+		if q == nil {
+			break
+		}
+		query, operationName = mapPropertyValue(q, "query"), mapPropertyValue(q, "operationName")
+		if raw, ok := q.Pairs["variables"].(*object.String); ok && raw.Value != "" {
+			if variables = parseJSON(raw.Value); isFailure(variables) {
+				return p, "Variables are invalid JSON."
+			}
+		}
+	default:
+		headers, _ := reqMap.Pairs["headers"].(*object.Map)
+		contentType := ""
+		if headers != nil {
+			contentType = objectString(headers.Pairs["Content-Type"], "")
+		}
+		switch {
+		case strings.Contains(contentType, "application/graphql"):
+			query = reqMap.Pairs["body"]
+		case strings.Contains(contentType, "application/json"):
+			body, ok := reqMap.Pairs["json"].(*object.Map)
+			if !ok {
+				return p, "POST body must be a JSON object."
+			}
+			query = mapPropertyValue(body, "query")
+			variables = mapPropertyValue(body, "variables")
+			operationName = mapPropertyValue(body, "operationName")
+		default:
+			return p, "POST body must be application/json or application/graphql."
+		}
+	}
+
+	str, ok := query.(*object.String)
+	if !ok || strings.TrimSpace(str.Value) == "" {
+		return p, "Must provide query string."
+	}
+	p.Query = str.Value
+	switch v := variables.(type) {
+	case *object.Map:
+		p.Variables = v
+	case *object.Null:
+	default:
+		return p, "Variables must be an object."
+	}
+	p.OperationName = objectString(operationName, "")
+	return p, ""
+}
+
+func writeGraphQLResponse(resMap *object.Map, result *graphql.Result) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		body = []byte(`{"errors":[{"message":"could not encode response"}]}`)
+		result.Status = http.StatusInternalServerError
+	}
+	headers := resMap.Pairs["headers"].(*object.Map)
+	headers.Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+	if result.Status == http.StatusMethodNotAllowed {
+		headers.Pairs["Allow"] = &object.String{Value: "POST"}
+	}
+	resMap.Pairs["status"] = &object.Number{Value: float64(result.Status)}
+	resMap.Pairs["body"] = &object.String{Value: string(body)}
+}
+
+func init() {
+	// graphql_banao (GraphQL বানাও - build a schema)
+	// graphql_banao(sdl, {Query: {user: kaj(parent, args, context, info) {...}}}, {maxDepth: 15, maxComplexity: 1000, introspection: sotti}?)
+	// Returns a SCHEMA with chalao(query, variables?, {operationName, context, root}?) → {data, errors}
+	Builtins["graphql_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1-3 (sdl, resolvers?, options?)", len(args))
+			}
+			sdl, ok := args[0].(*object.String)
+			if !ok {
+				return newError("first argument to `graphql_banao` must be STRING (sdl), got %s", args[0].Type())
+			}
+			schema, err := graphql.BuildSchema(sdl.Value)
+			if err != nil {
+				return newError("graphql_banao: %s", err.Error())
+			}
+			schema.Call = func(fn object.Object, fnArgs []object.Object) object.Object {
+				return awaitResult(callHandler(fn, fnArgs))
+			}
+			schema.Property = mapPropertyValue
+
+			if len(args) >= 2 && args[1] != object.NULL {
+				resolvers, ok := args[1].(*object.Map)
+				if !ok {
+					return newError("second argument to `graphql_banao` must be MAP (resolvers), got %s", args[1].Type())
+				}
+				if errObj := setGraphQLResolvers(schema, resolvers); errObj != nil {
+					return errObj
+				}
+			}
+			var opts *object.Map
+			if len(args) == 3 {
+				if opts, ok = args[2].(*object.Map); !ok {
+					return newError("third argument to `graphql_banao` must be MAP (options), got %s", args[2].Type())
+				}
+			}
+			if errObj := parseGraphQLOptions(schema, opts); errObj != nil {
+				return errObj
+			}
+
+			id := fmt.Sprintf("%p", schema)
+			graphqlRegistryMu.Lock()
+			graphqlRegistry[id] = schema
+			graphqlRegistryMu.Unlock()
+
+			schemaMap := newObjectMap()
+			schemaMap.Pairs["__graphql_id__"] = &object.String{Value: id}
+			// chalao (চালাও - run) executes a query against the schema
+			schemaMap.Pairs["chalao"] = &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					if len(args) < 1 || len(args) > 3 {
+						return newError("wrong number of arguments. got=%d, want=1-3 (query, variables?, options?)", len(args))
+					}
+					query, ok := args[0].(*object.String)
+					if !ok {
+						return newError("first argument to `chalao` must be STRING (query), got %s", args[0].Type())
+					}
+					p := graphql.Params{Query: query.Value}
+					if len(args) >= 2 && args[1] != object.NULL {
+						if p.Variables, ok = args[1].(*object.Map); !ok {
+							return newError("second argument to `chalao` must be MAP (variables), got %s", args[1].Type())
+						}
+					}
+					if len(args) == 3 {
+						opts, ok := args[2].(*object.Map)
+						if !ok {
+							return newError("third argument to `chalao` must be MAP (options), got %s", args[2].Type())
+						}
+						p.OperationName = objectString(opts.Pairs["operationName"], "")
+						p.Context, p.Root = opts.Pairs["context"], opts.Pairs["root"]
+					}
+					return graphqlResultMap(schema.Execute(p))
+				},
+			}
+			return schemaMap
+		},
+	}
+
+	// graphql_chalu (GraphQL চালু - serve a schema on the router)
+	// graphql_chalu(app, "/graphql", schema, {majhe: auth, context: kaj(req, res) {...}, root: {...}}?)
+	// Accepts GET (queries only) and POST (JSON or application/graphql). The context
+	// defaults to {req, res}.
+	Builtins["graphql_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 3 || len(args) > 4 {
+				return newError("wrong number of arguments. got=%d, want=3-4 (app, path, schema, options?)", len(args))
+			}
+			router, errObj := extractRouter("graphql_chalu", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			path, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `graphql_chalu` must be STRING (path), got %s", args[1].Type())
+			}
+			schema, errObj := extractGraphQLSchema("graphql_chalu", args[2])
+			if errObj != nil {
+				return errObj
+			}
+			var contextFn, root object.Object
+			if len(args) == 4 {
+				opts, ok := args[3].(*object.Map)
+				if !ok {
+					return newError("fourth argument to `graphql_chalu` must be MAP (options), got %s", args[3].Type())
+				}
+				if fn, ok := opts.Pairs["context"]; ok && fn != object.NULL {
+					if fn.Type() != object.FUNCTION_OBJ && fn.Type() != object.BUILTIN_OBJ {
+						return newError("`context` option to `graphql_chalu` must be FUNCTION, got %s", fn.Type())
+					}
+					contextFn = fn
+				}
+				root = opts.Pairs["root"]
+			}
+			routeOpts, optErr := routeOptions("graphql_chalu", args[1:])
+			if optErr != nil {
+				return optErr
+			}
+			routeOpts.Hidden = true
+
+			handler := &object.Builtin{
+				Fn: func(handlerArgs ...object.Object) object.Object {
+					reqMap, resMap := handlerArgs[0].(*object.Map), handlerArgs[1].(*object.Map)
+					p, msg := graphqlHTTPParams(reqMap)
+					if msg != "" {
+						writeGraphQLResponse(resMap, &graphql.Result{Errors: []*graphql.Error{{Message: msg}}, Status: http.StatusBadRequest})
+						return object.NULL
+					}
+					p.Root = root
+					if contextFn != nil {
+						p.Context = awaitResult(callHandler(contextFn, []object.Object{reqMap, resMap}))
+						if isFailure(p.Context) {
+							return p.Context
+						}
+					} else {
+						ctx := newObjectMap()
+						ctx.Pairs["req"], ctx.Pairs["res"] = reqMap, resMap
+						p.Context = ctx
+					}
+					writeGraphQLResponse(resMap, schema.Execute(p))
+					return object.NULL
+				},
+			}
+			for _, method := range []string{"GET", "POST"} {
+				if err := router.AddRoute(method, path.Value, handler, routeOpts); err != nil {
+					return newError("graphql_chalu: %s", err.Error())
+				}
+			}
+			return args[0]
+		},
+	}
+}
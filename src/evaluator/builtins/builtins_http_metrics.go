@@ -0,0 +1,572 @@
+package builtins
+
+import (
+	"BanglaCode/src/evaluator/builtins/metrics"
+	"BanglaCode/src/object"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics live in one process-wide registry: counter_banao/gauge_banao/histogram_banao
+// declare user metrics, metrics_chalu adds the http_* request metrics of a router, and
+// metrics_lekho() or the mounted route exports everything in the Prometheus text format.
+
+var metricsRegistry = metrics.NewRegistry()
+
+// routerMetrics are the request metrics recorded by a router after metrics_chalu
+type routerMetrics struct {
+	requests *metrics.Family // http_requests_total{method, route, status}
+	duration *metrics.Family // http_request_duration_seconds{method, route, status}
+	inFlight *metrics.Family // http_requests_in_flight
+}
+
+func newRouterMetrics(buckets []float64) (*routerMetrics, error) {
+	labels := []string{"method", "route", "status"}
+	requests, err := metricsRegistry.Register("http_requests_total", "HTTP requests served, by method, route pattern and status.", metrics.Counter, labels, nil)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := metricsRegistry.Register("http_request_duration_seconds", "HTTP request latency in seconds, by method, route pattern and status.", metrics.Histogram, labels, buckets)
+	if err != nil {
+		return nil, err
+	}
+	inFlight, err := metricsRegistry.Register("http_requests_in_flight", "HTTP requests currently being served.", metrics.Gauge, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &routerMetrics{requests: requests, duration: duration, inFlight: inFlight}, nil
+}
+
+// accessLogger writes one line per request, as text or as a JSON object
+type accessLogger struct {
+	json bool
+	file string // appended to, reopened for every line so rotated logs are picked up ("" = stdout)
+	mu   sync.Mutex
+}
+
+func (l *accessLogger) write(line []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == "" {
+		os.Stdout.Write(line)
+		return
+	}
+	f, err := os.OpenFile(l.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		fmt.Printf("🔴 [BanglaCode] access log: %s\n", err.Error())
+		return
+	}
+	f.Write(line)
+	f.Close()
+}
+
+// accessEntry is a JSON access log line
+type accessEntry struct {
+	Time       string  `json:"time"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	Query      string  `json:"query,omitempty"`
+	Route      string  `json:"route"`
+	Status     int     `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Bytes      int64   `json:"bytes"`
+	IP         string  `json:"ip"`
+	UserAgent  string  `json:"user_agent,omitempty"`
+	RequestID  string  `json:"request_id,omitempty"`
+}
+
+// accessRecorder remembers the status and size of a response for metrics and access logs
+type accessRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (ar *accessRecorder) WriteHeader(status int) {
+	if ar.status == 0 || ar.status < 200 {
+		ar.status = status
+	}
+	ar.ResponseWriter.WriteHeader(status)
+}
+
+func (ar *accessRecorder) Write(p []byte) (int, error) {
+	if ar.status == 0 {
+		ar.status = http.StatusOK
+	}
+	n, err := ar.ResponseWriter.Write(p)
+	ar.bytes += int64(n)
+	return n, err
+}
+
+// Hijack lets WebSocket routes take over the connection through the recorder
+func (ar *accessRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(ar.ResponseWriter).Hijack()
+	if err == nil && ar.status == 0 {
+		ar.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap lets http.ResponseController reach the connection (flushes, full duplex)
+func (ar *accessRecorder) Unwrap() http.ResponseWriter {
+	return ar.ResponseWriter
+}
+
+// observeRequest records a finished request in the router's metrics and access log
+func observeRequest(m *routerMetrics, l *accessLogger, req *http.Request, rec *accessRecorder, route string, start time.Time) {
+	elapsed := time.Since(start)
+	status := rec.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if route == "" {
+		route = "unmatched" // keeps unknown paths from creating a series each
+	}
+	if m != nil {
+		method := req.Method
+		if !slices.Contains(routeMethods, method) {
+			method = "OTHER" // made-up methods would otherwise create a series each
+		}
+		labels := []string{method, route, strconv.Itoa(status)}
+		m.inFlight.Add(nil, -1)
+		m.requests.Add(labels, 1)
+		m.duration.Observe(labels, elapsed.Seconds())
+	}
+	if l != nil {
+		var line []byte
+		if l.json {
+			line, _ = json.Marshal(accessEntry{
+				Time:       start.UTC().Format(time.RFC3339Nano),
+				Method:     req.Method,
+				Path:       req.URL.Path,
+				Query:      req.URL.RawQuery,
+				Route:      route,
+				Status:     status,
+				DurationMs: float64(elapsed.Microseconds()) / 1000,
+				Bytes:      rec.bytes,
+				IP:         getClientIP(req),
+				UserAgent:  req.UserAgent(),
+				RequestID:  req.Header.Get("X-Request-Id"),
+			})
+		} else {
+			line = fmt.Appendf(nil, "🔵 [BanglaCode] %s %s → %d (%v)", req.Method, req.URL.Path, status, elapsed)
+		}
+		l.write(append(line, '\n'))
+	}
+}
+
+// parseLogOptions reads log_chalu options: {format: "text" | "json", file}
+func parseLogOptions(m *object.Map) (*accessLogger, *object.Error) {
+	logger := &accessLogger{}
+	switch v := m.Pairs["format"].(type) {
+	case nil:
+	case *object.String:
+		if v.Value != "text" && v.Value != "json" {
+			return nil, newError("`format` option to `log_chalu` must be \"text\" or \"json\", got %q", v.Value)
+		}
+		logger.json = v.Value == "json"
+	default:
+		return nil, newError("`format` option to `log_chalu` must be STRING, got %s", v.Type())
+	}
+	switch v := m.Pairs["file"].(type) {
+	case nil:
+	case *object.String:
+		f, err := os.OpenFile(v.Value, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, newError("log_chalu: could not open log file '%s': %s", v.Value, err.Error())
+		}
+		f.Close()
+		logger.file = v.Value
+	default:
+		return nil, newError("`file` option to `log_chalu` must be STRING (path), got %s", v.Type())
+	}
+	return logger, nil
+}
+
+// metricLabelNames reads the optional ARRAY of label names given to the *_banao builtins
+func metricLabelNames(fn string, arg object.Object) ([]string, *object.Error) {
+	arr, ok := arg.(*object.Array)
+	if !ok {
+		return nil, newError("third argument to `%s` must be ARRAY of STRING (label names), got %s", fn, arg.Type())
+	}
+	names := make([]string, 0, len(arr.Elements))
+	for _, el := range arr.Elements {
+		s, ok := el.(*object.String)
+		if !ok {
+			return nil, newError("third argument to `%s` must be ARRAY of STRING (label names), got %s in array", fn, el.Type())
+		}
+		names = append(names, s.Value)
+	}
+	return names, nil
+}
+
+// metricLabelValues turns a {label: value} MAP into values in the family's label order
+func metricLabelValues(method string, f *metrics.Family, arg object.Object) ([]string, *object.Error) {
+	var pairs map[string]object.Object
+	switch v := arg.(type) {
+	case nil:
+	case *object.Map:
+		pairs = v.Pairs
+	default:
+		return nil, newError("%s(): labels must be MAP, got %s", method, arg.Type())
+	}
+	values := make([]string, len(f.LabelNames))
+	for i, name := range f.LabelNames {
+		v, ok := pairs[name]
+		if !ok {
+			return nil, newError("%s(): missing label %q for %s", method, name, f.Name)
+		}
+		values[i] = objectString(v, "")
+	}
+	if len(pairs) > len(values) {
+		for name := range pairs {
+			if !slices.Contains(f.LabelNames, name) {
+				return nil, newError("%s(): unknown label %q for %s", method, name, f.Name)
+			}
+		}
+	}
+	return values, nil
+}
+
+// metricAmount splits the (amount?, labels?) arguments of barao/komao
+func metricAmount(method string, args []object.Object) (float64, object.Object, *object.Error) {
+	amount := 1.0
+	if len(args) > 0 {
+		if n, ok := args[0].(*object.Number); ok {
+			amount, args = n.Value, args[1:]
+		}
+	}
+	if len(args) > 1 {
+		return 0, nil, newError("wrong number of arguments to %s(). got=%d, want=0-2 (amount?, labels?)", method, len(args)+1)
+	}
+	if len(args) == 1 {
+		return amount, args[0], nil
+	}
+	return amount, nil, nil
+}
+
+// metricValue builds maan (মান - value): the current value, or a histogram's observation count
+func metricValue(f *metrics.Family) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments to maan(). got=%d, want=0-1 (labels?)", len(args))
+			}
+			var labelArg object.Object
+			if len(args) == 1 {
+				labelArg = args[0]
+			}
+			labels, errObj := metricLabelValues("maan", f, labelArg)
+			if errObj != nil {
+				return errObj
+			}
+			v, err := f.Value(labels)
+			if err != nil {
+				return newError("maan(): %s", err.Error())
+			}
+			return &object.Number{Value: v}
+		},
+	}
+}
+
+// metricAdder builds barao (sign 1) or komao (sign -1)
+func metricAdder(method string, f *metrics.Family, sign float64) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			amount, labelArg, errObj := metricAmount(method, args)
+			if errObj != nil {
+				return errObj
+			}
+			labels, errObj := metricLabelValues(method, f, labelArg)
+			if errObj != nil {
+				return errObj
+			}
+			if err := f.Add(labels, sign*amount); err != nil {
+				return newError("%s(): %s", method, err.Error())
+			}
+			return object.NULL
+		},
+	}
+}
+
+// registerMetric reads (name, help, labels?) and registers the family
+func registerMetric(fn string, kind metrics.Kind, args []object.Object, buckets []float64) (*metrics.Family, object.Object) {
+	name, ok := args[0].(*object.String)
+	if !ok {
+		return nil, newError("first argument to `%s` must be STRING (name), got %s", fn, args[0].Type())
+	}
+	help, ok := args[1].(*object.String)
+	if !ok {
+		return nil, newError("second argument to `%s` must be STRING (help), got %s", fn, args[1].Type())
+	}
+	var labels []string
+	if len(args) > 2 && args[2] != object.NULL {
+		var errObj *object.Error
+		if labels, errObj = metricLabelNames(fn, args[2]); errObj != nil {
+			return nil, errObj
+		}
+	}
+	f, err := metricsRegistry.Register(name.Value, help.Value, kind, labels, buckets)
+	if err != nil {
+		return nil, newError("%s: %s", fn, err.Error())
+	}
+	return f, nil
+}
+
+// histogramBuckets reads an ARRAY of NUMBER bucket bounds
+func histogramBuckets(fn string, arg object.Object) ([]float64, object.Object) {
+	arr, ok := arg.(*object.Array)
+	if !ok {
+		return nil, newError("`buckets` for `%s` must be ARRAY of NUMBER, got %s", fn, arg.Type())
+	}
+	buckets := make([]float64, 0, len(arr.Elements))
+	for _, el := range arr.Elements {
+		n, ok := el.(*object.Number)
+		if !ok {
+			return nil, newError("`buckets` for `%s` must be ARRAY of NUMBER, got %s in array", fn, el.Type())
+		}
+		buckets = append(buckets, n.Value)
+	}
+	return buckets, nil
+}
+
+// metricsText renders the registry in the Prometheus text format
+func metricsText() string {
+	var sb strings.Builder
+	metricsRegistry.WriteText(&sb)
+	return sb.String()
+}
+
+func init() {
+	// counter_banao (কাউন্টার বানাও - create a counter)
+	// dhoro jobs = counter_banao("jobs_total", "Jobs processed", ["queue"]);
+	// jobs.barao({queue: "email"}); jobs.barao(5, {queue: "sms"}); jobs.maan({queue: "email"})
+	Builtins["counter_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3 (name, help, labels?)", len(args))
+			}
+			f, errObj := registerMetric("counter_banao", metrics.Counter, args, nil)
+			if errObj != nil {
+				return errObj
+			}
+			handle := newObjectMap()
+			handle.Pairs["naam"] = &object.String{Value: f.Name}
+			handle.Pairs["barao"] = metricAdder("barao", f, 1)
+			handle.Pairs["maan"] = metricValue(f)
+			return handle
+		},
+	}
+
+	// gauge_banao (গেজ বানাও - create a gauge)
+	// dhoro queue = gauge_banao("queue_length", "Jobs waiting");
+	// queue.rakho(12); queue.barao(); queue.komao(2)
+	Builtins["gauge_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3 (name, help, labels?)", len(args))
+			}
+			f, errObj := registerMetric("gauge_banao", metrics.Gauge, args, nil)
+			if errObj != nil {
+				return errObj
+			}
+			handle := newObjectMap()
+			handle.Pairs["naam"] = &object.String{Value: f.Name}
+			handle.Pairs["barao"] = metricAdder("barao", f, 1)
+			handle.Pairs["komao"] = metricAdder("komao", f, -1)
+			handle.Pairs["maan"] = metricValue(f)
+
+			// rakho (রাখো - set) replaces the value
+			handle.Pairs["rakho"] = &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					if len(args) < 1 || len(args) > 2 {
+						return newError("wrong number of arguments to rakho(). got=%d, want=1-2 (value, labels?)", len(args))
+					}
+					n, ok := args[0].(*object.Number)
+					if !ok {
+						return newError("first argument to rakho() must be NUMBER, got %s", args[0].Type())
+					}
+					var labelArg object.Object
+					if len(args) == 2 {
+						labelArg = args[1]
+					}
+					labels, errObj := metricLabelValues("rakho", f, labelArg)
+					if errObj != nil {
+						return errObj
+					}
+					if err := f.Set(labels, n.Value); err != nil {
+						return newError("rakho(): %s", err.Error())
+					}
+					return object.NULL
+				},
+			}
+			return handle
+		},
+	}
+
+	// histogram_banao (হিস্টোগ্রাম বানাও - create a histogram)
+	// dhoro latency = histogram_banao("db_query_seconds", "Query time", ["table"], [0.01, 0.1, 1]);
+	// latency.mapo(0.042, {table: "users"}); dhoro sesh = latency.somoy({table: "users"}); ... sesh();
+	Builtins["histogram_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 4 {
+				return newError("wrong number of arguments. got=%d, want=2-4 (name, help, labels?, buckets?)", len(args))
+			}
+			var buckets []float64
+			if len(args) == 4 {
+				var errObj object.Object
+				if buckets, errObj = histogramBuckets("histogram_banao", args[3]); errObj != nil {
+					return errObj
+				}
+			}
+			f, errObj := registerMetric("histogram_banao", metrics.Histogram, args, buckets)
+			if errObj != nil {
+				return errObj
+			}
+			observe := func(method string, value float64, labelArg object.Object) object.Object {
+				labels, errObj := metricLabelValues(method, f, labelArg)
+				if errObj != nil {
+					return errObj
+				}
+				if err := f.Observe(labels, value); err != nil {
+					return newError("%s(): %s", method, err.Error())
+				}
+				return nil
+			}
+			handle := newObjectMap()
+			handle.Pairs["naam"] = &object.String{Value: f.Name}
+			handle.Pairs["maan"] = metricValue(f)
+
+			// mapo (মাপো - measure) records one observation
+			handle.Pairs["mapo"] = &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					if len(args) < 1 || len(args) > 2 {
+						return newError("wrong number of arguments to mapo(). got=%d, want=1-2 (value, labels?)", len(args))
+					}
+					n, ok := args[0].(*object.Number)
+					if !ok {
+						return newError("first argument to mapo() must be NUMBER, got %s", args[0].Type())
+					}
+					var labelArg object.Object
+					if len(args) == 2 {
+						labelArg = args[1]
+					}
+					if errObj := observe("mapo", n.Value, labelArg); errObj != nil {
+						return errObj
+					}
+					return object.NULL
+				},
+			}
+
+			// somoy (সময় - time) starts a timer; calling the returned function records the
+			// seconds elapsed and returns them
+			handle.Pairs["somoy"] = &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					if len(args) > 1 {
+						return newError("wrong number of arguments to somoy(). got=%d, want=0-1 (labels?)", len(args))
+					}
+					var labelArg object.Object
+					if len(args) == 1 {
+						labelArg = args[0]
+					}
+					if _, errObj := metricLabelValues("somoy", f, labelArg); errObj != nil {
+						return errObj
+					}
+					start := time.Now()
+					return &object.Builtin{
+						Fn: func(_ ...object.Object) object.Object {
+							seconds := time.Since(start).Seconds()
+							if errObj := observe("somoy", seconds, labelArg); errObj != nil {
+								return errObj
+							}
+							return &object.Number{Value: seconds}
+						},
+					}
+				},
+			}
+			return handle
+		},
+	}
+
+	// metrics_lekho (মেট্রিক্স লেখো - write metrics) returns every metric in the Prometheus text format
+	Builtins["metrics_lekho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("wrong number of arguments. got=%d, want=0", len(args))
+			}
+			return &object.String{Value: metricsText()}
+		},
+	}
+
+	// metrics_chalu (মেট্রিক্স চালু - enable metrics) records request counts, latency and
+	// in-flight requests for the router and serves all metrics on a route
+	// metrics_chalu(app)                                  → GET /metrics
+	// metrics_chalu(app, "/internal/metrics", {buckets: [0.1, 0.5, 1], majhe: basic_auth})
+	Builtins["metrics_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1-3 (app, path?, options?)", len(args))
+			}
+			router, errObj := extractRouter("metrics_chalu", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			path := "/metrics"
+			if len(args) > 1 {
+				s, ok := args[1].(*object.String)
+				if !ok {
+					return newError("second argument to `metrics_chalu` must be STRING (path), got %s", args[1].Type())
+				}
+				path = s.Value
+			}
+			var buckets []float64
+			routeArgs := []object.Object{args[0], &object.String{Value: path}}
+			if len(args) == 3 {
+				opts, ok := args[2].(*object.Map)
+				if !ok {
+					return newError("third argument to `metrics_chalu` must be MAP (options), got %s", args[2].Type())
+				}
+				if v, ok := opts.Pairs["buckets"]; ok {
+					if buckets, errObj = histogramBuckets("metrics_chalu", v); errObj != nil {
+						return errObj
+					}
+				}
+				routeArgs = append(routeArgs, opts)
+			}
+			m, err := newRouterMetrics(buckets)
+			if err != nil {
+				return newError("metrics_chalu: %s", err.Error())
+			}
+
+			routeOpts, optErr := routeOptions("metrics_chalu", routeArgs)
+			if optErr != nil {
+				return optErr
+			}
+			routeOpts.Hidden = true
+			handler := &object.Builtin{
+				Fn: func(handlerArgs ...object.Object) object.Object {
+					resMap := handlerArgs[1].(*object.Map)
+					resMap.Pairs["body"] = &object.String{Value: metricsText()}
+					resMap.Pairs["headers"].(*object.Map).Pairs["Content-Type"] = &object.String{Value: "text/plain; version=0.0.4; charset=utf-8"}
+					return object.NULL
+				},
+			}
+			if addErr := router.AddRoute("GET", path, handler, routeOpts); addErr != nil {
+				return newError("metrics_chalu: %s", addErr.Error())
+			}
+			router.mu.Lock()
+			router.metrics = m
+			router.mu.Unlock()
+			return args[0]
+		},
+	}
+}
@@ -0,0 +1,238 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// multipartMemoryLimit is the largest file part kept in memory as a Buffer; bigger
+// parts are written to a temp file that is removed once the request is finished.
+const multipartMemoryLimit = 1 << 20
+
+// multipartUpload is a parsed multipart/form-data request
+type multipartUpload struct {
+	form      *object.Map // field name → first value
+	files     *object.Map // field name → file map (first part with that name)
+	tempFiles []string
+}
+
+// isMultipartRequest reports whether the request carries multipart/form-data
+func isMultipartRequest(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// readRequestBody reads a whole request body, parsing multipart/form-data uploads
+// part by part instead of buffering them
+func readRequestBody(req *http.Request, body io.Reader) ([]byte, *multipartUpload, error) {
+	if isMultipartRequest(req) {
+		upload, err := parseMultipartBody(req, body)
+		return nil, upload, err
+	}
+	data, err := io.ReadAll(body)
+	return data, nil, err
+}
+
+// parseMultipartBody reads a multipart/form-data body part by part. Fields become
+// strings; files become {name, filename, content_type, size, data, path} where small
+// parts are held in `data` (Buffer) and large ones are spilled to `path`.
+func parseMultipartBody(req *http.Request, body io.Reader) (*multipartUpload, error) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, errors.New("multipart body has no boundary")
+	}
+
+	upload := &multipartUpload{
+		form:  &object.Map{Pairs: make(map[string]object.Object)},
+		files: &object.Map{Pairs: make(map[string]object.Object)},
+	}
+	reader := multipart.NewReader(body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return upload, nil
+		}
+		if err != nil {
+			upload.cleanup()
+			return nil, err
+		}
+
+		name := part.FormName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+		if part.FileName() == "" {
+			value, err := io.ReadAll(part)
+			part.Close()
+			if err != nil {
+				upload.cleanup()
+				return nil, err
+			}
+			if _, exists := upload.form.Pairs[name]; !exists {
+				upload.form.Pairs[name] = &object.String{Value: string(value)}
+			}
+			continue
+		}
+
+		file, err := upload.readFilePart(part)
+		part.Close()
+		if err != nil {
+			upload.cleanup()
+			return nil, err
+		}
+		if _, exists := upload.files.Pairs[name]; !exists {
+			upload.files.Pairs[name] = file
+		}
+	}
+}
+
+// readFilePart buffers one file part, spilling it to a temp file past multipartMemoryLimit
+func (u *multipartUpload) readFilePart(part *multipart.Part) (*object.Map, error) {
+	contentType := part.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	file := &object.Map{Pairs: make(map[string]object.Object, 6)}
+	file.Pairs["name"] = &object.String{Value: part.FormName()}
+	file.Pairs["filename"] = &object.String{Value: filepath.Base(part.FileName())}
+	file.Pairs["content_type"] = &object.String{Value: contentType}
+
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, part, multipartMemoryLimit+1)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n <= multipartMemoryLimit {
+		file.Pairs["size"] = &object.Number{Value: float64(n)}
+		file.Pairs["data"] = object.NewBuffer(buf.Bytes())
+		file.Pairs["path"] = object.NULL
+		return file, nil
+	}
+
+	tmp, err := os.CreateTemp("", "banglacode-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	u.tempFiles = append(u.tempFiles, tmp.Name())
+	written, err := io.Copy(tmp, io.MultiReader(&buf, part))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	file.Pairs["size"] = &object.Number{Value: float64(written)}
+	file.Pairs["data"] = object.NULL
+	file.Pairs["path"] = &object.String{Value: tmp.Name()}
+	return file, nil
+}
+
+// attach exposes the upload on a request map as req.form and req.files
+func (u *multipartUpload) attach(reqMap *object.Map) {
+	reqMap.Pairs["form"] = u.form
+	reqMap.Pairs["files"] = u.files
+}
+
+// cleanup removes temp files created for large uploads
+func (u *multipartUpload) cleanup() {
+	for _, path := range u.tempFiles {
+		os.Remove(path)
+	}
+	u.tempFiles = nil
+}
+
+// multipartRequestBody encodes the `multipart` option of anun. Plain values become
+// fields; maps with `filename` become file parts read from `data` or `path`. Arrays
+// send several parts under the same name.
+func multipartRequestBody(fields *object.Map) (io.Reader, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	names := make([]string, 0, len(fields.Pairs))
+	for name := range fields.Pairs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := []object.Object{fields.Pairs[name]}
+		if arr, ok := fields.Pairs[name].(*object.Array); ok {
+			values = arr.Elements
+		}
+		for _, value := range values {
+			if err := writeMultipartValue(writer, name, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, writer.FormDataContentType(), nil
+}
+
+func writeMultipartValue(writer *multipart.Writer, name string, value object.Object) error {
+	file, ok := value.(*object.Map)
+	if !ok {
+		return writer.WriteField(name, string(chunkBytes(value)))
+	}
+	filename, ok := file.Pairs["filename"].(*object.String)
+	if !ok {
+		return fmt.Errorf("multipart file %q needs a `filename`", name)
+	}
+
+	var data []byte
+	switch {
+	case file.Pairs["data"] != nil && file.Pairs["data"] != object.NULL:
+		data = chunkBytes(file.Pairs["data"])
+	case file.Pairs["path"] != nil:
+		path, ok := file.Pairs["path"].(*object.String)
+		if !ok {
+			return fmt.Errorf("multipart file %q: `path` must be STRING", name)
+		}
+		content, err := os.ReadFile(path.Value)
+		if err != nil {
+			return fmt.Errorf("multipart file %q: %s", name, err.Error())
+		}
+		data = content
+	default:
+		return fmt.Errorf("multipart file %q needs `data` or `path`", name)
+	}
+
+	contentType := "application/octet-stream"
+	if ct, ok := file.Pairs["content_type"].(*object.String); ok && ct.Value != "" {
+		contentType = ct.Value
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		escapeMultipartQuotes(name), escapeMultipartQuotes(filename.Value)))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	return err
+}
+
+var multipartQuoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeMultipartQuotes(s string) string {
+	return multipartQuoteEscaper.Replace(s)
+}
@@ -0,0 +1,253 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// openAPIDocument builds an OpenAPI 3 document from the router's routes and schemas.
+// info overrides the default {title, version} and may add description.
+func openAPIDocument(router *Router, info *object.Map) *object.Map {
+	infoMap := newObjectMap()
+	infoMap.Pairs["title"] = &object.String{Value: "BanglaCode API"}
+	infoMap.Pairs["version"] = &object.String{Value: "1.0.0"}
+	if info != nil {
+		for k, v := range info.Pairs {
+			infoMap.Pairs[k] = v
+		}
+	}
+
+	paths := newObjectMap()
+	for _, route := range router.RouteTable() {
+		if route.hidden {
+			continue
+		}
+		path := openAPIPath(route.pattern)
+		item, ok := paths.Pairs[path].(*object.Map)
+		if !ok {
+			item = newObjectMap()
+			paths.Pairs[path] = item
+		}
+		item.Pairs[strings.ToLower(route.method)] = openAPIOperation(route)
+	}
+
+	doc := newObjectMap()
+	doc.Pairs["openapi"] = &object.String{Value: "3.0.3"}
+	doc.Pairs["info"] = infoMap
+	doc.Pairs["paths"] = paths
+	return doc
+}
+
+// openAPIPath rewrites router params to OpenAPI templates: /files/:id(\d+)/*rest → /files/{id}/{rest}
+func openAPIPath(pattern string) string {
+	parts := strings.Split(pattern, "/")
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			name := part[1:]
+			if open := strings.Index(name, "("); open >= 0 {
+				name = name[:open]
+			}
+			parts[i] = "{" + name + "}"
+		case strings.HasPrefix(part, "*"):
+			parts[i] = "{" + strings.TrimPrefix(part, "*") + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// openAPIOperation describes one route: summary/description/tags, parameters, body and responses
+func openAPIOperation(route Route) *object.Map {
+	op := newObjectMap()
+	schema := route.schema
+	if schema == nil {
+		schema = newObjectMap()
+	}
+	for _, key := range []string{"summary", "description", "tags"} {
+		if v, ok := schema.Pairs[key]; ok {
+			op.Pairs[key] = v
+		}
+	}
+
+	var parameters []object.Object
+	pathSchemas := schemaProperties(schema, "params")
+	for _, name := range route.params {
+		paramSchema, ok := pathSchemas[name]
+		if !ok {
+			paramSchema = newObjectMap()
+			paramSchema.Pairs["type"] = &object.String{Value: "string"}
+		}
+		parameters = append(parameters, openAPIParameter(name, "path", true, paramSchema))
+	}
+	for _, section := range []struct{ key, in string }{{"query", "query"}, {"headers", "header"}} {
+		properties := schemaProperties(schema, section.key)
+		required := map[string]bool{}
+		if sectionSchema, ok := schema.Pairs[section.key].(*object.Map); ok {
+			for _, name := range schemaRequired(sectionSchema) {
+				required[name] = true
+			}
+		}
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			parameters = append(parameters, openAPIParameter(name, section.in, required[name], properties[name]))
+		}
+	}
+	if len(parameters) > 0 {
+		op.Pairs["parameters"] = &object.Array{Elements: parameters}
+	}
+
+	if body, ok := schema.Pairs["body"].(*object.Map); ok {
+		requestBody := newObjectMap()
+		requestBody.Pairs["required"] = object.TRUE
+		requestBody.Pairs["content"] = jsonContent(body)
+		op.Pairs["requestBody"] = requestBody
+	}
+
+	responses := newObjectMap()
+	if declared, ok := schema.Pairs["responses"].(*object.Map); ok {
+		for status, value := range declared.Pairs {
+			response := newObjectMap()
+			code, _ := strconv.Atoi(status)
+			response.Pairs["description"] = &object.String{Value: http.StatusText(code)}
+			if spec, ok := value.(*object.Map); ok {
+				if d, ok := spec.Pairs["description"]; ok {
+					response.Pairs["description"] = d
+				}
+				if s, ok := spec.Pairs["schema"].(*object.Map); ok {
+					response.Pairs["content"] = jsonContent(s)
+				}
+			}
+			responses.Pairs[status] = response
+		}
+	}
+	if len(responses.Pairs) == 0 {
+		ok := newObjectMap()
+		ok.Pairs["description"] = &object.String{Value: "OK"}
+		responses.Pairs["200"] = ok
+	}
+	if route.schema != nil {
+		if _, declared := responses.Pairs["400"]; !declared {
+			invalid := newObjectMap()
+			invalid.Pairs["description"] = &object.String{Value: "Validation failed"}
+			responses.Pairs["400"] = invalid
+		}
+	}
+	op.Pairs["responses"] = responses
+	return op
+}
+
+func openAPIParameter(name, in string, required bool, schema *object.Map) object.Object {
+	param := newObjectMap()
+	param.Pairs["name"] = &object.String{Value: name}
+	param.Pairs["in"] = &object.String{Value: in}
+	param.Pairs["required"] = object.NativeBoolToBooleanObject(required)
+	param.Pairs["schema"] = schema
+	return param
+}
+
+// schemaProperties returns the property schemas of a params/query/headers section
+func schemaProperties(schema *object.Map, section string) map[string]*object.Map {
+	result := map[string]*object.Map{}
+	sectionSchema, ok := schema.Pairs[section].(*object.Map)
+	if !ok {
+		return result
+	}
+	properties, ok := sectionSchema.Pairs["properties"].(*object.Map)
+	if !ok {
+		return result
+	}
+	for name, prop := range properties.Pairs {
+		if propSchema, ok := prop.(*object.Map); ok {
+			result[name] = propSchema
+		}
+	}
+	return result
+}
+
+func jsonContent(schema *object.Map) *object.Map {
+	media := newObjectMap()
+	media.Pairs["schema"] = schema
+	content := newObjectMap()
+	content.Pairs["application/json"] = media
+	return content
+}
+
+func newObjectMap() *object.Map {
+	return &object.Map{Pairs: make(map[string]object.Object)}
+}
+
+func init() {
+	// openapi_banao (OpenAPI বানাও - build the OpenAPI document)
+	// openapi_banao(app, {title, version, description}?) → MAP
+	Builtins["openapi_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (app, [info])", len(args))
+			}
+			router, err := extractRouter("openapi_banao", args[0])
+			if err != nil {
+				return err
+			}
+			var info *object.Map
+			if len(args) == 2 {
+				m, ok := args[1].(*object.Map)
+				if !ok {
+					return newError("second argument to `openapi_banao` must be MAP (info), got %s", args[1].Type())
+				}
+				info = m
+			}
+			return openAPIDocument(router, info)
+		},
+	}
+
+	// openapi_chalu (OpenAPI চালু - serve the OpenAPI document)
+	// openapi_chalu(app, path = "/openapi.json", {title, version, description}?)
+	// The document is rebuilt on each request, so routes added later are included.
+	Builtins["openapi_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1-3 (app, [path], [info])", len(args))
+			}
+			router, err := extractRouter("openapi_chalu", args[0])
+			if err != nil {
+				return err
+			}
+			path := "/openapi.json"
+			if len(args) >= 2 {
+				p, ok := args[1].(*object.String)
+				if !ok {
+					return newError("second argument to `openapi_chalu` must be STRING (path), got %s", args[1].Type())
+				}
+				path = p.Value
+			}
+			var info *object.Map
+			if len(args) == 3 {
+				m, ok := args[2].(*object.Map)
+				if !ok {
+					return newError("third argument to `openapi_chalu` must be MAP (info), got %s", args[2].Type())
+				}
+				info = m
+			}
+
+			handler := &object.Builtin{
+				Fn: func(handlerArgs ...object.Object) object.Object {
+					resMap := handlerArgs[1].(*object.Map)
+					resMap.Pairs["body"] = &object.String{Value: stringifyJSON(openAPIDocument(router, info))}
+					resMap.Pairs["headers"].(*object.Map).Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+					return object.NULL
+				},
+			}
+			if addErr := router.AddRoute("GET", path, handler, RouteOptions{Hidden: true}); addErr != nil {
+				return newError("openapi_chalu: %s", addErr.Error())
+			}
+			return args[0]
+		},
+	}
+}
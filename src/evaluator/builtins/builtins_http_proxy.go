@@ -0,0 +1,486 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A proxy_chalu mount forwards every request under its prefix to one of its upstreams.
+// Bodies and responses are streamed, WebSocket upgrades are tunnelled through, and an
+// optional health check takes failing upstreams out of rotation until they recover.
+
+// upstream is one backend of a proxy mount.
+type upstream struct {
+	target   *url.URL
+	proxy    *httputil.ReverseProxy
+	healthy  atomic.Bool
+	active   atomic.Int64 // requests (and tunnels) in flight
+	requests atomic.Int64 // requests forwarded in total
+}
+
+// pathRewrite replaces the first match of a pattern in the forwarded path.
+type pathRewrite struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// proxyMount holds the upstreams and rewriting rules of a proxy_chalu route.
+type proxyMount struct {
+	upstreams         []*upstream
+	leastConn         bool              // pick the upstream with the fewest requests in flight
+	next              atomic.Uint64     // round-robin position
+	stripPrefix       bool              // forward only the part after the mount prefix
+	rewrites          []pathRewrite     // applied in order to the forwarded path
+	preserveHost      bool              // keep the client's Host header
+	xForwarded        bool              // add X-Forwarded-For/-Host/-Proto
+	setHeaders        map[string]string // request headers to set
+	removeHeaders     []string          // request headers to drop
+	setRespHeaders    map[string]string // response headers to set
+	removeRespHeaders []string          // response headers to drop
+	healthPath        string            // "" = no active health checks
+	healthInterval    time.Duration
+	healthTimeout     time.Duration
+	stopOnce          sync.Once
+	stop              chan struct{}
+	transport         *http.Transport
+}
+
+// proxyResHeadersKey carries the headers middleware set on res to ModifyResponse
+type proxyResHeadersKey struct{}
+
+// parseProxyOptions reads proxy_chalu options:
+// {upstreams, balance, stripPrefix, rewrite, preserveHost, xForwarded, headers, removeHeaders,
+// responseHeaders, removeResponseHeaders, timeout, healthCheck}
+func parseProxyOptions(m *object.Map) (*proxyMount, *object.Error) {
+	mount := &proxyMount{stripPrefix: true, xForwarded: true, stop: make(chan struct{})}
+
+	var targets []object.Object
+	switch v := m.Pairs["upstreams"].(type) {
+	case *object.String:
+		targets = []object.Object{v}
+	case *object.Array:
+		targets = v.Elements
+	case nil:
+		return nil, newError("proxy_chalu: `upstreams` option is required")
+	default:
+		return nil, newError("`upstreams` option to `proxy_chalu` must be STRING or ARRAY of STRING, got %s", v.Type())
+	}
+	if len(targets) == 0 {
+		return nil, newError("proxy_chalu: `upstreams` must list at least one URL")
+	}
+	for _, t := range targets {
+		s, ok := t.(*object.String)
+		if !ok {
+			return nil, newError("`upstreams` option to `proxy_chalu` must be ARRAY of STRING, got %s", t.Type())
+		}
+		target, err := url.Parse(s.Value)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return nil, newError("proxy_chalu: invalid upstream URL '%s' (want http:// or https://)", s.Value)
+		}
+		mount.upstreams = append(mount.upstreams, &upstream{target: target})
+	}
+
+	switch v := m.Pairs["balance"].(type) {
+	case nil:
+	case *object.String:
+		switch v.Value {
+		case "round_robin":
+		case "least_conn":
+			mount.leastConn = true
+		default:
+			return nil, newError("`balance` option to `proxy_chalu` must be \"round_robin\" or \"least_conn\", got %q", v.Value)
+		}
+	default:
+		return nil, newError("`balance` option to `proxy_chalu` must be STRING, got %s", v.Type())
+	}
+
+	for _, key := range []string{"stripPrefix", "preserveHost", "xForwarded"} {
+		if v, ok := m.Pairs[key]; ok && v.Type() != object.BOOLEAN_OBJ {
+			return nil, newError("`%s` option to `proxy_chalu` must be BOOLEAN, got %s", key, v.Type())
+		}
+	}
+	if v, ok := m.Pairs["stripPrefix"].(*object.Boolean); ok {
+		mount.stripPrefix = v.Value
+	}
+	if v, ok := m.Pairs["xForwarded"].(*object.Boolean); ok {
+		mount.xForwarded = v.Value
+	}
+	mount.preserveHost = isTruthy(m.Pairs["preserveHost"])
+
+	switch v := m.Pairs["rewrite"].(type) {
+	case nil:
+	case *object.Map:
+		// rules apply in pattern order so the result does not depend on map iteration
+		patterns := make([]string, 0, len(v.Pairs))
+		for pattern := range v.Pairs {
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, newError("proxy_chalu: invalid rewrite pattern %q: %s", pattern, err.Error())
+			}
+			replacement, ok := v.Pairs[pattern].(*object.String)
+			if !ok {
+				return nil, newError("proxy_chalu: rewrite for %q must be STRING, got %s", pattern, v.Pairs[pattern].Type())
+			}
+			mount.rewrites = append(mount.rewrites, pathRewrite{re: re, replacement: replacement.Value})
+		}
+	default:
+		return nil, newError("`rewrite` option to `proxy_chalu` must be MAP (pattern → replacement), got %s", v.Type())
+	}
+
+	var errObj *object.Error
+	if mount.setHeaders, errObj = proxyHeaderMap("headers", m.Pairs["headers"]); errObj != nil {
+		return nil, errObj
+	}
+	if mount.setRespHeaders, errObj = proxyHeaderMap("responseHeaders", m.Pairs["responseHeaders"]); errObj != nil {
+		return nil, errObj
+	}
+	if mount.removeHeaders, errObj = proxyHeaderList("removeHeaders", m.Pairs["removeHeaders"]); errObj != nil {
+		return nil, errObj
+	}
+	if mount.removeRespHeaders, errObj = proxyHeaderList("removeResponseHeaders", m.Pairs["removeResponseHeaders"]); errObj != nil {
+		return nil, errObj
+	}
+
+	mount.transport = http.DefaultTransport.(*http.Transport).Clone()
+	if v, ok := m.Pairs["timeout"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value <= 0 {
+			return nil, newError("`timeout` option to `proxy_chalu` must be a positive NUMBER of milliseconds")
+		}
+		mount.transport.ResponseHeaderTimeout = time.Duration(n.Value) * time.Millisecond
+	}
+
+	switch v := m.Pairs["healthCheck"].(type) {
+	case nil:
+	case *object.Map:
+		path, ok := v.Pairs["path"].(*object.String)
+		if !ok {
+			return nil, newError("proxy_chalu: `healthCheck` needs a STRING `path`")
+		}
+		mount.healthPath = "/" + strings.TrimPrefix(path.Value, "/")
+		mount.healthInterval, mount.healthTimeout = 10*time.Second, 2*time.Second
+		for key, dst := range map[string]*time.Duration{"interval": &mount.healthInterval, "timeout": &mount.healthTimeout} {
+			if d, ok := v.Pairs[key]; ok {
+				n, ok := d.(*object.Number)
+				if !ok || n.Value <= 0 {
+					return nil, newError("`healthCheck.%s` option to `proxy_chalu` must be a positive NUMBER of milliseconds", key)
+				}
+				*dst = time.Duration(n.Value) * time.Millisecond
+			}
+		}
+	default:
+		return nil, newError("`healthCheck` option to `proxy_chalu` must be MAP, got %s", v.Type())
+	}
+
+	for _, u := range mount.upstreams {
+		u.healthy.Store(true)
+		u.proxy = mount.reverseProxy(u)
+	}
+	return mount, nil
+}
+
+func proxyHeaderMap(key string, v object.Object) (map[string]string, *object.Error) {
+	if v == nil {
+		return nil, nil
+	}
+	m, ok := v.(*object.Map)
+	if !ok {
+		return nil, newError("`%s` option to `proxy_chalu` must be MAP, got %s", key, v.Type())
+	}
+	headers := make(map[string]string, len(m.Pairs))
+	for name, value := range m.Pairs {
+		headers[http.CanonicalHeaderKey(name)] = objectString(value, "")
+	}
+	return headers, nil
+}
+
+func proxyHeaderList(key string, v object.Object) ([]string, *object.Error) {
+	if v == nil {
+		return nil, nil
+	}
+	arr, ok := v.(*object.Array)
+	if !ok {
+		return nil, newError("`%s` option to `proxy_chalu` must be ARRAY of STRING, got %s", key, v.Type())
+	}
+	names := make([]string, 0, len(arr.Elements))
+	for _, el := range arr.Elements {
+		s, ok := el.(*object.String)
+		if !ok {
+			return nil, newError("`%s` option to `proxy_chalu` must be ARRAY of STRING, got %s", key, el.Type())
+		}
+		names = append(names, s.Value)
+	}
+	return names, nil
+}
+
+// reverseProxy builds the forwarding proxy for one upstream
+func (m *proxyMount) reverseProxy(u *upstream) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Transport:     m.transport,
+		FlushInterval: -1, // pass chunks on as soon as the upstream sends them
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(u.target)
+			if m.preserveHost {
+				pr.Out.Host = pr.In.Host
+			}
+			if m.xForwarded {
+				pr.SetXForwarded()
+			}
+			for _, name := range m.removeHeaders {
+				pr.Out.Header.Del(name)
+			}
+			for name, value := range m.setHeaders {
+				pr.Out.Header.Set(name, value)
+			}
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			if headers, ok := resp.Request.Context().Value(proxyResHeadersKey{}).(http.Header); ok {
+				for name, values := range headers {
+					resp.Header[name] = values
+				}
+			}
+			for _, name := range m.removeRespHeaders {
+				resp.Header.Del(name)
+			}
+			for name, value := range m.setRespHeaders {
+				resp.Header.Set(name, value)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			status := http.StatusBadGateway
+			var netErr net.Error
+			if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				status = http.StatusGatewayTimeout
+			}
+			fmt.Printf("🔴 [BanglaCode] proxy %s %s → %s: %s\n", req.Method, req.URL.Path, u.target.Host, err.Error())
+			proxyError(w, status)
+		},
+	}
+}
+
+// pick chooses a healthy upstream (nil when none is left)
+func (m *proxyMount) pick() *upstream {
+	n := len(m.upstreams)
+	start := int(m.next.Add(1)-1) % n
+	var best *upstream
+	for i := 0; i < n; i++ {
+		u := m.upstreams[(start+i)%n]
+		if !u.healthy.Load() {
+			continue
+		}
+		if !m.leastConn {
+			return u
+		}
+		if best == nil || u.active.Load() < best.active.Load() {
+			best = u
+		}
+	}
+	return best
+}
+
+// forwardPath is the path sent upstream: the wildcard (or the full path) after rewrites
+func (m *proxyMount) forwardPath(req *http.Request, rel string) string {
+	p := req.URL.Path
+	if m.stripPrefix {
+		p = "/" + strings.TrimPrefix(rel, "/")
+	}
+	for _, rw := range m.rewrites {
+		if loc := rw.re.FindStringSubmatchIndex(p); loc != nil {
+			p = p[:loc[0]] + string(rw.re.ExpandString(nil, rw.replacement, p, loc)) + p[loc[1]:]
+		}
+	}
+	return p
+}
+
+// serve forwards req to an upstream and returns the status for logging. Headers set on
+// res by middleware are sent along with the upstream's response.
+func (m *proxyMount) serve(w http.ResponseWriter, req *http.Request, body io.Reader, rel string, resMap *object.Map) int {
+	u := m.pick()
+	if u == nil {
+		setResponseHeaders(w.Header(), resMap)
+		return proxyError(w, http.StatusServiceUnavailable)
+	}
+	u.active.Add(1)
+	u.requests.Add(1)
+	defer u.active.Add(-1)
+
+	resHeaders := http.Header{}
+	setResponseHeaders(resHeaders, resMap)
+	resHeaders.Del("Content-Type") // the upstream decides the body type
+	out := req.Clone(context.WithValue(req.Context(), proxyResHeadersKey{}, resHeaders))
+	out.URL.Path, out.URL.RawPath = m.forwardPath(req, rel), ""
+	if req.Body != nil && req.Body != http.NoBody {
+		out.Body = io.NopCloser(body)
+	}
+
+	rec := &statusRecorder{ResponseWriter: w}
+	u.proxy.ServeHTTP(rec, out)
+	if rec.status == 0 {
+		if isUpgradeRequest(req) {
+			return http.StatusSwitchingProtocols // the tunnel wrote its 101 on the hijacked connection
+		}
+		return http.StatusOK
+	}
+	return rec.status
+}
+
+func isUpgradeRequest(req *http.Request) bool {
+	return strings.Contains(strings.ToLower(req.Header.Get("Connection")), "upgrade") && req.Header.Get("Upgrade") != ""
+}
+
+func proxyError(w http.ResponseWriter, status int) int {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"error":%q}`, http.StatusText(status))
+	return status
+}
+
+// checkHealth probes every upstream once; anything but a 2xx/3xx answer takes it out of rotation
+func (m *proxyMount) checkHealth() {
+	client := &http.Client{
+		Transport:     m.transport,
+		Timeout:       m.healthTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	var wg sync.WaitGroup
+	for _, u := range m.upstreams {
+		wg.Add(1)
+		go func(u *upstream) {
+			defer wg.Done()
+			target := *u.target
+			target.Path = strings.TrimSuffix(target.Path, "/") + m.healthPath
+			target.RawPath, target.RawQuery = "", ""
+			resp, err := client.Get(target.String())
+			if err != nil {
+				u.healthy.Store(false)
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			u.healthy.Store(resp.StatusCode < 400)
+		}(u)
+	}
+	wg.Wait()
+}
+
+// runHealthChecks probes the upstreams right away and then every interval until bondho()
+func (m *proxyMount) runHealthChecks() {
+	m.checkHealth()
+	ticker := time.NewTicker(m.healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.checkHealth()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// handle is the BanglaCode object returned by proxy_chalu
+func (m *proxyMount) handle() *object.Map {
+	h := newObjectMap()
+
+	// obostha (অবস্থা - state) lists every upstream with its health and load
+	h.Pairs["obostha"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			elements := make([]object.Object, 0, len(m.upstreams))
+			for _, u := range m.upstreams {
+				entry := newObjectMap()
+				entry.Pairs["url"] = &object.String{Value: u.target.String()}
+				entry.Pairs["healthy"] = object.FALSE
+				if u.healthy.Load() {
+					entry.Pairs["healthy"] = object.TRUE
+				}
+				entry.Pairs["active"] = &object.Number{Value: float64(u.active.Load())}
+				entry.Pairs["requests"] = &object.Number{Value: float64(u.requests.Load())}
+				elements = append(elements, entry)
+			}
+			return &object.Array{Elements: elements}
+		},
+	}
+
+	// bondho (বন্ধ - close) stops the health checks; the routes keep forwarding
+	h.Pairs["bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			m.stopOnce.Do(func() { close(m.stop) })
+			return object.NULL
+		},
+	}
+	return h
+}
+
+func init() {
+	// proxy_chalu (প্রক্সি চালু - start proxy) forwards prefix/* to upstream services
+	// proxy_chalu(app, "/api", {upstreams: ["http://10.0.0.1:8080", "http://10.0.0.2:8080"],
+	//     balance: "least_conn", healthCheck: {path: "/health", interval: 5000}})
+	Builtins["proxy_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 3 {
+				return newError("wrong number of arguments. got=%d, want=3 (app, prefix, options)", len(args))
+			}
+			router, errObj := extractRouter("proxy_chalu", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			prefix, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `proxy_chalu` must be STRING (path prefix), got %s", args[1].Type())
+			}
+			opts, ok := args[2].(*object.Map)
+			if !ok {
+				return newError("third argument to `proxy_chalu` must be MAP (options), got %s", args[2].Type())
+			}
+			mount, err := parseProxyOptions(opts)
+			if err != nil {
+				return err
+			}
+
+			// {majhe, akaar_shima} apply to the mount like to any route; bodies are never buffered
+			routeOpts, optErr := routeOptions("proxy_chalu", args)
+			if optErr != nil {
+				return optErr
+			}
+			routeOpts.Proxy, routeOpts.Stream, routeOpts.Hidden = mount, true, true
+
+			base := strings.TrimSuffix(prefix.Value, "/")
+			if !strings.HasPrefix(base, "/") {
+				base = "/" + base
+			}
+			patterns := []string{strings.TrimSuffix(base, "/") + "/*path"}
+			if base != "/" {
+				patterns = append(patterns, base)
+			}
+			for _, method := range routeMethods {
+				for _, pattern := range patterns {
+					if addErr := router.AddRoute(method, pattern, nil, routeOpts); addErr != nil {
+						return newError("proxy_chalu: %s", addErr.Error())
+					}
+				}
+			}
+			if mount.healthPath != "" {
+				go mount.runHealthChecks()
+			}
+			return mount.handle()
+		},
+	}
+}
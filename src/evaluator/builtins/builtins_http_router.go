@@ -4,6 +4,7 @@ import (
 	"BanglaCode/src/object"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -17,10 +18,39 @@ import (
 
 // Route holds a compiled route pattern with its handler.
 type Route struct {
-	pattern string
-	params  []string       // param names in order: /users/:id → ["id"]
-	re      *regexp.Regexp // precompiled once at AddRoute time
-	handler object.Object
+	method      string
+	pattern     string
+	params      []string       // param names in order: /users/:id → ["id"]
+	re          *regexp.Regexp // precompiled once at AddRoute time
+	handler     object.Object
+	middlewares []object.Object // per-route middleware, run after group middleware
+	group       *RouteGroup     // group the route was registered in (nil = top level)
+	stream      bool            // leave the body unread; the handler consumes req.stream
+	maxBody     int64           // per-route body limit overriding akaar_shima (0 = app limit)
+	schema      *object.Map     // request schema checked before the handler (nil = none)
+	hidden      bool            // left out of the OpenAPI document
+	ws          *wsRoute        // WebSocket route: upgraded once the middleware chain passes
+	static      *staticMount    // file_dao route: the file is served once the middleware chain passes
+	proxy       *proxyMount     // proxy_chalu route: forwarded upstream once the middleware chain passes
+}
+
+// RouteOptions holds per-route settings passed as an optional last argument.
+type RouteOptions struct {
+	Stream       bool            // {stream: sotti}
+	MaxBodyBytes int64           // {akaar_shima: bytes}
+	Middlewares  []object.Object // {majhe: fn | [fn, ...]}
+	Schema       *object.Map     // {schema: {params, query, headers, body, ...}}
+	Hidden       bool            // {openapi: mittha}
+	WebSocket    *wsRoute        // set by app.websocket()
+	Static       *staticMount    // set by file_dao()
+	Proxy        *proxyMount     // set by proxy_chalu()
+}
+
+// RouteGroup is a path prefix with its own middleware; groups nest (app.dol("/api").dol("/v1")).
+type RouteGroup struct {
+	prefix      string
+	parent      *RouteGroup
+	middlewares []object.Object
 }
 
 // CORSOptions holds CORS configuration.
@@ -31,12 +61,6 @@ type CORSOptions struct {
 	MaxAge  string
 }
 
-// FileRoute maps a URL prefix to a static file handler.
-type FileRoute struct {
-	prefix  string
-	handler http.Handler
-}
-
 // RateLimiter implements a per-IP sliding-window rate limiter.
 type RateLimiter struct {
 	max    int
@@ -71,18 +95,24 @@ type Router struct {
 	basePath     string
 	routes       map[string][]Route // HTTP method → ordered route slice
 	middlewares  []object.Object    // run before every route handler
-	fileRoutes   []FileRoute
-	errorHandler object.Object // bhul_sambhalo handler
+	errorHandler object.Object      // bhul_sambhalo handler
+	notFound     object.Object      // app.painai handler (custom 404)
+	notAllowed   object.Object      // app.onumoti_nei handler (custom 405)
 	corsEnabled  bool
 	corsOptions  CORSOptions
 	gzipEnabled  bool
 	logEnabled   bool
+	accessLog    *accessLogger  // log_chalu with options: text or JSON lines for every response
+	metrics      *routerMetrics // metrics_chalu: request counts, latency and in-flight gauge
 	timeout      time.Duration
 	maxBodyBytes int64
 	rateLimiter  *RateLimiter
 	mu           sync.RWMutex
 }
 
+// routeMethods lists the methods in the order they are reported (Allow, route table).
+var routeMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
 // NewRouter creates a Router with all HTTP methods pre-initialized.
 func NewRouter(basePath string) *Router {
 	return &Router{
@@ -95,40 +125,115 @@ func NewRouter(basePath string) *Router {
 }
 
 // compilePattern converts a path pattern into a precompiled regex and param list.
-// Example: /users/:id/posts/:pid → ^/users/([^/]+)/posts/([^/]+)$, ["id","pid"]
-func compilePattern(pattern string) (*regexp.Regexp, []string) {
+// Segments may be params (:id), constrained params (:id(\d+)) or a trailing wildcard
+// (*path) that matches the rest of the path, slashes included.
+// Example: /users/:id(\d+)/files/*rest → ^/users/(?P<p0>\d+)/files/(?P<p1>.*)$, ["id","rest"]
+func compilePattern(pattern string) (*regexp.Regexp, []string, error) {
 	parts := strings.Split(pattern, "/")
 	var paramNames []string
 	var regexParts []string
-	for _, part := range parts {
-		if strings.HasPrefix(part, ":") {
-			paramNames = append(paramNames, part[1:])
-			regexParts = append(regexParts, "([^/]+)")
-		} else {
+	for i, part := range parts {
+		group := fmt.Sprintf("(?P<p%d>", len(paramNames))
+		switch {
+		case strings.HasPrefix(part, ":"):
+			name, constraint := part[1:], "[^/]+"
+			if open := strings.Index(name, "("); open >= 0 {
+				if !strings.HasSuffix(name, ")") {
+					return nil, nil, fmt.Errorf("unclosed constraint in route param %q", part)
+				}
+				name, constraint = name[:open], name[open+1:len(name)-1]
+				if _, err := regexp.Compile(constraint); err != nil {
+					return nil, nil, fmt.Errorf("invalid constraint for route param %q: %s", name, err.Error())
+				}
+			}
+			if name == "" {
+				return nil, nil, fmt.Errorf("route param in %q has no name", pattern)
+			}
+			paramNames = append(paramNames, name)
+			regexParts = append(regexParts, group+constraint+")")
+		case strings.HasPrefix(part, "*"):
+			if i != len(parts)-1 {
+				return nil, nil, fmt.Errorf("wildcard %q must be the last segment of %q", part, pattern)
+			}
+			name := part[1:]
+			if name == "" {
+				name = "*"
+			}
+			paramNames = append(paramNames, name)
+			regexParts = append(regexParts, group+".*)")
+		default:
 			regexParts = append(regexParts, regexp.QuoteMeta(part))
 		}
 	}
-	re := regexp.MustCompile("^" + strings.Join(regexParts, "/") + "$")
-	return re, paramNames
+	re, err := regexp.Compile("^" + strings.Join(regexParts, "/") + "$")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid route pattern %q: %s", pattern, err.Error())
+	}
+	return re, paramNames, nil
 }
 
 // AddRoute registers a route; compiles the pattern once.
-func (r *Router) AddRoute(method, pattern string, handler object.Object) {
+func (r *Router) AddRoute(method, pattern string, handler object.Object, opts RouteOptions) error {
+	return r.addGroupRoute(nil, method, pattern, handler, opts)
+}
+
+// addGroupRoute registers a route under a group's prefix and middleware.
+func (r *Router) addGroupRoute(group *RouteGroup, method, pattern string, handler object.Object, opts RouteOptions) error {
 	if !strings.HasPrefix(pattern, "/") {
 		pattern = "/" + pattern
 	}
-	re, params := compilePattern(pattern)
+	if group != nil {
+		pattern = strings.TrimSuffix(group.fullPrefix()+pattern, "/")
+		if pattern == "" {
+			pattern = "/"
+		}
+	}
+	re, params, err := compilePattern(pattern)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.routes[method] = append(r.routes[method], Route{
-		pattern: pattern, params: params, re: re, handler: handler,
+		method: method, pattern: pattern, params: params, re: re, handler: handler,
+		middlewares: opts.Middlewares, group: group, stream: opts.Stream, maxBody: opts.MaxBodyBytes,
+		schema: opts.Schema, hidden: opts.Hidden, ws: opts.WebSocket, static: opts.Static,
+		proxy: opts.Proxy,
 	})
+	return nil
+}
+
+// fullPrefix joins the prefixes of a group and its parents.
+func (g *RouteGroup) fullPrefix() string {
+	if g == nil {
+		return ""
+	}
+	return g.parent.fullPrefix() + g.prefix
+}
+
+// newRouteGroup creates a route group under parent (nil = top level).
+func newRouteGroup(parent *RouteGroup, prefix string) *RouteGroup {
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return &RouteGroup{prefix: strings.TrimSuffix(prefix, "/"), parent: parent}
+}
+
+// AddGroupMiddleware appends a middleware that runs for every route in the group.
+func (r *Router) AddGroupMiddleware(group *RouteGroup, handler object.Object) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	group.middlewares = append(group.middlewares, handler)
 }
 
 // FindRoute returns the first matching route and extracted path params.
 func (r *Router) FindRoute(method, path string) (*Route, map[string]string, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.findRoute(method, path)
+}
+
+func (r *Router) findRoute(method, path string) (*Route, map[string]string, bool) {
 	for i := range r.routes[method] {
 		route := &r.routes[method][i]
 		matches := route.re.FindStringSubmatch(path)
@@ -137,13 +242,66 @@ func (r *Router) FindRoute(method, path string) (*Route, map[string]string, bool
 		}
 		params := make(map[string]string, len(route.params))
 		for j, name := range route.params {
-			params[name] = matches[j+1]
+			params[name] = matches[route.re.SubexpIndex(fmt.Sprintf("p%d", j))]
 		}
 		return route, params, true
 	}
 	return nil, nil, false
 }
 
+// AllowedMethods lists the methods with a route matching path, including the
+// automatic HEAD (for GET routes) and OPTIONS. Empty when no route matches.
+func (r *Router) AllowedMethods(path string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	found := map[string]bool{}
+	for _, method := range routeMethods {
+		if _, _, ok := r.findRoute(method, path); ok {
+			found[method] = true
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	found["OPTIONS"] = true
+	if found["GET"] {
+		found["HEAD"] = true
+	}
+	var allowed []string
+	for _, method := range routeMethods {
+		if found[method] {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// middlewareChain returns global, group (outermost first) and route middleware in run order.
+func (r *Router) middlewareChain(route *Route) []object.Object {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	chain := append([]object.Object(nil), r.middlewares...)
+	var groups []*RouteGroup
+	for g := route.group; g != nil; g = g.parent {
+		groups = append(groups, g)
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		chain = append(chain, groups[i].middlewares...)
+	}
+	return append(chain, route.middlewares...)
+}
+
+// RouteTable describes every registered route for introspection and docs.
+func (r *Router) RouteTable() []Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var table []Route
+	for _, method := range routeMethods {
+		table = append(table, r.routes[method]...)
+	}
+	return table
+}
+
 // AddMiddleware appends a middleware to the router's chain.
 func (r *Router) AddMiddleware(handler object.Object) {
 	r.mu.Lock()
@@ -166,10 +324,12 @@ func (r *Router) MountSubRouter(mountPath string, subRouter *Router) {
 	for method, routes := range subRouter.routes {
 		for _, route := range routes {
 			fullPattern := mountPath + route.pattern
-			re, params := compilePattern(fullPattern)
-			r.routes[method] = append(r.routes[method], Route{
-				pattern: fullPattern, params: params, re: re, handler: route.handler,
-			})
+			re, params, err := compilePattern(fullPattern)
+			if err != nil {
+				continue // the sub-router pattern already compiled; only the prefix is new
+			}
+			route.pattern, route.params, route.re = fullPattern, params, re
+			r.routes[method] = append(r.routes[method], route)
 		}
 	}
 }
@@ -191,12 +351,21 @@ func callHandler(handler object.Object, args []object.Object) object.Object {
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	start := time.Now()
 
-	// 1. Read body with optional size limit
-	var bodyReader io.Reader = req.Body
-	if r.maxBodyBytes > 0 {
-		bodyReader = io.LimitReader(req.Body, r.maxBodyBytes)
+	// 0. Metrics and access logs see every response, early rejections included
+	r.mu.RLock()
+	metrics, accessLog := r.metrics, r.accessLog
+	r.mu.RUnlock()
+	var pattern string
+	if metrics != nil || accessLog != nil {
+		if metrics != nil {
+			metrics.inFlight.Add(nil, 1)
+		}
+		rec := &accessRecorder{ResponseWriter: w}
+		w = rec
+		defer func() { observeRequest(metrics, accessLog, req, rec, pattern, start) }()
 	}
-	body, _ := io.ReadAll(bodyReader)
+
+	// 1. (body is read once a route is matched, see step 7)
 
 	// 2. CORS headers (before any WriteHeader)
 	if r.corsEnabled {
@@ -209,13 +378,7 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// 4. Static file routes
-	for _, fr := range r.fileRoutes {
-		if strings.HasPrefix(req.URL.Path, fr.prefix) {
-			fr.handler.ServeHTTP(w, req)
-			return
-		}
-	}
+	// 4. (static files are file_dao routes, served after their middleware in step 9)
 
 	// 5. Rate limiting
 	if r.rateLimiter != nil {
@@ -228,56 +391,171 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
-	// 6. Route matching
+	// 6. Route matching: HEAD falls back to GET routes, OPTIONS is answered with Allow, and
+	// a path that exists for other methods gets 405 instead of 404
 	route, params, ok := r.FindRoute(req.Method, req.URL.Path)
+	if !ok && req.Method == "HEAD" {
+		route, params, ok = r.FindRoute("GET", req.URL.Path)
+	}
+	status, allow := http.StatusOK, ""
 	if !ok {
-		http.NotFound(w, req)
-		return
+		allowed := r.AllowedMethods(req.URL.Path)
+		if len(allowed) > 0 {
+			allow = strings.Join(allowed, ", ")
+			w.Header().Set("Allow", allow)
+		}
+		r.mu.RLock()
+		notFound, notAllowed := r.notFound, r.notAllowed
+		r.mu.RUnlock()
+		switch {
+		case len(allowed) > 0 && req.Method == "OPTIONS":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case len(allowed) > 0 && notAllowed == nil:
+			http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+			return
+		case len(allowed) > 0:
+			status, route = http.StatusMethodNotAllowed, &Route{handler: notAllowed}
+		case notFound == nil:
+			http.NotFound(w, req)
+			return
+		default:
+			status, route = http.StatusNotFound, &Route{handler: notFound}
+		}
+		params = map[string]string{}
+	}
+	pattern = route.pattern
+
+	// 7. Read the body within the size limit (route option, else akaar_shima) and build
+	// the BanglaCode request / response maps; streaming routes read req.stream themselves
+	var bodyReader io.Reader = req.Body
+	limit := r.maxBodyBytes
+	if route.maxBody > 0 {
+		limit = route.maxBody
+	}
+	if limit > 0 {
+		bodyReader = http.MaxBytesReader(w, req.Body, limit)
 	}
 
-	// 7. Build BanglaCode request / response maps
+	var body []byte
+	var upload *multipartUpload
+	if !route.stream {
+		var err error
+		if body, upload, err = readRequestBody(req, bodyReader); err != nil {
+			writeBodyError(w, err)
+			return
+		}
+	}
 	reqMap := buildRequestMap(req, body, params)
+	if upload != nil {
+		defer upload.cleanup()
+		upload.attach(reqMap)
+	}
+	if route.stream {
+		// Let HTTP/1.x handlers keep reading the body after they start responding (echo/proxy)
+		_ = http.NewResponseController(w).EnableFullDuplex()
+		reqMap.Pairs["stream"] = requestBodyStream(nil, bodyReader)
+	}
 	resMap := buildResponseMap()
+	resMap.Pairs["status"] = &object.Number{Value: float64(status)}
+	if allow != "" {
+		resMap.Pairs["headers"].(*object.Map).Pairs["Allow"] = &object.String{Value: allow}
+	}
+	rs := attachResponseStream(w, req, resMap)
 
-	// 8. Middleware chain + route handler with optional timeout
-	middlewares := r.middlewares
-	var execute func(idx int)
-	execute = func(idx int) {
-		if idx < len(middlewares) {
-			mw := middlewares[idx]
-			nextFn := &object.Builtin{
-				Fn: func(_ ...object.Object) object.Object {
-					execute(idx + 1)
-					return object.NULL
-				},
+	// 8. Middleware chain (global → group → route) + route handler with optional timeout.
+	// Promises returned by handlers are awaited, and agorao() returns a promise so
+	// middleware can `opekha agorao()` and run code after the rest of the chain.
+	ctx := req.Context()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	middlewares := r.middlewareChain(route)
+	upgrade, serveFile, forward := false, false, false
+	var execute func(idx int) object.Object
+	execute = func(idx int) object.Object {
+		if idx == len(middlewares) {
+			if route.schema != nil {
+				if issues := validateRequest(route.schema, reqMap); len(issues) > 0 {
+					resMap.Pairs["status"] = &object.Number{Value: http.StatusBadRequest}
+					resMap.Pairs["body"] = &object.String{Value: stringifyJSON(validationErrorBody(issues))}
+					resMap.Pairs["headers"].(*object.Map).Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+					return nil
+				}
 			}
-			callHandler(mw, []object.Object{reqMap, resMap, nextFn})
-		} else {
-			result := callHandler(route.handler, []object.Object{reqMap, resMap})
-			if result != nil && result.Type() == object.ERROR_OBJ && r.errorHandler != nil {
-				callHandler(r.errorHandler, []object.Object{result, reqMap, resMap})
+			if route.ws != nil {
+				upgrade = true // the connection is upgraded after the chain, outside the timeout
+				return nil
+			}
+			if route.static != nil {
+				serveFile = true // like upgrades, files are sent outside the timeout
+				return nil
+			}
+			if route.proxy != nil {
+				forward = true // proxied requests are streamed outside the timeout too
+				return nil
 			}
+			return awaitHandler(ctx, callHandler(route.handler, []object.Object{reqMap, resMap}))
+		}
+		var downstream object.Object
+		var next *object.Promise
+		nextFn := &object.Builtin{
+			Fn: func(_ ...object.Object) object.Object {
+				next = object.CreatePromise()
+				if downstream = execute(idx + 1); downstream != nil {
+					object.RejectPromise(next, downstream)
+				} else {
+					object.ResolvePromise(next, object.NULL)
+				}
+				return next
+			},
+		}
+		if failure := awaitHandler(ctx, callHandler(middlewares[idx], []object.Object{reqMap, resMap, nextFn})); failure != nil {
+			return failure
+		}
+		if downstream != nil && len(next.ErrorChan) > 0 {
+			return downstream // the rest of the chain failed and the middleware never awaited it
+		}
+		return nil
+	}
+	run := func() {
+		if failure := execute(0); failure != nil {
+			r.handleFailure(ctx, failure, reqMap, resMap)
 		}
 	}
 
 	if r.timeout > 0 {
-		ctx, cancel := context.WithTimeout(req.Context(), r.timeout)
-		defer cancel()
 		done := make(chan struct{})
-		go func() { execute(0); close(done) }()
+		go func() { run(); close(done) }()
 		select {
 		case <-done:
 		case <-ctx.Done():
+			if rs.finish() {
+				return // already streaming; the partial response stands
+			}
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			w.WriteHeader(http.StatusGatewayTimeout)
 			fmt.Fprint(w, `{"error":"Request timeout"}`)
 			return
 		}
 	} else {
-		execute(0)
+		run()
+	}
+
+	// 9. file_dao routes send the file and proxy_chalu routes forward the request once
+	// every middleware let the request through
+	if serveFile && !rs.finish() {
+		rel := params[route.params[len(route.params)-1]]
+		resMap.Pairs["status"] = &object.Number{Value: float64(route.static.serve(w, req, rel, resMap))}
+	}
+	if forward && !rs.finish() {
+		status := route.proxy.serve(w, req, bodyReader, params["path"], resMap)
+		resMap.Pairs["status"] = &object.Number{Value: float64(status)}
 	}
 
-	// 9. Logging
+	// 10. Logging
 	if r.logEnabled {
 		status := 200
 		if s, ok2 := resMap.Pairs["status"].(*object.Number); ok2 {
@@ -286,11 +564,90 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		fmt.Printf("🔵 [BanglaCode] %s %s → %d (%v)\n", req.Method, req.URL.Path, status, time.Since(start))
 	}
 
-	// 10. Write HTTP response (gzip if requested and enabled)
+	// 11. WebSocket routes switch protocols once every middleware let the request through;
+	// res is detached first, since the connection is hijacked and no longer a response
+	if upgrade {
+		if !rs.finish() {
+			route.ws.serve(w, req, reqMap, resMap)
+		}
+		return
+	}
+
+	// 12. Write HTTP response (gzip if requested and enabled) unless it was streamed, a file or proxied
+	if serveFile || forward || rs.finish() {
+		return
+	}
 	useGzip := r.gzipEnabled && strings.Contains(req.Header.Get("Accept-Encoding"), "gzip")
 	writeHTTPResponse(w, resMap, useGzip)
 }
 
+// writeBodyError rejects a request whose body could not be read: 413 past the size
+// limit, 400 for malformed bodies
+func writeBodyError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		fmt.Fprintf(w, `{"error":"আকার সীমা অতিক্রান্ত / Request body larger than %d bytes"}`, tooLarge.Limit)
+		return
+	}
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, `{"error":"অবৈধ অনুরোধ / Invalid request body: %s"}`, strings.ReplaceAll(err.Error(), `"`, `'`))
+}
+
+// awaitHandler waits for a promise returned by a handler or middleware and returns the
+// failure (error, exception or rejection), or nil when it succeeded
+func awaitHandler(ctx context.Context, result object.Object) object.Object {
+	if promise, ok := result.(*object.Promise); ok {
+		promise.Mu.RLock()
+		state, value, err := promise.State, promise.Value, promise.Error
+		promise.Mu.RUnlock()
+		switch state {
+		case object.PROMISE_RESOLVED:
+			result = value
+		case object.PROMISE_REJECTED:
+			return err
+		default:
+			select {
+			case result = <-promise.ResultChan:
+			case err := <-promise.ErrorChan:
+				return err
+			case <-ctx.Done():
+				return newError("handler did not finish: %s", ctx.Err().Error())
+			}
+		}
+	}
+	if result != nil && (result.Type() == object.ERROR_OBJ || result.Type() == object.EXCEPTION_OBJ) {
+		return result
+	}
+	return nil
+}
+
+// handleFailure passes a failed request to the bhul_sambhalo handler, answering 500
+// when there is none or it fails too
+func (r *Router) handleFailure(ctx context.Context, failure object.Object, reqMap, resMap *object.Map) {
+	r.mu.RLock()
+	errorHandler := r.errorHandler
+	r.mu.RUnlock()
+	if errorHandler != nil {
+		failure = awaitHandler(ctx, callHandler(errorHandler, []object.Object{failure, reqMap, resMap}))
+		if failure == nil {
+			return
+		}
+	}
+	writeFailureResponse(resMap, failure)
+}
+
+// writeFailureResponse turns res into a 500 for a handler that failed without an error handler
+func writeFailureResponse(resMap *object.Map, failure object.Object) {
+	fmt.Printf("🔴 [BanglaCode] handler error: %s\n", failure.Inspect())
+	resMap.Pairs["status"] = &object.Number{Value: http.StatusInternalServerError}
+	resMap.Pairs["body"] = &object.String{Value: `{"error":"Internal Server Error"}`}
+	if h, ok := resMap.Pairs["headers"].(*object.Map); ok {
+		h.Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+	}
+}
+
 // setCORSHeaders writes the CORS headers to the response.
 func setCORSHeaders(w http.ResponseWriter, opts CORSOptions) {
 	w.Header().Set("Access-Control-Allow-Origin", opts.Origin)
@@ -332,8 +689,9 @@ func buildRequestMap(req *http.Request, body []byte, params map[string]string) *
 	}
 	m.Pairs["headers"] = headersMap
 
-	// Raw body
+	// Raw body, also readable as a stream
 	m.Pairs["body"] = &object.String{Value: string(body)}
+	m.Pairs["stream"] = requestBodyStream(body, nil)
 
 	// Auto JSON parse
 	ct := req.Header.Get("Content-Type")
@@ -360,6 +718,9 @@ func buildRequestMap(req *http.Request, body []byte, params map[string]string) *
 		m.Pairs["form"] = object.NULL
 	}
 
+	// Uploaded files (filled for multipart/form-data requests)
+	m.Pairs["files"] = &object.Map{Pairs: make(map[string]object.Object)}
+
 	// Path params
 	paramsMap := &object.Map{Pairs: make(map[string]object.Object, len(params))}
 	for k, v := range params {
@@ -384,6 +745,9 @@ func buildRequestMap(req *http.Request, body []byte, params map[string]string) *
 	}
 	m.Pairs["kukis"] = kukisMap
 
+	// TLS details (version, verified client certificate) for HTTPS requests
+	m.Pairs["tls"] = tlsStateMap(req.TLS)
+
 	return m
 }
 
@@ -399,16 +763,7 @@ func buildResponseMap() *object.Map {
 // writeHTTPResponse writes the BanglaCode res map to the HTTP response.
 // Headers are always set before WriteHeader to comply with HTTP/1.1.
 func writeHTTPResponse(w http.ResponseWriter, resMap *object.Map, useGzip bool) {
-	// Set custom headers first
-	if h, ok := resMap.Pairs["headers"].(*object.Map); ok {
-		for k, v := range h.Pairs {
-			w.Header().Set(k, v.Inspect())
-		}
-	}
-	status := 200
-	if s, ok := resMap.Pairs["status"].(*object.Number); ok {
-		status = int(s.Value)
-	}
+	status := applyResponseHeaders(w, resMap)
 	body := ""
 	if b, ok := resMap.Pairs["body"]; ok {
 		body = b.Inspect()
@@ -426,6 +781,34 @@ func writeHTTPResponse(w http.ResponseWriter, resMap *object.Map, useGzip bool)
 	}
 }
 
+// applyResponseHeaders copies res.headers onto the writer and returns res.status.
+// Cookies joined by kuki_rakho are sent as separate Set-Cookie headers.
+func applyResponseHeaders(w http.ResponseWriter, resMap *object.Map) int {
+	setResponseHeaders(w.Header(), resMap)
+	status := 200
+	if s, ok := resMap.Pairs["status"].(*object.Number); ok {
+		status = int(s.Value)
+	}
+	return status
+}
+
+// setResponseHeaders copies res.headers into header
+func setResponseHeaders(header http.Header, resMap *object.Map) {
+	h, ok := resMap.Pairs["headers"].(*object.Map)
+	if !ok {
+		return
+	}
+	for k, v := range h.Pairs {
+		if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+			for _, cookie := range strings.Split(v.Inspect(), setCookieSeparator) {
+				header.Add(k, cookie)
+			}
+			continue
+		}
+		header.Set(k, v.Inspect())
+	}
+}
+
 // Global router registry — maps pointer string → *Router.
 var (
 	routerRegistry   = make(map[string]*Router)
@@ -3,6 +3,7 @@ package builtins
 import (
 	"BanglaCode/src/object"
 	"fmt"
+	"sort"
 )
 
 func init() {
@@ -15,94 +16,64 @@ func init() {
 			routerMap := &object.Map{Pairs: make(map[string]object.Object)}
 			routerMap.Pairs["__router_id__"] = &object.String{Value: fmt.Sprintf("%p", router)}
 
-			// ana (আনা - GET - fetch)
-			routerMap.Pairs["ana"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.ana", args); err != nil {
-						return err
-					}
-					router.AddRoute("GET", args[0].(*object.String).Value, args[1])
-					return routerMap
-				},
-			}
+			addRouteMethods(routerMap, "router", func(method, pattern string, handler object.Object, opts RouteOptions) error {
+				return router.AddRoute(method, pattern, handler, opts)
+			})
+			addWebSocketMethod(routerMap, "router", func(pattern string, opts RouteOptions) error {
+				return router.AddRoute("GET", pattern, nil, opts)
+			})
 
-			// pathano (পাঠানো - POST - send)
-			routerMap.Pairs["pathano"] = &object.Builtin{
+			// majhe (মাঝে - middleware intercept - agorao = next)
+			routerMap.Pairs["majhe"] = &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.pathano", args); err != nil {
-						return err
+					if len(args) != 1 {
+						return newError("router.majhe() takes exactly 1 argument (handler), got %d", len(args))
 					}
-					router.AddRoute("POST", args[0].(*object.String).Value, args[1])
-					return routerMap
-				},
-			}
-
-			// bodlano (বদলানো - PUT - update)
-			routerMap.Pairs["bodlano"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.bodlano", args); err != nil {
-						return err
+					if args[0].Type() != object.FUNCTION_OBJ && args[0].Type() != object.BUILTIN_OBJ {
+						return newError("argument to router.majhe() must be FUNCTION, got %s", args[0].Type())
 					}
-					router.AddRoute("PUT", args[0].(*object.String).Value, args[1])
+					router.AddMiddleware(args[0])
 					return routerMap
 				},
 			}
 
-			// mujhe_felo (মুছে ফেলো - DELETE - remove)
-			routerMap.Pairs["mujhe_felo"] = &object.Builtin{
+			// dol (দল - group) returns a route group with its own prefix and middleware
+			routerMap.Pairs["dol"] = &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.mujhe_felo", args); err != nil {
-						return err
-					}
-					router.AddRoute("DELETE", args[0].(*object.String).Value, args[1])
-					return routerMap
+					return routeGroupMap(router, nil, "router.dol", args)
 				},
 			}
 
-			// songshodhon (সংশোধন - PATCH - modify)
-			routerMap.Pairs["songshodhon"] = &object.Builtin{
+			// painai (পাইনি - not found) sets a custom 404 handler: kaj(req, res)
+			routerMap.Pairs["painai"] = &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.songshodhon", args); err != nil {
+					if err := requireHandler("router.painai", args); err != nil {
 						return err
 					}
-					router.AddRoute("PATCH", args[0].(*object.String).Value, args[1])
+					router.mu.Lock()
+					router.notFound = args[0]
+					router.mu.Unlock()
 					return routerMap
 				},
 			}
 
-			// matha (মাথা - HEAD)
-			routerMap.Pairs["matha"] = &object.Builtin{
+			// onumoti_nei (অনুমতি নেই - not allowed) sets a custom 405 handler: kaj(req, res)
+			routerMap.Pairs["onumoti_nei"] = &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.matha", args); err != nil {
+					if err := requireHandler("router.onumoti_nei", args); err != nil {
 						return err
 					}
-					router.AddRoute("HEAD", args[0].(*object.String).Value, args[1])
+					router.mu.Lock()
+					router.notAllowed = args[0]
+					router.mu.Unlock()
 					return routerMap
 				},
 			}
 
-			// nirdharon (নির্ধারণ - OPTIONS)
-			routerMap.Pairs["nirdharon"] = &object.Builtin{
+			// talika (তালিকা - list) returns the route table: [{method, path, params, middleware, stream}]
+			routerMap.Pairs["talika"] = &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if err := requireRoute("router.nirdharon", args); err != nil {
-						return err
-					}
-					router.AddRoute("OPTIONS", args[0].(*object.String).Value, args[1])
-					return routerMap
-				},
-			}
-
-			// majhe (মাঝে - middleware intercept - agorao = next)
-			routerMap.Pairs["majhe"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 1 {
-						return newError("router.majhe() takes exactly 1 argument (handler), got %d", len(args))
-					}
-					if args[0].Type() != object.FUNCTION_OBJ && args[0].Type() != object.BUILTIN_OBJ {
-						return newError("argument to router.majhe() must be FUNCTION, got %s", args[0].Type())
-					}
-					router.AddMiddleware(args[0])
-					return routerMap
+					return routeTableArray(router)
 				},
 			}
 
@@ -143,11 +114,118 @@ func init() {
 	}
 }
 
-// requireRoute validates the 2-argument (path STRING, handler FUNCTION) signature
+// routeMethodNames maps the Banglish route registration methods to HTTP methods:
+// ana (আনা - fetch), pathano (পাঠানো - send), bodlano (বদলানো - update),
+// mujhe_felo (মুছে ফেলো - remove), songshodhon (সংশোধন - modify), matha (মাথা - head),
+// nirdharon (নির্ধারণ - options)
+var routeMethodNames = map[string]string{
+	"ana": "GET", "pathano": "POST", "bodlano": "PUT", "mujhe_felo": "DELETE",
+	"songshodhon": "PATCH", "matha": "HEAD", "nirdharon": "OPTIONS",
+}
+
+// addRouteMethods adds ana/pathano/... to a router or group map; register receives the
+// validated arguments.
+func addRouteMethods(target *object.Map, owner string, register func(method, pattern string, handler object.Object, opts RouteOptions) error) {
+	for name, method := range routeMethodNames {
+		name, method := name, method
+		target.Pairs[name] = &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				fullName := owner + "." + name
+				if err := requireRoute(fullName, args); err != nil {
+					return err
+				}
+				opts, errObj := routeOptions(fullName, args)
+				if errObj != nil {
+					return errObj
+				}
+				if err := register(method, args[0].(*object.String).Value, args[1], opts); err != nil {
+					return newError("%s(): %s", fullName, err.Error())
+				}
+				return target
+			},
+		}
+	}
+}
+
+// routeGroupMap builds the map returned by app.dol(prefix): route methods, majhe and
+// nested dol, all scoped to the group.
+func routeGroupMap(router *Router, parent *RouteGroup, name string, args []object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("%s() takes exactly 1 argument (prefix), got %d", name, len(args))
+	}
+	prefix, ok := args[0].(*object.String)
+	if !ok {
+		return newError("argument to %s() must be STRING (prefix), got %s", name, args[0].Type())
+	}
+	group := newRouteGroup(parent, prefix.Value)
+
+	groupMap := &object.Map{Pairs: make(map[string]object.Object, 10)}
+	groupMap.Pairs["prefix"] = &object.String{Value: group.fullPrefix()}
+	addRouteMethods(groupMap, "group", func(method, pattern string, handler object.Object, opts RouteOptions) error {
+		return router.addGroupRoute(group, method, pattern, handler, opts)
+	})
+	addWebSocketMethod(groupMap, "group", func(pattern string, opts RouteOptions) error {
+		return router.addGroupRoute(group, "GET", pattern, nil, opts)
+	})
+
+	// majhe (মাঝে - middleware) runs only for routes in this group
+	groupMap.Pairs["majhe"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if err := requireHandler("group.majhe", args); err != nil {
+				return err
+			}
+			router.AddGroupMiddleware(group, args[0])
+			return groupMap
+		},
+	}
+
+	// dol (দল - group) nests another group under this one
+	groupMap.Pairs["dol"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			return routeGroupMap(router, group, "group.dol", args)
+		},
+	}
+	return groupMap
+}
+
+// routeTableArray converts the router's routes into BanglaCode maps sorted by path
+func routeTableArray(router *Router) object.Object {
+	table := router.RouteTable()
+	sort.SliceStable(table, func(i, j int) bool { return table[i].pattern < table[j].pattern })
+
+	elements := make([]object.Object, 0, len(table))
+	for _, route := range table {
+		params := make([]object.Object, len(route.params))
+		for i, p := range route.params {
+			params[i] = &object.String{Value: p}
+		}
+		entry := &object.Map{Pairs: make(map[string]object.Object, 5)}
+		entry.Pairs["method"] = &object.String{Value: route.method}
+		entry.Pairs["path"] = &object.String{Value: route.pattern}
+		entry.Pairs["params"] = &object.Array{Elements: params}
+		entry.Pairs["middleware"] = &object.Number{Value: float64(len(router.middlewareChain(&route)))}
+		entry.Pairs["stream"] = object.NativeBoolToBooleanObject(route.stream)
+		elements = append(elements, entry)
+	}
+	return &object.Array{Elements: elements}
+}
+
+// requireHandler validates a single FUNCTION argument
+func requireHandler(name string, args []object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("%s() takes exactly 1 argument (handler), got %d", name, len(args))
+	}
+	if args[0].Type() != object.FUNCTION_OBJ && args[0].Type() != object.BUILTIN_OBJ {
+		return newError("argument to %s() must be FUNCTION, got %s", name, args[0].Type())
+	}
+	return nil
+}
+
+// requireRoute validates the (path STRING, handler FUNCTION, options MAP?) signature
 // used by all HTTP method registrations.
 func requireRoute(name string, args []object.Object) object.Object {
-	if len(args) != 2 {
-		return newError("wrong number of arguments to %s(). got=%d, want=2", name, len(args))
+	if len(args) < 2 || len(args) > 3 {
+		return newError("wrong number of arguments to %s(). got=%d, want=2-3", name, len(args))
 	}
 	if args[0].Type() != object.STRING_OBJ {
 		return newError("first argument to %s() must be STRING (path), got %s", name, args[0].Type())
@@ -155,5 +233,45 @@ func requireRoute(name string, args []object.Object) object.Object {
 	if args[1].Type() != object.FUNCTION_OBJ && args[1].Type() != object.BUILTIN_OBJ {
 		return newError("second argument to %s() must be FUNCTION (handler), got %s", name, args[1].Type())
 	}
+	if len(args) == 3 && args[2].Type() != object.MAP_OBJ {
+		return newError("third argument to %s() must be MAP (options), got %s", name, args[2].Type())
+	}
 	return nil
 }
+
+// routeOptions reads the optional route options map:
+// {stream: sotti, akaar_shima: bytes, majhe: fn | [fn, ...], schema: {...}, openapi: mittha}
+func routeOptions(name string, args []object.Object) (RouteOptions, object.Object) {
+	var opts RouteOptions
+	if len(args) != 3 {
+		return opts, nil
+	}
+	m := args[2].(*object.Map)
+	opts.Stream = isTruthy(m.Pairs["stream"])
+	if limit, ok := m.Pairs["akaar_shima"].(*object.Number); ok && limit.Value > 0 {
+		opts.MaxBodyBytes = int64(limit.Value)
+	}
+	if mw, ok := m.Pairs["majhe"]; ok && mw != object.NULL {
+		handlers := []object.Object{mw}
+		if arr, ok := mw.(*object.Array); ok {
+			handlers = arr.Elements
+		}
+		for _, h := range handlers {
+			if h.Type() != object.FUNCTION_OBJ && h.Type() != object.BUILTIN_OBJ {
+				return opts, newError("`majhe` option to %s() must be FUNCTION or ARRAY of FUNCTION, got %s", name, h.Type())
+			}
+		}
+		opts.Middlewares = handlers
+	}
+	if schema, ok := m.Pairs["schema"]; ok && schema != object.NULL {
+		schemaMap, ok := schema.(*object.Map)
+		if !ok {
+			return opts, newError("`schema` option to %s() must be MAP, got %s", name, schema.Type())
+		}
+		opts.Schema = schemaMap
+	}
+	if include, ok := m.Pairs["openapi"].(*object.Boolean); ok {
+		opts.Hidden = !include.Value
+	}
+	return opts, nil
+}
@@ -0,0 +1,672 @@
+package builtins
+
+import (
+	"BanglaCode/src/evaluator/builtins/crypto"
+	"BanglaCode/src/evaluator/builtins/database/redis"
+	"BanglaCode/src/object"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// signCookieValue appends an HMAC-SHA256 signature: value.signature.
+// The cookie name is part of the signed data, so a value can't be moved to another cookie.
+func signCookieValue(name, value, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(name + "=" + value))
+	return value + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// unsignCookieValue checks a signed cookie and returns the original value
+func unsignCookieValue(name, signed, secret string) (string, bool) {
+	dot := strings.LastIndex(signed, ".")
+	if dot < 0 {
+		return "", false
+	}
+	value := signed[:dot]
+	if !hmac.Equal([]byte(signCookieValue(name, value, secret)), []byte(signed)) {
+		return "", false
+	}
+	return value, true
+}
+
+// randomToken returns n random bytes as hex (session ids, CSRF tokens)
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %s", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// sessionStore keeps session data as JSON strings keyed by session id
+type sessionStore interface {
+	load(id string) (string, bool, error)
+	save(id, data string) error
+	destroy(id string) error
+}
+
+// memorySessionStore keeps sessions in process memory (lost on restart)
+type memorySessionStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]memorySession
+}
+
+type memorySession struct {
+	data    string
+	expires time.Time
+}
+
+func (s *memorySessionStore) load(id string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok || time.Now().After(sess.expires) {
+		delete(s.sessions, id)
+		return "", false, nil
+	}
+	return sess.data, true, nil
+}
+
+func (s *memorySessionStore) save(id, data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for key, sess := range s.sessions {
+		if now.After(sess.expires) {
+			delete(s.sessions, key)
+		}
+	}
+	s.sessions[id] = memorySession{data: data, expires: now.Add(s.ttl)}
+	return nil
+}
+
+func (s *memorySessionStore) destroy(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+// fileSessionStore writes one <id>.json file per session; expiry uses the file's mtime
+type fileSessionStore struct {
+	dir string
+	ttl time.Duration
+}
+
+func (s *fileSessionStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *fileSessionStore) load(id string) (string, bool, error) {
+	info, err := os.Stat(s.path(id))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if time.Since(info.ModTime()) > s.ttl {
+		os.Remove(s.path(id))
+		return "", false, nil
+	}
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+func (s *fileSessionStore) save(id, data string) error {
+	return os.WriteFile(s.path(id), []byte(data), 0600)
+}
+
+func (s *fileSessionStore) destroy(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// redisSessionStore uses a db_jukto_redis connection; Redis expires the keys
+type redisSessionStore struct {
+	conn   *object.DBConnection
+	prefix string
+	ttl    time.Duration
+}
+
+func (s *redisSessionStore) load(id string) (string, bool, error) {
+	data, err := redis.Get(s.conn, s.prefix+id)
+	if err != nil {
+		if err.Error() == "key does not exist" {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return data, true, nil
+}
+
+func (s *redisSessionStore) save(id, data string) error {
+	return redis.Set(s.conn, s.prefix+id, data, s.ttl)
+}
+
+func (s *redisSessionStore) destroy(id string) error {
+	return redis.Del(s.conn, s.prefix+id)
+}
+
+// sessionStoreFromOption builds the store named by session_chalu's `store` option:
+// "memory" (default), "file" (in `dir`), or a Redis connection
+func sessionStoreFromOption(opts *object.Map, ttl time.Duration) (sessionStore, object.Object) {
+	switch store := opts.Pairs["store"].(type) {
+	case nil:
+		return &memorySessionStore{ttl: ttl, sessions: make(map[string]memorySession)}, nil
+	case *object.String:
+		switch store.Value {
+		case "memory":
+			return &memorySessionStore{ttl: ttl, sessions: make(map[string]memorySession)}, nil
+		case "file":
+			dir := "./sessions"
+			if p, ok := opts.Pairs["dir"].(*object.String); ok {
+				dir = p.Value
+			}
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return nil, newError("session_chalu: could not create session directory: %s", err.Error())
+			}
+			return &fileSessionStore{dir: dir, ttl: ttl}, nil
+		}
+		return nil, newError("session_chalu: unknown store %q (want \"memory\", \"file\" or a Redis connection)", store.Value)
+	case *object.DBConnection:
+		if store.DBType != "redis" {
+			return nil, newError("session_chalu: store connection must be Redis (db_jukto_redis), got %s", store.DBType)
+		}
+		return &redisSessionStore{conn: store, prefix: "session:", ttl: ttl}, nil
+	default:
+		return nil, newError("`store` option to `session_chalu` must be STRING or Redis connection, got %s", store.Type())
+	}
+}
+
+// sessionMiddleware loads req.session from the signed session cookie before the rest of
+// the chain runs and saves it (refreshing the cookie) afterwards. Empty new sessions are
+// not stored; req.session = khali destroys the session and a changed req.session_id
+// (session_notun) moves the data to the new id.
+func sessionMiddleware(name, secret string, store sessionStore, cookieOpts *object.Map) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			reqMap, resMap := args[0].(*object.Map), args[1].(*object.Map)
+
+			id, data, isNew := "", newObjectMap(), true
+			if kukis, ok := reqMap.Pairs["kukis"].(*object.Map); ok {
+				if c, ok := kukis.Pairs[name].(*object.String); ok {
+					if raw, valid := unsignCookieValue(name, c.Value, secret); valid {
+						stored, found, err := store.load(raw)
+						if err != nil {
+							return newError("session_chalu: could not load session: %s", err.Error())
+						}
+						if m, ok := parseJSON(stored).(*object.Map); found && ok {
+							id, data, isNew = raw, m, false
+						}
+					}
+				}
+			}
+			if id == "" {
+				id = randomToken(16)
+			}
+			reqMap.Pairs["session"] = data
+			reqMap.Pairs["session_id"] = &object.String{Value: id}
+
+			// settle saves or destroys the session and returns the Set-Cookie value it needs
+			// ("" for none). It runs once the handler is done, and also when the handler starts
+			// streaming, since the cookie has to go out with the headers.
+			settle := func() (string, *object.Error) {
+				session, alive := reqMap.Pairs["session"].(*object.Map)
+				newID := id
+				if s, ok := reqMap.Pairs["session_id"].(*object.String); ok && s.Value != "" {
+					newID = s.Value
+				}
+				if !isNew && (!alive || newID != id) {
+					if err := store.destroy(id); err != nil {
+						return "", newError("session_chalu: could not destroy session: %s", err.Error())
+					}
+				}
+				if !alive {
+					if isNew {
+						return "", nil
+					}
+					expired := newObjectMap()
+					for k, v := range cookieOpts.Pairs {
+						expired.Pairs[k] = v
+					}
+					expired.Pairs["maxAge"] = &object.Number{Value: 0}
+					return cookieString(name, "", expired), nil
+				}
+				if isNew && newID == id && len(session.Pairs) == 0 {
+					return "", nil
+				}
+				if err := store.save(newID, stringifyJSON(session)); err != nil {
+					return "", newError("session_chalu: could not save session: %s", err.Error())
+				}
+				return cookieString(name, signCookieValue(name, newID, secret), cookieOpts), nil
+			}
+
+			streamed, sent := false, ""
+			onResponseHeaders(resMap, func() error {
+				cookie, failure := settle()
+				if failure != nil {
+					return errors.New(failure.Message)
+				}
+				streamed, sent = true, cookie
+				if cookie != "" {
+					appendSetCookie(resMap, cookie)
+				}
+				return nil
+			})
+
+			if failure := awaitHandler(context.Background(), callHandler(args[2], nil)); failure != nil {
+				return failure
+			}
+
+			cookie, failure := settle()
+			if failure != nil {
+				return failure
+			}
+			switch {
+			case !streamed:
+				if cookie != "" {
+					appendSetCookie(resMap, cookie)
+				}
+			case cookie != sent:
+				return newError("session_chalu: the session changed after the response headers were sent, so its cookie could not be updated")
+			}
+			return object.NULL
+		},
+	}
+}
+
+// csrfMiddleware keeps a per-session token in req.session._csrf / req.csrf_token and
+// rejects unsafe requests (not GET/HEAD/OPTIONS) that don't send it back
+func csrfMiddleware(header, field string) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			reqMap, resMap := args[0].(*object.Map), args[1].(*object.Map)
+			session, ok := reqMap.Pairs["session"].(*object.Map)
+			if !ok {
+				return newError("csrf_chalu: req.session is missing — call session_chalu(app, ...) before csrf_chalu")
+			}
+			token, ok := session.Pairs["_csrf"].(*object.String)
+			if !ok {
+				token = &object.String{Value: randomToken(32)}
+				session.Pairs["_csrf"] = token
+			}
+			reqMap.Pairs["csrf_token"] = token
+
+			switch reqMap.Pairs["method"].Inspect() {
+			case "GET", "HEAD", "OPTIONS":
+				return callHandler(args[2], nil)
+			}
+			submitted := ""
+			if headers, ok := reqMap.Pairs["headers"].(*object.Map); ok {
+				if v, ok := headers.Pairs[http.CanonicalHeaderKey(header)].(*object.String); ok {
+					submitted = v.Value
+				}
+			}
+			for _, source := range []string{"form", "json"} {
+				if fields, ok := reqMap.Pairs[source].(*object.Map); ok && submitted == "" {
+					if v, ok := fields.Pairs[field].(*object.String); ok {
+						submitted = v.Value
+					}
+				}
+			}
+			if submitted == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(token.Value)) != 1 {
+				writeJSONError(resMap, http.StatusForbidden, "invalid CSRF token")
+				return object.NULL
+			}
+			return callHandler(args[2], nil)
+		},
+	}
+}
+
+// authMiddleware reads the Authorization header for scheme ("Basic" / "Bearer"), asks
+// check for the req.user value, and answers 401 with WWW-Authenticate when it has none
+func authMiddleware(scheme, realm string, check func(credentials string) (object.Object, object.Object)) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			reqMap, resMap := args[0].(*object.Map), args[1].(*object.Map)
+			credentials := ""
+			if headers, ok := reqMap.Pairs["headers"].(*object.Map); ok {
+				if v, ok := headers.Pairs["Authorization"].(*object.String); ok {
+					if prefix := scheme + " "; len(v.Value) > len(prefix) && strings.EqualFold(v.Value[:len(prefix)], prefix) {
+						credentials = strings.TrimSpace(v.Value[len(prefix):])
+					}
+				}
+			}
+			if credentials != "" {
+				user, failure := check(credentials)
+				if failure != nil {
+					return failure
+				}
+				if user != nil {
+					reqMap.Pairs["user"] = user
+					return callHandler(args[2], nil)
+				}
+			}
+			challenge := fmt.Sprintf("%s realm=%q", scheme, realm)
+			if scheme == "Bearer" && credentials != "" {
+				challenge += `, error="invalid_token"`
+			}
+			resMap.Pairs["headers"].(*object.Map).Pairs["WWW-Authenticate"] = &object.String{Value: challenge}
+			writeJSONError(resMap, http.StatusUnauthorized, "Unauthorized")
+			return object.NULL
+		},
+	}
+}
+
+// callVerifier runs a user verifier (sync or proyash) and returns the req.user value:
+// nil when it returned a falsy value, the fallback when it returned sotti
+func callVerifier(fn object.Object, args []object.Object, fallback object.Object) (object.Object, object.Object) {
+	result := awaitResult(callHandler(fn, args))
+	if result == nil || isFailure(result) {
+		return nil, result
+	}
+	if !isTruthy(result) {
+		return nil, nil
+	}
+	if result.Type() == object.BOOLEAN_OBJ {
+		return fallback, nil
+	}
+	return result, nil
+}
+
+func writeJSONError(resMap *object.Map, status int, message string) {
+	resMap.Pairs["status"] = &object.Number{Value: float64(status)}
+	resMap.Pairs["body"] = &object.String{Value: stringifyJSON(&object.Map{Pairs: map[string]object.Object{"error": &object.String{Value: message}}})}
+	resMap.Pairs["headers"].(*object.Map).Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+}
+
+// authRealm reads the optional {realm} argument of basic_pahara / bearer_pahara
+func authRealm(fn string, args []object.Object) (string, object.Object) {
+	if len(args) < 2 {
+		return "BanglaCode", nil
+	}
+	opts, ok := args[1].(*object.Map)
+	if !ok {
+		return "", newError("second argument to `%s` must be MAP (options), got %s", fn, args[1].Type())
+	}
+	if realm, ok := opts.Pairs["realm"].(*object.String); ok {
+		return realm.Value, nil
+	}
+	return "BanglaCode", nil
+}
+
+// sessionRequest checks the (req) argument of session_notun / session_muchho
+func sessionRequest(fn string, args []object.Object) (*object.Map, object.Object) {
+	if len(args) != 1 {
+		return nil, newError("wrong number of arguments. got=%d, want=1 (req)", len(args))
+	}
+	reqMap, ok := args[0].(*object.Map)
+	if !ok {
+		return nil, newError("argument to `%s` must be request MAP, got %s", fn, args[0].Type())
+	}
+	if _, ok := reqMap.Pairs["session_id"]; !ok {
+		return nil, newError("%s: no session on this request — call session_chalu(app, ...) first", fn)
+	}
+	return reqMap, nil
+}
+
+func init() {
+	// kuki_pora (কুকি পড়া - read a signed cookie)
+	// kuki_pora(req, "name", "secret") → value, or khali when missing or tampered with
+	Builtins["kuki_pora"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 3 {
+				return newError("wrong number of arguments. got=%d, want=3 (req, name, secret)", len(args))
+			}
+			reqMap, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("first argument to `kuki_pora` must be request MAP, got %s", args[0].Type())
+			}
+			name, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `kuki_pora` must be STRING (name), got %s", args[1].Type())
+			}
+			secret, ok := args[2].(*object.String)
+			if !ok {
+				return newError("third argument to `kuki_pora` must be STRING (secret), got %s", args[2].Type())
+			}
+			kukis, _ := reqMap.Pairs["kukis"].(*object.Map)
+			if kukis == nil {
+				return object.NULL
+			}
+			signed, ok := kukis.Pairs[name.Value].(*object.String)
+			if !ok {
+				return object.NULL
+			}
+			if value, valid := unsignCookieValue(name.Value, signed.Value, secret.Value); valid {
+				return &object.String{Value: value}
+			}
+			return object.NULL
+		},
+	}
+
+	// session_chalu (সেশন চালু - enable sessions)
+	// session_chalu(app, {secret: "...", store: "memory" | "file" | redisConn, dir: "./sessions",
+	//                     name: "banglacode.sid", maxAge: 86400, path: "/", secure: sotti, sameSite: "Lax"})
+	// Handlers read and write req.session; the session id travels in a signed, HttpOnly cookie.
+	Builtins["session_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments. got=%d, want=2 (app, options)", len(args))
+			}
+			router, err := extractRouter("session_chalu", args[0])
+			if err != nil {
+				return err
+			}
+			opts, ok := args[1].(*object.Map)
+			if !ok {
+				return newError("second argument to `session_chalu` must be MAP (options), got %s", args[1].Type())
+			}
+			secret, ok := opts.Pairs["secret"].(*object.String)
+			if !ok || secret.Value == "" {
+				return newError("session_chalu: `secret` option is required")
+			}
+			name := "banglacode.sid"
+			if n, ok := opts.Pairs["name"].(*object.String); ok {
+				name = n.Value
+			}
+			maxAge := 86400.0
+			if n, ok := opts.Pairs["maxAge"].(*object.Number); ok && n.Value > 0 {
+				maxAge = n.Value
+			}
+			store, errObj := sessionStoreFromOption(opts, time.Duration(maxAge*float64(time.Second)))
+			if errObj != nil {
+				return errObj
+			}
+
+			cookieOpts := newObjectMap()
+			cookieOpts.Pairs["maxAge"] = &object.Number{Value: maxAge}
+			cookieOpts.Pairs["httpOnly"] = object.TRUE
+			cookieOpts.Pairs["sameSite"] = &object.String{Value: "Lax"}
+			for _, key := range []string{"path", "sameSite", "secure"} {
+				if v, ok := opts.Pairs[key]; ok {
+					cookieOpts.Pairs[key] = v
+				}
+			}
+			router.AddMiddleware(sessionMiddleware(name, secret.Value, store, cookieOpts))
+			return args[0]
+		},
+	}
+
+	// session_notun (নতুন সেশন - new session id, e.g. after login, keeping the data)
+	// session_notun(req) → new id
+	Builtins["session_notun"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			reqMap, errObj := sessionRequest("session_notun", args)
+			if errObj != nil {
+				return errObj
+			}
+			id := &object.String{Value: randomToken(16)}
+			reqMap.Pairs["session_id"] = id
+			return id
+		},
+	}
+
+	// session_muchho (সেশন মুছো - destroy the session, e.g. on logout)
+	// session_muchho(req)
+	Builtins["session_muchho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			reqMap, errObj := sessionRequest("session_muchho", args)
+			if errObj != nil {
+				return errObj
+			}
+			reqMap.Pairs["session"] = object.NULL
+			return object.NULL
+		},
+	}
+
+	// csrf_chalu (CSRF চালু - CSRF protection, needs session_chalu first)
+	// csrf_chalu(app, {header: "X-CSRF-Token", field: "_csrf"}?)
+	// Forms and clients echo req.csrf_token back in the header or field on POST/PUT/PATCH/DELETE.
+	Builtins["csrf_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (app, [options])", len(args))
+			}
+			router, err := extractRouter("csrf_chalu", args[0])
+			if err != nil {
+				return err
+			}
+			header, field := "X-CSRF-Token", "_csrf"
+			if len(args) == 2 {
+				opts, ok := args[1].(*object.Map)
+				if !ok {
+					return newError("second argument to `csrf_chalu` must be MAP (options), got %s", args[1].Type())
+				}
+				if v, ok := opts.Pairs["header"].(*object.String); ok {
+					header = v.Value
+				}
+				if v, ok := opts.Pairs["field"].(*object.String); ok {
+					field = v.Value
+				}
+			}
+			router.AddMiddleware(csrfMiddleware(header, field))
+			return args[0]
+		},
+	}
+
+	// basic_pahara (বেসিক পাহারা - HTTP Basic auth middleware)
+	// basic_pahara({"ankan": "password"}, {realm: "Admin"}?) or basic_pahara(kaj(user, pass) { ... })
+	// Use with app.majhe(...) or {majhe: ...}; req.user is the user name (or what the verifier returned).
+	Builtins["basic_pahara"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (users, [options])", len(args))
+			}
+			realm, errObj := authRealm("basic_pahara", args)
+			if errObj != nil {
+				return errObj
+			}
+			verifier := args[0]
+			users, isMap := verifier.(*object.Map)
+			if !isMap && verifier.Type() != object.FUNCTION_OBJ && verifier.Type() != object.BUILTIN_OBJ {
+				return newError("first argument to `basic_pahara` must be MAP (users) or FUNCTION (verifier), got %s", verifier.Type())
+			}
+			return authMiddleware("Basic", realm, func(credentials string) (object.Object, object.Object) {
+				decoded, err := base64.StdEncoding.DecodeString(credentials)
+				if err != nil {
+					return nil, nil
+				}
+				user, pass, found := strings.Cut(string(decoded), ":")
+				if !found {
+					return nil, nil
+				}
+				userObj := &object.String{Value: user}
+				if isMap {
+					expected, ok := users.Pairs[user].(*object.String)
+					if ok && subtle.ConstantTimeCompare([]byte(pass), []byte(expected.Value)) == 1 {
+						return userObj, nil
+					}
+					return nil, nil
+				}
+				return callVerifier(verifier, []object.Object{userObj, &object.String{Value: pass}}, userObj)
+			})
+		},
+	}
+
+	// bearer_pahara (বেয়ারার পাহারা - bearer token auth middleware)
+	// bearer_pahara(["token1", "token2"], {realm: "API"}?) or bearer_pahara(kaj(token) { ferao user; })
+	// req.user is the token, or the verifier's return value when it isn't sotti.
+	Builtins["bearer_pahara"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (tokens, [options])", len(args))
+			}
+			realm, errObj := authRealm("bearer_pahara", args)
+			if errObj != nil {
+				return errObj
+			}
+			verifier := args[0]
+			tokens, isArray := verifier.(*object.Array)
+			if !isArray && verifier.Type() != object.FUNCTION_OBJ && verifier.Type() != object.BUILTIN_OBJ {
+				return newError("first argument to `bearer_pahara` must be ARRAY (tokens) or FUNCTION (verifier), got %s", verifier.Type())
+			}
+			return authMiddleware("Bearer", realm, func(credentials string) (object.Object, object.Object) {
+				tokenObj := &object.String{Value: credentials}
+				if isArray {
+					for _, t := range tokens.Elements {
+						if s, ok := t.(*object.String); ok && subtle.ConstantTimeCompare([]byte(credentials), []byte(s.Value)) == 1 {
+							return tokenObj, nil
+						}
+					}
+					return nil, nil
+				}
+				return callVerifier(verifier, []object.Object{tokenObj}, tokenObj)
+			})
+		},
+	}
+
+	// jwt_pahara (JWT পাহারা - bearer JWT auth middleware)
+	// jwt_pahara(key, {algorithms, audience, issuer, leeway, realm}?)
+	// key is a secret, public key PEM or jwks_poro map; req.user is the verified claims map.
+	Builtins["jwt_pahara"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (key, [options])", len(args))
+			}
+			realm, errObj := authRealm("jwt_pahara", args)
+			if errObj != nil {
+				return errObj
+			}
+			keys, keyErr := crypto.JWTKeys("jwt_pahara", args[0])
+			if keyErr != nil {
+				return keyErr
+			}
+			var optsMap *object.Map
+			if len(args) == 2 {
+				optsMap = args[1].(*object.Map)
+			}
+			opts, optsErr := crypto.JWTVerifyOptions("jwt_pahara", optsMap)
+			if optsErr != nil {
+				return optsErr
+			}
+			return authMiddleware("Bearer", realm, func(credentials string) (object.Object, object.Object) {
+				claims, err := crypto.VerifyJWT(credentials, keys, opts)
+				if err != nil {
+					return nil, nil
+				}
+				return crypto.ClaimsToObject(claims), nil
+			})
+		},
+	}
+}
@@ -0,0 +1,269 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// staticMount serves files under dir for a file_dao route. Files are opened through
+// os.Root, so neither ".." nor symlinks can reach outside the directory.
+type staticMount struct {
+	dir           string
+	index         string            // file served for a directory ("" = none)
+	listing       bool              // list directories without an index file
+	spa           bool              // serve the index for unknown extensionless paths
+	dotfiles      string            // "ignore" (404), "deny" (403) or "allow"
+	precompressed bool              // serve file.gz to clients that accept gzip
+	etag          bool              // send an ETag built from size and modification time
+	cacheControl  map[string]string // extension → Cache-Control; "*" for everything else
+}
+
+// parseStaticOptions reads file_dao options:
+// {index, listing, spa, dotfiles, precompressed, etag, maxAge, immutable, cacheControl}
+func parseStaticOptions(dir string, m *object.Map) (*staticMount, *object.Error) {
+	mount := &staticMount{dir: dir, index: "index.html", dotfiles: "ignore", precompressed: true, etag: true,
+		cacheControl: make(map[string]string)}
+	if m == nil {
+		return mount, nil
+	}
+	for _, key := range []string{"listing", "spa", "precompressed", "etag", "immutable"} {
+		if v, ok := m.Pairs[key]; ok && v.Type() != object.BOOLEAN_OBJ {
+			return nil, newError("`%s` option to `file_dao` must be BOOLEAN, got %s", key, v.Type())
+		}
+	}
+	switch v := m.Pairs["index"].(type) {
+	case nil:
+	case *object.String:
+		mount.index = v.Value
+	case *object.Null:
+		mount.index = ""
+	case *object.Boolean:
+		if !v.Value {
+			mount.index = ""
+		}
+	default:
+		return nil, newError("`index` option to `file_dao` must be STRING or mittha, got %s", v.Type())
+	}
+	mount.listing = isTruthy(m.Pairs["listing"])
+	mount.spa = isTruthy(m.Pairs["spa"])
+	if mount.spa && mount.index == "" {
+		return nil, newError("file_dao: `spa` needs an `index` file")
+	}
+	if v, ok := m.Pairs["precompressed"].(*object.Boolean); ok {
+		mount.precompressed = v.Value
+	}
+	if v, ok := m.Pairs["etag"].(*object.Boolean); ok {
+		mount.etag = v.Value
+	}
+	if v, ok := m.Pairs["dotfiles"]; ok {
+		s, ok := v.(*object.String)
+		if !ok || (s.Value != "ignore" && s.Value != "deny" && s.Value != "allow") {
+			return nil, newError("`dotfiles` option to `file_dao` must be \"ignore\", \"deny\" or \"allow\"")
+		}
+		mount.dotfiles = s.Value
+	}
+
+	if v, ok := m.Pairs["maxAge"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value < 0 {
+			return nil, newError("`maxAge` option to `file_dao` must be a NUMBER of seconds")
+		}
+		mount.cacheControl["*"] = fmt.Sprintf("public, max-age=%d", int64(n.Value))
+		if isTruthy(m.Pairs["immutable"]) {
+			mount.cacheControl["*"] += ", immutable"
+		}
+	}
+	switch v := m.Pairs["cacheControl"].(type) {
+	case nil:
+	case *object.String:
+		mount.cacheControl["*"] = v.Value
+	case *object.Map:
+		for ext, value := range v.Pairs {
+			if ext != "*" && !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			mount.cacheControl[strings.ToLower(ext)] = value.Inspect()
+		}
+	default:
+		return nil, newError("`cacheControl` option to `file_dao` must be STRING or MAP (extension → value), got %s", v.Type())
+	}
+	return mount, nil
+}
+
+// statusRecorder remembers the status written by http.ServeContent for logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the connection (flushes, upgrades)
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// serve answers req with the file at rel (the route's wildcard) and returns the status.
+// Headers set on res by middleware are sent along.
+func (m *staticMount) serve(w http.ResponseWriter, req *http.Request, rel string, resMap *object.Map) int {
+	setResponseHeaders(w.Header(), resMap)
+
+	name := strings.TrimPrefix(path.Clean("/"+rel), "/")
+	if name == "" {
+		name = "."
+	}
+	if m.dotfiles != "allow" {
+		for _, segment := range strings.Split(name, "/") {
+			if strings.HasPrefix(segment, ".") && segment != "." {
+				if m.dotfiles == "deny" {
+					return staticError(w, http.StatusForbidden)
+				}
+				return staticError(w, http.StatusNotFound)
+			}
+		}
+	}
+
+	root, err := os.OpenRoot(m.dir)
+	if err != nil {
+		return staticError(w, http.StatusNotFound)
+	}
+	defer root.Close()
+
+	info, err := root.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) && m.spa && path.Ext(name) == "" {
+		name = m.index
+		info, err = root.Stat(name)
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return staticError(w, http.StatusForbidden)
+		}
+		return staticError(w, http.StatusNotFound)
+	}
+
+	if info.IsDir() {
+		if !strings.HasSuffix(req.URL.Path, "/") {
+			target := path.Base(req.URL.Path) + "/"
+			if req.URL.RawQuery != "" {
+				target += "?" + req.URL.RawQuery
+			}
+			http.Redirect(w, req, target, http.StatusMovedPermanently)
+			return http.StatusMovedPermanently
+		}
+		if m.index != "" {
+			if indexInfo, err := root.Stat(path.Join(name, m.index)); err == nil && !indexInfo.IsDir() {
+				return m.serveFile(w, req, root, path.Join(name, m.index), indexInfo)
+			}
+		}
+		if m.listing {
+			return m.serveListing(w, req, root, name)
+		}
+		return staticError(w, http.StatusNotFound)
+	}
+	return m.serveFile(w, req, root, name, info)
+}
+
+func (m *staticMount) serveFile(w http.ResponseWriter, req *http.Request, root *os.Root, name string, info fs.FileInfo) int {
+	ext := strings.ToLower(path.Ext(name))
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		if ctype := mime.TypeByExtension(ext); ctype != "" {
+			header.Set("Content-Type", ctype)
+		}
+	}
+	if header.Get("Cache-Control") == "" {
+		if value, ok := m.cacheControl[ext]; ok {
+			header.Set("Cache-Control", value)
+		} else if value, ok := m.cacheControl["*"]; ok {
+			header.Set("Cache-Control", value)
+		}
+	}
+
+	// A precompressed sibling (app.js.gz) is sent as-is to clients that accept gzip
+	if m.precompressed {
+		if gzInfo, err := root.Stat(name + ".gz"); err == nil && !gzInfo.IsDir() {
+			header.Add("Vary", "Accept-Encoding")
+			if acceptsGzip(req) {
+				name, info = name+".gz", gzInfo
+				header.Set("Content-Encoding", "gzip")
+			}
+		}
+	}
+
+	file, err := root.Open(name)
+	if err != nil {
+		return staticError(w, http.StatusNotFound)
+	}
+	defer file.Close()
+	if m.etag {
+		header.Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	}
+
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	http.ServeContent(rec, req, info.Name(), info.ModTime(), file)
+	return rec.status
+}
+
+// serveListing writes an HTML list of a directory's entries (dotfiles only when allowed)
+func (m *staticMount) serveListing(w http.ResponseWriter, req *http.Request, root *os.Root, name string) int {
+	dir, err := root.Open(name)
+	if err != nil {
+		return staticError(w, http.StatusNotFound)
+	}
+	defer dir.Close()
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return staticError(w, http.StatusInternalServerError)
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(req.URL.Path)
+	fmt.Fprintf(&b, "<!doctype html>\n<title>%s</title>\n<h1>%s</h1>\n<ul>\n", title, title)
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if strings.HasPrefix(entryName, ".") && m.dotfiles != "allow" {
+			continue
+		}
+		if entry.IsDir() {
+			entryName += "/"
+		}
+		link := (&url.URL{Path: entryName}).String()
+		fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(link), html.EscapeString(entryName))
+	}
+	b.WriteString("</ul>\n")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if req.Method != "HEAD" {
+		fmt.Fprint(w, b.String())
+	}
+	return http.StatusOK
+}
+
+func staticError(w http.ResponseWriter, status int) int {
+	http.Error(w, fmt.Sprintf("%d %s", status, strings.ToLower(http.StatusText(status))), status)
+	return status
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip
+func acceptsGzip(req *http.Request) bool {
+	for _, part := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") || strings.TrimSpace(coding) == "*" {
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// responseStream lets a handler write its response incrementally (res.lekho, res.stream,
+// sse_shuru) instead of setting res.body. Every write is flushed, so responses without a
+// Content-Length go out with chunked transfer encoding.
+type responseStream struct {
+	w      http.ResponseWriter
+	req    *http.Request
+	resMap *object.Map
+
+	mu            sync.Mutex
+	started       bool           // status and headers have been sent
+	ended         bool           // res.samapti() / stream_shesh(res.stream) was called
+	done          bool           // the server has finished with this request
+	beforeHeaders []func() error // run once, just before the status and headers go out
+}
+
+// responseStreams links res maps to their writers for helpers such as sse_shuru
+var responseStreams sync.Map // *object.Map → *responseStream
+
+// attachResponseStream adds the streaming API to a res map:
+// res.lekho(chunk), res.dhalo(), res.samapti(chunk?) and res.stream (writable Stream)
+func attachResponseStream(w http.ResponseWriter, req *http.Request, resMap *object.Map) *responseStream {
+	rs := &responseStream{w: w, req: req, resMap: resMap}
+	responseStreams.Store(resMap, rs)
+
+	// lekho (লেখো - write) sends a chunk right away
+	resMap.Pairs["lekho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments to res.lekho(). got=%d, want=1", len(args))
+			}
+			if _, err := rs.Write(chunkBytes(args[0])); err != nil {
+				return newError("res.lekho(): %s", err.Error())
+			}
+			return object.TRUE
+		},
+	}
+
+	// dhalo (ঢালো - flush) sends the status and headers (and anything written) immediately
+	resMap.Pairs["dhalo"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if _, err := rs.Write(nil); err != nil {
+				return newError("res.dhalo(): %s", err.Error())
+			}
+			return object.NULL
+		},
+	}
+
+	// samapti (সমাপ্তি - end) writes an optional last chunk and finishes the response
+	resMap.Pairs["samapti"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments to res.samapti(). got=%d, want=0-1", len(args))
+			}
+			var chunk []byte
+			if len(args) == 1 {
+				chunk = chunkBytes(args[0])
+			}
+			if _, err := rs.Write(chunk); err != nil {
+				return newError("res.samapti(): %s", err.Error())
+			}
+			rs.Close()
+			return object.NULL
+		},
+	}
+
+	resMap.Pairs["stream"] = &object.Stream{StreamType: "writable", Sink: rs, HighWaterMark: 16384}
+	return rs
+}
+
+// Write sends the status line and headers from the res map on first use, then the chunk,
+// and flushes
+func (rs *responseStream) Write(p []byte) (int, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.done {
+		return 0, errors.New("response already finished")
+	}
+	if rs.ended {
+		return 0, errors.New("response already ended with res.samapti()")
+	}
+	if !rs.started {
+		hooks := rs.beforeHeaders
+		rs.beforeHeaders = nil
+		for _, hook := range hooks {
+			if err := hook(); err != nil {
+				return 0, err
+			}
+		}
+		rs.started = true
+		status := applyResponseHeaders(rs.w, rs.resMap)
+		rs.w.WriteHeader(status)
+	}
+	n, err := rs.w.Write(p)
+	if err == nil {
+		err = http.NewResponseController(rs.w).Flush()
+	}
+	return n, err
+}
+
+// Close ends the response; later writes fail
+func (rs *responseStream) Close() error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.ended = true
+	return nil
+}
+
+// onResponseHeaders runs hook just before a streamed response sends its status and
+// headers, so middleware can still change them. It reports false when the response is
+// not being streamed or its headers are already out.
+func onResponseHeaders(resMap *object.Map, hook func() error) bool {
+	value, ok := responseStreams.Load(resMap)
+	if !ok {
+		return false
+	}
+	rs := value.(*responseStream)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.started || rs.done {
+		return false
+	}
+	rs.beforeHeaders = append(rs.beforeHeaders, hook)
+	return true
+}
+
+// finish detaches the stream once the handler is done and reports whether the
+// response was already (partly) sent, in which case res.body is ignored
+func (rs *responseStream) finish() bool {
+	responseStreams.Delete(rs.resMap)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.done = true
+	return rs.started
+}
+
+// requestBodyStream exposes a request body as a readable Stream: prefilled when the
+// body was already read, otherwise pulling from source as the script reads
+func requestBodyStream(body []byte, source io.Reader) *object.Stream {
+	stream := &object.Stream{StreamType: "readable", HighWaterMark: 16384}
+	if source != nil {
+		stream.Source = source
+		return stream
+	}
+	stream.Buffer = body
+	stream.IsEnded = true
+	stream.IsClosed = len(body) == 0
+	return stream
+}
+
+// chunkBytes converts a value written to a response into bytes
+func chunkBytes(obj object.Object) []byte {
+	switch v := obj.(type) {
+	case *object.String:
+		return []byte(v.Value)
+	case *object.Buffer:
+		v.Mu.RLock()
+		defer v.Mu.RUnlock()
+		return append([]byte(nil), v.Data...)
+	case *object.Map, *object.Array:
+		return []byte(stringifyJSON(obj))
+	}
+	return []byte(obj.Inspect())
+}
+
+func init() {
+	// sse_shuru (SSE শুরু - start Server-Sent Events) turns a response into an event stream.
+	// Returns {pathano(data, {event, id, retry}?), ping(), cholche(), bondho()}.
+	Builtins["sse_shuru"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+			resMap, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("argument to `sse_shuru` must be the response MAP, got %s", args[0].Type())
+			}
+			value, ok := responseStreams.Load(resMap)
+			if !ok {
+				return newError("`sse_shuru` needs the res object of a request that is still being handled")
+			}
+			rs := value.(*responseStream)
+
+			headers, ok := resMap.Pairs["headers"].(*object.Map)
+			if !ok {
+				headers = &object.Map{Pairs: make(map[string]object.Object)}
+				resMap.Pairs["headers"] = headers
+			}
+			headers.Pairs["Content-Type"] = &object.String{Value: "text/event-stream; charset=utf-8"}
+			headers.Pairs["Cache-Control"] = &object.String{Value: "no-cache"}
+			headers.Pairs["Connection"] = &object.String{Value: "keep-alive"}
+			headers.Pairs["X-Accel-Buffering"] = &object.String{Value: "no"}
+			if _, err := rs.Write(nil); err != nil {
+				return newError("sse_shuru: %s", err.Error())
+			}
+
+			return sseSenderMap(rs)
+		},
+	}
+}
+
+// sseSenderMap builds the event sender returned by sse_shuru
+func sseSenderMap(rs *responseStream) *object.Map {
+	sender := &object.Map{Pairs: make(map[string]object.Object, 4)}
+
+	// pathano (পাঠানো - send) writes one event; non-string data is sent as JSON
+	sender.Pairs["pathano"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments to sse.pathano(). got=%d, want=1-2", len(args))
+			}
+			var event strings.Builder
+			if len(args) == 2 {
+				opts, ok := args[1].(*object.Map)
+				if !ok {
+					return newError("options to sse.pathano() must be MAP, got %s", args[1].Type())
+				}
+				for _, field := range []string{"event", "id", "retry"} {
+					if v, ok := opts.Pairs[field]; ok && v != object.NULL {
+						fmt.Fprintf(&event, "%s: %s\n", field, v.Inspect())
+					}
+				}
+			}
+			for _, line := range strings.Split(string(chunkBytes(args[0])), "\n") {
+				fmt.Fprintf(&event, "data: %s\n", line)
+			}
+			event.WriteString("\n")
+			if _, err := rs.Write([]byte(event.String())); err != nil {
+				return newError("sse.pathano(): %s", err.Error())
+			}
+			return object.TRUE
+		},
+	}
+
+	// ping sends a comment line to keep idle connections open
+	sender.Pairs["ping"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if _, err := rs.Write([]byte(": ping\n\n")); err != nil {
+				return newError("sse.ping(): %s", err.Error())
+			}
+			return object.TRUE
+		},
+	}
+
+	// cholche (চলছে - running) reports whether the client is still connected
+	sender.Pairs["cholche"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if rs.req.Context().Err() != nil {
+				return object.FALSE
+			}
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			return object.NativeBoolToBooleanObject(!rs.ended && !rs.done)
+		},
+	}
+
+	// bondho (বন্ধ - close) ends the event stream
+	sender.Pairs["bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			rs.Close()
+			return object.NULL
+		},
+	}
+	return sender
+}
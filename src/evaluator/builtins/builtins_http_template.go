@@ -0,0 +1,435 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template/parse"
+	"time"
+)
+
+// Templates use Go's html/template syntax ({{.naam}}, {{if}}, {{range}}, {{template}},
+// {{block}}) so every value is escaped for the context it lands in (HTML, attribute,
+// URL, script). Files live under one directory and are named by their path without
+// the extension: "users/list" is <dir>/users/list.html. A page rendered with a layout
+// becomes the layout's "content" template, and {{define "title"}} in the page fills
+// {{block "title" .}} in the layout. Partials are loaded when a template references them.
+
+// templateFuncs are available in every template
+var templateFuncs = template.FuncMap{
+	// kacha (কাঁচা - raw) marks a trusted string as HTML so it is not escaped
+	"kacha": func(v interface{}) template.HTML { return template.HTML(fmt.Sprint(v)) },
+}
+
+// templateEngine renders the templates of one directory, caching the parsed sets
+type templateEngine struct {
+	dir    string
+	ext    string
+	layout string // default layout ("" = none)
+	dev    bool   // re-parse templates whose files changed since they were cached
+
+	mu    sync.Mutex
+	cache map[string]*templateEntry // "page|layout" → parsed set
+}
+
+// templateEntry is a parsed page (with its layout and partials) and the files it came from
+type templateEntry struct {
+	set   *template.Template
+	root  string
+	files map[string]time.Time
+}
+
+func newTemplateEngine(dir, ext, layout string, dev bool) *templateEngine {
+	return &templateEngine{dir: dir, ext: ext, layout: layout, dev: dev, cache: make(map[string]*templateEntry)}
+}
+
+// parseTemplateOptions reads {layout, ext, dev} for template_banao / template_chalu
+func parseTemplateOptions(name, dir string, arg object.Object) (*templateEngine, *object.Error) {
+	ext, layout, dev := ".html", "", false
+	if arg != nil {
+		opts, ok := arg.(*object.Map)
+		if !ok {
+			return nil, newError("options to `%s` must be MAP, got %s", name, arg.Type())
+		}
+		if v, ok := opts.Pairs["layout"]; ok && v != object.NULL {
+			s, ok := v.(*object.String)
+			if !ok {
+				return nil, newError("`layout` option to `%s` must be STRING, got %s", name, v.Type())
+			}
+			layout = s.Value
+		}
+		if v, ok := opts.Pairs["ext"]; ok {
+			s, ok := v.(*object.String)
+			if !ok {
+				return nil, newError("`ext` option to `%s` must be STRING, got %s", name, v.Type())
+			}
+			ext = s.Value
+			if ext != "" && !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+		}
+		if v, ok := opts.Pairs["dev"]; ok {
+			b, ok := v.(*object.Boolean)
+			if !ok {
+				return nil, newError("`dev` option to `%s` must be BOOLEAN, got %s", name, v.Type())
+			}
+			dev = b.Value
+		}
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, newError("%s: template directory '%s' not found", name, dir)
+	}
+	return newTemplateEngine(dir, ext, layout, dev), nil
+}
+
+// file maps a template name to its path, refusing names that leave the directory
+func (e *templateEngine) file(name string) (string, error) {
+	name = strings.TrimSuffix(filepath.ToSlash(name), e.ext)
+	if !fs.ValidPath(name) || name == "." {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+	return filepath.Join(e.dir, filepath.FromSlash(name)+e.ext), nil
+}
+
+// render executes page (inside layout unless it is "") with data
+func (e *templateEngine) render(page, layout string, data object.Object) (string, error) {
+	entry, err := e.lookup(page, layout)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := entry.set.ExecuteTemplate(&buf, entry.root, templateValue(data)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// lookup returns the cached set for page+layout, parsing it on first use and, in dev
+// mode, again whenever one of its files changed
+func (e *templateEngine) lookup(page, layout string) (*templateEntry, error) {
+	key := page + "|" + layout
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if entry, ok := e.cache[key]; ok && !(e.dev && entry.stale()) {
+		return entry, nil
+	}
+	entry, err := e.build(page, layout)
+	if err != nil {
+		return nil, err
+	}
+	e.cache[key] = entry
+	return entry, nil
+}
+
+func (e *templateEngine) build(page, layout string) (*templateEntry, error) {
+	entry := &templateEntry{files: make(map[string]time.Time)}
+	add := func(name, as string) error {
+		file, err := e.file(name)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("template %q not found in %s", name, e.dir)
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		entry.files[file] = info.ModTime()
+		if entry.set == nil {
+			entry.set = template.New(as).Funcs(templateFuncs)
+			_, err = entry.set.Parse(string(src))
+		} else {
+			_, err = entry.set.New(as).Parse(string(src))
+		}
+		return err
+	}
+
+	// The layout goes first so the page's {{define}}s replace its {{block}} defaults
+	entry.root = page
+	if layout != "" {
+		if err := add(layout, layout); err != nil {
+			return nil, err
+		}
+		entry.root = layout
+		if err := add(page, "content"); err != nil {
+			return nil, err
+		}
+	} else if err := add(page, page); err != nil {
+		return nil, err
+	}
+
+	// Load referenced partials until nothing new is found; names that are neither
+	// defined nor files are reported by html/template when executed
+	tried := make(map[string]bool)
+	for {
+		refs := make(map[string]bool)
+		for _, t := range entry.set.Templates() {
+			if t.Tree != nil {
+				templateRefs(t.Tree.Root, refs)
+			}
+		}
+		added := false
+		for name := range refs {
+			if tried[name] || entry.set.Lookup(name) != nil {
+				continue
+			}
+			tried[name] = true
+			if file, err := e.file(name); err != nil {
+				continue
+			} else if _, err := os.Stat(file); err != nil {
+				continue
+			}
+			if err := add(name, name); err != nil {
+				return nil, err
+			}
+			added = true
+		}
+		if !added {
+			return entry, nil
+		}
+	}
+}
+
+func (entry *templateEntry) stale() bool {
+	for file, modTime := range entry.files {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateRefs collects the names used in {{template "name"}} actions
+func templateRefs(node parse.Node, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateRefs(child, refs)
+		}
+	case *parse.IfNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	case *parse.RangeNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	case *parse.WithNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	case *parse.TemplateNode:
+		refs[n.Name] = true
+	}
+}
+
+// templateValue converts BanglaCode values to the Go values templates work with.
+// Whole numbers become ints so they print as 1000000 rather than 1e+06 and compare
+// with integer literals in {{eq}}/{{lt}}.
+func templateValue(obj object.Object) interface{} {
+	switch v := obj.(type) {
+	case nil, *object.Null:
+		return nil
+	case *object.Boolean:
+		return v.Value
+	case *object.Number:
+		if v.Value == math.Trunc(v.Value) && math.Abs(v.Value) < 1<<53 {
+			return int64(v.Value)
+		}
+		return v.Value
+	case *object.String:
+		return v.Value
+	case *object.Array:
+		arr := make([]interface{}, len(v.Elements))
+		for i, el := range v.Elements {
+			arr[i] = templateValue(el)
+		}
+		return arr
+	case *object.Map:
+		keys := v.EnumerableKeys()
+		m := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			m[key] = templateValue(mapPropertyValue(v, key))
+		}
+		return m
+	default:
+		return obj.Inspect()
+	}
+}
+
+// renderArgs reads (name, data?, {layout, status}?) for engine.likho and res.dekhao
+func renderArgs(fn string, e *templateEngine, args []object.Object) (page, layout string, data object.Object, status float64, errObj *object.Error) {
+	if len(args) < 1 || len(args) > 3 {
+		return "", "", nil, 0, newError("wrong number of arguments to `%s`. got=%d, want=1-3 (name, data?, options?)", fn, len(args))
+	}
+	name, ok := args[0].(*object.String)
+	if !ok {
+		return "", "", nil, 0, newError("argument 1 to `%s` must be STRING (template name), got %s", fn, args[0].Type())
+	}
+	page, layout, data = name.Value, e.layout, object.NULL
+	if len(args) >= 2 {
+		data = args[1]
+	}
+	if len(args) == 3 {
+		opts, ok := args[2].(*object.Map)
+		if !ok {
+			return "", "", nil, 0, newError("argument 3 to `%s` must be MAP (options), got %s", fn, args[2].Type())
+		}
+		switch v := opts.Pairs["layout"].(type) {
+		case nil:
+		case *object.Null:
+			layout = ""
+		case *object.String:
+			layout = v.Value
+		default:
+			return "", "", nil, 0, newError("`layout` option to `%s` must be STRING or khali, got %s", fn, v.Type())
+		}
+		if v, ok := opts.Pairs["status"]; ok {
+			n, ok := v.(*object.Number)
+			if !ok {
+				return "", "", nil, 0, newError("`status` option to `%s` must be NUMBER, got %s", fn, v.Type())
+			}
+			status = n.Value
+		}
+	}
+	return page, layout, data, status, nil
+}
+
+// templateDekhao builds res.dekhao(name, data?, {layout, status}?) for one response
+func templateDekhao(e *templateEngine, resMap *object.Map) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			page, layout, data, status, errObj := renderArgs("res.dekhao", e, args)
+			if errObj != nil {
+				return errObj
+			}
+			html, err := e.render(page, layout, data)
+			if err != nil {
+				return newError("res.dekhao: %s", err.Error())
+			}
+			if status > 0 {
+				resMap.Pairs["status"] = &object.Number{Value: status}
+			}
+			resMap.Pairs["body"] = &object.String{Value: html}
+			if h, ok := resMap.Pairs["headers"].(*object.Map); ok {
+				h.Pairs["Content-Type"] = &object.String{Value: "text/html; charset=utf-8"}
+			}
+			return resMap
+		},
+	}
+}
+
+func init() {
+	// template_banao (টেমপ্লেট বানাও - create a template engine for a directory)
+	// template_banao("./views", {layout: "layouts/main", ext: ".html", dev: sotti})
+	// Returns {dir, likho(name, data?, {layout}?)} where likho returns the rendered STRING.
+	Builtins["template_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (dir, options?)", len(args))
+			}
+			dir, ok := args[0].(*object.String)
+			if !ok {
+				return newError("first argument to `template_banao` must be STRING (dir), got %s", args[0].Type())
+			}
+			var opts object.Object
+			if len(args) == 2 {
+				opts = args[1]
+			}
+			engine, errObj := parseTemplateOptions("template_banao", dir.Value, opts)
+			if errObj != nil {
+				return errObj
+			}
+
+			engineMap := newObjectMap()
+			engineMap.Pairs["dir"] = &object.String{Value: dir.Value}
+			// likho (লেখো - write) renders a template to a string
+			engineMap.Pairs["likho"] = &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					page, layout, data, _, errObj := renderArgs("likho", engine, args)
+					if errObj != nil {
+						return errObj
+					}
+					html, err := engine.render(page, layout, data)
+					if err != nil {
+						return newError("template error: %s", err.Error())
+					}
+					return &object.String{Value: html}
+				},
+			}
+			return engineMap
+		},
+	}
+
+	// template_bhoro (টেমপ্লেট ভরো - fill an inline template)
+	// template_bhoro("<p>{{.naam}}</p>", {naam: "<Rahim>"})  → "<p>&lt;Rahim&gt;</p>"
+	Builtins["template_bhoro"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (source, data?)", len(args))
+			}
+			source, ok := args[0].(*object.String)
+			if !ok {
+				return newError("first argument to `template_bhoro` must be STRING (template), got %s", args[0].Type())
+			}
+			var data object.Object = object.NULL
+			if len(args) == 2 {
+				data = args[1]
+			}
+			tmpl, err := template.New("inline").Funcs(templateFuncs).Parse(source.Value)
+			if err != nil {
+				return newError("template error: %s", err.Error())
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, templateValue(data)); err != nil {
+				return newError("template error: %s", err.Error())
+			}
+			return &object.String{Value: buf.String()}
+		},
+	}
+
+	// template_chalu (টেমপ্লেট চালু - enable server-side rendering on the router)
+	// template_chalu(app, "./views", {layout: "layouts/main", ext: ".html", dev: sotti})
+	// Handlers then call res.dekhao("users/list", {users: users}, {layout: khali, status: 404}?)
+	Builtins["template_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3 (app, dir, options?)", len(args))
+			}
+			router, err := extractRouter("template_chalu", args[0])
+			if err != nil {
+				return err
+			}
+			dir, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `template_chalu` must be STRING (dir), got %s", args[1].Type())
+			}
+			var opts object.Object
+			if len(args) == 3 {
+				opts = args[2]
+			}
+			engine, errObj := parseTemplateOptions("template_chalu", dir.Value, opts)
+			if errObj != nil {
+				return errObj
+			}
+			router.AddMiddleware(&object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					resMap := args[1].(*object.Map)
+					resMap.Pairs["dekhao"] = templateDekhao(engine, resMap)
+					return callHandler(args[2], nil)
+				},
+			})
+			return args[0]
+		},
+	}
+}
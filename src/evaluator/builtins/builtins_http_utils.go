@@ -3,7 +3,6 @@ package builtins
 import (
 	"BanglaCode/src/object"
 	"fmt"
-	"net/http"
 	"os"
 	"strings"
 )
@@ -53,11 +52,13 @@ func init() {
 	}
 
 	// file_dao (ফাইল দাও - serve static files from directory)
-	// file_dao(app, "/public", "./static_dir")
+	// file_dao(app, "/public", "./static_dir", {maxAge: 3600, spa: sotti, majhe: [fn]}?)
+	// Registers GET/HEAD "/public/*path", so global and {majhe} middleware run first.
+	// options: {index, listing, spa, dotfiles, precompressed, etag, maxAge, immutable, cacheControl, majhe}
 	Builtins["file_dao"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 3 {
-				return newError("wrong number of arguments. got=%d, want=3 (app, urlPrefix, dirPath)", len(args))
+			if len(args) < 3 || len(args) > 4 {
+				return newError("wrong number of arguments. got=%d, want=3-4 (app, urlPrefix, dirPath, options?)", len(args))
 			}
 			router, err := extractRouter("file_dao", args[0])
 			if err != nil {
@@ -69,18 +70,37 @@ func init() {
 			if args[2].Type() != object.STRING_OBJ {
 				return newError("third argument to `file_dao` must be STRING (directory path), got %s", args[2].Type())
 			}
+			var opts *object.Map
+			if len(args) == 4 {
+				m, ok := args[3].(*object.Map)
+				if !ok {
+					return newError("fourth argument to `file_dao` must be MAP (options), got %s", args[3].Type())
+				}
+				opts = m
+			}
 
-			urlPrefix := args[1].(*object.String).Value
+			urlPrefix := strings.TrimSuffix(args[1].(*object.String).Value, "/")
 			dirPath := args[2].(*object.String).Value
-
+			if info, statErr := os.Stat(dirPath); statErr != nil || !info.IsDir() {
+				return newError("file_dao: directory '%s' not found", dirPath)
+			}
 			if !strings.HasPrefix(urlPrefix, "/") {
 				urlPrefix = "/" + urlPrefix
 			}
-			fileHandler := http.StripPrefix(urlPrefix, http.FileServer(http.Dir(dirPath)))
+			mount, errObj := parseStaticOptions(dirPath, opts)
+			if errObj != nil {
+				return errObj
+			}
 
-			router.mu.Lock()
-			router.fileRoutes = append(router.fileRoutes, FileRoute{prefix: urlPrefix, handler: fileHandler})
-			router.mu.Unlock()
+			// {majhe} and the other route options apply to the mount like to any route
+			routeOpts, optErr := routeOptions("file_dao", args[1:])
+			if optErr != nil {
+				return optErr
+			}
+			routeOpts.Static, routeOpts.Hidden = mount, true
+			if addErr := router.AddRoute("GET", strings.TrimSuffix(urlPrefix, "/")+"/*path", nil, routeOpts); addErr != nil {
+				return newError("file_dao: %s", addErr.Error())
+			}
 			return args[0]
 		},
 	}
@@ -119,6 +139,7 @@ func init() {
 	// kuki_rakho (কুকি রাখো - set a cookie on the response)
 	// kuki_rakho(res, "name", "value")
 	// kuki_rakho(res, "name", "value", {"httpOnly": sotti, "secure": sotti, "maxAge": 3600, "path": "/", "sameSite": "Lax"})
+	// kuki_rakho(res, "name", "value", {"secret": "..."})  → signed, read back with kuki_pora
 	Builtins["kuki_rakho"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			if len(args) < 3 || len(args) > 4 {
@@ -138,40 +159,14 @@ func init() {
 			name := args[1].(*object.String).Value
 			value := args[2].(*object.String).Value
 
-			cookieStr := fmt.Sprintf("%s=%s", name, value)
-
+			var opts *object.Map
 			if len(args) == 4 && args[3].Type() == object.MAP_OBJ {
-				opts := args[3].(*object.Map)
-				if v, ok := opts.Pairs["path"].(*object.String); ok {
-					cookieStr += "; Path=" + v.Value
-				} else {
-					cookieStr += "; Path=/"
-				}
-				if v, ok := opts.Pairs["maxAge"].(*object.Number); ok {
-					cookieStr += fmt.Sprintf("; Max-Age=%d", int(v.Value))
-				}
-				if v, ok := opts.Pairs["sameSite"].(*object.String); ok {
-					cookieStr += "; SameSite=" + v.Value
-				}
-				if v, ok := opts.Pairs["httpOnly"].(*object.Boolean); ok && v.Value {
-					cookieStr += "; HttpOnly"
-				}
-				if v, ok := opts.Pairs["secure"].(*object.Boolean); ok && v.Value {
-					cookieStr += "; Secure"
-				}
-			} else {
-				cookieStr += "; Path=/"
-			}
-
-			if h, ok := resMap.Pairs["headers"].(*object.Map); ok {
-				// Append to existing Set-Cookie or set new one
-				existing, hasExisting := h.Pairs["Set-Cookie"].(*object.String)
-				if hasExisting && existing.Value != "" {
-					h.Pairs["Set-Cookie"] = &object.String{Value: existing.Value + "\r\nSet-Cookie: " + cookieStr}
-				} else {
-					h.Pairs["Set-Cookie"] = &object.String{Value: cookieStr}
+				opts = args[3].(*object.Map)
+				if secret, ok := opts.Pairs["secret"].(*object.String); ok {
+					value = signCookieValue(name, value, secret.Value)
 				}
 			}
+			appendSetCookie(resMap, cookieString(name, value, opts))
 			return resMap
 		},
 	}
@@ -205,15 +200,30 @@ func init() {
 
 	// log_chalu (লগ চালু - enable request logging on the router)
 	// log_chalu(app)
+	// log_chalu(app, {format: "json", file: "access.log"}) → one JSON object per response
 	Builtins["log_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1 (app)", len(args))
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (app, options?)", len(args))
 			}
 			router, err := extractRouter("log_chalu", args[0])
 			if err != nil {
 				return err
 			}
+			if len(args) == 2 {
+				opts, ok := args[1].(*object.Map)
+				if !ok {
+					return newError("second argument to `log_chalu` must be MAP (options), got %s", args[1].Type())
+				}
+				logger, errObj := parseLogOptions(opts)
+				if errObj != nil {
+					return errObj
+				}
+				router.mu.Lock()
+				router.logEnabled, router.accessLog = false, logger
+				router.mu.Unlock()
+				return args[0]
+			}
 			router.mu.Lock()
 			router.logEnabled = true
 			router.mu.Unlock()
@@ -222,6 +232,49 @@ func init() {
 	}
 }
 
+// cookieString builds a Set-Cookie value from kuki_rakho-style options (Path defaults to /)
+func cookieString(name, value string, opts *object.Map) string {
+	cookieStr := fmt.Sprintf("%s=%s", name, value)
+	if opts == nil {
+		return cookieStr + "; Path=/"
+	}
+	if v, ok := opts.Pairs["path"].(*object.String); ok {
+		cookieStr += "; Path=" + v.Value
+	} else {
+		cookieStr += "; Path=/"
+	}
+	if v, ok := opts.Pairs["maxAge"].(*object.Number); ok {
+		cookieStr += fmt.Sprintf("; Max-Age=%d", int(v.Value))
+	}
+	if v, ok := opts.Pairs["sameSite"].(*object.String); ok {
+		cookieStr += "; SameSite=" + v.Value
+	}
+	if v, ok := opts.Pairs["httpOnly"].(*object.Boolean); ok && v.Value {
+		cookieStr += "; HttpOnly"
+	}
+	if v, ok := opts.Pairs["secure"].(*object.Boolean); ok && v.Value {
+		cookieStr += "; Secure"
+	}
+	return cookieStr
+}
+
+// setCookieSeparator joins several cookies inside res.headers["Set-Cookie"];
+// applyResponseHeaders sends each one as its own header
+const setCookieSeparator = "\r\nSet-Cookie: "
+
+// appendSetCookie adds a cookie to res.headers, keeping cookies set earlier
+func appendSetCookie(resMap *object.Map, cookieStr string) {
+	h, ok := resMap.Pairs["headers"].(*object.Map)
+	if !ok {
+		return
+	}
+	if existing, ok := h.Pairs["Set-Cookie"].(*object.String); ok && existing.Value != "" {
+		h.Pairs["Set-Cookie"] = &object.String{Value: existing.Value + setCookieSeparator + cookieStr}
+	} else {
+		h.Pairs["Set-Cookie"] = &object.String{Value: cookieStr}
+	}
+}
+
 // extractRouter retrieves the *Router from a BanglaCode router map.
 func extractRouter(fn string, arg object.Object) (*Router, object.Object) {
 	if arg.Type() != object.MAP_OBJ {
@@ -0,0 +1,338 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"math"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route schemas use a subset of JSON Schema written as BanglaCode maps:
+//
+//	{schema: {params: S, query: S, headers: S, body: S, summary, description, tags, responses}}
+//
+// where params/query/headers are object schemas whose properties are coerced from strings.
+// Supported keywords: type, properties, required, additionalProperties, items, enum,
+// minimum, maximum, minLength, maxLength, pattern, format, minItems, maxItems.
+
+// validationIssue is one failed check, reported in the 400 body
+type validationIssue struct {
+	in      string // params | query | headers | body
+	path    string // dotted location, e.g. body.user.age
+	message string
+}
+
+func (v validationIssue) toMap() *object.Map {
+	m := &object.Map{Pairs: make(map[string]object.Object, 3)}
+	m.Pairs["in"] = &object.String{Value: v.in}
+	m.Pairs["path"] = &object.String{Value: v.path}
+	m.Pairs["message"] = &object.String{Value: v.message}
+	return m
+}
+
+// validateRequest checks req against a route schema, coercing params/query/headers in
+// place. Returns the issues found (empty when valid).
+func validateRequest(schema *object.Map, reqMap *object.Map) []validationIssue {
+	var issues []validationIssue
+	for _, section := range []string{"params", "query", "headers"} {
+		sectionSchema, ok := schema.Pairs[section].(*object.Map)
+		if !ok {
+			continue
+		}
+		values, _ := reqMap.Pairs[section].(*object.Map)
+		if values == nil {
+			values = &object.Map{Pairs: make(map[string]object.Object)}
+		}
+		issues = append(issues, validateStringFields(section, values, sectionSchema)...)
+	}
+	if bodySchema, ok := schema.Pairs["body"].(*object.Map); ok {
+		body := reqMap.Pairs["json"]
+		if body == nil {
+			body = object.NULL
+		}
+		if body.Type() == object.ERROR_OBJ {
+			issues = append(issues, validationIssue{"body", "body", "must be valid JSON"})
+		} else {
+			validateValue("body", "body", body, bodySchema, &issues)
+		}
+	}
+	return issues
+}
+
+// validateStringFields checks string-valued request fields, converting them to the
+// property's type and writing the converted values back
+func validateStringFields(section string, values, schema *object.Map) []validationIssue {
+	var issues []validationIssue
+	properties, _ := schema.Pairs["properties"].(*object.Map)
+	lookup := func(name string) (string, object.Object, bool) {
+		if section == "headers" {
+			name = http.CanonicalHeaderKey(name)
+		}
+		v, ok := values.Pairs[name]
+		return name, v, ok
+	}
+
+	for _, name := range schemaRequired(schema) {
+		if _, _, ok := lookup(name); !ok {
+			issues = append(issues, validationIssue{section, section + "." + name, "is required"})
+		}
+	}
+	if properties == nil {
+		return issues
+	}
+	for _, name := range sortedKeys(properties) {
+		propSchema, ok := properties.Pairs[name].(*object.Map)
+		if !ok {
+			continue
+		}
+		key, value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if s, isString := value.(*object.String); isString {
+			value = coerceString(s.Value, propSchema)
+			values.Pairs[key] = value
+		}
+		validateValue(section, section+"."+name, value, propSchema, &issues)
+	}
+	return issues
+}
+
+// coerceString converts a string field to the schema's scalar type when it parses cleanly
+func coerceString(s string, schema *object.Map) object.Object {
+	types := schemaTypes(schema)
+	for _, t := range types {
+		switch t {
+		case "number", "integer":
+			if n, err := strconv.ParseFloat(s, 64); err == nil {
+				return &object.Number{Value: n}
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(s); err == nil {
+				return object.NativeBoolToBooleanObject(b)
+			}
+		}
+	}
+	return &object.String{Value: s}
+}
+
+// validateValue checks one value against a schema, appending issues
+func validateValue(in, path string, value object.Object, schema *object.Map, issues *[]validationIssue) {
+	fail := func(format string, args ...interface{}) {
+		*issues = append(*issues, validationIssue{in, path, fmt.Sprintf(format, args...)})
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !matchesAnyType(value, types) {
+		fail("must be %s", strings.Join(types, " or "))
+		return
+	}
+	if enum, ok := schema.Pairs["enum"].(*object.Array); ok {
+		found := false
+		options := make([]string, len(enum.Elements))
+		for i, option := range enum.Elements {
+			options[i] = stringifyJSON(option)
+			if stringifyJSON(option) == stringifyJSON(value) {
+				found = true
+			}
+		}
+		if !found {
+			fail("must be one of %s", strings.Join(options, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case *object.Number:
+		if min, ok := schemaNumber(schema, "minimum"); ok && v.Value < min {
+			fail("must be >= %s", formatSchemaNumber(min))
+		}
+		if max, ok := schemaNumber(schema, "maximum"); ok && v.Value > max {
+			fail("must be <= %s", formatSchemaNumber(max))
+		}
+	case *object.String:
+		length := len([]rune(v.Value))
+		if min, ok := schemaNumber(schema, "minLength"); ok && float64(length) < min {
+			fail("must be at least %s characters", formatSchemaNumber(min))
+		}
+		if max, ok := schemaNumber(schema, "maxLength"); ok && float64(length) > max {
+			fail("must be at most %s characters", formatSchemaNumber(max))
+		}
+		if pattern, ok := schema.Pairs["pattern"].(*object.String); ok {
+			if re, err := regexp.Compile(pattern.Value); err != nil || !re.MatchString(v.Value) {
+				fail("must match pattern %s", pattern.Value)
+			}
+		}
+		if format, ok := schema.Pairs["format"].(*object.String); ok && !matchesFormat(v.Value, format.Value) {
+			fail("must be a valid %s", format.Value)
+		}
+	case *object.Array:
+		if min, ok := schemaNumber(schema, "minItems"); ok && float64(len(v.Elements)) < min {
+			fail("must have at least %s items", formatSchemaNumber(min))
+		}
+		if max, ok := schemaNumber(schema, "maxItems"); ok && float64(len(v.Elements)) > max {
+			fail("must have at most %s items", formatSchemaNumber(max))
+		}
+		if items, ok := schema.Pairs["items"].(*object.Map); ok {
+			for i, item := range v.Elements {
+				validateValue(in, fmt.Sprintf("%s[%d]", path, i), item, items, issues)
+			}
+		}
+	case *object.Map:
+		for _, name := range schemaRequired(schema) {
+			if field, ok := v.Pairs[name]; !ok || field == object.NULL {
+				*issues = append(*issues, validationIssue{in, path + "." + name, "is required"})
+			}
+		}
+		properties, _ := schema.Pairs["properties"].(*object.Map)
+		for _, name := range sortedKeys(v) {
+			var propSchema *object.Map
+			if properties != nil {
+				propSchema, _ = properties.Pairs[name].(*object.Map)
+			}
+			if propSchema != nil {
+				validateValue(in, path+"."+name, v.Pairs[name], propSchema, issues)
+			} else if extra, ok := schema.Pairs["additionalProperties"].(*object.Boolean); ok && !extra.Value {
+				*issues = append(*issues, validationIssue{in, path + "." + name, "is not allowed"})
+			}
+		}
+	}
+}
+
+func schemaTypes(schema *object.Map) []string {
+	switch t := schema.Pairs["type"].(type) {
+	case *object.String:
+		return []string{t.Value}
+	case *object.Array:
+		types := make([]string, 0, len(t.Elements))
+		for _, el := range t.Elements {
+			if s, ok := el.(*object.String); ok {
+				types = append(types, s.Value)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesAnyType(value object.Object, types []string) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case *object.String:
+			if t == "string" {
+				return true
+			}
+		case *object.Number:
+			if t == "number" || (t == "integer" && v.Value == math.Trunc(v.Value)) {
+				return true
+			}
+		case *object.Boolean:
+			if t == "boolean" {
+				return true
+			}
+		case *object.Map:
+			if t == "object" {
+				return true
+			}
+		case *object.Array:
+			if t == "array" {
+				return true
+			}
+		case *object.Null:
+			if t == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// matchesFormat checks the string formats we understand; unknown formats pass
+func matchesFormat(s, format string) bool {
+	switch format {
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	}
+	return true
+}
+
+func schemaRequired(schema *object.Map) []string {
+	required, ok := schema.Pairs["required"].(*object.Array)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(required.Elements))
+	for _, el := range required.Elements {
+		if s, ok := el.(*object.String); ok {
+			names = append(names, s.Value)
+		}
+	}
+	return names
+}
+
+func schemaNumber(schema *object.Map, key string) (float64, bool) {
+	n, ok := schema.Pairs[key].(*object.Number)
+	if !ok {
+		return 0, false
+	}
+	return n.Value, true
+}
+
+func formatSchemaNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func sortedKeys(m *object.Map) []string {
+	keys := make([]string, 0, len(m.Pairs))
+	for k := range m.Pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validationErrorBody is the JSON map sent with a 400 for a request that fails its schema
+func validationErrorBody(issues []validationIssue) *object.Map {
+	details := make([]object.Object, len(issues))
+	for i, issue := range issues {
+		details[i] = issue.toMap()
+	}
+	body := &object.Map{Pairs: make(map[string]object.Object, 2)}
+	body.Pairs["error"] = &object.String{Value: "validation failed"}
+	body.Pairs["details"] = &object.Array{Elements: details}
+	return body
+}
+
+func init() {
+	// schema_jachai (স্কিমা যাচাই - validate against a schema)
+	// schema_jachai(value, schema) → [] when valid, else [{in, path, message}]
+	Builtins["schema_jachai"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments. got=%d, want=2 (value, schema)", len(args))
+			}
+			schema, ok := args[1].(*object.Map)
+			if !ok {
+				return newError("second argument to `schema_jachai` must be MAP (schema), got %s", args[1].Type())
+			}
+			var issues []validationIssue
+			validateValue("value", "value", args[0], schema, &issues)
+			return validationErrorBody(issues).Pairs["details"]
+		},
+	}
+}
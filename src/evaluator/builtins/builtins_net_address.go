@@ -0,0 +1,105 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// trimHostBrackets accepts IPv6 literals written as "[::1]" as well as "::1"
+func trimHostBrackets(host string) string {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+	return host
+}
+
+// joinHostPort builds a dial/listen address that is valid for IPv4, IPv6 and host names
+func joinHostPort(host string, port int) string {
+	return net.JoinHostPort(trimHostBrackets(host), strconv.Itoa(port))
+}
+
+// interfaceIPv4 finds the IPv4 address used to pick an interface for IPv4 multicast
+func interfaceIPv4(ifi *net.Interface) (net.IP, error) {
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet.IP.To4(), nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s has no IPv4 address", ifi.Name)
+}
+
+// parseAddress splits "host:port", "[v6]:port", a bare host or a Unix socket path
+func parseAddress(address string) (*object.Map, error) {
+	result := &object.Map{Pairs: make(map[string]object.Object)}
+	if strings.HasPrefix(address, "/") || strings.HasPrefix(address, "@") || strings.HasPrefix(address, "./") {
+		result.Pairs["family"] = &object.String{Value: "unix"}
+		result.Pairs["path"] = &object.String{Value: address}
+		return result, nil
+	}
+
+	host, port := address, object.Object(object.NULL)
+	if h, p, err := net.SplitHostPort(address); err == nil {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || n > 65535 {
+			return nil, fmt.Errorf("invalid port %q in address %q", p, address)
+		}
+		host, port = h, &object.Number{Value: float64(n)}
+	} else if strings.Count(address, ":") == 1 {
+		return nil, fmt.Errorf("invalid address %q", address)
+	}
+	host = trimHostBrackets(host)
+	if host == "" {
+		return nil, fmt.Errorf("address %q has no host", address)
+	}
+
+	zone := ""
+	if i := strings.LastIndex(host, "%"); i >= 0 {
+		host, zone = host[:i], host[i+1:]
+	}
+	family := "hostname"
+	if ip := net.ParseIP(host); ip != nil {
+		family = "ipv6"
+		if ip.To4() != nil && !strings.Contains(host, ":") {
+			family = "ipv4"
+		}
+	} else if zone != "" {
+		return nil, fmt.Errorf("invalid address %q", address)
+	}
+
+	result.Pairs["family"] = &object.String{Value: family}
+	result.Pairs["host"] = &object.String{Value: host}
+	result.Pairs["port"] = port
+	if zone != "" {
+		result.Pairs["zone"] = &object.String{Value: zone}
+	}
+	return result, nil
+}
+
+func init() {
+	// thikana_poro(address) - Parse a network address (ঠিকানা - address)
+	// Returns {family: "ipv4" | "ipv6" | "hostname", host, port, zone?} or {family: "unix", path}
+	// Example: thikana_poro("[::1]:8080") → {family: "ipv6", host: "::1", port: 8080}
+	Builtins["thikana_poro"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+			address, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to 'thikana_poro' must be STRING, got %s", args[0].Type())
+			}
+			result, err := parseAddress(strings.TrimSpace(address.Value))
+			if err != nil {
+				return newError("thikana_poro: %s", err.Error())
+			}
+			return result
+		},
+	}
+}
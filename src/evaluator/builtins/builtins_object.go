@@ -25,9 +25,10 @@ func registerObjectValues() {
 			return newError("argument to `maan` must be MAP, got %s", args[0].Type())
 		}
 		mapObj := args[0].(*object.Map)
-		values := make([]object.Object, 0, len(mapObj.Pairs))
-		for _, value := range mapObj.Pairs {
-			values = append(values, value)
+		keys := mapObj.EnumerableKeys()
+		values := make([]object.Object, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, mapPropertyValue(mapObj, key))
 		}
 		return &object.Array{Elements: values}
 	}}
@@ -42,9 +43,10 @@ func registerObjectEntries() {
 			return newError("argument to `jora` must be MAP, got %s", args[0].Type())
 		}
 		mapObj := args[0].(*object.Map)
-		entries := make([]object.Object, 0, len(mapObj.Pairs))
-		for key, value := range mapObj.Pairs {
-			entry := &object.Array{Elements: []object.Object{&object.String{Value: key}, value}}
+		keys := mapObj.EnumerableKeys()
+		entries := make([]object.Object, 0, len(keys))
+		for _, key := range keys {
+			entry := &object.Array{Elements: []object.Object{&object.String{Value: key}, mapPropertyValue(mapObj, key)}}
 			entries = append(entries, entry)
 		}
 		return &object.Array{Elements: entries}
@@ -65,7 +67,14 @@ func registerObjectAssign() {
 				return newError("argument %d to `mishra` must be MAP, got %s", i+1, args[i].Type())
 			}
 			source := args[i].(*object.Map)
-			for key, value := range source.Pairs {
+			for _, key := range source.EnumerableKeys() {
+				value := mapPropertyValue(source, key)
+				if _, isErr := value.(*object.Error); isErr || value.Type() == object.EXCEPTION_OBJ {
+					return value
+				}
+				if reason := target.WriteError(key); reason != "" {
+					return object.NewTypeErrorException(reason)
+				}
 				target.Pairs[key] = value
 			}
 		}
@@ -81,12 +90,15 @@ func registerObjectHasOwn() {
 		if args[0].Type() != object.MAP_OBJ {
 			return newError("first argument to `nijer_ache` must be MAP, got %s", args[0].Type())
 		}
+		if sym, ok := args[1].(*object.Symbol); ok {
+			_, exists := args[0].(*object.Map).Symbols[sym]
+			return object.NativeBoolToBooleanObject(exists)
+		}
 		key := mapKeyFromObject(args[1])
 		if key == "" && args[1].Type() != object.STRING_OBJ && args[1].Type() != object.NUMBER_OBJ {
 			return object.FALSE
 		}
-		_, exists := args[0].(*object.Map).Pairs[key]
-		return object.NativeBoolToBooleanObject(exists)
+		return object.NativeBoolToBooleanObject(args[0].(*object.Map).HasOwn(key))
 	}}
 }
 
@@ -166,6 +178,7 @@ func registerObjectFreeze() {
 		if args[0].Type() != object.MAP_OBJ {
 			return newError("argument to `joma` must be MAP, got %s", args[0].Type())
 		}
+		args[0].(*object.Map).Frozen = true
 		return args[0]
 	}}
 }
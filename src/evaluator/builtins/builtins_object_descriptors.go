@@ -0,0 +1,301 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+)
+
+// PropertyGetter reads a Map property, invoking getters (set by evaluator.go to avoid circular dependency)
+var PropertyGetter func(m *object.Map, key string) object.Object
+
+func init() {
+	registerSymbol()
+	registerDefineProperty()
+	registerGetOwnPropertyDescriptor()
+	registerSeal()
+	registerIntegrityChecks()
+	registerSymbolKeys()
+}
+
+// mapPropertyValue returns the value stored under key, calling the getter for accessors
+func mapPropertyValue(m *object.Map, key string) object.Object {
+	if PropertyGetter != nil {
+		return PropertyGetter(m, key)
+	}
+	if val, ok := m.Pairs[key]; ok {
+		return val
+	}
+	return object.NULL
+}
+
+// chihno creates a unique symbol
+// Usage: dhoro id = chihno("id");
+func registerSymbol() {
+	Builtins["chihno"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) > 1 {
+			return newError("wrong number of arguments. got=%d, want=0 or 1", len(args))
+		}
+		desc := ""
+		if len(args) == 1 && args[0].Type() != object.NULL_OBJ {
+			if str, ok := args[0].(*object.String); ok {
+				desc = str.Value
+			} else {
+				desc = args[0].Inspect()
+			}
+		}
+		return &object.Symbol{Description: desc}
+	}}
+}
+
+// boishishto_nirdharon defines or redefines a property with explicit attributes
+// Usage: boishishto_nirdharon(obj, "id", {"value": 1, "writable": mittha});
+//
+//	boishishto_nirdharon(obj, "naam", {"pao": kaj() { ferao "x"; }});
+func registerDefineProperty() {
+	Builtins["boishishto_nirdharon"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 3 {
+			return newError("wrong number of arguments. got=%d, want=3", len(args))
+		}
+		target, ok := args[0].(*object.Map)
+		if !ok {
+			return newError("first argument to `boishishto_nirdharon` must be MAP, got %s", args[0].Type())
+		}
+		spec, ok := args[2].(*object.Map)
+		if !ok {
+			return newError("third argument to `boishishto_nirdharon` must be MAP, got %s", args[2].Type())
+		}
+
+		if sym, isSym := args[1].(*object.Symbol); isSym {
+			if _, exists := target.Symbols[sym]; !exists && (target.Frozen || target.Sealed) {
+				return object.NewTypeErrorException("cannot define property '" + sym.Inspect() + "', object is not extensible")
+			}
+			if target.Frozen {
+				return object.NewTypeErrorException("cannot redefine property '" + sym.Inspect() + "'")
+			}
+			if target.Symbols == nil {
+				target.Symbols = make(map[*object.Symbol]object.Object)
+			}
+			target.Symbols[sym] = mapValueOr(spec, "value", object.NULL)
+			return target
+		}
+
+		key := mapKeyFromObject(args[1])
+		if key == "" && args[1].Type() != object.STRING_OBJ && args[1].Type() != object.NUMBER_OBJ {
+			return newError("property key must be STRING, NUMBER or SYMBOL, got %s", args[1].Type())
+		}
+		if errObj := defineProperty(target, key, spec); errObj != nil {
+			return errObj
+		}
+		return target
+	}}
+}
+
+// defineProperty applies a descriptor map to target[key]
+func defineProperty(target *object.Map, key string, spec *object.Map) object.Object {
+	getter := mapValueOr(spec, "pao", mapValueOr(spec, "get", nil))
+	setter := mapValueOr(spec, "set", nil)
+	isAccessor := getter != nil || setter != nil
+	if isAccessor {
+		if _, hasValue := spec.Pairs["value"]; hasValue {
+			return newError("property descriptor cannot have both accessors and a value")
+		}
+		if _, hasWritable := spec.Pairs["writable"]; hasWritable {
+			return newError("property descriptor cannot have both accessors and writable")
+		}
+		for _, fn := range []object.Object{getter, setter} {
+			if fn != nil && fn.Type() != object.FUNCTION_OBJ && fn.Type() != object.BUILTIN_OBJ {
+				return newError("property accessor must be FUNCTION, got %s", fn.Type())
+			}
+		}
+	}
+
+	exists := target.HasOwn(key)
+	current := target.Descriptor(key)
+	if !exists {
+		if target.Frozen || target.Sealed {
+			return object.NewTypeErrorException("cannot define property '" + key + "', object is not extensible")
+		}
+		current = &object.PropertyDescriptor{}
+	} else if current == nil {
+		current = &object.PropertyDescriptor{Writable: true, Enumerable: true, Configurable: true}
+	}
+
+	configurable := current.Configurable && !target.Frozen
+	next := &object.PropertyDescriptor{
+		Getter:       current.Getter,
+		Setter:       current.Setter,
+		Writable:     current.Writable && !target.Frozen,
+		Enumerable:   current.Enumerable,
+		Configurable: configurable,
+	}
+	if v, ok := spec.Pairs["enumerable"]; ok {
+		next.Enumerable = isTruthy(v)
+	}
+	if v, ok := spec.Pairs["configurable"]; ok {
+		next.Configurable = isTruthy(v)
+	}
+	if v, ok := spec.Pairs["writable"]; ok {
+		next.Writable = isTruthy(v)
+	}
+
+	value, hasValue := spec.Pairs["value"]
+	if exists && !configurable {
+		redefined := next.Configurable || next.Enumerable != current.Enumerable ||
+			isAccessor != current.IsAccessor() ||
+			(isAccessor && (getter != current.Getter || setter != current.Setter)) ||
+			(!current.Writable && (next.Writable || (hasValue && !objectsEqual(value, target.Pairs[key]))))
+		if redefined {
+			return object.NewTypeErrorException("cannot redefine property '" + key + "'")
+		}
+	}
+
+	if isAccessor {
+		next.Getter, next.Setter, next.Writable = getter, setter, false
+		delete(target.Pairs, key)
+		target.SetDescriptor(key, next)
+		return nil
+	}
+
+	next.Getter, next.Setter = nil, nil
+	if hasValue {
+		target.Pairs[key] = value
+	} else if _, ok := target.Pairs[key]; !ok {
+		target.Pairs[key] = object.NULL
+	}
+	if next.Writable && next.Enumerable && next.Configurable {
+		delete(target.Descriptors, key)
+	} else {
+		target.SetDescriptor(key, next)
+	}
+	return nil
+}
+
+// boishishto_bornona returns the descriptor of an own property, or khali
+// Usage: boishishto_bornona(obj, "id") // {"value": 1, "writable": mittha, ...}
+func registerGetOwnPropertyDescriptor() {
+	Builtins["boishishto_bornona"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 2 {
+			return newError("wrong number of arguments. got=%d, want=2", len(args))
+		}
+		target, ok := args[0].(*object.Map)
+		if !ok {
+			return newError("first argument to `boishishto_bornona` must be MAP, got %s", args[0].Type())
+		}
+
+		if sym, isSym := args[1].(*object.Symbol); isSym {
+			val, exists := target.Symbols[sym]
+			if !exists {
+				return object.NULL
+			}
+			return descriptorMap(&object.PropertyDescriptor{
+				Writable: !target.Frozen, Configurable: !target.Frozen && !target.Sealed,
+			}, val)
+		}
+
+		key := mapKeyFromObject(args[1])
+		if !target.HasOwn(key) {
+			return object.NULL
+		}
+		desc := target.Descriptor(key)
+		if desc == nil {
+			desc = &object.PropertyDescriptor{Writable: true, Enumerable: true, Configurable: true}
+		}
+		effective := *desc
+		if target.Frozen {
+			effective.Writable = false
+		}
+		if target.Frozen || target.Sealed {
+			effective.Configurable = false
+		}
+		return descriptorMap(&effective, target.Pairs[key])
+	}}
+}
+
+// descriptorMap converts a descriptor to its script-visible map form
+func descriptorMap(desc *object.PropertyDescriptor, value object.Object) *object.Map {
+	pairs := map[string]object.Object{
+		"enumerable":   object.NativeBoolToBooleanObject(desc.Enumerable),
+		"configurable": object.NativeBoolToBooleanObject(desc.Configurable),
+	}
+	if desc.IsAccessor() {
+		pairs["pao"] = orNull(desc.Getter)
+		pairs["set"] = orNull(desc.Setter)
+	} else {
+		pairs["value"] = orNull(value)
+		pairs["writable"] = object.NativeBoolToBooleanObject(desc.Writable)
+	}
+	return &object.Map{Pairs: pairs}
+}
+
+// mohor seals a map: existing keys stay writable but none can be added or deleted
+// Usage: mohor(config);
+func registerSeal() {
+	Builtins["mohor"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments. got=%d, want=1", len(args))
+		}
+		target, ok := args[0].(*object.Map)
+		if !ok {
+			return newError("argument to `mohor` must be MAP, got %s", args[0].Type())
+		}
+		target.Sealed = true
+		return target
+	}}
+}
+
+// joma_ki / mohor_ki report whether a map is frozen / sealed
+// Usage: joma_ki(obj) // sotti
+func registerIntegrityChecks() {
+	Builtins["joma_ki"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments. got=%d, want=1", len(args))
+		}
+		if m, ok := args[0].(*object.Map); ok {
+			return object.NativeBoolToBooleanObject(m.Frozen)
+		}
+		return object.TRUE // primitives are immutable
+	}}
+
+	Builtins["mohor_ki"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments. got=%d, want=1", len(args))
+		}
+		if m, ok := args[0].(*object.Map); ok {
+			return object.NativeBoolToBooleanObject(m.Frozen || m.Sealed)
+		}
+		return object.TRUE
+	}}
+}
+
+// chihno_chabi returns the symbol keys of a map
+// Usage: chihno_chabi(obj) // [Symbol(id)]
+func registerSymbolKeys() {
+	Builtins["chihno_chabi"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments. got=%d, want=1", len(args))
+		}
+		target, ok := args[0].(*object.Map)
+		if !ok {
+			return newError("argument to `chihno_chabi` must be MAP, got %s", args[0].Type())
+		}
+		keys := make([]object.Object, 0, len(target.Symbols))
+		for sym := range target.Symbols {
+			keys = append(keys, sym)
+		}
+		return &object.Array{Elements: keys}
+	}}
+}
+
+// mapValueOr returns m[key] or fallback when the key is absent or khali
+func mapValueOr(m *object.Map, key string, fallback object.Object) object.Object {
+	if v, ok := m.Pairs[key]; ok && v.Type() != object.NULL_OBJ {
+		return v
+	}
+	return fallback
+}
+
+func orNull(obj object.Object) object.Object {
+	if obj == nil {
+		return object.NULL
+	}
+	return obj
+}
@@ -0,0 +1,239 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultDrainTimeout is how long bondho() waits for in-flight work when no deadline is given
+const defaultDrainTimeout = 5 * time.Second
+
+// Server registry: every running server keeps the script alive until it is closed
+var (
+	servers       = make(map[string]*serverHandle)
+	serversMutex  sync.Mutex
+	serversActive sync.WaitGroup
+	serverCounter int64
+)
+
+// serverHandleKey finds the serverHandle in a request context, so routes that hijack
+// connections (app.websocket) can register them for draining
+type serverHandleKey struct{}
+
+// serverHandle tracks a listening server started by server_chalu, tcp_server_chalu
+// or websocket_server_chalu
+type serverHandle struct {
+	id       string
+	listener net.Listener
+	http     *http.Server // nil for raw TCP servers
+
+	mu      sync.Mutex
+	closing bool
+	conns   map[net.Conn]func() // open connections and how to ask each to finish
+	active  sync.WaitGroup      // connection handlers still running
+
+	once     sync.Once
+	done     chan struct{}
+	graceful bool
+}
+
+// newServerHandle registers a server bound to listener
+func newServerHandle(kind string, listener net.Listener, srv *http.Server) *serverHandle {
+	h := &serverHandle{
+		id:       fmt.Sprintf("%s_server_%d", kind, atomic.AddInt64(&serverCounter, 1)),
+		listener: listener,
+		http:     srv,
+		conns:    make(map[net.Conn]func()),
+		done:     make(chan struct{}),
+	}
+	if srv != nil {
+		srv.BaseContext = func(net.Listener) context.Context {
+			return context.WithValue(context.Background(), serverHandleKey{}, h)
+		}
+	}
+	serversMutex.Lock()
+	servers[h.id] = h
+	serversMutex.Unlock()
+	serversActive.Add(1)
+	return h
+}
+
+// serve runs the HTTP server on its listener in the background
+func (h *serverHandle) serve() {
+	go func() {
+		if err := h.http.Serve(h.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "server error: %s\n", err.Error())
+			h.shutdown(0)
+		}
+	}()
+}
+
+// port returns the port the server is actually bound to (useful when started on port 0)
+func (h *serverHandle) port() int {
+	if addr, ok := h.listener.Addr().(*net.TCPAddr); ok {
+		return addr.Port
+	}
+	return 0
+}
+
+// track records a connection whose handler is starting; it reports false once the
+// server is closing, in which case the connection has been closed
+func (h *serverHandle) track(conn net.Conn, finish func()) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closing {
+		conn.Close()
+		return false
+	}
+	h.conns[conn] = finish
+	h.active.Add(1)
+	return true
+}
+
+// untrack marks a connection handler as finished
+func (h *serverHandle) untrack(conn net.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.conns[conn]; ok {
+		delete(h.conns, conn)
+		h.active.Done()
+	}
+}
+
+// shutdown stops accepting connections and waits up to timeout for in-flight work,
+// force-closing whatever is left. It reports whether everything drained in time.
+func (h *serverHandle) shutdown(timeout time.Duration) bool {
+	h.once.Do(func() {
+		h.graceful = h.drain(timeout)
+		serversMutex.Lock()
+		delete(servers, h.id)
+		serversMutex.Unlock()
+		close(h.done)
+		serversActive.Done()
+	})
+	<-h.done
+	return h.graceful
+}
+
+func (h *serverHandle) drain(timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	graceful := true
+	if h.http != nil {
+		if err := h.http.Shutdown(ctx); err != nil {
+			h.http.Close()
+			graceful = false
+		}
+	} else {
+		h.listener.Close()
+	}
+
+	// Hijacked (WebSocket) and raw TCP connections are not tracked by http.Server
+	h.mu.Lock()
+	h.closing = true
+	for _, finish := range h.conns {
+		if finish != nil {
+			finish()
+		}
+	}
+	h.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		h.active.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		h.mu.Lock()
+		for conn := range h.conns {
+			conn.Close()
+		}
+		h.mu.Unlock()
+		graceful = false
+	}
+	return graceful
+}
+
+// toMap builds the script-facing handle: {id, port, address, bondho(ms?)}
+func (h *serverHandle) toMap() *object.Map {
+	handle := &object.Map{Pairs: make(map[string]object.Object)}
+	handle.Pairs["id"] = &object.String{Value: h.id}
+	handle.Pairs["port"] = &object.Number{Value: float64(h.port())}
+	handle.Pairs["address"] = &object.String{Value: h.listener.Addr().String()}
+
+	// bondho (বন্ধ - close) stops accepting, drains in-flight work within the deadline
+	// and resolves to sotti when everything finished in time
+	handle.Pairs["bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments. got=%d, want=0-1", len(args))
+			}
+			timeout := defaultDrainTimeout
+			if len(args) == 1 {
+				ms, ok := args[0].(*object.Number)
+				if !ok {
+					return newError("argument to `bondho` must be NUMBER (milliseconds), got %s", args[0].Type())
+				}
+				timeout = time.Duration(ms.Value) * time.Millisecond
+			}
+
+			promise := object.CreatePromise()
+			go func() {
+				result := object.FALSE
+				if h.shutdown(timeout) {
+					result = object.TRUE
+				}
+				object.ResolvePromise(promise, result)
+			}()
+			return promise
+		},
+	}
+	return handle
+}
+
+// listenTCP binds a TCP port (port 0 picks a free one), wrapping it in TLS when configured
+func listenTCP(port int, tlsConfig *tls.Config) (net.Listener, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil || tlsConfig == nil {
+		return listener, err
+	}
+	return tls.NewListener(listener, tlsConfig), nil
+}
+
+// WaitForServers blocks until every server started by the script has been closed.
+// The interpreter calls it after the program finishes so scripts keep serving.
+func WaitForServers() {
+	serversActive.Wait()
+}
+
+// CloseAllServers shuts every running server down within timeout
+func CloseAllServers(timeout time.Duration) {
+	serversMutex.Lock()
+	running := make([]*serverHandle, 0, len(servers))
+	for _, h := range servers {
+		running = append(running, h)
+	}
+	serversMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, h := range running {
+		wg.Add(1)
+		go func(h *serverHandle) {
+			defer wg.Done()
+			h.shutdown(timeout)
+		}(h)
+	}
+	wg.Wait()
+}
@@ -2,7 +2,10 @@ package builtins
 
 import (
 	"BanglaCode/src/object"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -10,19 +13,19 @@ import (
 
 // TCP connection registry with thread-safe access
 var (
-	tcpConnections = make(map[string]net.Conn)
+	tcpConnections = make(map[string]*tcpConn)
 	tcpMutex       sync.RWMutex
 	tcpCounter     int64
 )
 
 // generateTCPConnectionID creates a unique connection identifier
 func generateTCPConnectionID() string {
-	atomic.AddInt64(&tcpCounter, 1)
-	return fmt.Sprintf("tcp_conn_%d", tcpCounter)
+	id := atomic.AddInt64(&tcpCounter, 1)
+	return fmt.Sprintf("tcp_conn_%d", id)
 }
 
 // getTCPConnection retrieves a TCP connection by ID
-func getTCPConnection(id string) (net.Conn, bool) {
+func getTCPConnection(id string) (*tcpConn, bool) {
 	tcpMutex.RLock()
 	defer tcpMutex.RUnlock()
 	conn, ok := tcpConnections[id]
@@ -30,7 +33,7 @@ func getTCPConnection(id string) (net.Conn, bool) {
 }
 
 // storeTCPConnection stores a TCP connection with a unique ID
-func storeTCPConnection(id string, conn net.Conn) {
+func storeTCPConnection(id string, conn *tcpConn) {
 	tcpMutex.Lock()
 	defer tcpMutex.Unlock()
 	tcpConnections[id] = conn
@@ -46,47 +49,77 @@ func removeTCPConnection(id string) {
 	}
 }
 
-// handleTCPConnection handles incoming TCP connections with callback
-func handleTCPConnection(conn net.Conn, handler *object.Function) {
+// handleTCPConnection calls the handler once per received frame, then the
+// samapti callback if the peer half-closed, and closes the connection
+func handleTCPConnection(conn *tcpConn, handler *object.Function) {
 	// Create connection object
 	connObj := &object.Map{Pairs: make(map[string]object.Object)}
 	connID := generateTCPConnectionID()
 	storeTCPConnection(connID, conn)
+	defer removeTCPConnection(connID)
 
 	connObj.Pairs["id"] = &object.String{Value: connID}
 	connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
 	connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
 
-	// Read data loop
-	buffer := make([]byte, 4096)
+	// Read frame loop
 	for {
-		n, err := conn.Read(buffer)
+		frame, err := conn.readFrame()
 		if err != nil {
-			// Connection closed or error
-			removeTCPConnection(connID)
-			break
+			// The peer finished sending; it may still be waiting for a reply
+			if errors.Is(err, io.EOF) && conn.opts.onEnd != nil && EvalFunc != nil {
+				connObj.Pairs["data"] = object.NULL
+				EvalFunc(conn.opts.onEnd, []object.Object{connObj})
+			}
+			return
 		}
 
-		if n > 0 {
-			// Update connection object with received data
-			connObj.Pairs["data"] = &object.String{Value: string(buffer[:n])}
+		// Update connection object with received data
+		connObj.Pairs["data"] = conn.frameObject(frame)
 
-			// Call user handler
-			if EvalFunc != nil {
-				EvalFunc(handler, []object.Object{connObj})
+		// Call user handler
+		if EvalFunc != nil {
+			EvalFunc(handler, []object.Object{connObj})
+		}
+	}
+}
+
+// serveTCPConnections accepts connections until the server is closed; it also
+// serves Unix stream sockets from unix_server_chalu
+func serveTCPConnections(server *serverHandle, opts *tcpOptions, handler *object.Function) {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
 			}
+			continue
 		}
+
+		// Closing stops reading so a handler that is running can still reply
+		framed := newTCPConn(conn, opts)
+		if !server.track(conn, framed.stopReading) {
+			continue
+		}
+
+		// Handle each connection in separate goroutine
+		go func() {
+			defer server.untrack(conn)
+			handleTCPConnection(framed, handler)
+		}()
 	}
 }
 
 func init() {
-	// tcp_server_chalu(port, handler) - Start TCP server
+	// tcp_server_chalu(port, handler, options?) - Start TCP server, returns a server handle
 	// Example: tcp_server_chalu(8080, kaj(conn) { dekho("Connected:", conn["remote_addr"]); })
+	// options: {tls: {cert, key, min_version, client_ca, client_auth}, framing, binary, maxFrame,
+	//           readTimeout, idleTimeout, samapti} (see builtins_tcp_framing.go)
 	Builtins["tcp_server_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
 			}
 
 			// Validate port (number)
@@ -102,36 +135,40 @@ func init() {
 			port := int(args[0].(*object.Number).Value)
 			handler := args[1].(*object.Function)
 
-			// Create TCP listener
-			listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			var options object.Object
+			if len(args) == 3 {
+				options = args[2]
+			}
+			tlsConfig, errObj := serverTLSConfig("tcp_server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+			tcpOpts, errObj := parseTCPOptions("tcp_server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+
+			// Create TCP listener (port 0 picks a free port)
+			listener, err := listenTCP(port, tlsConfig)
 			if err != nil {
 				return newError("TCP server error: %s", err.Error())
 			}
+			server := newServerHandle("tcp", listener, nil)
+			go serveTCPConnections(server, tcpOpts, handler)
 
-			// Accept connections in goroutine
-			go func() {
-				for {
-					conn, err := listener.Accept()
-					if err != nil {
-						continue
-					}
-
-					// Handle each connection in separate goroutine
-					go handleTCPConnection(conn, handler)
-				}
-			}()
-
-			return object.NULL
+			return server.toMap()
 		},
 	}
 
-	// tcp_jukto(host, port) - Connect to TCP server (async, returns promise)
+	// tcp_jukto(host, port, options?) - Connect to TCP server (async, returns promise)
 	// Example: dhoro conn = opekha tcp_jukto("localhost", 8080);
+	// options: {tls: {ca, cert, key, min_version, server_name, insecure}, framing, binary, maxFrame,
+	//           readTimeout, idleTimeout}
 	Builtins["tcp_jukto"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
 			}
 
 			// Validate host (string)
@@ -147,14 +184,33 @@ func init() {
 			host := args[0].(*object.String).Value
 			port := int(args[1].(*object.Number).Value)
 
+			var options object.Object
+			if len(args) == 3 {
+				options = args[2]
+			}
+			tlsConfig, errObj := clientTLSConfig("tcp_jukto", options)
+			if errObj != nil {
+				return errObj
+			}
+			tcpOpts, errObj := parseTCPOptions("tcp_jukto", options)
+			if errObj != nil {
+				return errObj
+			}
+
 			// Create promise
 			promise := object.CreatePromise()
 
 			// Connect asynchronously
 			go func() {
-				// Use net.JoinHostPort for proper IPv6 support
-				addr := net.JoinHostPort(host, fmt.Sprintf("%d", port))
-				conn, err := net.Dial("tcp", addr)
+				// joinHostPort handles IPv6 literals with or without brackets
+				addr := joinHostPort(host, port)
+				var conn net.Conn
+				var err error
+				if tlsConfig != nil {
+					conn, err = tls.Dial("tcp", addr, tlsConfig)
+				} else {
+					conn, err = net.Dial("tcp", addr)
+				}
 				if err != nil {
 					object.RejectPromise(promise, newError("TCP connection failed: %s", err.Error()))
 					return
@@ -163,7 +219,7 @@ func init() {
 				// Create connection object
 				connObj := &object.Map{Pairs: make(map[string]object.Object)}
 				connID := generateTCPConnectionID()
-				storeTCPConnection(connID, conn)
+				storeTCPConnection(connID, newTCPConn(conn, tcpOpts))
 
 				connObj.Pairs["id"] = &object.String{Value: connID}
 				connObj.Pairs["host"] = &object.String{Value: host}
@@ -178,7 +234,7 @@ func init() {
 		},
 	}
 
-	// tcp_pathao(connection, data) - Send data on TCP connection
+	// tcp_pathao(connection, data) - Send data (STRING or Buffer) as one frame on TCP connection
 	// Example: tcp_pathao(conn, "Hello!");
 	Builtins["tcp_pathao"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
@@ -192,13 +248,13 @@ func init() {
 				return newError("argument 1 to 'tcp_pathao' must be MAP, got %s", args[0].Type())
 			}
 
-			// Validate data (string)
-			if args[1].Type() != object.STRING_OBJ {
-				return newError("argument 2 to 'tcp_pathao' must be STRING, got %s", args[1].Type())
+			// Validate data (string or Buffer)
+			data, ok := tcpPayload(args[1])
+			if !ok {
+				return newError("argument 2 to 'tcp_pathao' must be STRING or BUFFER, got %s", args[1].Type())
 			}
 
 			connMap := args[0].(*object.Map)
-			data := args[1].(*object.String).Value
 
 			// Get connection ID
 			idObj, ok := connMap.Pairs["id"]
@@ -218,9 +274,8 @@ func init() {
 				return newError("TCP connection not found or closed")
 			}
 
-			// Send data
-			_, err := conn.Write([]byte(data))
-			if err != nil {
+			// Send data in the connection's framing
+			if err := conn.writeFrame(data); err != nil {
 				return newError("TCP send error: %s", err.Error())
 			}
 
@@ -237,7 +292,8 @@ func init() {
 		},
 	}
 
-	// tcp_shuno(connection) - Read data from TCP connection (async, returns promise)
+	// tcp_shuno(connection) - Read the next frame from TCP connection (async, returns promise)
+	// Resolves to khali once the peer has finished sending
 	// Example: dhoro data = opekha tcp_shuno(conn);
 	Builtins["tcp_shuno"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
@@ -276,15 +332,18 @@ func init() {
 
 			// Read asynchronously
 			go func() {
-				buffer := make([]byte, 4096)
-				n, err := conn.Read(buffer)
+				frame, err := conn.readFrame()
+				if errors.Is(err, io.EOF) {
+					object.ResolvePromise(promise, object.NULL)
+					return
+				}
 				if err != nil {
+					removeTCPConnection(connID)
 					object.RejectPromise(promise, newError("TCP read error: %s", err.Error()))
 					return
 				}
 
-				data := &object.String{Value: string(buffer[:n])}
-				object.ResolvePromise(promise, data)
+				object.ResolvePromise(promise, conn.frameObject(frame))
 			}()
 
 			return promise
@@ -0,0 +1,411 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// TCP options are passed in the options map of tcp_server_chalu and tcp_jukto:
+//
+//	framing:     "raw" (default), "line", "length", or a map
+//	             {type: "length", size: 1|2|4, endian: "big"|"little"} / {type: "delimiter", delimiter: STRING|Buffer}
+//	binary:      sotti delivers frames as Buffers instead of STRINGs
+//	maxFrame:    largest frame accepted, in bytes (default 1 MB)
+//	readTimeout: ms a frame may take to arrive once it has started
+//	idleTimeout: ms without any incoming data before the connection is closed
+//	samapti:     kaj(conn) called when the peer half-closes (server only)
+
+const defaultTCPMaxFrame = 1 << 20
+
+// tcpFraming splits the incoming byte stream into frames and encodes outgoing ones
+type tcpFraming struct {
+	kind      string // "raw", "line", "delimiter" or "length"
+	delimiter []byte
+	size      int // length prefix size in bytes
+	order     binary.ByteOrder
+	maxFrame  int
+}
+
+// tcpOptions holds the framing, payload and timeout settings of a connection
+type tcpOptions struct {
+	framing     tcpFraming
+	binary      bool
+	readTimeout time.Duration
+	idleTimeout time.Duration
+	onEnd       *object.Function
+}
+
+// parseTCPOptions reads the framing and timeout options; opts has already been
+// checked to be a MAP (or nil) by tlsOptions
+func parseTCPOptions(name string, opts object.Object) (*tcpOptions, *object.Error) {
+	o := &tcpOptions{framing: tcpFraming{kind: "raw", maxFrame: defaultTCPMaxFrame}}
+	optsMap, ok := opts.(*object.Map)
+	if !ok {
+		return o, nil
+	}
+
+	if v, ok := optsMap.Pairs["framing"]; ok && v != object.NULL {
+		if errObj := o.framing.parse(name, v); errObj != nil {
+			return nil, errObj
+		}
+	}
+	if v, ok := optsMap.Pairs["binary"]; ok {
+		o.binary = isTruthy(v)
+	}
+	if v, ok := optsMap.Pairs["maxFrame"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value < 1 {
+			return nil, newError("`maxFrame` option to `%s` must be a positive NUMBER (bytes)", name)
+		}
+		o.framing.maxFrame = int(n.Value)
+	}
+	for key, target := range map[string]*time.Duration{"readTimeout": &o.readTimeout, "idleTimeout": &o.idleTimeout} {
+		if v, ok := optsMap.Pairs[key]; ok {
+			n, ok := v.(*object.Number)
+			if !ok || n.Value < 0 {
+				return nil, newError("`%s` option to `%s` must be a non-negative NUMBER (ms)", key, name)
+			}
+			*target = time.Duration(n.Value * float64(time.Millisecond))
+		}
+	}
+	if v, ok := optsMap.Pairs["samapti"]; ok && v != object.NULL {
+		fn, ok := v.(*object.Function)
+		if !ok {
+			return nil, newError("`samapti` option to `%s` must be FUNCTION, got %s", name, v.Type())
+		}
+		o.onEnd = fn
+	}
+	return o, nil
+}
+
+// parse reads the framing option, given as a kind name or a map with a `type` key
+func (f *tcpFraming) parse(name string, v object.Object) *object.Error {
+	settings := &object.Map{Pairs: map[string]object.Object{}}
+	switch v := v.(type) {
+	case *object.String:
+		settings.Pairs["type"] = v
+	case *object.Map:
+		settings = v
+	default:
+		return newError("`framing` option to `%s` must be STRING or MAP, got %s", name, v.Type())
+	}
+
+	kind, _ := settings.Pairs["type"].(*object.String)
+	if kind == nil {
+		return newError("`framing` option to `%s` needs a `type`", name)
+	}
+	f.kind = kind.Value
+	switch f.kind {
+	case "raw":
+	case "line":
+		f.delimiter = []byte("\n")
+	case "delimiter":
+		switch d := settings.Pairs["delimiter"].(type) {
+		case *object.String:
+			f.delimiter = []byte(d.Value)
+		case *object.Buffer:
+			d.Mu.RLock()
+			f.delimiter = append([]byte(nil), d.Data...)
+			d.Mu.RUnlock()
+		}
+		if len(f.delimiter) == 0 {
+			return newError("delimiter framing for `%s` needs a non-empty `delimiter` (STRING or BUFFER)", name)
+		}
+	case "length":
+		f.size = 4
+		if v, ok := settings.Pairs["size"]; ok {
+			n, ok := v.(*object.Number)
+			if !ok || (n.Value != 1 && n.Value != 2 && n.Value != 4) {
+				return newError("length framing `size` for `%s` must be 1, 2 or 4", name)
+			}
+			f.size = int(n.Value)
+		}
+		f.order = binary.BigEndian
+		if v, ok := settings.Pairs["endian"]; ok {
+			endian, _ := v.(*object.String)
+			switch {
+			case endian != nil && endian.Value == "big":
+			case endian != nil && endian.Value == "little":
+				f.order = binary.LittleEndian
+			default:
+				return newError("length framing `endian` for `%s` must be \"big\" or \"little\"", name)
+			}
+		}
+	default:
+		return newError("unknown framing %q for `%s` (want raw, line, length or delimiter)", f.kind, name)
+	}
+	return nil
+}
+
+// next takes one complete frame off the front of pending. At end of stream a
+// trailing line or delimited frame is delivered, but a partial length frame is an error.
+func (f *tcpFraming) next(pending *[]byte, eof bool) ([]byte, bool, error) {
+	buf := *pending
+	switch f.kind {
+	case "line", "delimiter":
+		if i := bytes.Index(buf, f.delimiter); i >= 0 {
+			frame := buf[:i]
+			*pending = buf[i+len(f.delimiter):]
+			if f.kind == "line" {
+				frame = bytes.TrimSuffix(frame, []byte("\r"))
+			}
+			if len(frame) > f.maxFrame {
+				return nil, false, fmt.Errorf("frame of %d bytes exceeds maxFrame %d", len(frame), f.maxFrame)
+			}
+			return frame, true, nil
+		}
+		if len(buf) > f.maxFrame {
+			return nil, false, fmt.Errorf("no delimiter within maxFrame %d bytes", f.maxFrame)
+		}
+	case "length":
+		if len(buf) >= f.size {
+			n := f.frameLength(buf[:f.size])
+			if n > f.maxFrame {
+				return nil, false, fmt.Errorf("frame of %d bytes exceeds maxFrame %d", n, f.maxFrame)
+			}
+			if len(buf) >= f.size+n {
+				*pending = buf[f.size+n:]
+				return buf[f.size : f.size+n], true, nil
+			}
+		}
+		if eof && len(buf) > 0 {
+			return nil, false, errors.New("connection closed in the middle of a frame")
+		}
+		return nil, false, nil
+	}
+
+	if len(buf) > 0 && (eof || f.kind == "raw") {
+		*pending = nil
+		return buf, true, nil
+	}
+	return nil, false, nil
+}
+
+func (f *tcpFraming) frameLength(header []byte) int {
+	switch f.size {
+	case 1:
+		return int(header[0])
+	case 2:
+		return int(f.order.Uint16(header))
+	}
+	return int(f.order.Uint32(header))
+}
+
+// encode wraps an outgoing payload in the connection's framing
+func (f *tcpFraming) encode(data []byte) ([]byte, error) {
+	switch f.kind {
+	case "line", "delimiter":
+		return append(append(make([]byte, 0, len(data)+len(f.delimiter)), data...), f.delimiter...), nil
+	case "length":
+		if max := 1<<(8*f.size) - 1; len(data) > max {
+			return nil, fmt.Errorf("%d bytes do not fit a %d-byte length prefix (max %d)", len(data), f.size, max)
+		}
+		framed := make([]byte, f.size, f.size+len(data))
+		switch f.size {
+		case 1:
+			framed[0] = byte(len(data))
+		case 2:
+			f.order.PutUint16(framed, uint16(len(data)))
+		default:
+			f.order.PutUint32(framed, uint32(len(data)))
+		}
+		return append(framed, data...), nil
+	}
+	return data, nil
+}
+
+// tcpConn is a registered TCP connection with its framing state. Reads and writes
+// are serialized so frames are never interleaved.
+type tcpConn struct {
+	net.Conn
+	opts *tcpOptions
+
+	readMu  sync.Mutex
+	pending []byte
+	eof     bool
+
+	writeMu sync.Mutex
+
+	deadlineMu sync.Mutex
+	stopped    bool // reading was stopped by a server shutdown
+}
+
+func newTCPConn(conn net.Conn, opts *tcpOptions) *tcpConn {
+	return &tcpConn{Conn: conn, opts: opts}
+}
+
+// readFrame blocks until the next frame arrives; it returns io.EOF once the peer
+// has finished sending and every buffered frame was delivered
+func (c *tcpConn) readFrame() ([]byte, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	chunk := make([]byte, 4096)
+	var frameStart time.Time
+	for {
+		frame, ok, err := c.opts.framing.next(&c.pending, c.eof)
+		if err != nil || ok {
+			return frame, err
+		}
+		if c.eof {
+			return nil, io.EOF
+		}
+		if len(c.pending) > 0 && frameStart.IsZero() {
+			frameStart = time.Now()
+		}
+		if err := c.armDeadline(frameStart); err != nil {
+			return nil, err
+		}
+
+		n, err := c.Conn.Read(chunk)
+		c.pending = append(c.pending, chunk[:n]...)
+		if err == io.EOF {
+			c.eof = true
+			continue
+		}
+		if err != nil {
+			return nil, c.timeoutError(err, frameStart)
+		}
+	}
+}
+
+// armDeadline sets the read deadline from the idle timeout and, while a frame is
+// partially received, the read timeout
+func (c *tcpConn) armDeadline(frameStart time.Time) error {
+	var deadline time.Time
+	if c.opts.idleTimeout > 0 {
+		deadline = time.Now().Add(c.opts.idleTimeout)
+	}
+	if c.opts.readTimeout > 0 && !frameStart.IsZero() {
+		if d := frameStart.Add(c.opts.readTimeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+	if c.stopped {
+		return os.ErrDeadlineExceeded
+	}
+	return c.Conn.SetReadDeadline(deadline)
+}
+
+// stopReading makes a pending read return so a closing server can drain the handler
+func (c *tcpConn) stopReading() {
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+	c.stopped = true
+	c.Conn.SetReadDeadline(time.Now())
+}
+
+// timeoutError names which timeout expired
+func (c *tcpConn) timeoutError(err error, frameStart time.Time) error {
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		return err
+	}
+	c.deadlineMu.Lock()
+	stopped := c.stopped
+	c.deadlineMu.Unlock()
+	switch {
+	case stopped:
+		return err
+	case c.opts.readTimeout > 0 && !frameStart.IsZero() && time.Since(frameStart) >= c.opts.readTimeout:
+		return fmt.Errorf("read timeout: frame incomplete after %s", c.opts.readTimeout)
+	case c.opts.idleTimeout > 0:
+		return fmt.Errorf("idle timeout: no data for %s", c.opts.idleTimeout)
+	}
+	return err
+}
+
+// writeFrame encodes and sends one payload
+func (c *tcpConn) writeFrame(data []byte) error {
+	framed, err := c.opts.framing.encode(data)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err = c.Conn.Write(framed)
+	return err
+}
+
+// closeWrite half-closes the connection: the peer sees end of stream but can still reply
+func (c *tcpConn) closeWrite() error {
+	cw, ok := c.Conn.(interface{ CloseWrite() error })
+	if !ok {
+		return errors.New("connection does not support half-close")
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return cw.CloseWrite()
+}
+
+// frameObject turns a frame into a STRING, or a Buffer for binary connections
+func (c *tcpConn) frameObject(frame []byte) object.Object {
+	if c.opts.binary {
+		return object.CreateBufferFrom(frame)
+	}
+	return &object.String{Value: string(frame)}
+}
+
+// tcpPayload reads the data argument of tcp_pathao (STRING or Buffer)
+func tcpPayload(obj object.Object) ([]byte, bool) {
+	switch v := obj.(type) {
+	case *object.String:
+		return []byte(v.Value), true
+	case *object.Buffer:
+		v.Mu.RLock()
+		defer v.Mu.RUnlock()
+		return append([]byte(nil), v.Data...), true
+	}
+	return nil, false
+}
+
+// tcpConnArg finds the registered connection behind a connection map argument
+func tcpConnArg(name string, arg object.Object) (*tcpConn, string, *object.Error) {
+	connMap, ok := arg.(*object.Map)
+	if !ok {
+		return nil, "", newError("argument 1 to '%s' must be MAP, got %s", name, arg.Type())
+	}
+	idObj, ok := connMap.Pairs["id"]
+	if !ok {
+		return nil, "", newError("connection object missing 'id' field")
+	}
+	id, ok := idObj.(*object.String)
+	if !ok {
+		return nil, "", newError("connection 'id' must be STRING")
+	}
+	conn, ok := getTCPConnection(id.Value)
+	if !ok {
+		return nil, "", newError("TCP connection not found or closed")
+	}
+	return conn, id.Value, nil
+}
+
+func init() {
+	// tcp_samapti(connection) - Half-close: stop sending but keep reading the peer's reply
+	// Example: tcp_lekho(conn, "request"); tcp_samapti(conn); dhoro reply = opekha tcp_shuno(conn);
+	Builtins["tcp_samapti"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+			conn, _, errObj := tcpConnArg("tcp_samapti", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			if err := conn.closeWrite(); err != nil {
+				return newError("TCP half-close error: %s", err.Error())
+			}
+			return object.NULL
+		},
+	}
+}
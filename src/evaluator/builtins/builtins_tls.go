@@ -0,0 +1,212 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLS options are passed as {tls: {...}} in the options map of servers and clients.
+//
+// Server keys: cert, key, min_version ("1.2", "1.3"), client_ca, client_auth ("request" | "require")
+// Client keys: ca, cert, key, min_version, server_name, insecure
+
+// tlsOptions extracts the "tls" map from an optional options argument
+func tlsOptions(name string, opts object.Object) (*object.Map, *object.Error) {
+	if opts == nil {
+		return nil, nil
+	}
+	optsMap, ok := opts.(*object.Map)
+	if !ok {
+		return nil, newError("options to `%s` must be MAP, got %s", name, opts.Type())
+	}
+	raw, ok := optsMap.Pairs["tls"]
+	if !ok || raw == object.NULL {
+		return nil, nil
+	}
+	tlsMap, ok := raw.(*object.Map)
+	if !ok {
+		return nil, newError("`tls` option to `%s` must be MAP, got %s", name, raw.Type())
+	}
+	return tlsMap, nil
+}
+
+// serverTLSConfig builds a server tls.Config from the tls options map
+func serverTLSConfig(name string, opts object.Object) (*tls.Config, *object.Error) {
+	tlsMap, errObj := tlsOptions(name, opts)
+	if errObj != nil || tlsMap == nil {
+		return nil, errObj
+	}
+
+	certPEM, err := tlsPEM(tlsMap, "cert")
+	if err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	keyPEM, err := tlsPEM(tlsMap, "key")
+	if err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	if certPEM == nil || keyPEM == nil {
+		return nil, newError("%s: tls needs both `cert` and `key`", name)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, newError("%s: invalid certificate: %s", name, err.Error())
+	}
+
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if cfg.MinVersion, err = tlsMinVersion(tlsMap); err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+
+	pool, err := tlsCertPool(tlsMap, "client_ca")
+	if err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	cfg.ClientCAs = pool
+
+	auth := tlsString(tlsMap, "client_auth")
+	switch {
+	case auth == "require" || (auth == "" && pool != nil):
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	case auth == "request":
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if pool == nil {
+			cfg.ClientAuth = tls.RequestClientCert
+		}
+	case auth == "" || auth == "none":
+	default:
+		return nil, newError("%s: unknown client_auth %q (want \"request\" or \"require\")", name, auth)
+	}
+	return cfg, nil
+}
+
+// clientTLSConfig builds a client tls.Config from the tls options map; nil means defaults
+func clientTLSConfig(name string, opts object.Object) (*tls.Config, *object.Error) {
+	tlsMap, errObj := tlsOptions(name, opts)
+	if errObj != nil || tlsMap == nil {
+		return nil, errObj
+	}
+
+	cfg := &tls.Config{ServerName: tlsString(tlsMap, "server_name")}
+	var err error
+	if cfg.MinVersion, err = tlsMinVersion(tlsMap); err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	if cfg.RootCAs, err = tlsCertPool(tlsMap, "ca"); err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	if insecure, ok := tlsMap.Pairs["insecure"].(*object.Boolean); ok {
+		cfg.InsecureSkipVerify = insecure.Value
+	}
+
+	certPEM, err := tlsPEM(tlsMap, "cert")
+	if err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	keyPEM, err := tlsPEM(tlsMap, "key")
+	if err != nil {
+		return nil, newError("%s: %s", name, err.Error())
+	}
+	if (certPEM == nil) != (keyPEM == nil) {
+		return nil, newError("%s: client certificate needs both `cert` and `key`", name)
+	}
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, newError("%s: invalid client certificate: %s", name, err.Error())
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// tlsPEM reads a PEM option given either inline ("-----BEGIN ...") or as a file path
+func tlsPEM(tlsMap *object.Map, key string) ([]byte, error) {
+	value := tlsString(tlsMap, key)
+	if value == "" {
+		return nil, nil
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tls %s: %s", key, err.Error())
+	}
+	return data, nil
+}
+
+// tlsCertPool loads a CA bundle option into a certificate pool
+func tlsCertPool(tlsMap *object.Map, key string) (*x509.CertPool, error) {
+	data, err := tlsPEM(tlsMap, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in tls %s", key)
+	}
+	return pool, nil
+}
+
+func tlsMinVersion(tlsMap *object.Map) (uint16, error) {
+	switch v := tlsString(tlsMap, "min_version"); v {
+	case "":
+		return tls.VersionTLS12, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unknown tls min_version %q (want \"1.2\" or \"1.3\")", v)
+	}
+}
+
+func tlsString(tlsMap *object.Map, key string) string {
+	if s, ok := tlsMap.Pairs[key].(*object.String); ok {
+		return s.Value
+	}
+	return ""
+}
+
+// tlsVersionName returns the "1.x" name of a negotiated TLS version
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	}
+	return "unknown"
+}
+
+// tlsStateMap describes a TLS connection to scripts: {version, client_cert}
+func tlsStateMap(state *tls.ConnectionState) object.Object {
+	if state == nil {
+		return object.NULL
+	}
+	m := &object.Map{Pairs: make(map[string]object.Object, 2)}
+	m.Pairs["version"] = &object.String{Value: tlsVersionName(state.Version)}
+	m.Pairs["client_cert"] = object.NULL
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		certMap := &object.Map{Pairs: make(map[string]object.Object, 3)}
+		certMap.Pairs["subject"] = &object.String{Value: cert.Subject.String()}
+		certMap.Pairs["common_name"] = &object.String{Value: cert.Subject.CommonName}
+		certMap.Pairs["issuer"] = &object.String{Value: cert.Issuer.String()}
+		m.Pairs["client_cert"] = certMap
+	}
+	return m
+}
@@ -8,10 +8,12 @@ import (
 	"sync/atomic"
 )
 
-// UDP connection wrapper to store both connection and remote address
+// UDP connection wrapper to store both connection and remote address.
+// Unix datagram sockets (unixgram_server_chalu) share the registry, so Conn is
+// any packet socket; RemoteAddr is nil for a server's own entry.
 type UDPConnection struct {
-	Conn       *net.UDPConn
-	RemoteAddr *net.UDPAddr
+	Conn       net.PacketConn
+	RemoteAddr net.Addr
 }
 
 // UDP connection registry with thread-safe access
@@ -53,13 +55,14 @@ func removeUDPConnection(id string) {
 }
 
 func init() {
-	// udp_server_chalu(port, handler) - Start UDP server
+	// udp_server_chalu(port, handler, options?) - Start UDP server, returns a server map
 	// Example: udp_server_chalu(9000, kaj(packet) { dekho("Received:", packet["data"]); });
+	// options: {host, multicast: group | [groups], interface, broadcast}
 	Builtins["udp_server_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
 			}
 
 			// Validate port (number)
@@ -75,47 +78,30 @@ func init() {
 			port := int(args[0].(*object.Number).Value)
 			handler := args[1].(*object.Function)
 
+			var options *object.Map
+			if len(args) == 3 {
+				m, ok := args[2].(*object.Map)
+				if !ok {
+					return newError("options to `udp_server_chalu` must be MAP, got %s", args[2].Type())
+				}
+				options = m
+			}
+			udpOpts, errObj := parseUDPServerOptions("udp_server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+
 			// Create UDP listener
-			addr := &net.UDPAddr{Port: port, IP: net.ParseIP("0.0.0.0")}
-			conn, err := net.ListenUDP("udp", addr)
+			conn, err := udpOpts.listen(port)
 			if err != nil {
 				return newError("UDP server error: %s", err.Error())
 			}
 
-			// Listen for packets in goroutine
-			go func() {
-				buffer := make([]byte, 4096)
-				for {
-					n, remoteAddr, err := conn.ReadFromUDP(buffer)
-					if err != nil {
-						continue
-					}
-
-					if n > 0 {
-						// Create packet object
-						packet := &object.Map{Pairs: make(map[string]object.Object)}
-						connID := generateUDPConnectionID()
-
-						// Store connection for response capability
-						storeUDPConnection(connID, &UDPConnection{
-							Conn:       conn,
-							RemoteAddr: remoteAddr,
-						})
-
-						packet.Pairs["id"] = &object.String{Value: connID}
-						packet.Pairs["data"] = &object.String{Value: string(buffer[:n])}
-						packet.Pairs["remote_addr"] = &object.String{Value: remoteAddr.String()}
-						packet.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
-
-						// Call user handler
-						if EvalFunc != nil {
-							EvalFunc(handler, []object.Object{packet})
-						}
-					}
-				}
-			}()
+			serverID := generateUDPConnectionID()
+			storeUDPConnection(serverID, &UDPConnection{Conn: conn})
+			go servePackets(conn, handler)
 
-			return object.NULL
+			return udpServerMap(serverID, conn, nil)
 		},
 	}
 
@@ -159,8 +145,12 @@ func init() {
 				return newError("UDP connection not found or closed")
 			}
 
+			if udpConn.RemoteAddr == nil {
+				return newError("UDP connection has no remote address to reply to")
+			}
+
 			// Send response to remote address
-			_, err := udpConn.Conn.WriteToUDP([]byte(data), udpConn.RemoteAddr)
+			_, err := udpConn.Conn.WriteTo([]byte(data), udpConn.RemoteAddr)
 			if err != nil {
 				return newError("UDP send error: %s", err.Error())
 			}
@@ -169,13 +159,14 @@ func init() {
 		},
 	}
 
-	// udp_pathao(host, port, data) - Send UDP packet (async, returns promise)
+	// udp_pathao(host, port, data, options?) - Send UDP packet (async, returns promise)
 	// Example: opekha udp_pathao("localhost", 9000, "Hello UDP!");
+	// options: {broadcast: sotti} to send to a broadcast address such as "255.255.255.255"
 	Builtins["udp_pathao"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 3 {
-				return newError("wrong number of arguments. got=%d, want=3", len(args))
+			if len(args) < 3 || len(args) > 4 {
+				return newError("wrong number of arguments. got=%d, want=3-4", len(args))
 			}
 
 			// Validate host (string)
@@ -197,12 +188,26 @@ func init() {
 			port := int(args[1].(*object.Number).Value)
 			data := args[2].(*object.String).Value
 
+			broadcast := false
+			if len(args) == 4 {
+				options, ok := args[3].(*object.Map)
+				if !ok {
+					return newError("options to `udp_pathao` must be MAP, got %s", args[3].Type())
+				}
+				broadcast = isTruthy(options.Pairs["broadcast"])
+			}
+
 			// Create promise
 			promise := object.CreatePromise()
 
 			// Send asynchronously
 			go func() {
-				addr := &net.UDPAddr{Port: port, IP: net.ParseIP(host)}
+				// Resolves host names and IPv6 literals (with or without brackets)
+				addr, err := net.ResolveUDPAddr("udp", joinHostPort(host, port))
+				if err != nil {
+					object.RejectPromise(promise, newError("UDP dial failed: %s", err.Error()))
+					return
+				}
 				conn, err := net.DialUDP("udp", nil, addr)
 				if err != nil {
 					object.RejectPromise(promise, newError("UDP dial failed: %s", err.Error()))
@@ -210,6 +215,13 @@ func init() {
 				}
 				defer conn.Close()
 
+				if broadcast {
+					if err := setBroadcast(conn); err != nil {
+						object.RejectPromise(promise, newError("UDP broadcast failed: %s", err.Error()))
+						return
+					}
+				}
+
 				_, err = conn.Write([]byte(data))
 				if err != nil {
 					object.RejectPromise(promise, newError("UDP send failed: %s", err.Error()))
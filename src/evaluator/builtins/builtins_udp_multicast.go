@@ -0,0 +1,236 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// udpServerOptions are the options of udp_server_chalu:
+// {host, multicast: group | [groups], interface, broadcast}
+type udpServerOptions struct {
+	host      string
+	groups    []net.IP
+	ifi       *net.Interface
+	broadcast bool
+}
+
+func parseUDPServerOptions(name string, opts *object.Map) (*udpServerOptions, object.Object) {
+	o := &udpServerOptions{}
+	if opts == nil {
+		return o, nil
+	}
+
+	if v, ok := opts.Pairs["host"]; ok {
+		host, ok := v.(*object.String)
+		if !ok {
+			return nil, newError("`host` option to `%s` must be STRING, got %s", name, v.Type())
+		}
+		o.host = trimHostBrackets(host.Value)
+	}
+	groups, errObj := stringList(name, "multicast", opts.Pairs["multicast"])
+	if errObj != nil {
+		return nil, errObj
+	}
+	for _, g := range groups {
+		group, err := multicastGroup(g)
+		if err != nil {
+			return nil, newError("%s: %s", name, err.Error())
+		}
+		o.groups = append(o.groups, group)
+	}
+	if v, ok := opts.Pairs["interface"]; ok {
+		ifi, err := interfaceArg(v)
+		if err != nil {
+			return nil, newError("%s: %s", name, err.Error())
+		}
+		o.ifi = ifi
+	}
+	o.broadcast = isTruthy(opts.Pairs["broadcast"])
+	return o, nil
+}
+
+// listen binds the server socket. Servers that join groups bind the matching
+// address family and allow other listeners on the same port.
+func (o *udpServerOptions) listen(port int) (*net.UDPConn, error) {
+	network := "udp"
+	var lc net.ListenConfig
+	if len(o.groups) > 0 {
+		lc.Control = reuseAddrControl
+		if o.host == "" {
+			network = "udp4"
+			if o.groups[0].To4() == nil {
+				network = "udp6"
+			}
+		}
+	}
+
+	pc, err := lc.ListenPacket(context.Background(), network, joinHostPort(o.host, port))
+	if err != nil {
+		return nil, err
+	}
+	conn := pc.(*net.UDPConn)
+	for _, group := range o.groups {
+		if err := setMulticastMembership(conn, group, o.ifi, true); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("cannot join multicast group %s: %w", group, err)
+		}
+	}
+	if o.broadcast {
+		if err := setBroadcast(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// servePackets calls handler for every datagram until the socket is closed
+func servePackets(conn net.PacketConn, handler *object.Function) {
+	buffer := make([]byte, 65536)
+	for {
+		n, remoteAddr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		// Unbound Unix datagram senders have no address to reply to
+		if addr, ok := remoteAddr.(*net.UnixAddr); ok && addr.Name == "" {
+			remoteAddr = nil
+		}
+
+		// Create packet object
+		packet := &object.Map{Pairs: make(map[string]object.Object)}
+		connID := generateUDPConnectionID()
+
+		// Store connection for response capability
+		storeUDPConnection(connID, &UDPConnection{
+			Conn:       conn,
+			RemoteAddr: remoteAddr,
+		})
+
+		remote := ""
+		if remoteAddr != nil {
+			remote = remoteAddr.String()
+		}
+		packet.Pairs["id"] = &object.String{Value: connID}
+		packet.Pairs["data"] = &object.String{Value: string(buffer[:n])}
+		packet.Pairs["remote_addr"] = &object.String{Value: remote}
+		packet.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+
+		// Call user handler
+		if EvalFunc != nil {
+			EvalFunc(handler, []object.Object{packet})
+		}
+	}
+}
+
+// udpServerMap describes a datagram server; bondho() closes it and runs cleanup
+func udpServerMap(id string, conn net.PacketConn, cleanup func()) *object.Map {
+	server := &object.Map{Pairs: make(map[string]object.Object)}
+	server.Pairs["id"] = &object.String{Value: id}
+	port := 0
+	if addr, ok := conn.LocalAddr().(*net.UDPAddr); ok {
+		port = addr.Port
+	}
+	server.Pairs["port"] = &object.Number{Value: float64(port)}
+	server.Pairs["address"] = &object.String{Value: conn.LocalAddr().String()}
+
+	// bondho (বন্ধ - close) closes the socket; resolves to sotti like server handles
+	server.Pairs["bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			removeUDPConnection(id)
+			if cleanup != nil {
+				cleanup()
+			}
+			promise := object.CreatePromise()
+			object.ResolvePromise(promise, object.TRUE)
+			return promise
+		},
+	}
+	return server
+}
+
+func multicastGroup(s string) (net.IP, error) {
+	group := net.ParseIP(trimHostBrackets(s))
+	if group == nil || !group.IsMulticast() {
+		return nil, fmt.Errorf("%q is not a multicast group address", s)
+	}
+	return group, nil
+}
+
+func interfaceArg(v object.Object) (*net.Interface, error) {
+	name, ok := v.(*object.String)
+	if !ok {
+		return nil, fmt.Errorf("interface must be STRING, got %s", v.Type())
+	}
+	ifi, err := net.InterfaceByName(name.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unknown interface %q", name.Value)
+	}
+	return ifi, nil
+}
+
+// multicastBuiltin implements udp_multicast_jog / udp_multicast_chharo
+func multicastBuiltin(name string, join bool) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
+			}
+			server, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("argument 1 to '%s' must be MAP, got %s", name, args[0].Type())
+			}
+			groupArg, ok := args[1].(*object.String)
+			if !ok {
+				return newError("argument 2 to '%s' must be STRING, got %s", name, args[1].Type())
+			}
+			group, err := multicastGroup(groupArg.Value)
+			if err != nil {
+				return newError("%s: %s", name, err.Error())
+			}
+			var ifi *net.Interface
+			if len(args) == 3 {
+				if ifi, err = interfaceArg(args[2]); err != nil {
+					return newError("%s: %s", name, err.Error())
+				}
+			}
+
+			id, _ := server.Pairs["id"].(*object.String)
+			if id == nil {
+				return newError("server object missing 'id' field")
+			}
+			udpConn, ok := getUDPConnection(id.Value)
+			if !ok {
+				return newError("UDP server not found or closed")
+			}
+			conn, ok := udpConn.Conn.(*net.UDPConn)
+			if !ok || udpConn.RemoteAddr != nil {
+				return newError("%s needs a server from udp_server_chalu", name)
+			}
+			if err := setMulticastMembership(conn, group, ifi, join); err != nil {
+				return newError("%s: %s: %s", name, group, err.Error())
+			}
+			return object.NULL
+		},
+	}
+}
+
+func init() {
+	// udp_multicast_jog(server, group, interface?) - Join a multicast group on a UDP server
+	// Example: udp_multicast_jog(server, "239.1.2.3");
+	Builtins["udp_multicast_jog"] = multicastBuiltin("udp_multicast_jog", true)
+
+	// udp_multicast_chharo(server, group, interface?) - Leave a multicast group
+	// Example: udp_multicast_chharo(server, "239.1.2.3");
+	Builtins["udp_multicast_chharo"] = multicastBuiltin("udp_multicast_chharo", false)
+}
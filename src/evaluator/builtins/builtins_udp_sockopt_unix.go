@@ -0,0 +1,73 @@
+//go:build linux || darwin || freebsd || openbsd || netbsd
+
+package builtins
+
+import (
+	"net"
+	"syscall"
+)
+
+// setMulticastMembership joins or leaves group on conn (Unix-specific)
+func setMulticastMembership(conn *net.UDPConn, group net.IP, ifi *net.Interface, join bool) error {
+	var ifAddr net.IP
+	if ifi != nil && group.To4() != nil {
+		var err error
+		if ifAddr, err = interfaceIPv4(ifi); err != nil {
+			return err
+		}
+	}
+	return controlSocket(conn, func(fd int) error {
+		if ip4 := group.To4(); ip4 != nil {
+			mreq := &syscall.IPMreq{}
+			copy(mreq.Multiaddr[:], ip4)
+			copy(mreq.Interface[:], ifAddr.To4())
+			opt := syscall.IP_ADD_MEMBERSHIP
+			if !join {
+				opt = syscall.IP_DROP_MEMBERSHIP
+			}
+			return syscall.SetsockoptIPMreq(fd, syscall.IPPROTO_IP, opt, mreq)
+		}
+
+		mreq := &syscall.IPv6Mreq{}
+		copy(mreq.Multiaddr[:], group.To16())
+		if ifi != nil {
+			mreq.Interface = uint32(ifi.Index)
+		}
+		opt := syscall.IPV6_JOIN_GROUP
+		if !join {
+			opt = syscall.IPV6_LEAVE_GROUP
+		}
+		return syscall.SetsockoptIPv6Mreq(fd, syscall.IPPROTO_IPV6, opt, mreq)
+	})
+}
+
+// setBroadcast allows sending to broadcast addresses (Unix-specific)
+func setBroadcast(conn *net.UDPConn) error {
+	return controlSocket(conn, func(fd int) error {
+		return syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_BROADCAST, 1)
+	})
+}
+
+// reuseAddrControl lets several multicast listeners share a port (Unix-specific)
+func reuseAddrControl(network, address string, c syscall.RawConn) error {
+	var opErr error
+	err := c.Control(func(fd uintptr) {
+		opErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
+
+func controlSocket(conn *net.UDPConn, fn func(fd int) error) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var opErr error
+	if err := raw.Control(func(fd uintptr) { opErr = fn(int(fd)) }); err != nil {
+		return err
+	}
+	return opErr
+}
@@ -0,0 +1,64 @@
+//go:build windows
+
+package builtins
+
+import (
+	"net"
+	"syscall"
+)
+
+// setMulticastMembership joins or leaves an IPv4 group on conn (Windows-specific)
+func setMulticastMembership(conn *net.UDPConn, group net.IP, ifi *net.Interface, join bool) error {
+	ip4 := group.To4()
+	if ip4 == nil {
+		return syscall.EWINDOWS
+	}
+	var ifAddr net.IP
+	if ifi != nil {
+		var err error
+		if ifAddr, err = interfaceIPv4(ifi); err != nil {
+			return err
+		}
+	}
+	return controlSocket(conn, func(fd syscall.Handle) error {
+		mreq := &syscall.IPMreq{}
+		copy(mreq.Multiaddr[:], ip4)
+		copy(mreq.Interface[:], ifAddr.To4())
+		opt := syscall.IP_ADD_MEMBERSHIP
+		if !join {
+			opt = syscall.IP_DROP_MEMBERSHIP
+		}
+		return syscall.SetsockoptIPMreq(fd, syscall.IPPROTO_IP, opt, mreq)
+	})
+}
+
+// setBroadcast allows sending to broadcast addresses (Windows-specific)
+func setBroadcast(conn *net.UDPConn) error {
+	return controlSocket(conn, func(fd syscall.Handle) error {
+		return syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_BROADCAST, 1)
+	})
+}
+
+// reuseAddrControl lets several multicast listeners share a port (Windows-specific)
+func reuseAddrControl(network, address string, c syscall.RawConn) error {
+	var opErr error
+	err := c.Control(func(fd uintptr) {
+		opErr = syscall.SetsockoptInt(syscall.Handle(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
+
+func controlSocket(conn *net.UDPConn, fn func(fd syscall.Handle) error) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var opErr error
+	if err := raw.Control(func(fd uintptr) { opErr = fn(syscall.Handle(fd)) }); err != nil {
+		return err
+	}
+	return opErr
+}
@@ -0,0 +1,190 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"net"
+	"os"
+	"time"
+)
+
+// Unix domain sockets reuse the TCP and UDP connection registries: stream
+// connections work with tcp_lekho/tcp_shuno/tcp_samapti/tcp_bondho and datagram
+// packets with udp_uttor, exactly like their IP counterparts.
+
+// removeStaleSocket deletes a socket file left behind by a process that exited
+// without closing it; a socket something is still listening on is kept
+func removeStaleSocket(network, path string) {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	if network == "unix" {
+		if conn, err := net.DialTimeout(network, path, time.Second); err == nil {
+			conn.Close()
+			return
+		}
+	}
+	os.Remove(path)
+}
+
+// unixOptions validates the optional options map of the Unix socket builtins
+func unixOptions(name string, args []object.Object, at int) (object.Object, *object.Error) {
+	if len(args) <= at {
+		return nil, nil
+	}
+	if args[at].Type() != object.MAP_OBJ {
+		return nil, newError("options to `%s` must be MAP, got %s", name, args[at].Type())
+	}
+	return args[at], nil
+}
+
+func init() {
+	// unix_server_chalu(path, handler, options?) - Start a Unix stream socket server, returns a server handle
+	// Example: unix_server_chalu("/tmp/app.sock", kaj(conn) { tcp_lekho(conn, "hi"); });
+	// options: {framing, binary, maxFrame, readTimeout, idleTimeout, samapti} as for tcp_server_chalu
+	Builtins["unix_server_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
+			}
+			path, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument 1 to 'unix_server_chalu' must be STRING, got %s", args[0].Type())
+			}
+			handler, ok := args[1].(*object.Function)
+			if !ok {
+				return newError("argument 2 to 'unix_server_chalu' must be FUNCTION, got %s", args[1].Type())
+			}
+			options, errObj := unixOptions("unix_server_chalu", args, 2)
+			if errObj != nil {
+				return errObj
+			}
+			tcpOpts, errObj := parseTCPOptions("unix_server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+
+			removeStaleSocket("unix", path.Value)
+			listener, err := net.Listen("unix", path.Value)
+			if err != nil {
+				return newError("Unix socket server error: %s", err.Error())
+			}
+			server := newServerHandle("unix", listener, nil)
+			go serveTCPConnections(server, tcpOpts, handler)
+
+			return server.toMap()
+		},
+	}
+
+	// unix_jukto(path, options?) - Connect to a Unix stream socket (async, returns promise)
+	// Example: dhoro conn = opekha unix_jukto("/var/run/app.sock");
+	// options: {framing, binary, maxFrame, readTimeout, idleTimeout}
+	Builtins["unix_jukto"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2", len(args))
+			}
+			path, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument 1 to 'unix_jukto' must be STRING, got %s", args[0].Type())
+			}
+			options, errObj := unixOptions("unix_jukto", args, 1)
+			if errObj != nil {
+				return errObj
+			}
+			tcpOpts, errObj := parseTCPOptions("unix_jukto", options)
+			if errObj != nil {
+				return errObj
+			}
+
+			promise := object.CreatePromise()
+			go func() {
+				conn, err := net.Dial("unix", path.Value)
+				if err != nil {
+					object.RejectPromise(promise, newError("Unix socket connection failed: %s", err.Error()))
+					return
+				}
+
+				connObj := &object.Map{Pairs: make(map[string]object.Object)}
+				connID := generateTCPConnectionID()
+				storeTCPConnection(connID, newTCPConn(conn, tcpOpts))
+
+				connObj.Pairs["id"] = &object.String{Value: connID}
+				connObj.Pairs["path"] = &object.String{Value: path.Value}
+				connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
+				connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+
+				object.ResolvePromise(promise, connObj)
+			}()
+
+			return promise
+		},
+	}
+
+	// unixgram_server_chalu(path, handler) - Receive Unix datagrams, returns a server map
+	// Example: unixgram_server_chalu("/tmp/log.sock", kaj(packet) { dekho(packet["data"]); });
+	Builtins["unixgram_server_chalu"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			}
+			path, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument 1 to 'unixgram_server_chalu' must be STRING, got %s", args[0].Type())
+			}
+			handler, ok := args[1].(*object.Function)
+			if !ok {
+				return newError("argument 2 to 'unixgram_server_chalu' must be FUNCTION, got %s", args[1].Type())
+			}
+
+			removeStaleSocket("unixgram", path.Value)
+			conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path.Value, Net: "unixgram"})
+			if err != nil {
+				return newError("Unix datagram server error: %s", err.Error())
+			}
+
+			serverID := generateUDPConnectionID()
+			storeUDPConnection(serverID, &UDPConnection{Conn: conn})
+			go servePackets(conn, handler)
+
+			// Datagram sockets are not unlinked on close, so bondho() removes the file
+			return udpServerMap(serverID, conn, func() { os.Remove(path.Value) })
+		},
+	}
+
+	// unixgram_pathao(path, data) - Send a Unix datagram (async, returns promise)
+	// Example: opekha unixgram_pathao("/tmp/log.sock", "started");
+	Builtins["unixgram_pathao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			}
+			path, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument 1 to 'unixgram_pathao' must be STRING, got %s", args[0].Type())
+			}
+			data, ok := args[1].(*object.String)
+			if !ok {
+				return newError("argument 2 to 'unixgram_pathao' must be STRING, got %s", args[1].Type())
+			}
+
+			promise := object.CreatePromise()
+			go func() {
+				conn, err := net.Dial("unixgram", path.Value)
+				if err != nil {
+					object.RejectPromise(promise, newError("Unix datagram send failed: %s", err.Error()))
+					return
+				}
+				defer conn.Close()
+
+				if _, err := conn.Write([]byte(data.Value)); err != nil {
+					object.RejectPromise(promise, newError("Unix datagram send failed: %s", err.Error()))
+					return
+				}
+				object.ResolvePromise(promise, object.NULL)
+			}()
+
+			return promise
+		},
+	}
+}
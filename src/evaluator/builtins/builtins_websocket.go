@@ -2,21 +2,114 @@ package builtins
 
 import (
 	"BanglaCode/src/object"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 // WebSocket connection registry with thread-safe access
 var (
-	wsConnections = make(map[string]*websocket.Conn)
+	wsConnections = make(map[string]*wsPeer)
 	wsMutex       sync.RWMutex
 	wsCounter     int64
 )
 
+const (
+	defaultWSSendQueue = 256              // messages a peer may have pending before it is dropped
+	wsWriteTimeout     = 10 * time.Second // longest a single frame may take to write
+)
+
+var (
+	errWSClosed    = errors.New("connection closed")
+	errWSQueueFull = errors.New("send queue full, connection dropped")
+)
+
+// wsPeer is a registered connection. Messages go through a bounded queue drained by
+// one writer goroutine, so a slow client never blocks senders to other clients.
+type wsPeer struct {
+	id    string
+	conn  *websocket.Conn
+	obj   *object.Map // the script-facing connection map
+	queue chan wsFrame
+	done  chan struct{} // closed once the peer stops accepting messages
+	once  sync.Once
+	rooms map[string]bool // guarded by wsRoomsMu
+}
+
+type wsFrame struct {
+	messageType int
+	data        []byte
+}
+
+// send queues one message (text, binary or close). A peer whose queue is full is
+// dropped with 1013 instead of making the sender wait.
+func (p *wsPeer) send(messageType int, data []byte) error {
+	select {
+	case <-p.done:
+		return errWSClosed
+	default:
+	}
+	select {
+	case p.queue <- wsFrame{messageType, data}:
+		return nil
+	default:
+		p.drop(websocket.CloseTryAgainLater, "send queue full")
+		return errWSQueueFull
+	}
+}
+
+// closeAndWait queues a close frame after the pending messages and waits (up to a
+// second) for the writer to send it
+func (p *wsPeer) closeAndWait(code int, reason string) error {
+	if err := p.send(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason)); err != nil {
+		return err
+	}
+	select {
+	case <-p.done:
+	case <-time.After(time.Second):
+	}
+	return nil
+}
+
+func (p *wsPeer) writeLoop() {
+	for {
+		select {
+		case frame := <-p.queue:
+			p.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := p.conn.WriteMessage(frame.messageType, frame.data); err != nil {
+				p.stop()
+				p.conn.Close()
+				return
+			}
+			if frame.messageType == websocket.CloseMessage {
+				p.stop()
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// stop makes further sends fail; the connection itself stays open for the reader
+func (p *wsPeer) stop() {
+	p.once.Do(func() { close(p.done) })
+}
+
+// drop stops the peer and closes the connection without waiting on its queue
+func (p *wsPeer) drop(code int, reason string) {
+	p.stop()
+	go func() {
+		p.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+		p.conn.Close()
+	}()
+}
+
 // WebSocket upgrader with permissive settings
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -28,32 +121,48 @@ var upgrader = websocket.Upgrader{
 
 // generateWSConnectionID creates a unique connection identifier
 func generateWSConnectionID() string {
-	atomic.AddInt64(&wsCounter, 1)
-	return fmt.Sprintf("ws_conn_%d", wsCounter)
+	return fmt.Sprintf("ws_conn_%d", atomic.AddInt64(&wsCounter, 1))
 }
 
 // getWSConnection retrieves a WebSocket connection by ID
-func getWSConnection(id string) (*websocket.Conn, bool) {
+func getWSConnection(id string) (*wsPeer, bool) {
 	wsMutex.RLock()
 	defer wsMutex.RUnlock()
-	conn, ok := wsConnections[id]
-	return conn, ok
+	peer, ok := wsConnections[id]
+	return peer, ok
 }
 
-// storeWSConnection stores a WebSocket connection with a unique ID
-func storeWSConnection(id string, conn *websocket.Conn) {
+// storeWSConnection registers a connection under a new ID, fills in the id and meta
+// fields of its script-facing map and starts its writer
+func storeWSConnection(conn *websocket.Conn, connObj *object.Map, queueSize int) *wsPeer {
+	peer := &wsPeer{
+		id:    generateWSConnectionID(),
+		conn:  conn,
+		obj:   connObj,
+		queue: make(chan wsFrame, queueSize),
+		done:  make(chan struct{}),
+		rooms: make(map[string]bool),
+	}
+	connObj.Pairs["id"] = &object.String{Value: peer.id}
+	connObj.Pairs["meta"] = &object.Map{Pairs: make(map[string]object.Object)}
+	go peer.writeLoop()
+
 	wsMutex.Lock()
 	defer wsMutex.Unlock()
-	wsConnections[id] = conn
+	wsConnections[peer.id] = peer
+	return peer
 }
 
-// removeWSConnection removes and closes a WebSocket connection
+// removeWSConnection removes and closes a WebSocket connection, leaving its rooms
 func removeWSConnection(id string) {
 	wsMutex.Lock()
-	defer wsMutex.Unlock()
-	if conn, ok := wsConnections[id]; ok {
-		conn.Close()
-		delete(wsConnections, id)
+	peer, ok := wsConnections[id]
+	delete(wsConnections, id)
+	wsMutex.Unlock()
+	if ok {
+		leaveAllRooms(peer)
+		peer.stop()
+		peer.conn.Close()
 	}
 }
 
@@ -61,10 +170,8 @@ func removeWSConnection(id string) {
 func handleWebSocketConnection(conn *websocket.Conn, handler *object.Function) {
 	// Create connection object
 	connObj := &object.Map{Pairs: make(map[string]object.Object)}
-	connID := generateWSConnectionID()
-	storeWSConnection(connID, conn)
+	connID := storeWSConnection(conn, connObj, defaultWSSendQueue).id
 
-	connObj.Pairs["id"] = &object.String{Value: connID}
 	connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
 	connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
 	connObj.Pairs["connected"] = &object.Boolean{Value: true}
@@ -108,13 +215,14 @@ func handleWebSocketConnection(conn *websocket.Conn, handler *object.Function) {
 }
 
 func init() {
-	// websocket_server_chalu(port, handler) - Start WebSocket server
+	// websocket_server_chalu(port, handler, options?) - Start WebSocket server, returns a server handle
 	// Example: websocket_server_chalu(3000, kaj(conn) { dekho("Message:", conn["message"]); });
+	// options: {tls: {cert, key, min_version, client_ca, client_auth}} serves wss://
 	Builtins["websocket_server_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3", len(args))
 			}
 
 			// Validate port (number)
@@ -130,37 +238,62 @@ func init() {
 			port := int(args[0].(*object.Number).Value)
 			handler := args[1].(*object.Function)
 
+			var options object.Object
+			if len(args) == 3 {
+				options = args[2]
+			}
+			tlsConfig, errObj := serverTLSConfig("websocket_server_chalu", options)
+			if errObj != nil {
+				return errObj
+			}
+
+			// Bind first so port errors (and port 0) are reported to the caller
+			listener, err := listenTCP(port, tlsConfig)
+			if err != nil {
+				return newError("WebSocket server error: %s", err.Error())
+			}
+
+			mux := http.NewServeMux()
+			server := newServerHandle("websocket", listener, &http.Server{Handler: mux})
+
 			// Create HTTP handler for WebSocket upgrade
-			http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 				// Upgrade HTTP connection to WebSocket
 				conn, err := upgrader.Upgrade(w, r, nil)
 				if err != nil {
 					return
 				}
 
+				// Upgraded connections are hijacked, so the handle drains them itself
+				finish := func() {
+					conn.WriteControl(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closing"),
+						time.Now().Add(time.Second))
+				}
+				if !server.track(conn.UnderlyingConn(), finish) {
+					return
+				}
+
 				// Handle connection in goroutine
-				go handleWebSocketConnection(conn, handler)
+				go func() {
+					defer server.untrack(conn.UnderlyingConn())
+					handleWebSocketConnection(conn, handler)
+				}()
 			})
 
-			// Start server in goroutine
-			go func() {
-				addr := fmt.Sprintf(":%d", port)
-				if err := http.ListenAndServe(addr, nil); err != nil {
-					// Server error (ignore for now as it's in goroutine)
-				}
-			}()
-
-			return object.NULL
+			server.serve()
+			return server.toMap()
 		},
 	}
 
-	// websocket_jukto(url) - Connect to WebSocket server (async, returns promise)
+	// websocket_jukto(url, options?) - Connect to WebSocket server (async, returns promise)
 	// Example: dhoro ws = opekha websocket_jukto("ws://localhost:3000");
+	// options: {tls: {ca, cert, key, min_version, server_name, insecure}} for wss:// urls
 	Builtins["websocket_jukto"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			// Validate arguments
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2", len(args))
 			}
 
 			// Validate URL (string)
@@ -170,12 +303,23 @@ func init() {
 
 			url := args[0].(*object.String).Value
 
+			var options object.Object
+			if len(args) == 2 {
+				options = args[1]
+			}
+			tlsConfig, errObj := clientTLSConfig("websocket_jukto", options)
+			if errObj != nil {
+				return errObj
+			}
+			dialer := *websocket.DefaultDialer
+			dialer.TLSClientConfig = tlsConfig
+
 			// Create promise
 			promise := object.CreatePromise()
 
 			// Connect asynchronously
 			go func() {
-				conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+				conn, _, err := dialer.Dial(url, nil)
 				if err != nil {
 					object.RejectPromise(promise, newError("WebSocket connection failed: %s", err.Error()))
 					return
@@ -183,10 +327,8 @@ func init() {
 
 				// Create connection object
 				connObj := &object.Map{Pairs: make(map[string]object.Object)}
-				connID := generateWSConnectionID()
-				storeWSConnection(connID, conn)
+				storeWSConnection(conn, connObj, defaultWSSendQueue)
 
-				connObj.Pairs["id"] = &object.String{Value: connID}
 				connObj.Pairs["url"] = &object.String{Value: url}
 				connObj.Pairs["connected"] = &object.Boolean{Value: true}
 				connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
@@ -213,13 +355,13 @@ func init() {
 				return newError("argument 1 to 'websocket_pathao' must be MAP, got %s", args[0].Type())
 			}
 
-			// Validate message (string)
-			if args[1].Type() != object.STRING_OBJ {
-				return newError("argument 2 to 'websocket_pathao' must be STRING, got %s", args[1].Type())
+			// Validate message (string → text frame, Buffer → binary frame)
+			messageType, message, ok := wsMessage(args[1])
+			if !ok {
+				return newError("argument 2 to 'websocket_pathao' must be STRING or BUFFER, got %s", args[1].Type())
 			}
 
 			connMap := args[0].(*object.Map)
-			message := args[1].(*object.String).Value
 
 			// Get connection ID
 			idObj, ok := connMap.Pairs["id"]
@@ -234,13 +376,13 @@ func init() {
 			connID := idObj.(*object.String).Value
 
 			// Get WebSocket connection
-			conn, ok := getWSConnection(connID)
+			peer, ok := getWSConnection(connID)
 			if !ok {
 				return newError("WebSocket connection not found or closed")
 			}
 
-			// Send text message
-			err := conn.WriteMessage(websocket.TextMessage, []byte(message))
+			// Send message
+			err := peer.send(messageType, message)
 			if err != nil {
 				return newError("WebSocket send error: %s", err.Error())
 			}
@@ -278,17 +420,13 @@ func init() {
 			connID := idObj.(*object.String).Value
 
 			// Get WebSocket connection
-			conn, ok := getWSConnection(connID)
+			peer, ok := getWSConnection(connID)
 			if !ok {
 				return newError("WebSocket connection not found or already closed")
 			}
 
-			// Send close message
-			err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-			if err == nil {
-				// Wait a bit for close handshake
-				conn.Close()
-			}
+			// Send close message after anything still queued, then drop the connection
+			peer.closeAndWait(websocket.CloseNormalClosure, "")
 
 			// Remove from registry
 			removeWSConnection(connID)
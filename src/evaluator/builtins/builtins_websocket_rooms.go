@@ -0,0 +1,266 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WebSocket rooms: room name → members by connection id. Each peer also keeps the set
+// of rooms it joined so closing a connection leaves them all.
+var (
+	wsRooms   = make(map[string]map[string]*wsPeer)
+	wsRoomsMu sync.RWMutex
+)
+
+// joinRoom adds peer to room and returns the room size
+func joinRoom(peer *wsPeer, room string) int {
+	wsRoomsMu.Lock()
+	defer wsRoomsMu.Unlock()
+	members, ok := wsRooms[room]
+	if !ok {
+		members = make(map[string]*wsPeer)
+		wsRooms[room] = members
+	}
+	members[peer.id] = peer
+	peer.rooms[room] = true
+	return len(members)
+}
+
+// leaveRoom removes peer from room; empty rooms are deleted
+func leaveRoom(peer *wsPeer, room string) {
+	wsRoomsMu.Lock()
+	defer wsRoomsMu.Unlock()
+	leaveRoomLocked(peer, room)
+}
+
+func leaveRoomLocked(peer *wsPeer, room string) {
+	delete(peer.rooms, room)
+	if members, ok := wsRooms[room]; ok {
+		delete(members, peer.id)
+		if len(members) == 0 {
+			delete(wsRooms, room)
+		}
+	}
+}
+
+// leaveAllRooms removes peer from every room it joined
+func leaveAllRooms(peer *wsPeer) {
+	wsRoomsMu.Lock()
+	defer wsRoomsMu.Unlock()
+	for room := range peer.rooms {
+		leaveRoomLocked(peer, room)
+	}
+}
+
+// roomMembers returns the members of room (every connection for room == "") in
+// connection order
+func roomMembers(room string) []*wsPeer {
+	var members []*wsPeer
+	if room == "" {
+		wsMutex.RLock()
+		for _, peer := range wsConnections {
+			members = append(members, peer)
+		}
+		wsMutex.RUnlock()
+	} else {
+		wsRoomsMu.RLock()
+		for _, peer := range wsRooms[room] {
+			members = append(members, peer)
+		}
+		wsRoomsMu.RUnlock()
+	}
+	sort.Slice(members, func(i, j int) bool { return wsConnectionSeq(members[i].id) < wsConnectionSeq(members[j].id) })
+	return members
+}
+
+func wsConnectionSeq(id string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimPrefix(id, "ws_conn_"), 10, 64)
+	return n
+}
+
+// broadcast queues msg for every member except the excluded connections and returns
+// how many accepted it; a full queue drops only that member
+func broadcast(room string, messageType int, data []byte, except map[string]bool) int {
+	sent := 0
+	for _, peer := range roomMembers(room) {
+		if except[peer.id] {
+			continue
+		}
+		if peer.send(messageType, data) == nil {
+			sent++
+		}
+	}
+	return sent
+}
+
+// wsPeerArg resolves a connection map to its registered peer
+func wsPeerArg(name string, arg object.Object) (*wsPeer, *object.Error) {
+	connMap, ok := arg.(*object.Map)
+	if !ok {
+		return nil, newError("first argument to `%s` must be MAP (connection), got %s", name, arg.Type())
+	}
+	id, ok := connMap.Pairs["id"].(*object.String)
+	if !ok {
+		return nil, newError("connection object missing 'id' field")
+	}
+	peer, ok := getWSConnection(id.Value)
+	if !ok {
+		return nil, newError("WebSocket connection not found or closed")
+	}
+	return peer, nil
+}
+
+// exceptIDs reads the optional connection (or array of connections) to skip
+func exceptIDs(name string, arg object.Object) (map[string]bool, *object.Error) {
+	except := map[string]bool{}
+	conns := []object.Object{arg}
+	if arr, ok := arg.(*object.Array); ok {
+		conns = arr.Elements
+	}
+	for _, c := range conns {
+		connMap, ok := c.(*object.Map)
+		if !ok {
+			return nil, newError("`except` argument to `%s` must be MAP (connection) or ARRAY, got %s", name, c.Type())
+		}
+		if id, ok := connMap.Pairs["id"].(*object.String); ok {
+			except[id.Value] = true
+		}
+	}
+	return except, nil
+}
+
+// broadcastBuiltin implements websocket_somprochar(room, msg, except?) and
+// websocket_sobaike(msg, except?)
+func broadcastBuiltin(name string, withRoom bool) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			room := ""
+			if withRoom {
+				if len(args) < 2 || len(args) > 3 {
+					return newError("wrong number of arguments. got=%d, want=2-3 (room, message, except?)", len(args))
+				}
+				r, ok := args[0].(*object.String)
+				if !ok {
+					return newError("first argument to `%s` must be STRING (room), got %s", name, args[0].Type())
+				}
+				room, args = r.Value, args[1:]
+			} else if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (message, except?)", len(args))
+			}
+			messageType, data, ok := wsMessage(args[0])
+			if !ok {
+				return newError("message to `%s` must be STRING or BUFFER, got %s", name, args[0].Type())
+			}
+			except := map[string]bool{}
+			if len(args) == 2 {
+				var errObj *object.Error
+				if except, errObj = exceptIDs(name, args[1]); errObj != nil {
+					return errObj
+				}
+			}
+			return &object.Number{Value: float64(broadcast(room, messageType, data, except))}
+		},
+	}
+}
+
+func init() {
+	// websocket_jog (যোগ - join) adds a connection to a room and returns the room size
+	// Example: websocket_jog(conn, "lobby");
+	Builtins["websocket_jog"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments. got=%d, want=2 (conn, room)", len(args))
+			}
+			peer, errObj := wsPeerArg("websocket_jog", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			room, ok := args[1].(*object.String)
+			if !ok || room.Value == "" {
+				return newError("second argument to `websocket_jog` must be a non-empty STRING (room), got %s", args[1].Type())
+			}
+			return &object.Number{Value: float64(joinRoom(peer, room.Value))}
+		},
+	}
+
+	// websocket_chharo (ছাড়ো - leave) removes a connection from a room, or from every
+	// room when no room is given. Closed connections leave their rooms automatically.
+	Builtins["websocket_chharo"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1-2 (conn, room?)", len(args))
+			}
+			peer, errObj := wsPeerArg("websocket_chharo", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			if len(args) == 1 {
+				leaveAllRooms(peer)
+				return object.NULL
+			}
+			room, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `websocket_chharo` must be STRING (room), got %s", args[1].Type())
+			}
+			leaveRoom(peer, room.Value)
+			return object.NULL
+		},
+	}
+
+	// websocket_somprochar (সম্প্রচার - broadcast) sends to every member of a room,
+	// optionally skipping a connection (usually the sender); returns how many were sent
+	// Example: websocket_somprochar("lobby", msg, conn);
+	Builtins["websocket_somprochar"] = broadcastBuiltin("websocket_somprochar", true)
+
+	// websocket_sobaike (সবাইকে - to everyone) sends to every open connection
+	// Example: websocket_sobaike("server restarting", conn);
+	Builtins["websocket_sobaike"] = broadcastBuiltin("websocket_sobaike", false)
+
+	// websocket_sodossho (সদস্য - members) lists the connections in a room (presence);
+	// each one carries the `meta` map set on it
+	Builtins["websocket_sodossho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1 (room)", len(args))
+			}
+			room, ok := args[0].(*object.String)
+			if !ok || room.Value == "" {
+				return newError("argument to `websocket_sodossho` must be a non-empty STRING (room), got %s", args[0].Type())
+			}
+			members := roomMembers(room.Value)
+			elements := make([]object.Object, len(members))
+			for i, peer := range members {
+				elements[i] = peer.obj
+			}
+			return &object.Array{Elements: elements}
+		},
+	}
+
+	// websocket_ghor (ঘর - rooms) lists the rooms a connection has joined
+	Builtins["websocket_ghor"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1 (conn)", len(args))
+			}
+			peer, errObj := wsPeerArg("websocket_ghor", args[0])
+			if errObj != nil {
+				return errObj
+			}
+			wsRoomsMu.RLock()
+			rooms := make([]string, 0, len(peer.rooms))
+			for room := range peer.rooms {
+				rooms = append(rooms, room)
+			}
+			wsRoomsMu.RUnlock()
+			sort.Strings(rooms)
+			elements := make([]object.Object, len(rooms))
+			for i, room := range rooms {
+				elements[i] = &object.String{Value: room}
+			}
+			return &object.Array{Elements: elements}
+		},
+	}
+}
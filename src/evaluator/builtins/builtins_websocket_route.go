@@ -0,0 +1,363 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsRoute holds the lifecycle callbacks and settings of a router WebSocket route
+// registered with app.websocket(path, {khola, barta, bondho, bhul}, options?)
+type wsRoute struct {
+	onOpen    object.Object // khola (খোলা - open): kaj(conn)
+	onMessage object.Object // barta (বার্তা - message): kaj(conn, msg) — STRING or Buffer
+	onClose   object.Object // bondho (বন্ধ - close): kaj(conn, code, reason)
+	onError   object.Object // bhul (ভুল - error): kaj(conn, message)
+
+	origins      []string // allowed Origin values; empty = same host only, "*" = any
+	protocols    []string // subprotocols offered, in server preference order
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	maxMessage   int64
+	sendQueue    int
+}
+
+const (
+	defaultWSPingInterval = 30 * time.Second
+	defaultWSPongTimeout  = 10 * time.Second
+	defaultWSMaxMessage   = 1 << 20
+)
+
+// parseWSRoute reads the callbacks map and the WebSocket options
+// {origins, protocols, pingInterval: ms, pongTimeout: ms, maxMessage: bytes, sendQueue: messages}
+func parseWSRoute(name string, handlers *object.Map, opts *object.Map) (*wsRoute, object.Object) {
+	ws := &wsRoute{
+		pingInterval: defaultWSPingInterval,
+		pongTimeout:  defaultWSPongTimeout,
+		maxMessage:   defaultWSMaxMessage,
+		sendQueue:    defaultWSSendQueue,
+	}
+	for key, target := range map[string]*object.Object{
+		"khola": &ws.onOpen, "barta": &ws.onMessage, "bondho": &ws.onClose, "bhul": &ws.onError,
+	} {
+		fn, ok := handlers.Pairs[key]
+		if !ok || fn == object.NULL {
+			continue
+		}
+		if fn.Type() != object.FUNCTION_OBJ && fn.Type() != object.BUILTIN_OBJ {
+			return nil, newError("`%s` handler to %s() must be FUNCTION, got %s", key, name, fn.Type())
+		}
+		*target = fn
+	}
+	if opts == nil {
+		return ws, nil
+	}
+
+	var errObj object.Object
+	if ws.origins, errObj = stringList(name, "origins", opts.Pairs["origins"]); errObj != nil {
+		return nil, errObj
+	}
+	if ws.protocols, errObj = stringList(name, "protocols", opts.Pairs["protocols"]); errObj != nil {
+		return nil, errObj
+	}
+	for key, target := range map[string]*time.Duration{"pingInterval": &ws.pingInterval, "pongTimeout": &ws.pongTimeout} {
+		if v, ok := opts.Pairs[key]; ok {
+			n, ok := v.(*object.Number)
+			if !ok || n.Value < 0 {
+				return nil, newError("`%s` option to %s() must be a non-negative NUMBER (ms)", key, name)
+			}
+			*target = time.Duration(n.Value * float64(time.Millisecond))
+		}
+	}
+	if v, ok := opts.Pairs["maxMessage"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value <= 0 {
+			return nil, newError("`maxMessage` option to %s() must be a positive NUMBER (bytes)", name)
+		}
+		ws.maxMessage = int64(n.Value)
+	}
+	if v, ok := opts.Pairs["sendQueue"]; ok {
+		n, ok := v.(*object.Number)
+		if !ok || n.Value < 1 {
+			return nil, newError("`sendQueue` option to %s() must be a positive NUMBER (messages)", name)
+		}
+		ws.sendQueue = int(n.Value)
+	}
+	return ws, nil
+}
+
+// stringList reads an option given as a STRING or an ARRAY of STRING
+func stringList(name, key string, v object.Object) ([]string, object.Object) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case *object.String:
+		return []string{v.Value}, nil
+	case *object.Array:
+		list := make([]string, 0, len(v.Elements))
+		for _, el := range v.Elements {
+			s, ok := el.(*object.String)
+			if !ok {
+				return nil, newError("`%s` option to %s() must contain STRING values, got %s", key, name, el.Type())
+			}
+			list = append(list, s.Value)
+		}
+		return list, nil
+	}
+	return nil, newError("`%s` option to %s() must be STRING or ARRAY, got %s", key, name, v.Type())
+}
+
+// checkOrigin allows non-browser clients (no Origin), the listed origins, or by
+// default only pages served from the same host
+func (ws *wsRoute) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if len(ws.origins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, allowed := range ws.origins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// serve upgrades a request that passed the middleware chain and runs the connection
+// until it closes. Headers set on res (e.g. session cookies) go out with the 101.
+func (ws *wsRoute) serve(w http.ResponseWriter, req *http.Request, reqMap, resMap *object.Map) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+		Subprotocols:    ws.protocols,
+		CheckOrigin:     ws.checkOrigin,
+	}
+	header := http.Header{}
+	setResponseHeaders(header, resMap)
+	header.Del("Sec-Websocket-Extensions")
+	header.Del("Content-Type")
+
+	conn, err := upgrader.Upgrade(w, req, header)
+	if err != nil {
+		return // the upgrader already answered 400 / 403
+	}
+	peer := storeWSConnection(conn, &object.Map{Pairs: make(map[string]object.Object, 10)}, ws.sendQueue)
+	addWSConnectionFields(peer, reqMap)
+	defer removeWSConnection(peer.id)
+
+	// Upgraded connections are hijacked, so the server handle drains them itself
+	if server, ok := req.Context().Value(serverHandleKey{}).(*serverHandle); ok {
+		finish := func() {
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closing"),
+				time.Now().Add(time.Second))
+		}
+		if !server.track(conn.UnderlyingConn(), finish) {
+			return
+		}
+		defer server.untrack(conn.UnderlyingConn())
+	}
+	ws.run(peer)
+}
+
+// run delivers the open, message, close and error events of one connection
+func (ws *wsRoute) run(peer *wsPeer) {
+	conn, connObj := peer.conn, peer.obj
+	conn.SetReadLimit(ws.maxMessage)
+
+	// Keepalive: ping every pingInterval and drop peers that miss a pong by pongTimeout
+	stop := make(chan struct{})
+	defer close(stop)
+	wait := ws.pingInterval + ws.pongTimeout
+	if ws.pingInterval > 0 {
+		conn.SetReadDeadline(time.Now().Add(wait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wait))
+		})
+		go func() {
+			ticker := time.NewTicker(ws.pingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(ws.pongTimeout)) != nil {
+						return
+					}
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	ws.emit(ws.onOpen, connObj)
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			code, reason := websocket.CloseAbnormalClosure, ""
+			if closeErr, ok := err.(*websocket.CloseError); ok {
+				code, reason = closeErr.Code, closeErr.Text
+			} else {
+				ws.fail(connObj, newError("WebSocket error: %s", err.Error()))
+			}
+			connObj.Pairs["connected"] = object.FALSE
+			ws.emit(ws.onClose, connObj, &object.Number{Value: float64(code)}, &object.String{Value: reason})
+			return
+		}
+		var msg object.Object = &object.String{Value: string(data)}
+		if messageType == websocket.BinaryMessage {
+			msg = object.NewBuffer(data)
+		}
+		ws.emit(ws.onMessage, connObj, msg)
+		if ws.pingInterval > 0 {
+			// Pongs are only seen while reading, so a slow handler must not count against the peer
+			conn.SetReadDeadline(time.Now().Add(wait))
+		}
+	}
+}
+
+// emit calls one lifecycle callback, awaiting promises; failures go to bhul
+func (ws *wsRoute) emit(callback object.Object, args ...object.Object) {
+	if callback == nil {
+		return
+	}
+	if result := awaitResult(callHandler(callback, args)); isFailure(result) {
+		ws.fail(args[0], result)
+	}
+}
+
+// fail reports an error to the bhul callback, or logs it when there is none
+func (ws *wsRoute) fail(connObj object.Object, failure object.Object) {
+	if ws.onError != nil {
+		result := awaitResult(callHandler(ws.onError, []object.Object{connObj, &object.String{Value: failureMessage(failure)}}))
+		if !isFailure(result) {
+			return
+		}
+		failure = result
+	}
+	fmt.Printf("🔴 [BanglaCode] websocket error: %s\n", failureMessage(failure))
+}
+
+// failureMessage is the text of an error or thrown value
+func failureMessage(failure object.Object) string {
+	switch f := failure.(type) {
+	case *object.Error:
+		return f.Message
+	case *object.Exception:
+		if f.Value != nil {
+			return f.Value.Inspect()
+		}
+		return f.Message
+	}
+	return failure.Inspect()
+}
+
+// addWSConnectionFields completes the conn object handed to the callbacks:
+// {id, meta, path, protocol, remote_addr, req, connected, lekho(msg), bondho(code?, reason?)}
+func addWSConnectionFields(peer *wsPeer, reqMap *object.Map) {
+	conn, m := peer.conn, peer.obj
+	m.Pairs["path"] = reqMap.Pairs["path"]
+	m.Pairs["protocol"] = &object.String{Value: conn.Subprotocol()}
+	m.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
+	m.Pairs["req"] = reqMap
+	m.Pairs["connected"] = object.TRUE
+
+	// lekho (লেখো - write) sends a text frame for STRING, a binary frame for Buffer
+	m.Pairs["lekho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("conn.lekho() takes exactly 1 argument (message), got %d", len(args))
+			}
+			messageType, data, ok := wsMessage(args[0])
+			if !ok {
+				return newError("argument to conn.lekho() must be STRING or BUFFER, got %s", args[0].Type())
+			}
+			if err := peer.send(messageType, data); err != nil {
+				return newError("WebSocket send error: %s", err.Error())
+			}
+			return object.NULL
+		},
+	}
+
+	// bondho (বন্ধ - close) starts the closing handshake: conn.bondho(code?, reason?)
+	m.Pairs["bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=0-2 (code, reason)", len(args))
+			}
+			code, reason := websocket.CloseNormalClosure, ""
+			if len(args) >= 1 {
+				n, ok := args[0].(*object.Number)
+				if !ok {
+					return newError("first argument to conn.bondho() must be NUMBER (code), got %s", args[0].Type())
+				}
+				code = int(n.Value)
+			}
+			if len(args) == 2 {
+				reason = args[1].Inspect()
+			}
+			if err := peer.send(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason)); err != nil {
+				return newError("WebSocket close error: %s", err.Error())
+			}
+			return object.NULL
+		},
+	}
+}
+
+// wsMessage maps a STRING to a text frame and a Buffer to a binary frame
+func wsMessage(obj object.Object) (int, []byte, bool) {
+	switch v := obj.(type) {
+	case *object.String:
+		return websocket.TextMessage, []byte(v.Value), true
+	case *object.Buffer:
+		v.Mu.RLock()
+		defer v.Mu.RUnlock()
+		return websocket.BinaryMessage, append([]byte(nil), v.Data...), true
+	}
+	return 0, nil, false
+}
+
+// addWebSocketMethod adds websocket(path, handlers, options?) to a router or group map
+func addWebSocketMethod(target *object.Map, owner string, register func(pattern string, opts RouteOptions) error) {
+	name := owner + ".websocket"
+	target.Pairs["websocket"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments to %s(). got=%d, want=2-3", name, len(args))
+			}
+			if args[0].Type() != object.STRING_OBJ {
+				return newError("first argument to %s() must be STRING (path), got %s", name, args[0].Type())
+			}
+			handlers, ok := args[1].(*object.Map)
+			if !ok {
+				return newError("second argument to %s() must be MAP ({khola, barta, bondho, bhul}), got %s", name, args[1].Type())
+			}
+			var optsMap *object.Map
+			if len(args) == 3 {
+				if optsMap, ok = args[2].(*object.Map); !ok {
+					return newError("third argument to %s() must be MAP (options), got %s", name, args[2].Type())
+				}
+			}
+			opts, errObj := routeOptions(name, args)
+			if errObj != nil {
+				return errObj
+			}
+			if opts.WebSocket, errObj = parseWSRoute(name, handlers, optsMap); errObj != nil {
+				return errObj
+			}
+			opts.Hidden = true
+			if err := register(args[0].(*object.String).Value, opts); err != nil {
+				return newError("%s(): %s", name, err.Error())
+			}
+			return target
+		},
+	}
+}
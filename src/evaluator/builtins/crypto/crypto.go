@@ -124,8 +124,22 @@ var Builtins = map[string]*object.Builtin{
 	},
 
 	// Generate RSA Key Pair (crypto_generate_keypair)
+	// crypto_generate_keypair("ec") generates an ECDSA P-256 pair instead (for ES256 JWTs)
 	"crypto_generate_keypair": {
 		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 0 {
+				if kind, ok := args[0].(*object.String); ok {
+					switch kind.Value {
+					case "ec":
+						return generateECKeyPair()
+					case "rsa":
+						args = args[1:]
+					default:
+						return newError("key type must be \"rsa\" or \"ec\", got %q", kind.Value)
+					}
+				}
+			}
+
 			bits := 2048 // Default RSA key size
 			if len(args) > 0 {
 				if num, ok := args[0].(*object.Number); ok {
@@ -0,0 +1,650 @@
+package crypto
+
+import (
+	"BanglaCode/src/object"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jwtAlgorithms lists the supported JWS algorithms
+var jwtAlgorithms = map[string]crypto.Hash{
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+	"RS256": crypto.SHA256,
+	"ES256": crypto.SHA256,
+}
+
+func hashFunc(h crypto.Hash) func() hash.Hash {
+	switch h {
+	case crypto.SHA384:
+		return sha512.New384
+	case crypto.SHA512:
+		return sha512.New
+	}
+	return sha256.New
+}
+
+// generateECKeyPair returns a P-256 pair as PEM strings, shaped like the RSA pair
+func generateECKeyPair() object.Object {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return newError("failed to generate key pair: %s", err.Error())
+	}
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return newError("failed to marshal private key: %s", err.Error())
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return newError("failed to marshal public key: %s", err.Error())
+	}
+	result := make(map[string]object.Object)
+	result["privateKey"] = &object.String{Value: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}))}
+	result["publicKey"] = &object.String{Value: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))}
+	return &object.Map{Pairs: result}
+}
+
+func isPEM(key string) bool {
+	return strings.Contains(key, "-----BEGIN")
+}
+
+// parsePrivateKeyPEM reads PKCS#1 RSA, SEC 1 EC or PKCS#8 private keys
+func parsePrivateKeyPEM(key string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %s", err)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
+
+// parsePublicKeyPEM reads PKIX public keys (any label), PKCS#1 RSA keys and certificates
+func parsePublicKeyPEM(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("failed to decode public key PEM")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %s", err)
+		}
+		return cert.PublicKey, nil
+	}
+	if pub, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return pub, nil
+	}
+	if pub, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return pub, nil
+	}
+	return nil, errors.New("failed to parse public key")
+}
+
+// keyAlgorithms returns the algorithms a verification key can check: a PEM key allows
+// only its own algorithm, so a public key can never be used as an HMAC secret
+func keyAlgorithms(key string) ([]string, crypto.PublicKey, error) {
+	if !isPEM(key) {
+		return []string{"HS256", "HS384", "HS512"}, nil, nil
+	}
+	pub, err := parsePublicKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return []string{"RS256"}, k, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, nil, errors.New("ES256 needs a P-256 key")
+		}
+		return []string{"ES256"}, k, nil
+	}
+	return nil, nil, errors.New("unsupported public key type")
+}
+
+// SignJWT encodes header and claims and signs them with alg
+func SignJWT(alg string, header, claims map[string]interface{}, key string) (string, error) {
+	h, ok := jwtAlgorithms[alg]
+	if !ok {
+		return "", fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	header["alg"] = alg
+	header["typ"] = "JWT"
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := hashFunc(h)()
+	digest.Write([]byte(signingInput))
+
+	var signature []byte
+	switch alg[:2] {
+	case "HS":
+		if isPEM(key) {
+			return "", fmt.Errorf("%s needs a secret string, not a PEM key", alg)
+		}
+		mac := hmac.New(hashFunc(h), []byte(key))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case "RS":
+		signer, err := parsePrivateKeyPEM(key)
+		if err != nil {
+			return "", err
+		}
+		rsaKey, ok := signer.(*rsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("%s needs an RSA private key", alg)
+		}
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, rsaKey, h, digest.Sum(nil)); err != nil {
+			return "", err
+		}
+	case "ES":
+		signer, err := parsePrivateKeyPEM(key)
+		if err != nil {
+			return "", err
+		}
+		ecKey, ok := signer.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return "", fmt.Errorf("%s needs a P-256 EC private key", alg)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest.Sum(nil))
+		if err != nil {
+			return "", err
+		}
+		// JWS uses the fixed-size r || s form, not ASN.1
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// JWTOptions are the checks applied by VerifyJWT
+type JWTOptions struct {
+	Algorithms []string // allowed algorithms; default: every algorithm the key supports
+	Audience   []string // token aud must contain one of these
+	Issuer     []string // token iss must be one of these
+	Leeway     time.Duration
+}
+
+// decodeJWT splits a token into its header, claims and signature without verifying it
+func decodeJWT(token string) (map[string]interface{}, map[string]interface{}, []byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, nil, errors.New("malformed token")
+	}
+	var header, claims map[string]interface{}
+	for i, target := range []*map[string]interface{}{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			return nil, nil, nil, errors.New("malformed token")
+		}
+		decoder := json.NewDecoder(strings.NewReader(string(data)))
+		decoder.UseNumber()
+		if err := decoder.Decode(target); err != nil {
+			return nil, nil, nil, errors.New("malformed token")
+		}
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, nil, errors.New("malformed token")
+	}
+	return header, claims, signature, nil
+}
+
+// VerifyJWT checks the signature and registered claims and returns the claims.
+// keys maps key ids to secrets or public key PEMs (from jwks_poro); a single key has id "".
+func VerifyJWT(token string, keys map[string]string, opts JWTOptions) (map[string]interface{}, error) {
+	header, claims, signature, err := decodeJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	alg, _ := header["alg"].(string)
+	h, ok := jwtAlgorithms[alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	kid, _ := header["kid"].(string)
+	key, found := keys[kid]
+	if !found {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("no key for kid %q", kid)
+		}
+		for _, only := range keys {
+			key = only
+		}
+	}
+	supported, pub, err := keyAlgorithms(key)
+	if err != nil {
+		return nil, err
+	}
+	allowed := opts.Algorithms
+	if len(allowed) == 0 {
+		allowed = supported
+	}
+	if !containsString(allowed, alg) || !containsString(supported, alg) {
+		return nil, fmt.Errorf("algorithm %s not allowed", alg)
+	}
+
+	signingInput := token[:strings.LastIndex(token, ".")]
+	digest := hashFunc(h)()
+	digest.Write([]byte(signingInput))
+	valid := false
+	switch k := pub.(type) {
+	case nil:
+		mac := hmac.New(hashFunc(h), []byte(key))
+		mac.Write([]byte(signingInput))
+		valid = hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(k, h, digest.Sum(nil), signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature) == 64 {
+			r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+			valid = ecdsa.Verify(k, digest.Sum(nil), r, s)
+		}
+	}
+	if !valid {
+		return nil, errors.New("invalid signature")
+	}
+
+	now := time.Now()
+	if exp, ok := numericClaim(claims, "exp"); ok && now.After(exp.Add(opts.Leeway)) {
+		return nil, errors.New("token expired")
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(opts.Leeway).Before(nbf) {
+		return nil, errors.New("token not yet valid")
+	}
+	if iat, ok := numericClaim(claims, "iat"); ok && now.Add(opts.Leeway).Before(iat) {
+		return nil, errors.New("token issued in the future")
+	}
+	if len(opts.Issuer) > 0 {
+		if iss, _ := claims["iss"].(string); !containsString(opts.Issuer, iss) {
+			return nil, errors.New("invalid issuer")
+		}
+	}
+	if len(opts.Audience) > 0 {
+		var audiences []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			audiences = []string{aud}
+		case []interface{}:
+			for _, a := range aud {
+				if s, ok := a.(string); ok {
+					audiences = append(audiences, s)
+				}
+			}
+		}
+		matched := false
+		for _, a := range audiences {
+			matched = matched || containsString(opts.Audience, a)
+		}
+		if !matched {
+			return nil, errors.New("invalid audience")
+		}
+	}
+	return claims, nil
+}
+
+func numericClaim(claims map[string]interface{}, name string) (time.Time, bool) {
+	n, ok := claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseJWKS turns a JWKS JSON document into kid → key: RSA and EC keys become public
+// key PEMs, oct keys their raw secret. Keys without a kid are numbered by position.
+func ParseJWKS(document string) (map[string]string, error) {
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(document), &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JWKS JSON: %s", err)
+	}
+	keys := make(map[string]string, len(jwks.Keys))
+	for i, jwk := range jwks.Keys {
+		kid, ok := jwk["kid"].(string)
+		if !ok {
+			kid = strconv.Itoa(i)
+		}
+		field := func(name string) ([]byte, error) {
+			s, _ := jwk[name].(string)
+			data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+			if err != nil || len(data) == 0 {
+				return nil, fmt.Errorf("key %q: invalid or missing %q", kid, name)
+			}
+			return data, nil
+		}
+
+		var pub crypto.PublicKey
+		switch kty, _ := jwk["kty"].(string); kty {
+		case "oct":
+			secret, err := field("k")
+			if err != nil {
+				return nil, err
+			}
+			keys[kid] = string(secret)
+			continue
+		case "RSA":
+			n, err := field("n")
+			if err != nil {
+				return nil, err
+			}
+			e, err := field("e")
+			if err != nil {
+				return nil, err
+			}
+			pub = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			if crv, _ := jwk["crv"].(string); crv != "P-256" {
+				return nil, fmt.Errorf("key %q: unsupported curve %q", kid, crv)
+			}
+			x, err := field("x")
+			if err != nil {
+				return nil, err
+			}
+			y, err := field("y")
+			if err != nil {
+				return nil, err
+			}
+			pub = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		default:
+			return nil, fmt.Errorf("key %q: unsupported kty %q", kid, kty)
+		}
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %s", kid, err)
+		}
+		keys[kid] = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+	return keys, nil
+}
+
+// JWTKeys reads the key argument of jwt_jachai / jwt_pahara: a secret or PEM string,
+// or a kid → key map from jwks_poro
+func JWTKeys(fn string, arg object.Object) (map[string]string, *object.Error) {
+	switch key := arg.(type) {
+	case *object.String:
+		return map[string]string{"": key.Value}, nil
+	case *object.Map:
+		keys := make(map[string]string, len(key.Pairs))
+		for kid, v := range key.Pairs {
+			s, ok := v.(*object.String)
+			if !ok {
+				return nil, newError("%s: key %q must be STRING, got %s", fn, kid, v.Type())
+			}
+			keys[kid] = s.Value
+		}
+		if len(keys) == 0 {
+			return nil, newError("%s: key set is empty", fn)
+		}
+		return keys, nil
+	}
+	return nil, newError("key argument to `%s` must be STRING (secret / public key) or MAP (jwks_poro keys), got %s", fn, arg.Type())
+}
+
+// JWTVerifyOptions reads {algorithms, audience, issuer, leeway} for jwt_jachai / jwt_pahara
+func JWTVerifyOptions(fn string, opts *object.Map) (JWTOptions, *object.Error) {
+	var result JWTOptions
+	if opts == nil {
+		return result, nil
+	}
+	for name, target := range map[string]*[]string{"algorithms": &result.Algorithms, "audience": &result.Audience, "issuer": &result.Issuer} {
+		switch v := opts.Pairs[name].(type) {
+		case nil:
+		case *object.String:
+			*target = []string{v.Value}
+		case *object.Array:
+			for _, el := range v.Elements {
+				s, ok := el.(*object.String)
+				if !ok {
+					return result, newError("`%s` option to `%s` must contain STRING values, got %s", name, fn, el.Type())
+				}
+				*target = append(*target, s.Value)
+			}
+		default:
+			return result, newError("`%s` option to `%s` must be STRING or ARRAY, got %s", name, fn, v.Type())
+		}
+	}
+	if leeway, ok := opts.Pairs["leeway"].(*object.Number); ok {
+		result.Leeway = time.Duration(leeway.Value * float64(time.Second))
+	}
+	return result, nil
+}
+
+// toNative converts BanglaCode values to JSON-encodable Go values
+func toNative(obj object.Object) interface{} {
+	switch v := obj.(type) {
+	case *object.String:
+		return v.Value
+	case *object.Number:
+		return v.Value
+	case *object.Boolean:
+		return v.Value
+	case *object.Array:
+		items := make([]interface{}, len(v.Elements))
+		for i, el := range v.Elements {
+			items[i] = toNative(el)
+		}
+		return items
+	case *object.Map:
+		m := make(map[string]interface{}, len(v.Pairs))
+		for k, val := range v.Pairs {
+			m[k] = toNative(val)
+		}
+		return m
+	}
+	return nil
+}
+
+// fromNative converts decoded JSON (numbers as json.Number) to BanglaCode values
+func fromNative(value interface{}) object.Object {
+	switch v := value.(type) {
+	case string:
+		return &object.String{Value: v}
+	case json.Number:
+		f, _ := v.Float64()
+		return &object.Number{Value: f}
+	case bool:
+		return object.NativeBoolToBooleanObject(v)
+	case []interface{}:
+		items := make([]object.Object, len(v))
+		for i, el := range v {
+			items[i] = fromNative(el)
+		}
+		return &object.Array{Elements: items}
+	case map[string]interface{}:
+		m := &object.Map{Pairs: make(map[string]object.Object, len(v))}
+		for k, val := range v {
+			m.Pairs[k] = fromNative(val)
+		}
+		return m
+	}
+	return object.NULL
+}
+
+// ClaimsToObject converts verified claims to a BanglaCode map
+func ClaimsToObject(claims map[string]interface{}) *object.Map {
+	return fromNative(claims).(*object.Map)
+}
+
+func init() {
+	// jwt_banao (JWT বানাও - create a signed token)
+	// jwt_banao(claims, key, {algorithm: "HS256", expiresIn: 3600, kid: "..."}?)
+	// key is a secret for HS256/384/512 or a private key PEM (crypto_generate_keypair) for RS256/ES256.
+	// iat is added automatically; expiresIn (seconds) sets exp.
+	Builtins["jwt_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3 (claims, key, [options])", len(args))
+			}
+			claimsMap, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("first argument to `jwt_banao` must be MAP (claims), got %s", args[0].Type())
+			}
+			key, ok := args[1].(*object.String)
+			if !ok {
+				return newError("second argument to `jwt_banao` must be STRING (key), got %s", args[1].Type())
+			}
+			alg := "HS256"
+			header := map[string]interface{}{}
+			claims := toNative(claimsMap).(map[string]interface{})
+			now := time.Now().Unix()
+			if _, ok := claims["iat"]; !ok {
+				claims["iat"] = now
+			}
+			if len(args) == 3 {
+				opts, ok := args[2].(*object.Map)
+				if !ok {
+					return newError("third argument to `jwt_banao` must be MAP (options), got %s", args[2].Type())
+				}
+				if v, ok := opts.Pairs["algorithm"].(*object.String); ok {
+					alg = v.Value
+				}
+				if v, ok := opts.Pairs["expiresIn"].(*object.Number); ok {
+					claims["exp"] = now + int64(v.Value)
+				}
+				if v, ok := opts.Pairs["kid"].(*object.String); ok {
+					header["kid"] = v.Value
+				}
+			}
+			token, err := SignJWT(alg, header, claims, key.Value)
+			if err != nil {
+				return newError("jwt_banao: %s", err.Error())
+			}
+			return &object.String{Value: token}
+		},
+	}
+
+	// jwt_jachai (JWT যাচাই - verify a token and return its claims)
+	// jwt_jachai(token, key, {algorithms: ["RS256"], audience: "api", issuer: "auth", leeway: 30}?)
+	// key is a secret, a public key PEM, or the kid → key map from jwks_poro.
+	Builtins["jwt_jachai"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2-3 (token, key, [options])", len(args))
+			}
+			token, ok := args[0].(*object.String)
+			if !ok {
+				return newError("first argument to `jwt_jachai` must be STRING (token), got %s", args[0].Type())
+			}
+			keys, errObj := JWTKeys("jwt_jachai", args[1])
+			if errObj != nil {
+				return errObj
+			}
+			var optsMap *object.Map
+			if len(args) == 3 {
+				if optsMap, ok = args[2].(*object.Map); !ok {
+					return newError("third argument to `jwt_jachai` must be MAP (options), got %s", args[2].Type())
+				}
+			}
+			opts, errObj := JWTVerifyOptions("jwt_jachai", optsMap)
+			if errObj != nil {
+				return errObj
+			}
+			claims, err := VerifyJWT(token.Value, keys, opts)
+			if err != nil {
+				return newError("jwt_jachai: %s", err.Error())
+			}
+			return ClaimsToObject(claims)
+		},
+	}
+
+	// jwt_khulo (JWT খোলো - decode without verifying, e.g. to read kid)
+	// jwt_khulo(token) → {header, claims}
+	Builtins["jwt_khulo"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1 (token)", len(args))
+			}
+			token, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to `jwt_khulo` must be STRING (token), got %s", args[0].Type())
+			}
+			header, claims, _, err := decodeJWT(token.Value)
+			if err != nil {
+				return newError("jwt_khulo: %s", err.Error())
+			}
+			result := &object.Map{Pairs: make(map[string]object.Object, 2)}
+			result.Pairs["header"] = fromNative(header)
+			result.Pairs["claims"] = fromNative(claims)
+			return result
+		},
+	}
+
+	// jwks_poro (JWKS পড়ো - parse a JWKS JSON document)
+	// jwks_poro(poro("jwks.json")) → {kid: key} for jwt_jachai / jwt_pahara
+	Builtins["jwks_poro"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1 (jwksJSON)", len(args))
+			}
+			document, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to `jwks_poro` must be STRING (JWKS JSON), got %s", args[0].Type())
+			}
+			keys, err := ParseJWKS(document.Value)
+			if err != nil {
+				return newError("jwks_poro: %s", err.Error())
+			}
+			result := &object.Map{Pairs: make(map[string]object.Object, len(keys))}
+			for kid, key := range keys {
+				result.Pairs[kid] = &object.String{Value: key}
+			}
+			return result
+		},
+	}
+}
@@ -0,0 +1,218 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Location is a 1-based line and column in a document
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is a GraphQL error as reported in the "errors" list of a response
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"` // field names and list indexes
+}
+
+func (e *Error) Error() string {
+	if len(e.Locations) > 0 {
+		return fmt.Sprintf("%s (line %d, column %d)", e.Message, e.Locations[0].Line, e.Locations[0].Column)
+	}
+	return e.Message
+}
+
+func syntaxError(loc Location, format string, args ...interface{}) *Error {
+	return &Error{Message: "Syntax Error: " + fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+func newError(loc Location, format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+// ==================== Executable documents ====================
+
+// Document is a parsed query document: operations and fragment definitions
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+	fragOrder  []*Fragment
+}
+
+// Operation is a query, mutation or subscription
+type Operation struct {
+	Kind       string // "query", "mutation" or "subscription"
+	Name       string
+	Variables  []*VariableDefinition
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+// VariableDefinition is $name: Type = default
+type VariableDefinition struct {
+	Name    string
+	Type    *TypeRef
+	Default *Value // nil when there is none
+	Loc     Location
+}
+
+// Fragment is fragment Name on Type { ... }
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment
+type Selection interface {
+	location() Location
+}
+
+// Field is alias: name(args) @directives { selections }
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  []*Argument
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+// ResponseKey is the alias, or the field name without one
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread is ...Name
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+// InlineFragment is ... on Type { selections }
+type InlineFragment struct {
+	TypeCondition string // "" applies to every type
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+func (f *Field) location() Location          { return f.Loc }
+func (f *FragmentSpread) location() Location { return f.Loc }
+func (f *InlineFragment) location() Location { return f.Loc }
+
+// Argument is name: value in a field or directive
+type Argument struct {
+	Name  string
+	Value Value
+	Loc   Location
+}
+
+// Directive is @name(args)
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Loc       Location
+}
+
+// ==================== Values and types ====================
+
+// ValueKind tells which literal a Value holds
+type ValueKind int
+
+const (
+	VariableValue ValueKind = iota
+	IntValue
+	FloatValue
+	StringValue
+	BooleanValue
+	NullValue
+	EnumValue
+	ListValue
+	ObjectValue
+)
+
+// Value is a literal or $variable in a document
+type Value struct {
+	Kind   ValueKind
+	Raw    string         // name, number text or string contents
+	List   []Value        // ListValue items
+	Fields []*ObjectField // ObjectValue fields in order
+	Loc    Location
+}
+
+// ObjectField is one name: value pair of an input object literal
+type ObjectField struct {
+	Name  string
+	Value Value
+}
+
+// String prints the value in GraphQL syntax (used for introspection defaults)
+func (v Value) String() string {
+	switch v.Kind {
+	case VariableValue:
+		return "$" + v.Raw
+	case StringValue:
+		return strconv.Quote(v.Raw)
+	case NullValue:
+		return "null"
+	case ListValue:
+		parts := make([]string, len(v.List))
+		for i, item := range v.List {
+			parts[i] = item.String()
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case ObjectValue:
+		parts := make([]string, len(v.Fields))
+		for i, f := range v.Fields {
+			parts[i] = f.Name + ": " + f.Value.String()
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return v.Raw
+}
+
+// TypeRef is a named type, [list] or non-null! wrapper
+type TypeRef struct {
+	Name    string   // named type; "" for lists
+	OfType  *TypeRef // list element
+	NonNull bool
+}
+
+func (t *TypeRef) String() string {
+	s := t.Name
+	if t.OfType != nil {
+		s = "[" + t.OfType.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// NamedType is the innermost type name
+func (t *TypeRef) NamedType() string {
+	for t.OfType != nil {
+		t = t.OfType
+	}
+	return t.Name
+}
+
+// nullable returns the type without its non-null marker
+func (t *TypeRef) nullable() *TypeRef {
+	if !t.NonNull {
+		return t
+	}
+	return &TypeRef{Name: t.Name, OfType: t.OfType}
+}
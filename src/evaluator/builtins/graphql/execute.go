@@ -0,0 +1,585 @@
+package graphql
+
+import (
+	"BanglaCode/src/object"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Params describe one request against a schema
+type Params struct {
+	Query         string
+	Variables     *object.Map // nil when none were sent
+	OperationName string
+	Root          object.Object // parent value of the root resolvers; an empty map when nil
+	Context       object.Object // passed to every resolver
+	ReadOnly      bool          // reject mutations, as GET requests must
+}
+
+// Result is a GraphQL response. Data is absent when the request failed before execution
+// started, and null when a non-null root field failed.
+type Result struct {
+	Data     *OrderedMap
+	Errors   []*Error
+	Executed bool
+	Status   int // HTTP status for the response
+}
+
+// MarshalJSON writes {"data": ..., "errors": [...]}, leaving out what is absent
+func (r *Result) MarshalJSON() ([]byte, error) {
+	out := &OrderedMap{}
+	if len(r.Errors) > 0 {
+		out.Set("errors", r.Errors)
+	}
+	if r.Executed {
+		if r.Data == nil {
+			out.Set("data", nil)
+		} else {
+			out.Set("data", r.Data)
+		}
+	}
+	return json.Marshal(out)
+}
+
+// OrderedMap is a response object that keeps fields in the order they were selected
+type OrderedMap struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// Set adds or replaces a key
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.Values == nil {
+		m.Values = make(map[string]interface{})
+	}
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = value
+}
+
+// MarshalJSON writes the keys in order
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(m.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func rejected(status int, errs ...*Error) *Result {
+	return &Result{Errors: errs, Status: status}
+}
+
+func asError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+// Execute parses, validates and runs a request. Resolvers run one at a time, so mutations
+// apply in the order they are written.
+func (s *Schema) Execute(p Params) *Result {
+	doc, err := Parse(p.Query)
+	if err != nil {
+		return rejected(http.StatusBadRequest, asError(err))
+	}
+	if errs := s.validateDocument(doc); len(errs) > 0 {
+		return rejected(http.StatusBadRequest, errs...)
+	}
+	op, gerr := selectOperation(doc, p.OperationName)
+	if gerr != nil {
+		return rejected(http.StatusBadRequest, gerr)
+	}
+	if op.Kind == "mutation" && p.ReadOnly {
+		return rejected(http.StatusMethodNotAllowed, newError(op.Loc, "Can only perform a mutation operation from a POST request."))
+	}
+	vars, errs := s.coerceVariables(op, p.Variables)
+	if len(errs) > 0 {
+		return rejected(http.StatusBadRequest, errs...)
+	}
+	if err := s.checkLimits(doc, op, vars); err != nil {
+		return rejected(http.StatusBadRequest, err)
+	}
+
+	root := p.Root
+	if root == nil || root == object.NULL {
+		root = &object.Map{Pairs: make(map[string]object.Object)}
+	}
+	ctx := p.Context
+	if ctx == nil {
+		ctx = object.NULL
+	}
+	e := &executor{s: s, doc: doc, op: op, vars: vars, ctx: ctx}
+	rootType := s.Types[s.Query]
+	if op.Kind == "mutation" {
+		rootType = s.Types[s.Mutation]
+	}
+	data, ok := e.selectionSet(rootType, root, op.Selections, nil)
+	if !ok {
+		data = nil
+	}
+	return &Result{Data: data, Errors: e.errors, Executed: true, Status: http.StatusOK}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, *Error) {
+	if name == "" {
+		if len(doc.Operations) == 1 {
+			return doc.Operations[0], nil
+		}
+		return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: "Unknown operation named \"" + name + "\"."}
+}
+
+func (s *Schema) coerceVariables(op *Operation, given *object.Map) (map[string]object.Object, []*Error) {
+	vars := make(map[string]object.Object, len(op.Variables))
+	var errs []*Error
+	for _, def := range op.Variables {
+		var val object.Object
+		provided := false
+		if given != nil {
+			_, provided = given.Pairs[def.Name]
+			if provided {
+				val = s.get(given, def.Name)
+			}
+		}
+		if !provided {
+			if def.Default != nil {
+				vars[def.Name], _ = s.coerceLiteral(*def.Default, def.Type, nil)
+			} else if def.Type.NonNull {
+				errs = append(errs, newError(def.Loc, "Variable \"$%s\" of required type \"%s\" was not provided.", def.Name, def.Type))
+			}
+			continue
+		}
+		coerced, msg := s.coerceVariable(val, def.Type)
+		if msg != "" {
+			errs = append(errs, newError(def.Loc, "Variable \"$%s\" got invalid value %s; %s", def.Name, s.describe(val), msg))
+			continue
+		}
+		vars[def.Name] = coerced
+	}
+	return vars, errs
+}
+
+// coerceArguments builds the args map handed to a resolver
+func (s *Schema) coerceArguments(defs []*InputValue, args []*Argument, vars map[string]object.Object, loc Location) (*object.Map, *Error) {
+	result := &object.Map{Pairs: make(map[string]object.Object, len(defs))}
+	for _, def := range defs {
+		var value object.Object
+		for _, arg := range args {
+			if arg.Name != def.Name {
+				continue
+			}
+			var err *Error
+			if value, err = s.coerceLiteral(arg.Value, def.Type, vars); err != nil {
+				return nil, err
+			}
+		}
+		if value == nil && def.Default != nil {
+			value, _ = s.coerceLiteral(*def.Default, def.Type, nil)
+		}
+		if value == nil {
+			if def.Type.NonNull {
+				return nil, newError(loc, "Argument \"%s\" of required type \"%s\" was not provided.", def.Name, def.Type)
+			}
+			continue
+		}
+		if value == object.NULL && def.Type.NonNull {
+			return nil, newError(loc, "Argument \"%s\" of non-null type \"%s\" must not be null.", def.Name, def.Type)
+		}
+		result.Pairs[def.Name] = value
+	}
+	return result, nil
+}
+
+// executor runs one operation
+type executor struct {
+	s      *Schema
+	doc    *Document
+	op     *Operation
+	vars   map[string]object.Object
+	ctx    object.Object
+	errors []*Error
+}
+
+// site is the field being completed, for resolver info and error messages
+type site struct {
+	parent *Type
+	def    *FieldDef
+	fields []*Field
+	path   []interface{}
+}
+
+func (st *site) coordinate() string {
+	return st.parent.Name + "." + st.def.Name
+}
+
+func (e *executor) fail(path []interface{}, loc Location, message string) {
+	e.errors = append(e.errors, &Error{Message: message, Locations: []Location{loc}, Path: path})
+}
+
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	next := make([]interface{}, len(path), len(path)+1)
+	copy(next, path)
+	return append(next, key)
+}
+
+// collectFields groups the selected fields of an object type by response key
+func (e *executor) collectFields(t *Type, sels []Selection, keys *[]string, groups map[string][]*Field, visited map[string]bool) {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			if _, seen := groups[key]; !seen {
+				*keys = append(*keys, key)
+			}
+			groups[key] = append(groups[key], sel)
+		case *FragmentSpread:
+			frag := e.doc.Fragments[sel.Name]
+			if visited[sel.Name] || !e.included(sel.Directives) || !e.applies(t, frag.TypeCondition) {
+				continue
+			}
+			visited[sel.Name] = true
+			e.collectFields(t, frag.Selections, keys, groups, visited)
+		case *InlineFragment:
+			if e.included(sel.Directives) && e.applies(t, sel.TypeCondition) {
+				e.collectFields(t, sel.Selections, keys, groups, visited)
+			}
+		}
+	}
+}
+
+// included evaluates @skip and @include
+func (e *executor) included(directives []*Directive) bool {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			continue
+		}
+		args, err := e.s.coerceArguments(e.s.Directives[d.Name].Args, d.Arguments, e.vars, d.Loc)
+		if err != nil {
+			continue
+		}
+		if cond := args.Pairs["if"] == object.TRUE; cond == (d.Name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) applies(t *Type, condition string) bool {
+	return condition == "" || condition == t.Name || e.s.possible(condition, t.Name)
+}
+
+// selectionSet resolves the fields of an object; ok is false when a non-null field came back
+// null, which makes the whole object null
+func (e *executor) selectionSet(t *Type, value object.Object, sels []Selection, path []interface{}) (*OrderedMap, bool) {
+	var keys []string
+	groups := make(map[string][]*Field)
+	e.collectFields(t, sels, &keys, groups, make(map[string]bool))
+
+	result := &OrderedMap{}
+	for _, key := range keys {
+		fields := groups[key]
+		if fields[0].Name == "__typename" {
+			result.Set(key, t.Name)
+			continue
+		}
+		def := e.s.fieldDef(t, fields[0].Name)
+		if def == nil {
+			continue
+		}
+		st := &site{parent: t, def: def, fields: fields, path: appendPath(path, key)}
+		v, ok := e.field(st, value)
+		if !ok {
+			if def.Type.NonNull {
+				return nil, false
+			}
+			v = nil
+		}
+		result.Set(key, v)
+	}
+	return result, true
+}
+
+func (e *executor) field(st *site, parent object.Object) (interface{}, bool) {
+	f := st.fields[0]
+	args, err := e.s.coerceArguments(st.def.Args, f.Arguments, e.vars, f.Loc)
+	if err != nil {
+		e.fail(st.path, f.Loc, err.Message)
+		return nil, false
+	}
+	resolved := e.resolve(st, parent, args)
+	if msg, failed := failureMessage(resolved); failed {
+		e.fail(st.path, f.Loc, msg)
+		return nil, false
+	}
+	return e.complete(st, st.def.Type, resolved, st.path)
+}
+
+// resolve calls the field's resolver, or reads the field from the parent value
+func (e *executor) resolve(st *site, parent object.Object, args *object.Map) object.Object {
+	if strings.HasPrefix(st.parent.Name, "__") || strings.HasPrefix(st.def.Name, "__") {
+		return e.introspect(st, parent, args)
+	}
+	if st.def.resolve != nil {
+		return e.s.Call(st.def.resolve, []object.Object{parent, args, e.ctx, e.info(st)})
+	}
+	var prop object.Object = object.NULL
+	switch p := parent.(type) {
+	case *object.Map:
+		prop = e.s.get(p, st.def.Name)
+	case *object.Instance:
+		if v, ok := p.Properties[st.def.Name]; ok {
+			prop = v
+		}
+	}
+	if prop.Type() == object.FUNCTION_OBJ || prop.Type() == object.BUILTIN_OBJ {
+		return e.s.Call(prop, []object.Object{args, e.ctx, e.info(st)})
+	}
+	return prop
+}
+
+// info is the fourth resolver argument: where in the query the field is
+func (e *executor) info(st *site) object.Object {
+	path := make([]object.Object, len(st.path))
+	for i, p := range st.path {
+		switch p := p.(type) {
+		case string:
+			path[i] = &object.String{Value: p}
+		case int:
+			path[i] = &object.Number{Value: float64(p)}
+		}
+	}
+	vars := make(map[string]object.Object, len(e.vars))
+	for k, v := range e.vars {
+		vars[k] = v
+	}
+	return &object.Map{Pairs: map[string]object.Object{
+		"fieldName":     &object.String{Value: st.def.Name},
+		"parentType":    &object.String{Value: st.parent.Name},
+		"returnType":    &object.String{Value: st.def.Type.String()},
+		"path":          &object.Array{Elements: path},
+		"operation":     &object.String{Value: e.op.Kind},
+		"operationName": &object.String{Value: e.op.Name},
+		"variables":     &object.Map{Pairs: vars},
+	}}
+}
+
+// complete turns a resolved value into response data of type t. ok is false when the
+// value became null because of an error, which non-null parents pass upwards.
+func (e *executor) complete(st *site, t *TypeRef, val object.Object, path []interface{}) (interface{}, bool) {
+	loc := st.fields[0].Loc
+	if t.NonNull {
+		v, ok := e.complete(st, t.nullable(), val, path)
+		if ok && v == nil {
+			e.fail(path, loc, "Cannot return null for non-nullable field "+st.coordinate()+".")
+			return nil, false
+		}
+		return v, ok
+	}
+	if val == nil || val == object.NULL {
+		return nil, true
+	}
+
+	if t.OfType != nil {
+		arr, ok := val.(*object.Array)
+		if !ok {
+			e.fail(path, loc, "Expected Iterable, but did not find one for field \""+st.coordinate()+"\".")
+			return nil, false
+		}
+		items := make([]interface{}, len(arr.Elements))
+		for i, el := range arr.Elements {
+			v, ok := e.complete(st, t.OfType, el, appendPath(path, i))
+			if !ok && t.OfType.NonNull {
+				return nil, false
+			}
+			items[i] = v
+		}
+		return items, true
+	}
+
+	named := e.s.Types[t.Name]
+	switch named.Kind {
+	case KindScalar, KindEnum:
+		v, msg := e.s.serialize(named, val)
+		if msg != "" {
+			e.fail(path, loc, msg)
+			return nil, false
+		}
+		return v, true
+	case KindInterface, KindUnion:
+		if named = e.resolveType(st, named, val, path); named == nil {
+			return nil, false
+		}
+	}
+
+	var sels []Selection
+	for _, f := range st.fields {
+		sels = append(sels, f.Selections...)
+	}
+	m, ok := e.selectionSet(named, val, sels, path)
+	if !ok {
+		return nil, false
+	}
+	return m, true
+}
+
+// resolveType finds the object type of a value returned for an interface or union, using
+// the type's __resolveType resolver or a __typename key on the value
+func (e *executor) resolveType(st *site, abstract *Type, val object.Object, path []interface{}) *Type {
+	loc := st.fields[0].Loc
+	name := ""
+	switch {
+	case abstract.resolve != nil:
+		result := e.s.Call(abstract.resolve, []object.Object{val, e.ctx, e.info(st)})
+		if msg, failed := failureMessage(result); failed {
+			e.fail(path, loc, msg)
+			return nil
+		}
+		if str, ok := result.(*object.String); ok {
+			name = str.Value
+		}
+	default:
+		switch v := val.(type) {
+		case *object.Map:
+			if str, ok := e.s.get(v, "__typename").(*object.String); ok {
+				name = str.Value
+			}
+		case *object.Instance:
+			name = v.Class.Name
+		}
+		if name == "" && len(abstract.PossibleTypes) == 1 {
+			name = abstract.PossibleTypes[0]
+		}
+	}
+	if name == "" {
+		e.fail(path, loc, "Abstract type \""+abstract.Name+"\" must resolve to an Object type at runtime for field \""+
+			st.coordinate()+"\". Either the \""+abstract.Name+"\" type should provide a \"__resolveType\" resolver or each possible type should provide a \"__typename\" key.")
+		return nil
+	}
+	t := e.s.Types[name]
+	if t == nil || t.Kind != KindObject || !e.s.possible(abstract.Name, name) {
+		e.fail(path, loc, "Runtime Object type \""+name+"\" is not a possible type for \""+abstract.Name+"\".")
+		return nil
+	}
+	return t
+}
+
+// failureMessage reports whether a resolver returned an error or threw
+func failureMessage(obj object.Object) (string, bool) {
+	switch v := obj.(type) {
+	case *object.Error:
+		return v.Message, true
+	case *object.Exception:
+		if m, ok := v.Value.(*object.Map); ok {
+			if msg, ok := m.Pairs["message"].(*object.String); ok {
+				return msg.Value, true
+			}
+		}
+		if str, ok := v.Value.(*object.String); ok {
+			return str.Value, true
+		}
+		if v.Message != "" {
+			return v.Message, true
+		}
+		if v.Value != nil {
+			return v.Value.Inspect(), true
+		}
+		return "exception", true
+	}
+	return "", false
+}
+
+// ToObject converts response data to BanglaCode values
+func ToObject(data interface{}) object.Object {
+	switch v := data.(type) {
+	case nil:
+		return object.NULL
+	case bool:
+		return nativeBool(v)
+	case int64:
+		return &object.Number{Value: float64(v)}
+	case float64:
+		return &object.Number{Value: v}
+	case string:
+		return &object.String{Value: v}
+	case []interface{}:
+		elements := make([]object.Object, len(v))
+		for i, item := range v {
+			elements[i] = ToObject(item)
+		}
+		return &object.Array{Elements: elements}
+	case *OrderedMap:
+		if v == nil {
+			return object.NULL
+		}
+		m := &object.Map{Pairs: make(map[string]object.Object, len(v.Keys))}
+		for _, key := range v.Keys {
+			m.Pairs[key] = ToObject(v.Values[key])
+		}
+		return m
+	case map[string]interface{}:
+		m := &object.Map{Pairs: make(map[string]object.Object, len(v))}
+		for key, item := range v {
+			m.Pairs[key] = ToObject(item)
+		}
+		return m
+	case []*Error:
+		elements := make([]object.Object, len(v))
+		for i, err := range v {
+			elements[i] = err.toObject()
+		}
+		return &object.Array{Elements: elements}
+	}
+	return object.NULL
+}
+
+func (e *Error) toObject() object.Object {
+	m := &object.Map{Pairs: map[string]object.Object{"message": &object.String{Value: e.Message}}}
+	if len(e.Locations) > 0 {
+		locs := make([]object.Object, len(e.Locations))
+		for i, loc := range e.Locations {
+			locs[i] = &object.Map{Pairs: map[string]object.Object{
+				"line":   &object.Number{Value: float64(loc.Line)},
+				"column": &object.Number{Value: float64(loc.Column)},
+			}}
+		}
+		m.Pairs["locations"] = &object.Array{Elements: locs}
+	}
+	if len(e.Path) > 0 {
+		path := make([]interface{}, len(e.Path))
+		for i, p := range e.Path {
+			if n, ok := p.(int); ok {
+				path[i] = int64(n)
+			} else {
+				path[i] = p
+			}
+		}
+		m.Pairs["path"] = ToObject(path)
+	}
+	return m
+}
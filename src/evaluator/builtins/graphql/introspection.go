@@ -0,0 +1,239 @@
+package graphql
+
+import (
+	"BanglaCode/src/object"
+)
+
+// meta carries a schema element (*Schema, *Type, *TypeRef, *FieldDef, *InputValue,
+// *EnumValueDef or *DirectiveDef) through the executor as an introspection value
+type meta struct {
+	v interface{}
+}
+
+func (m *meta) Type() object.ObjectType { return "GRAPHQL_META" }
+func (m *meta) Inspect() string         { return "graphql introspection value" }
+
+// introspect resolves __schema, __type and the fields of the introspection types
+func (e *executor) introspect(st *site, parent object.Object, args *object.Map) object.Object {
+	s := e.s
+	switch st.def.Name {
+	case "__schema":
+		return &meta{s}
+	case "__type":
+		if name, ok := args.Pairs["name"].(*object.String); ok && s.Types[name.Value] != nil {
+			return &meta{s.Types[name.Value]}
+		}
+		return object.NULL
+	}
+	m, ok := parent.(*meta)
+	if !ok {
+		return object.NULL
+	}
+	includeDeprecated := args.Pairs["includeDeprecated"] == object.TRUE
+	field := st.def.Name
+
+	switch v := m.v.(type) {
+	case *Schema:
+		switch field {
+		case "types":
+			types := make([]object.Object, len(s.typeOrder))
+			for i, name := range s.typeOrder {
+				types[i] = &meta{s.Types[name]}
+			}
+			return &object.Array{Elements: types}
+		case "queryType":
+			return s.namedType(s.Query)
+		case "mutationType":
+			return s.namedType(s.Mutation)
+		case "subscriptionType":
+			return s.namedType(s.Subscription)
+		case "directives":
+			directives := make([]object.Object, len(s.directiveOrder))
+			for i, name := range s.directiveOrder {
+				directives[i] = &meta{s.Directives[name]}
+			}
+			return &object.Array{Elements: directives}
+		}
+
+	case *TypeRef: // a LIST or NON_NULL wrapper
+		switch field {
+		case "kind":
+			if v.NonNull {
+				return &object.String{Value: "NON_NULL"}
+			}
+			return &object.String{Value: "LIST"}
+		case "ofType":
+			if v.NonNull {
+				return s.typeRef(v.nullable())
+			}
+			return s.typeRef(v.OfType)
+		}
+
+	case *Type:
+		switch field {
+		case "kind":
+			return &object.String{Value: v.Kind}
+		case "name":
+			return &object.String{Value: v.Name}
+		case "description":
+			return optional(v.Description)
+		case "specifiedByURL":
+			for _, d := range v.Directives {
+				if d.Name == "specifiedBy" && len(d.Arguments) > 0 {
+					return &object.String{Value: d.Arguments[0].Value.Raw}
+				}
+			}
+		case "fields":
+			if v.Kind != KindObject && v.Kind != KindInterface {
+				return object.NULL
+			}
+			var fields []object.Object
+			for _, f := range v.Fields {
+				if deprecated, _ := deprecation(f.Directives); includeDeprecated || !deprecated {
+					fields = append(fields, &meta{f})
+				}
+			}
+			return &object.Array{Elements: fields}
+		case "interfaces":
+			if v.Kind != KindObject && v.Kind != KindInterface {
+				return object.NULL
+			}
+			return s.namedTypes(v.Interfaces)
+		case "possibleTypes":
+			if v.Kind != KindInterface && v.Kind != KindUnion {
+				return object.NULL
+			}
+			return s.namedTypes(v.PossibleTypes)
+		case "enumValues":
+			if v.Kind != KindEnum {
+				return object.NULL
+			}
+			var values []object.Object
+			for _, ev := range v.EnumValues {
+				if deprecated, _ := deprecation(ev.Directives); includeDeprecated || !deprecated {
+					values = append(values, &meta{ev})
+				}
+			}
+			return &object.Array{Elements: values}
+		case "inputFields":
+			if v.Kind != KindInputObject {
+				return object.NULL
+			}
+			return inputValues(v.InputFields, includeDeprecated)
+		case "isOneOf":
+			if v.Kind == KindInputObject {
+				return object.FALSE
+			}
+		}
+
+	case *FieldDef:
+		switch field {
+		case "name":
+			return &object.String{Value: v.Name}
+		case "description":
+			return optional(v.Description)
+		case "args":
+			return inputValues(v.Args, includeDeprecated)
+		case "type":
+			return s.typeRef(v.Type)
+		case "isDeprecated", "deprecationReason":
+			return deprecationField(field, v.Directives)
+		}
+
+	case *InputValue:
+		switch field {
+		case "name":
+			return &object.String{Value: v.Name}
+		case "description":
+			return optional(v.Description)
+		case "type":
+			return s.typeRef(v.Type)
+		case "defaultValue":
+			if v.Default != nil {
+				return &object.String{Value: v.Default.String()}
+			}
+		case "isDeprecated", "deprecationReason":
+			return deprecationField(field, v.Directives)
+		}
+
+	case *EnumValueDef:
+		switch field {
+		case "name":
+			return &object.String{Value: v.Name}
+		case "description":
+			return optional(v.Description)
+		case "isDeprecated", "deprecationReason":
+			return deprecationField(field, v.Directives)
+		}
+
+	case *DirectiveDef:
+		switch field {
+		case "name":
+			return &object.String{Value: v.Name}
+		case "description":
+			return optional(v.Description)
+		case "isRepeatable":
+			return nativeBool(v.Repeatable)
+		case "locations":
+			locations := make([]object.Object, len(v.Locations))
+			for i, loc := range v.Locations {
+				locations[i] = &object.String{Value: loc}
+			}
+			return &object.Array{Elements: locations}
+		case "args":
+			return inputValues(v.Args, includeDeprecated)
+		}
+	}
+	return object.NULL
+}
+
+func (s *Schema) namedType(name string) object.Object {
+	if t := s.Types[name]; t != nil {
+		return &meta{t}
+	}
+	return object.NULL
+}
+
+func (s *Schema) namedTypes(names []string) object.Object {
+	types := make([]object.Object, 0, len(names))
+	for _, name := range names {
+		types = append(types, s.namedType(name))
+	}
+	return &object.Array{Elements: types}
+}
+
+// typeRef presents a type reference: wrappers as themselves, named types as the *Type
+func (s *Schema) typeRef(t *TypeRef) object.Object {
+	if t.NonNull || t.OfType != nil {
+		return &meta{t}
+	}
+	return s.namedType(t.Name)
+}
+
+func inputValues(values []*InputValue, includeDeprecated bool) object.Object {
+	elements := make([]object.Object, 0, len(values))
+	for _, iv := range values {
+		if deprecated, _ := deprecation(iv.Directives); includeDeprecated || !deprecated {
+			elements = append(elements, &meta{iv})
+		}
+	}
+	return &object.Array{Elements: elements}
+}
+
+func deprecationField(field string, directives []*Directive) object.Object {
+	deprecated, reason := deprecation(directives)
+	if field == "isDeprecated" {
+		return nativeBool(deprecated)
+	}
+	if !deprecated {
+		return object.NULL
+	}
+	return &object.String{Value: reason}
+}
+
+func optional(s string) object.Object {
+	if s == "" {
+		return object.NULL
+	}
+	return &object.String{Value: s}
+}
@@ -0,0 +1,706 @@
+package graphql
+
+// parser is a recursive-descent parser for executable documents and SDL
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func newParser(src string) (*parser, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return syntaxError(p.tok.loc, "unexpected %s", p.tok.describe())
+}
+
+// peek reports whether the current token is the punctuator s
+func (p *parser) peek(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.value == s
+}
+
+// peekKeyword reports whether the current token is the name s
+func (p *parser) peekKeyword(s string) bool {
+	return p.tok.kind == tokName && p.tok.value == s
+}
+
+// skip consumes the punctuator s if it is next
+func (p *parser) skip(s string) (bool, error) {
+	if !p.peek(s) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(s string) error {
+	if !p.peek(s) {
+		return syntaxError(p.tok.loc, "expected %q, found %s", s, p.tok.describe())
+	}
+	return p.advance()
+}
+
+func (p *parser) expectKeyword(s string) error {
+	if !p.peekKeyword(s) {
+		return syntaxError(p.tok.loc, "expected %q, found %s", s, p.tok.describe())
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, Location, error) {
+	if p.tok.kind != tokName {
+		return "", p.tok.loc, syntaxError(p.tok.loc, "expected name, found %s", p.tok.describe())
+	}
+	name, loc := p.tok.value, p.tok.loc
+	return name, loc, p.advance()
+}
+
+// many parses open item+ close
+func (p *parser) many(open, close string, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if done, err := p.skip(close); err != nil || done {
+			return err
+		}
+	}
+}
+
+// ==================== Executable documents ====================
+
+// Parse parses a query document
+func Parse(src string) (*Document, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	if p.tok.kind == tokEOF {
+		return nil, syntaxError(p.tok.loc, "unexpected <EOF>")
+	}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek("{"):
+			op := &Operation{Kind: "query", Loc: p.tok.loc}
+			if op.Selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekKeyword("query"), p.peekKeyword("mutation"), p.peekKeyword("subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekKeyword("fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.Fragments[frag.Name]; dup {
+				return nil, newError(frag.Loc, "There can be only one fragment named %q.", frag.Name)
+			}
+			doc.Fragments[frag.Name] = frag
+			doc.fragOrder = append(doc.fragOrder, frag)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Kind: p.tok.value, Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.tok.kind == tokName {
+		if op.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		err = p.many("(", ")", func() error {
+			def := &VariableDefinition{Loc: p.tok.loc}
+			if err := p.expect("$"); err != nil {
+				return err
+			}
+			var err error
+			if def.Name, _, err = p.name(); err != nil {
+				return err
+			}
+			if err := p.expect(":"); err != nil {
+				return err
+			}
+			if def.Type, err = p.typeRef(); err != nil {
+				return err
+			}
+			if ok, err := p.skip("="); err != nil {
+				return err
+			} else if ok {
+				value, err := p.value(true)
+				if err != nil {
+					return err
+				}
+				def.Default = &value
+			}
+			_, err = p.directives(true)
+			op.Variables = append(op.Variables, def)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if op.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if op.Selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	frag := &Fragment{Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if frag.Name, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.Name == "on" {
+		return nil, syntaxError(frag.Loc, "unexpected name \"on\"")
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if frag.TypeCondition, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if frag.Selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	var selections []Selection
+	err := p.many("{", "}", func() error {
+		sel, err := p.selection()
+		selections = append(selections, sel)
+		return err
+	})
+	return selections, err
+}
+
+func (p *parser) selection() (Selection, error) {
+	loc := p.tok.loc
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokName && p.tok.value != "on" {
+			spread := &FragmentSpread{Loc: loc}
+			var err error
+			if spread.Name, _, err = p.name(); err != nil {
+				return nil, err
+			}
+			spread.Directives, err = p.directives(false)
+			return spread, err
+		}
+		inline := &InlineFragment{Loc: loc}
+		var err error
+		if p.peekKeyword("on") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if inline.TypeCondition, _, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if inline.Directives, err = p.directives(false); err != nil {
+			return nil, err
+		}
+		inline.Selections, err = p.selectionSet()
+		return inline, err
+	}
+
+	field := &Field{Loc: loc}
+	var err error
+	if field.Name, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = field.Name
+		if field.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		field.Selections, err = p.selectionSet()
+	}
+	return field, err
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+	var args []*Argument
+	err := p.many("(", ")", func() error {
+		arg := &Argument{Loc: p.tok.loc}
+		var err error
+		if arg.Name, _, err = p.name(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		arg.Value, err = p.value(constant)
+		args = append(args, arg)
+		return err
+	})
+	return args, err
+}
+
+func (p *parser) directives(constant bool) ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek("@") {
+		d := &Directive{Loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if d.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+		if d.Arguments, err = p.arguments(constant); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// value parses a literal; variables are not allowed in constant positions
+func (p *parser) value(constant bool) (Value, error) {
+	tok := p.tok
+	v := Value{Raw: tok.value, Loc: tok.loc}
+	switch tok.kind {
+	case tokInt:
+		v.Kind = IntValue
+	case tokFloat:
+		v.Kind = FloatValue
+	case tokString, tokBlockString:
+		v.Kind = StringValue
+	case tokName:
+		switch tok.value {
+		case "true", "false":
+			v.Kind = BooleanValue
+		case "null":
+			v.Kind = NullValue
+		default:
+			v.Kind = EnumValue
+		}
+	case tokPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return v, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			name, _, err := p.name()
+			return Value{Kind: VariableValue, Raw: name, Loc: tok.loc}, err
+		case "[":
+			v.Kind = ListValue
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			for !p.peek("]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return v, err
+				}
+				v.List = append(v.List, item)
+			}
+			return v, p.advance()
+		case "{":
+			v.Kind = ObjectValue
+			if err := p.advance(); err != nil {
+				return v, err
+			}
+			for !p.peek("}") {
+				name, _, err := p.name()
+				if err != nil {
+					return v, err
+				}
+				if err := p.expect(":"); err != nil {
+					return v, err
+				}
+				fv, err := p.value(constant)
+				if err != nil {
+					return v, err
+				}
+				v.Fields = append(v.Fields, &ObjectField{Name: name, Value: fv})
+			}
+			return v, p.advance()
+		default:
+			return v, p.unexpected()
+		}
+	default:
+		return v, p.unexpected()
+	}
+	return v, p.advance()
+}
+
+func (p *parser) typeRef() (*TypeRef, error) {
+	var t *TypeRef
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		inner, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		t = &TypeRef{OfType: inner}
+	} else {
+		name, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Name: name}
+	}
+	nonNull, err := p.skip("!")
+	t.NonNull = nonNull
+	return t, err
+}
+
+// ==================== SDL ====================
+
+// sdlDocument is the raw result of parsing a schema definition
+type sdlDocument struct {
+	types      []*Type
+	extensions []*Type
+	schema     []*schemaDefinition
+	directives []*DirectiveDef
+}
+
+type schemaDefinition struct {
+	operations map[string]string // "query" → type name
+	loc        Location
+}
+
+func parseSDL(src string) (*sdlDocument, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	doc := &sdlDocument{}
+	for p.tok.kind != tokEOF {
+		description, err := p.description()
+		if err != nil {
+			return nil, err
+		}
+		extend := false
+		if p.peekKeyword("extend") {
+			if description != "" {
+				return nil, p.unexpected()
+			}
+			extend = true
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if p.tok.kind != tokName {
+			return nil, p.unexpected()
+		}
+		switch p.tok.value {
+		case "schema":
+			def, err := p.schemaDefinition()
+			if err != nil {
+				return nil, err
+			}
+			doc.schema = append(doc.schema, def)
+		case "directive":
+			if extend {
+				return nil, p.unexpected()
+			}
+			def, err := p.directiveDefinition(description)
+			if err != nil {
+				return nil, err
+			}
+			doc.directives = append(doc.directives, def)
+		case "scalar", "type", "interface", "union", "enum", "input":
+			t, err := p.typeDefinition(extend)
+			if err != nil {
+				return nil, err
+			}
+			t.Description = description
+			if extend {
+				doc.extensions = append(doc.extensions, t)
+			} else {
+				doc.types = append(doc.types, t)
+			}
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) description() (string, error) {
+	if p.tok.kind != tokString && p.tok.kind != tokBlockString {
+		return "", nil
+	}
+	d := p.tok.value
+	return d, p.advance()
+}
+
+func (p *parser) schemaDefinition() (*schemaDefinition, error) {
+	def := &schemaDefinition{operations: make(map[string]string), loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(true); err != nil {
+		return nil, err
+	}
+	err := p.many("{", "}", func() error {
+		op, loc, err := p.name()
+		if err != nil {
+			return err
+		}
+		if op != "query" && op != "mutation" && op != "subscription" {
+			return syntaxError(loc, "unexpected name %q", op)
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		def.operations[op], _, err = p.name()
+		return err
+	})
+	return def, err
+}
+
+func (p *parser) directiveDefinition(description string) (*DirectiveDef, error) {
+	def := &DirectiveDef{Description: description}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("@"); err != nil {
+		return nil, err
+	}
+	var err error
+	if def.Name, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if def.Args, err = p.inputValueDefinitions("(", ")"); err != nil {
+		return nil, err
+	}
+	if p.peekKeyword("repeatable") {
+		def.Repeatable = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if _, err := p.skip("|"); err != nil {
+		return nil, err
+	}
+	for {
+		loc, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		def.Locations = append(def.Locations, loc)
+		if ok, err := p.skip("|"); err != nil || !ok {
+			return def, err
+		}
+	}
+}
+
+func (p *parser) typeDefinition(extend bool) (*Type, error) {
+	kinds := map[string]string{"scalar": KindScalar, "type": KindObject, "interface": KindInterface,
+		"union": KindUnion, "enum": KindEnum, "input": KindInputObject}
+	t := &Type{Kind: kinds[p.tok.value]}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if t.Name, t.Loc, err = p.name(); err != nil {
+		return nil, err
+	}
+
+	if (t.Kind == KindObject || t.Kind == KindInterface) && p.peekKeyword("implements") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if _, err := p.skip("&"); err != nil {
+			return nil, err
+		}
+		for {
+			name, _, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			t.Interfaces = append(t.Interfaces, name)
+			if ok, err := p.skip("&"); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+	}
+	if t.Directives, err = p.directives(true); err != nil {
+		return nil, err
+	}
+
+	switch t.Kind {
+	case KindObject, KindInterface:
+		if p.peek("{") {
+			err = p.many("{", "}", func() error {
+				f, err := p.fieldDefinition()
+				t.Fields = append(t.Fields, f)
+				return err
+			})
+		}
+	case KindUnion:
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if _, err := p.skip("|"); err != nil {
+				return nil, err
+			}
+			for {
+				name, _, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				t.PossibleTypes = append(t.PossibleTypes, name)
+				if ok, err := p.skip("|"); err != nil {
+					return nil, err
+				} else if !ok {
+					break
+				}
+			}
+		}
+	case KindEnum:
+		if p.peek("{") {
+			err = p.many("{", "}", func() error {
+				description, err := p.description()
+				if err != nil {
+					return err
+				}
+				ev := &EnumValueDef{Description: description}
+				if ev.Name, _, err = p.name(); err != nil {
+					return err
+				}
+				if ev.Name == "true" || ev.Name == "false" || ev.Name == "null" {
+					return syntaxError(p.tok.loc, "enum value cannot be %q", ev.Name)
+				}
+				ev.Directives, err = p.directives(true)
+				t.EnumValues = append(t.EnumValues, ev)
+				return err
+			})
+		}
+	case KindInputObject:
+		if p.peek("{") {
+			t.InputFields, err = p.inputValueDefinitions("{", "}")
+		}
+	}
+	return t, err
+}
+
+func (p *parser) fieldDefinition() (*FieldDef, error) {
+	description, err := p.description()
+	if err != nil {
+		return nil, err
+	}
+	f := &FieldDef{Description: description}
+	if f.Name, f.Loc, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.Args, err = p.inputValueDefinitions("(", ")"); err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	if f.Type, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+	f.Directives, err = p.directives(true)
+	return f, err
+}
+
+// inputValueDefinitions parses (arg: Type = default, ...) or { field: Type, ... } when present
+func (p *parser) inputValueDefinitions(open, close string) ([]*InputValue, error) {
+	if !p.peek(open) {
+		return nil, nil
+	}
+	var values []*InputValue
+	err := p.many(open, close, func() error {
+		description, err := p.description()
+		if err != nil {
+			return err
+		}
+		iv := &InputValue{Description: description}
+		if iv.Name, iv.Loc, err = p.name(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if iv.Type, err = p.typeRef(); err != nil {
+			return err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return err
+		} else if ok {
+			def, err := p.value(true)
+			if err != nil {
+				return err
+			}
+			iv.Default = &def
+		}
+		iv.Directives, err = p.directives(true)
+		values = append(values, iv)
+		return err
+	})
+	return values, err
+}
@@ -0,0 +1,501 @@
+package graphql
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"strings"
+)
+
+// Type kinds as reported by introspection
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+)
+
+// Type is a named type of the schema
+type Type struct {
+	Kind          string
+	Name          string
+	Description   string
+	Fields        []*FieldDef // objects and interfaces
+	Interfaces    []string    // implemented interfaces
+	PossibleTypes []string    // union members, or the object types implementing an interface
+	EnumValues    []*EnumValueDef
+	InputFields   []*InputValue
+	Directives    []*Directive
+	Loc           Location
+
+	fields     map[string]*FieldDef
+	enumValues map[string]*EnumValueDef
+	resolve    object.Object // __resolveType for interfaces and unions
+}
+
+// Field looks up a field of an object or interface type
+func (t *Type) Field(name string) *FieldDef {
+	return t.fields[name]
+}
+
+func (t *Type) isComposite() bool {
+	return t.Kind == KindObject || t.Kind == KindInterface || t.Kind == KindUnion
+}
+
+func (t *Type) isInput() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum || t.Kind == KindInputObject
+}
+
+// FieldDef is a field of an object or interface type
+type FieldDef struct {
+	Name        string
+	Description string
+	Args        []*InputValue
+	Type        *TypeRef
+	Directives  []*Directive
+	Loc         Location
+
+	resolve object.Object // BanglaCode resolver; nil uses the parent's property
+}
+
+func (f *FieldDef) arg(name string) *InputValue {
+	for _, a := range f.Args {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// InputValue is an argument or input object field
+type InputValue struct {
+	Name        string
+	Description string
+	Type        *TypeRef
+	Default     *Value
+	Directives  []*Directive
+	Loc         Location
+}
+
+// EnumValueDef is one value of an enum type
+type EnumValueDef struct {
+	Name        string
+	Description string
+	Directives  []*Directive
+}
+
+// DirectiveDef is a directive declaration (@skip, @deprecated or a schema's own)
+type DirectiveDef struct {
+	Name        string
+	Description string
+	Args        []*InputValue
+	Locations   []string
+	Repeatable  bool
+}
+
+// deprecation reads @deprecated(reason) from definition directives
+func deprecation(directives []*Directive) (bool, string) {
+	for _, d := range directives {
+		if d.Name == "deprecated" {
+			for _, a := range d.Arguments {
+				if a.Name == "reason" && a.Value.Kind == StringValue {
+					return true, a.Value.Raw
+				}
+			}
+			return true, "No longer supported"
+		}
+	}
+	return false, ""
+}
+
+// Schema is a parsed and validated schema with its resolvers
+type Schema struct {
+	Types        map[string]*Type
+	Directives   map[string]*DirectiveDef
+	Query        string
+	Mutation     string
+	Subscription string
+
+	// Call runs a resolver and waits for its result; Property reads a map key, accessors included
+	Call     func(fn object.Object, args []object.Object) object.Object
+	Property func(m *object.Map, key string) object.Object
+
+	MaxDepth      int  // deepest field nesting allowed in an operation; 0 is unlimited
+	MaxComplexity int  // highest field cost allowed in an operation; 0 is unlimited
+	Introspection bool // whether __schema and __type may be queried
+
+	typeOrder      []string
+	directiveOrder []string
+}
+
+// Type looks up a named type
+func (s *Schema) Type(name string) *Type {
+	return s.Types[name]
+}
+
+func (s *Schema) get(m *object.Map, key string) object.Object {
+	if s.Property != nil {
+		return s.Property(m, key)
+	}
+	if val, ok := m.Pairs[key]; ok {
+		return val
+	}
+	return object.NULL
+}
+
+const builtinSDL = `
+"The ` + "`Int`" + ` scalar type represents non-fractional signed whole numeric values between -(2^31) and 2^31 - 1."
+scalar Int
+"The ` + "`Float`" + ` scalar type represents signed double-precision fractional values."
+scalar Float
+"The ` + "`String`" + ` scalar type represents textual data as UTF-8 character sequences."
+scalar String
+"The ` + "`Boolean`" + ` scalar type represents ` + "`true`" + ` or ` + "`false`" + `."
+scalar Boolean
+"The ` + "`ID`" + ` scalar type represents a unique identifier, serialized as a String."
+scalar ID
+
+"Directs the executor to include this field or fragment only when the ` + "`if`" + ` argument is true."
+directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+"Directs the executor to skip this field or fragment when the ` + "`if`" + ` argument is true."
+directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+"Marks an element of a GraphQL schema as no longer supported."
+directive @deprecated(reason: String = "No longer supported") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+"Exposes a URL that specifies the behavior of this scalar."
+directive @specifiedBy(url: String!) on SCALAR
+
+type __Schema {
+  description: String
+  types: [__Type!]!
+  queryType: __Type!
+  mutationType: __Type
+  subscriptionType: __Type
+  directives: [__Directive!]!
+}
+type __Type {
+  kind: __TypeKind!
+  name: String
+  description: String
+  specifiedByURL: String
+  fields(includeDeprecated: Boolean = false): [__Field!]
+  interfaces: [__Type!]
+  possibleTypes: [__Type!]
+  enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
+  ofType: __Type
+  isOneOf: Boolean
+}
+type __Field {
+  name: String!
+  description: String
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  type: __Type!
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+type __InputValue {
+  name: String!
+  description: String
+  type: __Type!
+  defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+type __EnumValue {
+  name: String!
+  description: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+type __Directive {
+  name: String!
+  description: String
+  isRepeatable: Boolean!
+  locations: [__DirectiveLocation!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+}
+enum __TypeKind { SCALAR OBJECT INTERFACE UNION ENUM INPUT_OBJECT LIST NON_NULL }
+enum __DirectiveLocation {
+  QUERY MUTATION SUBSCRIPTION FIELD FRAGMENT_DEFINITION FRAGMENT_SPREAD INLINE_FRAGMENT
+  VARIABLE_DEFINITION SCHEMA SCALAR OBJECT FIELD_DEFINITION ARGUMENT_DEFINITION INTERFACE
+  UNION ENUM ENUM_VALUE INPUT_OBJECT INPUT_FIELD_DEFINITION
+}
+`
+
+// Meta-fields available on every type (__typename) and the query root (__schema, __type)
+var (
+	typenameField = &FieldDef{Name: "__typename", Type: &TypeRef{Name: "String", NonNull: true}}
+	schemaField   = &FieldDef{Name: "__schema", Type: &TypeRef{Name: "__Schema", NonNull: true}}
+	typeField     = &FieldDef{Name: "__type", Type: &TypeRef{Name: "__Type"},
+		Args: []*InputValue{{Name: "name", Type: &TypeRef{Name: "String", NonNull: true}}}}
+)
+
+// BuildSchema parses SDL and checks that the type system is consistent
+func BuildSchema(sdl string) (*Schema, error) {
+	builtins, err := parseSDL(builtinSDL)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseSDL(sdl)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{Types: make(map[string]*Type), Directives: make(map[string]*DirectiveDef), Introspection: true}
+	for _, t := range append(builtins.types, doc.types...) {
+		if _, dup := s.Types[t.Name]; dup {
+			return nil, newError(t.Loc, "There can be only one type named %q.", t.Name)
+		}
+		s.Types[t.Name] = t
+		s.typeOrder = append(s.typeOrder, t.Name)
+	}
+	for _, d := range append(builtins.directives, doc.directives...) {
+		if _, dup := s.Directives[d.Name]; dup {
+			return nil, fmt.Errorf("There can be only one directive named \"@%s\".", d.Name)
+		}
+		s.Directives[d.Name] = d
+		s.directiveOrder = append(s.directiveOrder, d.Name)
+	}
+	for _, ext := range doc.extensions {
+		t := s.Types[ext.Name]
+		if t == nil {
+			return nil, newError(ext.Loc, "Cannot extend type %q because it is not defined.", ext.Name)
+		}
+		if t.Kind != ext.Kind {
+			return nil, newError(ext.Loc, "Cannot extend non-%s type %q.", strings.ToLower(ext.Kind), ext.Name)
+		}
+		t.Fields = append(t.Fields, ext.Fields...)
+		t.Interfaces = append(t.Interfaces, ext.Interfaces...)
+		t.PossibleTypes = append(t.PossibleTypes, ext.PossibleTypes...)
+		t.EnumValues = append(t.EnumValues, ext.EnumValues...)
+		t.InputFields = append(t.InputFields, ext.InputFields...)
+		t.Directives = append(t.Directives, ext.Directives...)
+	}
+
+	s.Query, s.Mutation, s.Subscription = "Query", "Mutation", "Subscription"
+	if len(doc.schema) > 1 {
+		return nil, newError(doc.schema[1].loc, "Must provide only one schema definition.")
+	}
+	if len(doc.schema) == 1 {
+		ops := doc.schema[0].operations
+		s.Query, s.Mutation, s.Subscription = ops["query"], ops["mutation"], ops["subscription"]
+	}
+	for _, root := range []*string{&s.Query, &s.Mutation, &s.Subscription} {
+		if *root == "" {
+			continue
+		}
+		t := s.Types[*root]
+		if t == nil {
+			if len(doc.schema) == 1 || root == &s.Query {
+				return nil, fmt.Errorf("Root type %q is not defined.", *root)
+			}
+			*root = ""
+			continue
+		}
+		if t.Kind != KindObject {
+			return nil, newError(t.Loc, "Root type %q must be an object type.", *root)
+		}
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validate checks type references, field and argument types, interfaces and unions,
+// and fills the lookup maps and interface implementations
+func (s *Schema) validate() error {
+	// enum values first: default values anywhere may refer to them
+	for _, name := range s.typeOrder {
+		t := s.Types[name]
+		if t.Kind != KindEnum {
+			continue
+		}
+		if len(t.EnumValues) == 0 {
+			return newError(t.Loc, "Enum type %s must define one or more values.", name)
+		}
+		t.enumValues = make(map[string]*EnumValueDef, len(t.EnumValues))
+		for _, v := range t.EnumValues {
+			if _, dup := t.enumValues[v.Name]; dup {
+				return newError(t.Loc, "Enum value %s.%s can only be defined once.", name, v.Name)
+			}
+			t.enumValues[v.Name] = v
+		}
+	}
+
+	implementations := make(map[string][]string)
+	for _, name := range s.typeOrder {
+		t := s.Types[name]
+		if strings.HasPrefix(name, "__") && !isIntrospectionType(name) {
+			return newError(t.Loc, "Name %q must not begin with \"__\", which is reserved by GraphQL introspection.", name)
+		}
+		switch t.Kind {
+		case KindObject, KindInterface:
+			if len(t.Fields) == 0 {
+				return newError(t.Loc, "Type %s must define one or more fields.", name)
+			}
+			t.fields = make(map[string]*FieldDef, len(t.Fields))
+			for _, f := range t.Fields {
+				if _, dup := t.fields[f.Name]; dup {
+					return newError(f.Loc, "Field %q can only be defined once.", name+"."+f.Name)
+				}
+				t.fields[f.Name] = f
+				ft := s.Types[f.Type.NamedType()]
+				if ft == nil {
+					return newError(f.Loc, "Unknown type %q.", f.Type.NamedType())
+				}
+				if ft.Kind == KindInputObject {
+					return newError(f.Loc, "The type of %s.%s must be Output Type but got: %s.", name, f.Name, f.Type)
+				}
+				if err := s.validateInputValues(name+"."+f.Name, f.Args); err != nil {
+					return err
+				}
+			}
+			for _, iface := range t.Interfaces {
+				it := s.Types[iface]
+				if it == nil || it.Kind != KindInterface {
+					return newError(t.Loc, "Type %s must only implement Interface types, it cannot implement %s.", name, iface)
+				}
+				implementations[iface] = append(implementations[iface], name)
+			}
+		case KindUnion:
+			if len(t.PossibleTypes) == 0 {
+				return newError(t.Loc, "Union type %s must define one or more member types.", name)
+			}
+			for _, member := range t.PossibleTypes {
+				mt := s.Types[member]
+				if mt == nil || mt.Kind != KindObject {
+					return newError(t.Loc, "Union type %s can only include Object types, it cannot include %s.", name, member)
+				}
+			}
+		case KindInputObject:
+			if len(t.InputFields) == 0 {
+				return newError(t.Loc, "Input Object type %s must define one or more fields.", name)
+			}
+			if err := s.validateInputValues(name, t.InputFields); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Objects must provide every interface field with a compatible type and the same arguments
+	for _, name := range s.typeOrder {
+		t := s.Types[name]
+		for _, iface := range t.Interfaces {
+			for _, want := range s.Types[iface].Fields {
+				got := t.fields[want.Name]
+				if got == nil {
+					return newError(t.Loc, "Interface field %s.%s expected but %s does not provide it.", iface, want.Name, name)
+				}
+				if !s.isSubType(got.Type, want.Type) {
+					return newError(got.Loc, "Interface field %s.%s expects type %s but %s.%s is type %s.",
+						iface, want.Name, want.Type, name, got.Name, got.Type)
+				}
+				for _, arg := range want.Args {
+					if a := got.arg(arg.Name); a == nil || a.Type.String() != arg.Type.String() {
+						return newError(got.Loc, "Interface field argument %s.%s(%s:) expected but %s.%s does not provide it.",
+							iface, want.Name, arg.Name, name, got.Name)
+					}
+				}
+			}
+		}
+	}
+	for iface, types := range implementations {
+		s.Types[iface].PossibleTypes = types
+	}
+	return nil
+}
+
+func (s *Schema) validateInputValues(owner string, values []*InputValue) error {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if seen[v.Name] {
+			return newError(v.Loc, "Argument %s(%s:) can only be defined once.", owner, v.Name)
+		}
+		seen[v.Name] = true
+		t := s.Types[v.Type.NamedType()]
+		if t == nil {
+			return newError(v.Loc, "Unknown type %q.", v.Type.NamedType())
+		}
+		if !t.isInput() {
+			return newError(v.Loc, "The type of %s(%s:) must be Input Type but got: %s.", owner, v.Name, v.Type)
+		}
+		if v.Default != nil {
+			if _, err := s.coerceLiteral(*v.Default, v.Type, nil); err != nil {
+				return newError(v.Loc, "Invalid default value for %s(%s:): %s", owner, v.Name, err.Message)
+			}
+		}
+	}
+	return nil
+}
+
+// isSubType reports whether a value of type got can be used where want is expected
+// (covariant output types for interface fields)
+func (s *Schema) isSubType(got, want *TypeRef) bool {
+	if want.NonNull {
+		return got.NonNull && s.isSubType(got.nullable(), want.nullable())
+	}
+	if got.NonNull {
+		return s.isSubType(got.nullable(), want)
+	}
+	if want.OfType != nil || got.OfType != nil {
+		return want.OfType != nil && got.OfType != nil && s.isSubType(got.OfType, want.OfType)
+	}
+	return got.Name == want.Name || s.possible(want.Name, got.Name)
+}
+
+// possible reports whether the object type obj belongs to the abstract type abstract
+func (s *Schema) possible(abstract, obj string) bool {
+	t := s.Types[abstract]
+	if t == nil {
+		return false
+	}
+	if t.Kind == KindInterface {
+		if o := s.Types[obj]; o != nil {
+			for _, iface := range o.Interfaces {
+				if iface == abstract {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, member := range t.PossibleTypes {
+		if member == obj {
+			return true
+		}
+	}
+	return false
+}
+
+func isIntrospectionType(name string) bool {
+	switch name {
+	case "__Schema", "__Type", "__Field", "__InputValue", "__EnumValue", "__Directive", "__TypeKind", "__DirectiveLocation":
+		return true
+	}
+	return false
+}
+
+// SetResolver attaches a BanglaCode function to Type.field; "__resolveType" on an
+// interface or union picks the concrete type of a value
+func (s *Schema) SetResolver(typeName, fieldName string, fn object.Object) error {
+	t := s.Types[typeName]
+	if t == nil || isIntrospectionType(typeName) {
+		return fmt.Errorf("resolvers given for unknown type %q", typeName)
+	}
+	if fieldName == "__resolveType" {
+		if t.Kind != KindInterface && t.Kind != KindUnion {
+			return fmt.Errorf("__resolveType is only used on interfaces and unions, %s is %s", typeName, strings.ToLower(t.Kind))
+		}
+		t.resolve = fn
+		return nil
+	}
+	f := t.fields[fieldName]
+	if f == nil || t.Kind != KindObject {
+		return fmt.Errorf("resolver given for %s.%s, which is not a field of an object type", typeName, fieldName)
+	}
+	f.resolve = fn
+	return nil
+}
@@ -0,0 +1,529 @@
+package graphql
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// varUsage is a $variable found in an argument, with the type expected at that position
+type varUsage struct {
+	name       string
+	expected   *TypeRef
+	hasDefault bool // the argument or input field has a default of its own
+	loc        Location
+}
+
+// validator checks a document against the schema before anything is executed
+type validator struct {
+	s      *Schema
+	doc    *Document
+	errors []*Error
+
+	usages      []varUsage
+	spreads     []string
+	fragUsages  map[string][]varUsage
+	fragSpreads map[string][]string
+}
+
+func (v *validator) report(loc Location, format string, args ...interface{}) {
+	v.errors = append(v.errors, newError(loc, format, args...))
+}
+
+// validateDocument returns every rule the document breaks; an empty result means it can run
+func (s *Schema) validateDocument(doc *Document) []*Error {
+	v := &validator{s: s, doc: doc, fragUsages: make(map[string][]varUsage), fragSpreads: make(map[string][]string)}
+
+	names := make(map[string]bool)
+	for _, op := range doc.Operations {
+		if op.Name == "" && len(doc.Operations) > 1 {
+			v.report(op.Loc, "This anonymous operation must be the only defined operation.")
+		}
+		if op.Name != "" {
+			if names[op.Name] {
+				v.report(op.Loc, "There can be only one operation named \"%s\".", op.Name)
+			}
+			names[op.Name] = true
+		}
+	}
+
+	for _, frag := range doc.fragOrder {
+		v.usages, v.spreads = nil, nil
+		v.directives(frag.Directives, "FRAGMENT_DEFINITION")
+		t := s.Types[frag.TypeCondition]
+		switch {
+		case t == nil:
+			v.report(frag.Loc, "Unknown type \"%s\".", frag.TypeCondition)
+		case !t.isComposite():
+			v.report(frag.Loc, "Fragment \"%s\" cannot condition on non composite type \"%s\".", frag.Name, frag.TypeCondition)
+		default:
+			v.selections(t, frag.Selections)
+		}
+		v.fragUsages[frag.Name], v.fragSpreads[frag.Name] = v.usages, v.spreads
+	}
+	if v.cycles() {
+		return v.errors
+	}
+
+	used := make(map[string]bool)
+	for _, op := range doc.Operations {
+		v.usages, v.spreads = nil, nil
+		v.directives(op.Directives, strings.ToUpper(op.Kind))
+		root := v.rootType(op)
+		if root != nil {
+			v.selections(root, op.Selections)
+		}
+		// variable usages reachable through fragments
+		queue := v.spreads
+		reached := make(map[string]bool)
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			if reached[name] || doc.Fragments[name] == nil {
+				continue
+			}
+			reached[name], used[name] = true, true
+			v.usages = append(v.usages, v.fragUsages[name]...)
+			queue = append(queue, v.fragSpreads[name]...)
+		}
+		v.variables(op)
+	}
+	for _, frag := range doc.fragOrder {
+		if !used[frag.Name] {
+			v.report(frag.Loc, "Fragment \"%s\" is never used.", frag.Name)
+		}
+	}
+	return v.errors
+}
+
+func (v *validator) rootType(op *Operation) *Type {
+	switch op.Kind {
+	case "mutation":
+		if v.s.Mutation == "" {
+			v.report(op.Loc, "Schema is not configured for mutations.")
+			return nil
+		}
+		return v.s.Types[v.s.Mutation]
+	case "subscription":
+		v.report(op.Loc, "Subscriptions are not supported.")
+		return nil
+	}
+	return v.s.Types[v.s.Query]
+}
+
+// cycles reports fragments that spread themselves, directly or through others
+func (v *validator) cycles() bool {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	found := false
+	var visit func(name string, stack []string)
+	visit = func(name string, stack []string) {
+		state[name] = visiting
+		for _, next := range v.fragSpreads[name] {
+			switch state[next] {
+			case visiting:
+				via := ""
+				for i, frag := range stack {
+					if frag == next {
+						via = strings.Join(append(stack[i+1:len(stack):len(stack)], name), "\", \"")
+						break
+					}
+				}
+				if next == name || via == "" {
+					v.report(v.doc.Fragments[next].Loc, "Cannot spread fragment \"%s\" within itself.", next)
+				} else {
+					v.report(v.doc.Fragments[next].Loc, "Cannot spread fragment \"%s\" within itself via \"%s\".", next, via)
+				}
+				found = true
+			case 0:
+				if v.doc.Fragments[next] != nil {
+					visit(next, append(stack, name))
+				}
+			}
+		}
+		state[name] = done
+	}
+	for _, frag := range v.doc.fragOrder {
+		if state[frag.Name] == 0 {
+			visit(frag.Name, nil)
+		}
+	}
+	return found
+}
+
+// fieldDef finds a field including the __typename, __schema and __type meta-fields
+func (s *Schema) fieldDef(t *Type, name string) *FieldDef {
+	switch name {
+	case "__typename":
+		return typenameField
+	case "__schema":
+		if t.Name == s.Query {
+			return schemaField
+		}
+	case "__type":
+		if t.Name == s.Query {
+			return typeField
+		}
+	}
+	return t.Field(name)
+}
+
+func (v *validator) selections(parent *Type, sels []Selection) {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			v.field(parent, sel)
+		case *FragmentSpread:
+			v.directives(sel.Directives, "FRAGMENT_SPREAD")
+			frag := v.doc.Fragments[sel.Name]
+			if frag == nil {
+				v.report(sel.Loc, "Unknown fragment \"%s\".", sel.Name)
+				continue
+			}
+			v.spreads = append(v.spreads, sel.Name)
+			if t := v.s.Types[frag.TypeCondition]; t != nil && t.isComposite() && !v.s.overlap(parent, t) {
+				v.report(sel.Loc, "Fragment \"%s\" cannot be spread here as objects of type \"%s\" can never be of type \"%s\".",
+					sel.Name, parent.Name, t.Name)
+			}
+		case *InlineFragment:
+			v.directives(sel.Directives, "INLINE_FRAGMENT")
+			t := parent
+			if sel.TypeCondition != "" {
+				t = v.s.Types[sel.TypeCondition]
+				switch {
+				case t == nil:
+					v.report(sel.Loc, "Unknown type \"%s\".", sel.TypeCondition)
+					continue
+				case !t.isComposite():
+					v.report(sel.Loc, "Fragment cannot condition on non composite type \"%s\".", sel.TypeCondition)
+					continue
+				case !v.s.overlap(parent, t):
+					v.report(sel.Loc, "Fragment cannot be spread here as objects of type \"%s\" can never be of type \"%s\".",
+						parent.Name, t.Name)
+				}
+			}
+			v.selections(t, sel.Selections)
+		}
+	}
+}
+
+func (v *validator) field(parent *Type, f *Field) {
+	def := v.s.fieldDef(parent, f.Name)
+	if def == nil || (parent.Kind == KindUnion && f.Name != "__typename") {
+		v.report(f.Loc, "Cannot query field \"%s\" on type \"%s\".", f.Name, parent.Name)
+		return
+	}
+	if !v.s.Introspection && (f.Name == "__schema" || f.Name == "__type") {
+		v.report(f.Loc, "GraphQL introspection is not allowed, but the query contained \"%s\".", f.Name)
+		return
+	}
+	v.arguments(def.Args, f.Arguments, f.Loc, fmt.Sprintf("field \"%s.%s\"", parent.Name, f.Name))
+	v.directives(f.Directives, "FIELD")
+
+	t := v.s.Types[def.Type.NamedType()]
+	switch {
+	case !t.isComposite() && len(f.Selections) > 0:
+		v.report(f.Loc, "Field \"%s\" must not have a selection since type \"%s\" has no subfields.", f.Name, def.Type)
+	case t.isComposite() && len(f.Selections) == 0:
+		v.report(f.Loc, "Field \"%s\" of type \"%s\" must have a selection of subfields. Did you mean \"%s { ... }\"?",
+			f.Name, def.Type, f.Name)
+	case t.isComposite():
+		v.selections(t, f.Selections)
+	}
+}
+
+// arguments checks given arguments against their definitions; owner names the field or directive
+func (v *validator) arguments(defs []*InputValue, args []*Argument, loc Location, owner string) {
+	seen := make(map[string]bool, len(args))
+	for _, arg := range args {
+		if seen[arg.Name] {
+			v.report(arg.Loc, "There can be only one argument named \"%s\".", arg.Name)
+			continue
+		}
+		seen[arg.Name] = true
+		var def *InputValue
+		for _, d := range defs {
+			if d.Name == arg.Name {
+				def = d
+			}
+		}
+		if def == nil {
+			v.report(arg.Loc, "Unknown argument \"%s\" on %s.", arg.Name, owner)
+			continue
+		}
+		v.value(arg.Value, def.Type, def.Default != nil)
+	}
+	for _, def := range defs {
+		if def.Type.NonNull && def.Default == nil && !seen[def.Name] {
+			v.report(loc, "Argument \"%s\" of type \"%s\" is required on %s, but it was not provided.", def.Name, def.Type, owner)
+		}
+	}
+}
+
+// value checks a literal and records the variables it uses
+func (v *validator) value(val Value, t *TypeRef, hasDefault bool) {
+	v.collect(val, t, hasDefault)
+	if _, err := v.s.coerceLiteral(val, t, nil); err != nil {
+		v.errors = append(v.errors, err)
+	}
+}
+
+func (v *validator) collect(val Value, t *TypeRef, hasDefault bool) {
+	switch val.Kind {
+	case VariableValue:
+		v.usages = append(v.usages, varUsage{name: val.Raw, expected: t, hasDefault: hasDefault, loc: val.Loc})
+	case ListValue:
+		item := t.nullable()
+		if item.OfType != nil {
+			item = item.OfType
+		}
+		for _, el := range val.List {
+			v.collect(el, item, false)
+		}
+	case ObjectValue:
+		named := v.s.Types[t.NamedType()]
+		if named == nil || named.Kind != KindInputObject || t.nullable().OfType != nil {
+			return
+		}
+		for _, f := range val.Fields {
+			for _, def := range named.InputFields {
+				if def.Name == f.Name {
+					v.collect(f.Value, def.Type, def.Default != nil)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) directives(directives []*Directive, location string) {
+	seen := make(map[string]bool, len(directives))
+	for _, d := range directives {
+		def := v.s.Directives[d.Name]
+		if def == nil {
+			v.report(d.Loc, "Unknown directive \"@%s\".", d.Name)
+			continue
+		}
+		allowed := false
+		for _, loc := range def.Locations {
+			allowed = allowed || loc == location
+		}
+		if !allowed {
+			v.report(d.Loc, "Directive \"@%s\" may not be used on %s.", d.Name, location)
+			continue
+		}
+		if seen[d.Name] && !def.Repeatable {
+			v.report(d.Loc, "The directive \"@%s\" can only be used once at this location.", d.Name)
+		}
+		seen[d.Name] = true
+		v.arguments(def.Args, d.Arguments, d.Loc, "directive \"@"+d.Name+"\"")
+	}
+}
+
+// variables checks the operation's variable definitions against their usages
+func (v *validator) variables(op *Operation) {
+	defs := make(map[string]*VariableDefinition, len(op.Variables))
+	for _, def := range op.Variables {
+		if defs[def.Name] != nil {
+			v.report(def.Loc, "There can be only one variable named \"$%s\".", def.Name)
+			continue
+		}
+		defs[def.Name] = def
+		t := v.s.Types[def.Type.NamedType()]
+		if t == nil {
+			v.report(def.Loc, "Unknown type \"%s\".", def.Type.NamedType())
+			continue
+		}
+		if !t.isInput() {
+			v.report(def.Loc, "Variable \"$%s\" cannot be non-input type \"%s\".", def.Name, def.Type)
+			continue
+		}
+		if def.Default != nil {
+			if _, err := v.s.coerceLiteral(*def.Default, def.Type, nil); err != nil {
+				v.errors = append(v.errors, err)
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, u := range v.usages {
+		used[u.name] = true
+		def := defs[u.name]
+		if def == nil {
+			if op.Name != "" {
+				v.report(u.loc, "Variable \"$%s\" is not defined by operation \"%s\".", u.name, op.Name)
+			} else {
+				v.report(u.loc, "Variable \"$%s\" is not defined.", u.name)
+			}
+			continue
+		}
+		if v.s.Types[def.Type.NamedType()] == nil {
+			continue
+		}
+		varType := def.Type
+		if u.expected.NonNull && !varType.NonNull {
+			if (def.Default == nil || def.Default.Kind == NullValue) && !u.hasDefault {
+				v.report(u.loc, "Variable \"$%s\" of type \"%s\" used in position expecting type \"%s\".", u.name, def.Type, u.expected)
+				continue
+			}
+			varType = &TypeRef{Name: varType.Name, OfType: varType.OfType, NonNull: true}
+		}
+		if !v.s.isSubType(varType, u.expected) {
+			v.report(u.loc, "Variable \"$%s\" of type \"%s\" used in position expecting type \"%s\".", u.name, def.Type, u.expected)
+		}
+	}
+	for _, def := range op.Variables {
+		if !used[def.Name] {
+			if op.Name != "" {
+				v.report(def.Loc, "Variable \"$%s\" is never used in operation \"%s\".", def.Name, op.Name)
+			} else {
+				v.report(def.Loc, "Variable \"$%s\" is never used.", def.Name)
+			}
+		}
+	}
+}
+
+// overlap reports whether some object type can be both a and b
+func (s *Schema) overlap(a, b *Type) bool {
+	if a.Name == b.Name {
+		return true
+	}
+	for _, x := range s.objectTypes(a) {
+		for _, y := range s.objectTypes(b) {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Schema) objectTypes(t *Type) []string {
+	if t.Kind == KindObject {
+		return []string{t.Name}
+	}
+	return t.PossibleTypes
+}
+
+// ==================== Limits ====================
+
+// checkLimits rejects operations nested deeper or costing more than the schema allows.
+// Introspection fields are not counted.
+func (s *Schema) checkLimits(doc *Document, op *Operation, vars map[string]object.Object) *Error {
+	w := &limitWalker{s: s, doc: doc, vars: vars, depths: map[string]int{}, costs: map[string]int{}}
+	if s.MaxDepth > 0 {
+		if depth := w.depth(op.Selections, map[string]bool{}); depth > s.MaxDepth {
+			return newError(op.Loc, "Query depth %d exceeds the maximum allowed depth of %d.", depth, s.MaxDepth)
+		}
+	}
+	if s.MaxComplexity > 0 {
+		if cost := w.complexity(op.Selections, map[string]bool{}); cost > s.MaxComplexity {
+			return newError(op.Loc, "Query complexity %d exceeds the maximum allowed complexity of %d.", cost, s.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// limitWalker measures an operation against the schema's limits. Each fragment is measured
+// once per document, and a fragment spread twice in one selection set counts once, as it
+// does when the fields are collected. Walks stop as soon as a limit is exceeded, so a
+// result over the limit is what was measured up to that point.
+type limitWalker struct {
+	s      *Schema
+	doc    *Document
+	vars   map[string]object.Object
+	depths map[string]int // fragment name → depth of its selections
+	costs  map[string]int // fragment name → complexity of its selections
+}
+
+func (w *limitWalker) depth(sels []Selection, visited map[string]bool) int {
+	deepest := 0
+	for _, sel := range sels {
+		d := 0
+		switch sel := sel.(type) {
+		case *Field:
+			if !strings.HasPrefix(sel.Name, "__") {
+				d = 1 + w.depth(sel.Selections, map[string]bool{})
+			}
+		case *FragmentSpread:
+			if visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			d = w.fragmentDepth(sel.Name)
+		case *InlineFragment:
+			d = w.depth(sel.Selections, visited)
+		}
+		if d > deepest {
+			deepest = d
+		}
+		if deepest > w.s.MaxDepth {
+			break
+		}
+	}
+	return deepest
+}
+
+func (w *limitWalker) fragmentDepth(name string) int {
+	if d, ok := w.depths[name]; ok {
+		return d
+	}
+	d := w.depth(w.doc.Fragments[name].Selections, map[string]bool{name: true})
+	w.depths[name] = d
+	return d
+}
+
+// maxCost caps measured complexity so huge first/last/limit arguments cannot overflow
+const maxCost = math.MaxInt32
+
+// complexity costs one per field, with the cost of a list field's children multiplied by
+// its first, last or limit argument
+func (w *limitWalker) complexity(sels []Selection, visited map[string]bool) int {
+	total := 0
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			multiplier := 1
+			for _, arg := range sel.Arguments {
+				if arg.Name != "first" && arg.Name != "last" && arg.Name != "limit" {
+					continue
+				}
+				if n, ok := literalObject(arg.Value, w.vars).(*object.Number); ok && n.Value > 1 {
+					multiplier = int(math.Min(n.Value, maxCost))
+				}
+			}
+			children := w.complexity(sel.Selections, map[string]bool{})
+			if children > 0 && multiplier > (maxCost-1)/children {
+				return maxCost
+			}
+			total += 1 + multiplier*children
+		case *FragmentSpread:
+			if visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			total += w.fragmentComplexity(sel.Name)
+		case *InlineFragment:
+			total += w.complexity(sel.Selections, visited)
+		}
+		if total > w.s.MaxComplexity {
+			return min(total, maxCost)
+		}
+	}
+	return total
+}
+
+func (w *limitWalker) fragmentComplexity(name string) int {
+	if cost, ok := w.costs[name]; ok {
+		return cost
+	}
+	cost := w.complexity(w.doc.Fragments[name].Selections, map[string]bool{name: true})
+	w.costs[name] = cost
+	return cost
+}
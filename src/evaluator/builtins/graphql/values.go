@@ -0,0 +1,397 @@
+package graphql
+
+import (
+	"BanglaCode/src/object"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// coerceLiteral converts a literal to the BanglaCode value for type t. Variables are read
+// from vars; with vars == nil (validation) they are accepted as they are. A nil result
+// without an error means the value is absent (an unset variable).
+func (s *Schema) coerceLiteral(v Value, t *TypeRef, vars map[string]object.Object) (object.Object, *Error) {
+	if v.Kind == VariableValue {
+		if vars == nil {
+			return nil, nil
+		}
+		return vars[v.Raw], nil
+	}
+	if v.Kind == NullValue {
+		if t.NonNull {
+			return nil, newError(v.Loc, "Expected value of type \"%s\", found null.", t)
+		}
+		return object.NULL, nil
+	}
+	t = t.nullable()
+
+	if t.OfType != nil {
+		if v.Kind != ListValue {
+			item, err := s.coerceLiteral(v, t.OfType, vars)
+			if err != nil || item == nil {
+				return item, err
+			}
+			return &object.Array{Elements: []object.Object{item}}, nil
+		}
+		elements := make([]object.Object, len(v.List))
+		for i, lit := range v.List {
+			item, err := s.coerceLiteral(lit, t.OfType, vars)
+			if err != nil {
+				return nil, err
+			}
+			if item == nil {
+				if t.OfType.NonNull && vars != nil {
+					return nil, newError(lit.Loc, "Expected value of type \"%s\", found null.", t.OfType)
+				}
+				item = object.NULL
+			}
+			elements[i] = item
+		}
+		return &object.Array{Elements: elements}, nil
+	}
+
+	named := s.Types[t.Name]
+	switch named.Kind {
+	case KindScalar:
+		if result, ok := literalScalar(named.Name, v, vars); ok {
+			return result, nil
+		}
+	case KindEnum:
+		if v.Kind == EnumValue && named.enumValues[v.Raw] != nil {
+			return &object.String{Value: v.Raw}, nil
+		}
+	case KindInputObject:
+		if v.Kind == ObjectValue {
+			return s.coerceInputLiteral(named, v, vars)
+		}
+	}
+	return nil, newError(v.Loc, "Expected value of type \"%s\", found %s.", t, v)
+}
+
+func (s *Schema) coerceInputLiteral(t *Type, v Value, vars map[string]object.Object) (object.Object, *Error) {
+	given := make(map[string]*ObjectField, len(v.Fields))
+	for _, f := range v.Fields {
+		if _, dup := given[f.Name]; dup {
+			return nil, newError(f.Value.Loc, "There can be only one input field named \"%s\".", f.Name)
+		}
+		given[f.Name] = f
+	}
+	result := &object.Map{Pairs: make(map[string]object.Object, len(t.InputFields))}
+	for _, def := range t.InputFields {
+		var value object.Object
+		if f, ok := given[def.Name]; ok {
+			delete(given, def.Name)
+			var err *Error
+			if value, err = s.coerceLiteral(f.Value, def.Type, vars); err != nil {
+				return nil, err
+			}
+			if value == nil && vars == nil {
+				continue // variable checked during validation
+			}
+		}
+		if value == nil && def.Default != nil {
+			value, _ = s.coerceLiteral(*def.Default, def.Type, nil)
+		}
+		if value == nil {
+			if def.Type.NonNull {
+				return nil, newError(v.Loc, "Field \"%s.%s\" of required type \"%s\" was not provided.", t.Name, def.Name, def.Type)
+			}
+			continue
+		}
+		result.Pairs[def.Name] = value
+	}
+	for _, f := range v.Fields {
+		if _, unknown := given[f.Name]; unknown {
+			return nil, newError(f.Value.Loc, "Field \"%s\" is not defined by type \"%s\".", f.Name, t.Name)
+		}
+	}
+	return result, nil
+}
+
+// literalScalar parses a literal for a built-in or custom scalar
+func literalScalar(name string, v Value, vars map[string]object.Object) (object.Object, bool) {
+	switch name {
+	case "Int":
+		if v.Kind == IntValue {
+			if n, err := strconv.ParseInt(v.Raw, 10, 32); err == nil {
+				return &object.Number{Value: float64(n)}, true
+			}
+		}
+	case "Float":
+		if v.Kind == IntValue || v.Kind == FloatValue {
+			if f, err := strconv.ParseFloat(v.Raw, 64); err == nil {
+				return &object.Number{Value: f}, true
+			}
+		}
+	case "String":
+		if v.Kind == StringValue {
+			return &object.String{Value: v.Raw}, true
+		}
+	case "Boolean":
+		if v.Kind == BooleanValue {
+			return nativeBool(v.Raw == "true"), true
+		}
+	case "ID":
+		if v.Kind == StringValue || v.Kind == IntValue {
+			return &object.String{Value: v.Raw}, true
+		}
+	default:
+		return literalObject(v, vars), true
+	}
+	return nil, false
+}
+
+// literalObject converts any literal to a value, for custom scalars
+func literalObject(v Value, vars map[string]object.Object) object.Object {
+	switch v.Kind {
+	case VariableValue:
+		if val, ok := vars[v.Raw]; ok {
+			return val
+		}
+		return object.NULL
+	case IntValue, FloatValue:
+		f, _ := strconv.ParseFloat(v.Raw, 64)
+		return &object.Number{Value: f}
+	case BooleanValue:
+		return nativeBool(v.Raw == "true")
+	case NullValue:
+		return object.NULL
+	case ListValue:
+		elements := make([]object.Object, len(v.List))
+		for i, item := range v.List {
+			elements[i] = literalObject(item, vars)
+		}
+		return &object.Array{Elements: elements}
+	case ObjectValue:
+		m := &object.Map{Pairs: make(map[string]object.Object, len(v.Fields))}
+		for _, f := range v.Fields {
+			m.Pairs[f.Name] = literalObject(f.Value, vars)
+		}
+		return m
+	}
+	return &object.String{Value: v.Raw}
+}
+
+// coerceVariable checks a variable value supplied with the request against type t
+func (s *Schema) coerceVariable(val object.Object, t *TypeRef) (object.Object, string) {
+	if val == nil || val == object.NULL {
+		if t.NonNull {
+			return nil, fmt.Sprintf("Expected non-nullable type \"%s\" not to be null.", t)
+		}
+		return object.NULL, ""
+	}
+	t = t.nullable()
+
+	if t.OfType != nil {
+		arr, ok := val.(*object.Array)
+		if !ok {
+			item, msg := s.coerceVariable(val, t.OfType)
+			if msg != "" {
+				return nil, msg
+			}
+			return &object.Array{Elements: []object.Object{item}}, ""
+		}
+		elements := make([]object.Object, len(arr.Elements))
+		for i, el := range arr.Elements {
+			item, msg := s.coerceVariable(el, t.OfType)
+			if msg != "" {
+				return nil, fmt.Sprintf("At index %d: %s", i, msg)
+			}
+			elements[i] = item
+		}
+		return &object.Array{Elements: elements}, ""
+	}
+
+	named := s.Types[t.Name]
+	switch named.Kind {
+	case KindScalar:
+		return variableScalar(named.Name, val)
+	case KindEnum:
+		if str, ok := val.(*object.String); ok && named.enumValues[str.Value] != nil {
+			return str, ""
+		}
+		return nil, fmt.Sprintf("Value %s does not exist in \"%s\" enum.", s.describe(val), named.Name)
+	}
+
+	m, ok := val.(*object.Map)
+	if !ok {
+		return nil, fmt.Sprintf("Expected type \"%s\" to be an object.", named.Name)
+	}
+	result := &object.Map{Pairs: make(map[string]object.Object, len(named.InputFields))}
+	known := make(map[string]bool, len(named.InputFields))
+	for _, def := range named.InputFields {
+		known[def.Name] = true
+		if _, given := m.Pairs[def.Name]; !given {
+			if def.Default != nil {
+				result.Pairs[def.Name], _ = s.coerceLiteral(*def.Default, def.Type, nil)
+			} else if def.Type.NonNull {
+				return nil, fmt.Sprintf("Field \"%s\" of required type \"%s\" was not provided.", def.Name, def.Type)
+			}
+			continue
+		}
+		value, msg := s.coerceVariable(s.get(m, def.Name), def.Type)
+		if msg != "" {
+			return nil, fmt.Sprintf("At \"%s\": %s", def.Name, msg)
+		}
+		result.Pairs[def.Name] = value
+	}
+	for _, key := range sortedKeys(m) {
+		if !known[key] {
+			return nil, fmt.Sprintf("Field \"%s\" is not defined by type \"%s\".", key, named.Name)
+		}
+	}
+	return result, ""
+}
+
+func variableScalar(name string, val object.Object) (object.Object, string) {
+	switch name {
+	case "Int":
+		if n, ok := val.(*object.Number); ok && isInt32(n.Value) {
+			return n, ""
+		}
+		return nil, fmt.Sprintf("Int cannot represent non-integer value: %s", val.Inspect())
+	case "Float":
+		if n, ok := val.(*object.Number); ok {
+			return n, ""
+		}
+		return nil, fmt.Sprintf("Float cannot represent non numeric value: %s", val.Inspect())
+	case "String":
+		if str, ok := val.(*object.String); ok {
+			return str, ""
+		}
+		return nil, fmt.Sprintf("String cannot represent a non string value: %s", val.Inspect())
+	case "Boolean":
+		if b, ok := val.(*object.Boolean); ok {
+			return b, ""
+		}
+		return nil, fmt.Sprintf("Boolean cannot represent a non boolean value: %s", val.Inspect())
+	case "ID":
+		switch v := val.(type) {
+		case *object.String:
+			return v, ""
+		case *object.Number:
+			if v.Value == math.Trunc(v.Value) {
+				return &object.String{Value: strconv.FormatFloat(v.Value, 'f', -1, 64)}, ""
+			}
+		}
+		return nil, fmt.Sprintf("ID cannot represent value: %s", val.Inspect())
+	}
+	return val, ""
+}
+
+// serialize converts a resolved leaf value to its JSON form
+func (s *Schema) serialize(t *Type, val object.Object) (interface{}, string) {
+	if t.Kind == KindEnum {
+		if str, ok := val.(*object.String); ok && t.enumValues[str.Value] != nil {
+			return str.Value, ""
+		}
+		return nil, fmt.Sprintf("Enum \"%s\" cannot represent value: %s", t.Name, s.describe(val))
+	}
+	switch t.Name {
+	case "Int":
+		switch v := val.(type) {
+		case *object.Number:
+			if isInt32(v.Value) {
+				return int64(v.Value), ""
+			}
+		case *object.Boolean:
+			if v.Value {
+				return int64(1), ""
+			}
+			return int64(0), ""
+		}
+		return nil, fmt.Sprintf("Int cannot represent non-integer value: %s", s.describe(val))
+	case "Float":
+		switch v := val.(type) {
+		case *object.Number:
+			return v.Value, ""
+		case *object.Boolean:
+			if v.Value {
+				return 1.0, ""
+			}
+			return 0.0, ""
+		}
+		return nil, fmt.Sprintf("Float cannot represent non numeric value: %s", s.describe(val))
+	case "String", "ID":
+		switch v := val.(type) {
+		case *object.String:
+			return v.Value, ""
+		case *object.Number:
+			if t.Name == "String" || v.Value == math.Trunc(v.Value) {
+				return strconv.FormatFloat(v.Value, 'f', -1, 64), ""
+			}
+		case *object.Boolean:
+			if t.Name == "String" {
+				return strconv.FormatBool(v.Value), ""
+			}
+		}
+		return nil, fmt.Sprintf("%s cannot represent value: %s", t.Name, s.describe(val))
+	case "Boolean":
+		switch v := val.(type) {
+		case *object.Boolean:
+			return v.Value, ""
+		case *object.Number:
+			return v.Value != 0, ""
+		}
+		return nil, fmt.Sprintf("Boolean cannot represent a non boolean value: %s", s.describe(val))
+	}
+	return s.plain(val), ""
+}
+
+// plain converts a value to Go data for JSON, as custom scalars are returned
+func (s *Schema) plain(val object.Object) interface{} {
+	switch v := val.(type) {
+	case *object.Null:
+		return nil
+	case *object.Boolean:
+		return v.Value
+	case *object.Number:
+		return v.Value
+	case *object.String:
+		return v.Value
+	case *object.Array:
+		arr := make([]interface{}, len(v.Elements))
+		for i, el := range v.Elements {
+			arr[i] = s.plain(el)
+		}
+		return arr
+	case *object.Map:
+		m := make(map[string]interface{}, len(v.Pairs))
+		for _, key := range v.EnumerableKeys() {
+			m[key] = s.plain(s.get(v, key))
+		}
+		return m
+	}
+	return val.Inspect()
+}
+
+// describe prints a value as JSON for error messages
+func (s *Schema) describe(val object.Object) string {
+	data, err := json.Marshal(s.plain(val))
+	if err != nil {
+		return val.Inspect()
+	}
+	return string(data)
+}
+
+func isInt32(f float64) bool {
+	return f == math.Trunc(f) && f >= math.MinInt32 && f <= math.MaxInt32
+}
+
+func nativeBool(b bool) object.Object {
+	if b {
+		return object.TRUE
+	}
+	return object.FALSE
+}
+
+func sortedKeys(m *object.Map) []string {
+	keys := make([]string, 0, len(m.Pairs))
+	for key := range m.Pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
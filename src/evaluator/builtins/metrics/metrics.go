@@ -0,0 +1,291 @@
+// Package metrics keeps counters, gauges and histograms and writes them in the
+// Prometheus text exposition format (version 0.0.4).
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Kind is the metric type reported in the # TYPE line
+type Kind string
+
+const (
+	Counter   Kind = "counter"
+	Gauge     Kind = "gauge"
+	Histogram Kind = "histogram"
+)
+
+// DefaultBuckets are the histogram upper bounds used when none are given (seconds)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var (
+	metricNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRe  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Family is a named metric with one series per combination of label values
+type Family struct {
+	Name       string
+	Help       string
+	Kind       Kind
+	LabelNames []string
+	Buckets    []float64 // histogram upper bounds, ascending, without +Inf
+
+	mu     sync.Mutex
+	series map[string]*series // joined label values → series
+}
+
+type series struct {
+	labels []string
+	value  float64  // counter and gauge value
+	counts []uint64 // histogram observations per bucket (not cumulative)
+	sum    float64
+	count  uint64
+}
+
+// Registry holds metric families by name
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]*Family
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*Family)}
+}
+
+// Register adds a metric family. Registering a name again with the same kind and labels
+// returns the existing family, so a script can declare its metrics more than once.
+func (r *Registry) Register(name, help string, kind Kind, labels []string, buckets []float64) (*Family, error) {
+	if !metricNameRe.MatchString(name) {
+		return nil, fmt.Errorf("invalid metric name %q", name)
+	}
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if !labelNameRe.MatchString(label) || strings.HasPrefix(label, "__") {
+			return nil, fmt.Errorf("invalid label name %q", label)
+		}
+		if kind == Histogram && label == "le" {
+			return nil, fmt.Errorf("histogram %s cannot use the label \"le\"", name)
+		}
+		if seen[label] {
+			return nil, fmt.Errorf("duplicate label %q", label)
+		}
+		seen[label] = true
+	}
+	if kind == Histogram {
+		if buckets == nil {
+			buckets = DefaultBuckets
+		}
+		if len(buckets) == 0 {
+			return nil, fmt.Errorf("histogram %s needs at least one bucket", name)
+		}
+		for i := 1; i < len(buckets); i++ {
+			if buckets[i] <= buckets[i-1] {
+				return nil, fmt.Errorf("histogram %s buckets must be in increasing order", name)
+			}
+		}
+		if math.IsInf(buckets[len(buckets)-1], 1) {
+			buckets = buckets[:len(buckets)-1] // +Inf is always added
+		}
+	} else {
+		buckets = nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.families[name]; ok {
+		if existing.Kind != kind || strings.Join(existing.LabelNames, ",") != strings.Join(labels, ",") {
+			return nil, fmt.Errorf("metric %s is already registered as a %s with labels [%s]",
+				name, existing.Kind, strings.Join(existing.LabelNames, ", "))
+		}
+		return existing, nil
+	}
+	f := &Family{
+		Name: name, Help: help, Kind: kind,
+		LabelNames: append([]string(nil), labels...),
+		Buckets:    append([]float64(nil), buckets...),
+		series:     make(map[string]*series),
+	}
+	r.families[name] = f
+	return f, nil
+}
+
+// get returns the series for labels, creating it on first use. Call with f.mu held.
+func (f *Family) get(labels []string) (*series, error) {
+	if len(labels) != len(f.LabelNames) {
+		return nil, fmt.Errorf("%s wants %d label values, got %d", f.Name, len(f.LabelNames), len(labels))
+	}
+	key := strings.Join(labels, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: append([]string(nil), labels...)}
+		if f.Kind == Histogram {
+			s.counts = make([]uint64, len(f.Buckets))
+		}
+		f.series[key] = s
+	}
+	return s, nil
+}
+
+// Add increases a counter or changes a gauge by delta; counters only go up
+func (f *Family) Add(labels []string, delta float64) error {
+	switch {
+	case f.Kind == Histogram:
+		return fmt.Errorf("%s is a histogram; observe values instead", f.Name)
+	case f.Kind == Counter && delta < 0:
+		return fmt.Errorf("counter %s cannot decrease", f.Name)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.get(labels)
+	if err != nil {
+		return err
+	}
+	s.value += delta
+	return nil
+}
+
+// Set replaces the value of a gauge
+func (f *Family) Set(labels []string, value float64) error {
+	if f.Kind != Gauge {
+		return fmt.Errorf("%s is a %s; only gauges can be set", f.Name, f.Kind)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.get(labels)
+	if err != nil {
+		return err
+	}
+	s.value = value
+	return nil
+}
+
+// Observe records one value in a histogram
+func (f *Family) Observe(labels []string, value float64) error {
+	if f.Kind != Histogram {
+		return fmt.Errorf("%s is a %s; only histograms observe values", f.Name, f.Kind)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.get(labels)
+	if err != nil {
+		return err
+	}
+	if i := sort.SearchFloat64s(f.Buckets, value); i < len(f.Buckets) {
+		s.counts[i]++
+	}
+	s.sum += value
+	s.count++
+	return nil
+}
+
+// Value returns a counter or gauge value, or a histogram's observation count
+func (f *Family) Value(labels []string) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(labels) != len(f.LabelNames) {
+		return 0, fmt.Errorf("%s wants %d label values, got %d", f.Name, len(f.LabelNames), len(labels))
+	}
+	s, ok := f.series[strings.Join(labels, "\xff")]
+	switch {
+	case !ok:
+		return 0, nil
+	case f.Kind == Histogram:
+		return float64(s.count), nil
+	}
+	return s.value, nil
+}
+
+// WriteText writes every family in the Prometheus text format, sorted by name and labels
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	families := make([]*Family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.RUnlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].Name < families[j].Name })
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.writeText(bw)
+	}
+	return bw.Flush()
+}
+
+func (f *Family) writeText(w *bufio.Writer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.Name, f.Kind)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 && len(f.LabelNames) == 0 && f.Kind != Histogram {
+		fmt.Fprintf(w, "%s 0\n", f.Name) // an unlabelled counter or gauge starts at zero
+	}
+	for _, key := range keys {
+		s := f.series[key]
+		if f.Kind != Histogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.Name, f.labelText(s.labels, ""), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, upper := range f.Buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.Name, f.labelText(s.labels, formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.Name, f.labelText(s.labels, "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.Name, f.labelText(s.labels, ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.Name, f.labelText(s.labels, ""), s.count)
+	}
+}
+
+// labelText renders {name="value",...}, adding le for histogram buckets
+func (f *Family) labelText(values []string, le string) string {
+	if len(values) == 0 && le == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(values)+1)
+	for i, v := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", f.LabelNames[i], escapeLabel(v)))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf("le=\"%s\"", le))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -4,6 +4,7 @@ import (
 	"BanglaCode/src/ast"
 	"BanglaCode/src/object"
 	"fmt"
+	"io"
 )
 
 var (
@@ -110,17 +111,29 @@ func streamPoro(args ...object.Object) object.Object {
 	}
 
 	// Optional: read size
-	readSize := len(stream.Buffer) // Read all by default
+	requested := 0
 	if len(args) > 1 {
 		if num, ok := args[1].(*object.Number); ok {
-			readSize = int(num.Value)
-			if readSize > len(stream.Buffer) {
-				readSize = len(stream.Buffer)
-			}
+			requested = int(num.Value)
 		}
 	}
 
+	// Streams backed by a reader (e.g. an HTTP request body) pull the next chunk on demand
+	if len(stream.Buffer) == 0 && stream.Source != nil && !stream.IsEnded {
+		if err := pullFromSource(stream, requested); err != nil {
+			return &object.Error{Message: fmt.Sprintf("stream_poro() read error: %s", err.Error())}
+		}
+	}
+
+	readSize := len(stream.Buffer) // Read all by default
+	if requested > 0 && requested < readSize {
+		readSize = requested
+	}
+
 	if readSize == 0 {
+		if stream.IsEnded {
+			stream.IsClosed = true
+		}
 		return object.NULL
 	}
 
@@ -176,8 +189,14 @@ func streamLekho(args ...object.Object) object.Object {
 		data = []byte(args[1].Inspect())
 	}
 
-	// Append to buffer
-	stream.Buffer = append(stream.Buffer, data...)
+	// Streams backed by a writer (e.g. an HTTP response) write straight through
+	if stream.Sink != nil {
+		if _, err := stream.Sink.Write(data); err != nil {
+			return &object.Error{Message: fmt.Sprintf("stream_lekho() write error: %s", err.Error())}
+		}
+	} else {
+		stream.Buffer = append(stream.Buffer, data...)
+	}
 
 	// Trigger data event if handler exists
 	if stream.OnData != nil && evalFunc != nil {
@@ -212,8 +231,14 @@ func streamBondho(args ...object.Object) object.Object {
 
 	stream.Mu.Lock()
 	stream.IsClosed = true
+	source := stream.Source
 	stream.Mu.Unlock()
 
+	// Release network-backed sources (e.g. anun {stream: sotti} response bodies)
+	if closer, ok := source.(io.Closer); ok {
+		closer.Close()
+	}
+
 	return object.NULL
 }
 
@@ -236,6 +261,10 @@ func streamShesh(args ...object.Object) object.Object {
 	if len(stream.Buffer) == 0 {
 		stream.IsClosed = true
 	}
+	// Ending a writer-backed stream finishes the underlying writer (e.g. the HTTP response)
+	if closer, ok := stream.Sink.(io.Closer); ok {
+		closer.Close()
+	}
 	stream.Mu.Unlock()
 
 	// Trigger end event if handler exists
@@ -272,20 +301,93 @@ func streamPipe(args ...object.Object) object.Object {
 		return &object.Error{Message: "stream_pipe() second argument must be a writable Stream"}
 	}
 
-	// Transfer all data from readable to writable
+	// Transfer all buffered data from readable to writable
 	readable.Mu.Lock()
 	data := make([]byte, len(readable.Buffer))
 	copy(data, readable.Buffer)
 	readable.Buffer = readable.Buffer[:0] // Clear buffer
 	readable.Mu.Unlock()
 
+	if err := writeToStream(writable, data); err != nil {
+		return &object.Error{Message: fmt.Sprintf("stream_pipe() write error: %s", err.Error())}
+	}
+
+	// Reader-backed streams are copied chunk by chunk until the source is exhausted,
+	// then a writer-backed destination is ended
+	readable.Mu.Lock()
+	source := readable.Source
+	readable.Mu.Unlock()
+	if source == nil {
+		return writable
+	}
+
+	for {
+		readable.Mu.Lock()
+		if readable.IsEnded {
+			readable.IsClosed = true
+			readable.Mu.Unlock()
+			break
+		}
+		err := pullFromSource(readable, 0)
+		chunk := readable.Buffer
+		readable.Buffer = nil
+		readable.Mu.Unlock()
+
+		if err != nil {
+			return &object.Error{Message: fmt.Sprintf("stream_pipe() read error: %s", err.Error())}
+		}
+		if err := writeToStream(writable, chunk); err != nil {
+			return &object.Error{Message: fmt.Sprintf("stream_pipe() write error: %s", err.Error())}
+		}
+	}
+
 	writable.Mu.Lock()
-	writable.Buffer = append(writable.Buffer, data...)
+	if closer, ok := writable.Sink.(io.Closer); ok {
+		writable.IsEnded = true
+		closer.Close()
+	}
 	writable.Mu.Unlock()
 
 	return writable
 }
 
+// sourceChunkSize is how much a reader-backed stream pulls when no size is requested
+const sourceChunkSize = 32 * 1024
+
+// pullFromSource reads the next chunk of a reader-backed stream into its buffer,
+// marking the stream ended at EOF. The caller holds stream.Mu.
+func pullFromSource(stream *object.Stream, size int) error {
+	if size <= 0 {
+		size = sourceChunkSize
+	}
+	chunk := make([]byte, size)
+	n, err := io.ReadAtLeast(stream.Source, chunk, 1)
+	stream.Buffer = append(stream.Buffer, chunk[:n]...)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		stream.IsEnded = true
+		return nil
+	}
+	if err != nil {
+		stream.IsEnded = true
+	}
+	return err
+}
+
+// writeToStream appends data to a writable stream, writing through its Sink when it has one
+func writeToStream(writable *object.Stream, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	writable.Mu.Lock()
+	defer writable.Mu.Unlock()
+	if writable.Sink != nil {
+		_, err := writable.Sink.Write(data)
+		return err
+	}
+	writable.Buffer = append(writable.Buffer, data...)
+	return nil
+}
+
 // streamOn registers event handlers for streams
 // Usage: stream_on(stream, "data", kaj(chunk) { ... });
 func streamOn(args ...object.Object) object.Object {
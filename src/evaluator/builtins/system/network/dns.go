@@ -0,0 +1,292 @@
+package network
+
+import (
+	"BanglaCode/src/object"
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultDNSTimeout = 5 * time.Second
+
+var (
+	// dnsServerOverride is the resolver set with dns_server(addr); empty means the system resolver
+	dnsServerOverride string
+	dnsServerMutex    sync.RWMutex
+)
+
+// dnsQuery holds one lookup: the record type and which resolver to ask
+type dnsQuery struct {
+	name       string
+	recordType string
+	server     string
+	timeout    time.Duration
+}
+
+// parseDNSQuery reads dns_khojo(name, type?, {server, timeout}?) arguments
+func parseDNSQuery(fnName string, args []object.Object) (*dnsQuery, *object.Error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, newError("wrong number of arguments to %s. got=%d, want=1-3", fnName, len(args))
+	}
+	name, ok := args[0].(*object.String)
+	if !ok {
+		return nil, newError("argument 1 to %s must be STRING, got %s", fnName, args[0].Type())
+	}
+
+	dnsServerMutex.RLock()
+	q := &dnsQuery{name: name.Value, recordType: "A", server: dnsServerOverride, timeout: defaultDNSTimeout}
+	dnsServerMutex.RUnlock()
+
+	if len(args) >= 2 && args[1] != object.NULL {
+		recordType, ok := args[1].(*object.String)
+		if !ok {
+			return nil, newError("argument 2 to %s must be STRING (record type), got %s", fnName, args[1].Type())
+		}
+		q.recordType = strings.ToUpper(recordType.Value)
+		switch q.recordType {
+		case "A", "AAAA", "CNAME", "MX", "TXT", "SRV", "PTR", "NS":
+		default:
+			return nil, newError("%s: unsupported record type %q (want A, AAAA, CNAME, MX, TXT, SRV, PTR or NS)", fnName, recordType.Value)
+		}
+	}
+
+	if len(args) == 3 {
+		opts, ok := args[2].(*object.Map)
+		if !ok {
+			return nil, newError("argument 3 to %s must be MAP, got %s", fnName, args[2].Type())
+		}
+		if v, ok := opts.Pairs["server"]; ok {
+			server, ok := v.(*object.String)
+			if !ok {
+				return nil, newError("`server` option to %s must be STRING, got %s", fnName, v.Type())
+			}
+			q.server = dnsServerAddress(server.Value)
+		}
+		if v, ok := opts.Pairs["timeout"]; ok {
+			ms, ok := v.(*object.Number)
+			if !ok || ms.Value <= 0 {
+				return nil, newError("`timeout` option to %s must be a positive NUMBER (ms)", fnName)
+			}
+			q.timeout = time.Duration(ms.Value * float64(time.Millisecond))
+		}
+	}
+	return q, nil
+}
+
+// dnsServerAddress adds the default port 53 to a resolver address without one
+func dnsServerAddress(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), "53")
+}
+
+// resolver returns the system resolver, or one that sends every query to q.server
+func (q *dnsQuery) resolver() *net.Resolver {
+	if q.server == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, q.server)
+		},
+	}
+}
+
+// run performs the lookup and converts the answer to BanglaCode values
+func (q *dnsQuery) run() object.Object {
+	ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+	defer cancel()
+	r := q.resolver()
+
+	switch q.recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if q.recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := r.LookupIP(ctx, network, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		elements := make([]object.Object, 0, len(ips))
+		for _, ip := range ips {
+			elements = append(elements, &object.String{Value: ip.String()})
+		}
+		return &object.Array{Elements: elements}
+
+	case "CNAME":
+		cname, err := r.LookupCNAME(ctx, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		return &object.String{Value: strings.TrimSuffix(cname, ".")}
+
+	case "MX":
+		records, err := r.LookupMX(ctx, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Pref < records[j].Pref })
+		elements := make([]object.Object, 0, len(records))
+		for _, mx := range records {
+			elements = append(elements, &object.Map{Pairs: map[string]object.Object{
+				"host":     &object.String{Value: strings.TrimSuffix(mx.Host, ".")},
+				"priority": &object.Number{Value: float64(mx.Pref)},
+			}})
+		}
+		return &object.Array{Elements: elements}
+
+	case "TXT":
+		records, err := r.LookupTXT(ctx, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		return stringArray(records)
+
+	case "SRV":
+		// The name is the full "_service._proto.domain"
+		_, records, err := r.LookupSRV(ctx, "", "", q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		elements := make([]object.Object, 0, len(records))
+		for _, srv := range records {
+			elements = append(elements, &object.Map{Pairs: map[string]object.Object{
+				"target":   &object.String{Value: strings.TrimSuffix(srv.Target, ".")},
+				"port":     &object.Number{Value: float64(srv.Port)},
+				"priority": &object.Number{Value: float64(srv.Priority)},
+				"weight":   &object.Number{Value: float64(srv.Weight)},
+			}})
+		}
+		return &object.Array{Elements: elements}
+
+	case "PTR":
+		if net.ParseIP(q.name) == nil {
+			return newError("PTR lookup needs an IP address, got %q", q.name)
+		}
+		names, err := r.LookupAddr(ctx, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		for i := range names {
+			names[i] = strings.TrimSuffix(names[i], ".")
+		}
+		return stringArray(names)
+
+	case "NS":
+		records, err := r.LookupNS(ctx, q.name)
+		if err != nil {
+			return dnsError(q, err)
+		}
+		hosts := make([]string, 0, len(records))
+		for _, ns := range records {
+			hosts = append(hosts, strings.TrimSuffix(ns.Host, "."))
+		}
+		return stringArray(hosts)
+	}
+	return object.NULL
+}
+
+func dnsError(q *dnsQuery, err error) *object.Error {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return newError("DNS lookup failed: no %s records for %s", q.recordType, q.name)
+	}
+	return newError("DNS lookup failed: %s", err.Error())
+}
+
+func stringArray(values []string) *object.Array {
+	elements := make([]object.Object, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &object.String{Value: v})
+	}
+	return &object.Array{Elements: elements}
+}
+
+// systemDNSServers reads the nameservers from /etc/resolv.conf where it exists
+func systemDNSServers() []string {
+	file, err := os.Open("/etc/resolv.conf")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var servers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+	return servers
+}
+
+func init() {
+	// ==================== DNS ====================
+
+	// dns_khojo (ডিএনএস খোঁজো) - Look up DNS records
+	// dns_khojo(name, type?, {server, timeout}?) with type A (default), AAAA, CNAME, MX, TXT, SRV, PTR or NS
+	// Example: dns_khojo("example.com", "MX") → [{host: "mail.example.com", priority: 10}]
+	registerBuiltin("dns_khojo", func(args ...object.Object) object.Object {
+		q, errObj := parseDNSQuery("dns_khojo", args)
+		if errObj != nil {
+			return errObj
+		}
+		return q.run()
+	})
+
+	// dns_server (ডিএনএস সার্ভার) - Get or set the DNS servers used by dns_khojo
+	// dns_server() returns the resolver addresses; dns_server("127.0.0.1:5353") sends every
+	// lookup to that server and dns_server(khali) goes back to the system resolver
+	registerBuiltin("dns_server", func(args ...object.Object) object.Object {
+		if len(args) > 1 {
+			return newError("dns_server takes 0-1 arguments (address), got %d", len(args))
+		}
+		dnsServerMutex.Lock()
+		defer dnsServerMutex.Unlock()
+		if len(args) == 1 {
+			switch addr := args[0].(type) {
+			case *object.String:
+				dnsServerOverride = dnsServerAddress(addr.Value)
+			case *object.Null:
+				dnsServerOverride = ""
+			default:
+				return newError("dns server address must be STRING, got %s", args[0].Type())
+			}
+		}
+		if dnsServerOverride != "" {
+			return stringArray([]string{dnsServerOverride})
+		}
+		return stringArray(systemDNSServers())
+	})
+
+	// dns_khojo_async (ডিএনএস খোঁজো async) - Same as dns_khojo, returns a promise
+	// Example: dhoro ips = opekha dns_khojo_async("example.com", "AAAA");
+	registerBuiltin("dns_khojo_async", func(args ...object.Object) object.Object {
+		q, errObj := parseDNSQuery("dns_khojo_async", args)
+		if errObj != nil {
+			return errObj
+		}
+		promise := object.CreatePromise()
+		go func() {
+			result := q.run()
+			if errObj, ok := result.(*object.Error); ok {
+				object.RejectPromise(promise, errObj)
+				return
+			}
+			object.ResolvePromise(promise, result)
+		}()
+		return promise
+	})
+}
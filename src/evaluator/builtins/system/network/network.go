@@ -149,11 +149,4 @@ func init() {
 	registerBuiltin("network_gateway", func(args ...object.Object) object.Object {
 		return newError("network_gateway not implemented yet")
 	})
-
-	// dns_server (ডিএনএস সার্ভার) - Get DNS server addresses
-	// Note: This is platform-specific and complex to implement
-	// Returning placeholder for now
-	registerBuiltin("dns_server", func(args ...object.Object) object.Object {
-		return newError("dns_server not implemented yet")
-	})
 }
@@ -160,9 +160,17 @@ func init() {
 	})
 
 	// process_signal (প্রসেস সিগন্যাল) - Send signal to process
+	// process_signal(pid, signal) sends a signal; process_signal("SIGTERM", handler) hooks one
 	registerBuiltin("process_signal", func(args ...object.Object) object.Object {
 		if len(args) != 2 {
-			return newError("process_signal requires 2 arguments (pid, signal)")
+			return newError("process_signal requires 2 arguments (pid, signal) or (signal name, handler)")
+		}
+		if name, ok := args[0].(*object.String); ok {
+			handler, ok := args[1].(*object.Function)
+			if !ok {
+				return newError("signal handler must be FUNCTION, got %s", args[1].Type())
+			}
+			return addSignalHook(name.Value, handler)
 		}
 		if args[0].Type() != object.NUMBER_OBJ || args[1].Type() != object.NUMBER_OBJ {
 			return newError("both arguments must be NUMBER")
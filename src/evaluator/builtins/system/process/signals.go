@@ -0,0 +1,66 @@
+package process
+
+import (
+	"BanglaCode/src/object"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// evalFunc calls back into the evaluator for signal hooks
+var evalFunc func(*object.Function, []object.Object) object.Object
+
+// SetEvalFunc sets the function evaluator callback used to run signal hooks
+func SetEvalFunc(fn func(*object.Function, []object.Object) object.Object) {
+	evalFunc = fn
+}
+
+// signalNames lists the signals a script can hook with process_signal(name, handler)
+var signalNames = map[string]syscall.Signal{
+	"SIGINT":  syscall.SIGINT,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGQUIT": syscall.SIGQUIT,
+}
+
+var (
+	signalMu    sync.Mutex
+	signalHooks = make(map[syscall.Signal][]*object.Function)
+)
+
+// addSignalHook registers handler to run (with the signal name) whenever the process
+// receives the named signal. Hooked signals no longer terminate the process.
+func addSignalHook(name string, handler *object.Function) object.Object {
+	sig, ok := signalNames[strings.ToUpper(name)]
+	if !ok {
+		return newError("process_signal: unknown signal '%s' (supported: SIGINT, SIGTERM, SIGHUP, SIGQUIT)", name)
+	}
+
+	signalMu.Lock()
+	defer signalMu.Unlock()
+	first := len(signalHooks[sig]) == 0
+	signalHooks[sig] = append(signalHooks[sig], handler)
+	if first {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, sig)
+		go dispatchSignals(ch, strings.ToUpper(name))
+	}
+	return object.NULL
+}
+
+// dispatchSignals runs every hook for each signal received on ch
+func dispatchSignals(ch chan os.Signal, name string) {
+	for received := range ch {
+		signalMu.Lock()
+		hooks := append([]*object.Function(nil), signalHooks[received.(syscall.Signal)]...)
+		signalMu.Unlock()
+
+		for _, hook := range hooks {
+			if evalFunc != nil {
+				evalFunc(hook, []object.Object{&object.String{Value: name}})
+			}
+		}
+	}
+}
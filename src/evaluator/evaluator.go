@@ -8,6 +8,7 @@ import (
 	"BanglaCode/src/evaluator/builtins/collections"
 	"BanglaCode/src/evaluator/builtins/events"
 	"BanglaCode/src/evaluator/builtins/streams"
+	"BanglaCode/src/evaluator/builtins/system/process"
 	"BanglaCode/src/evaluator/builtins/worker"
 	"BanglaCode/src/object"
 )
@@ -15,10 +16,12 @@ import (
 func init() {
 	// Set up EvalFunc for builtins that need to call back into the evaluator
 	builtins.EvalFunc = evalFunctionCall
+	builtins.PropertyGetter = getMapProperty
 	events.SetEvalFunc(evalFunctionCall)
 	worker.SetEvalFunc(Eval)
 	streams.SetEvalFunc(Eval)
 	collections.SetEvalFunc(evalFunctionCall)
+	process.SetEvalFunc(evalFunctionCall)
 }
 
 // evalFunctionCall evaluates a function with the given arguments
@@ -76,6 +79,14 @@ func evalStatementNode(node ast.Node, env *object.Environment) (object.Object, b
 		return evalArrayDestructuringDeclaration(node, env), true
 	case *ast.ObjectDestructuringDeclaration:
 		return evalObjectDestructuringDeclaration(node, env), true
+	case *ast.DeclarationList:
+		var result object.Object
+		for _, decl := range node.Declarations {
+			if result = Eval(decl, env); isError(result) {
+				return result, true
+			}
+		}
+		return result, true
 	}
 	return evalFlowStatementNode(node, env)
 }
@@ -85,15 +96,15 @@ func evalFlowStatementNode(node ast.Node, env *object.Environment) (object.Objec
 	case *ast.IfStatement:
 		return evalIfStatement(node, env), true
 	case *ast.WhileStatement:
-		return evalWhileStatement(node, env), true
+		return evalWhileStatement(node, env, ""), true
 	case *ast.DoWhileStatement:
-		return evalDoWhileStatement(node, env), true
+		return evalDoWhileStatement(node, env, ""), true
 	case *ast.ForStatement:
-		return evalForStatement(node, env), true
+		return evalForStatement(node, env, ""), true
 	case *ast.ForOfStatement:
-		return evalForOfStatement(node, env), true
+		return evalForOfStatement(node, env, ""), true
 	case *ast.ForInStatement:
-		return evalForInStatement(node, env), true
+		return evalForInStatement(node, env, ""), true
 	case *ast.ReturnStatement:
 		val := Eval(node.ReturnValue, env)
 		if isError(val) {
@@ -101,11 +112,19 @@ func evalFlowStatementNode(node ast.Node, env *object.Environment) (object.Objec
 		}
 		return &object.ReturnValue{Value: val}, true
 	case *ast.BreakStatement:
+		if node.Label != nil {
+			return &object.Break{Label: node.Label.Value}, true
+		}
 		return object.BREAK, true
 	case *ast.ContinueStatement:
+		if node.Label != nil {
+			return &object.Continue{Label: node.Label.Value}, true
+		}
 		return object.CONTINUE, true
 	case *ast.SwitchStatement:
 		return evalSwitchStatement(node, env), true
+	case *ast.LabeledStatement:
+		return evalLabeledStatement(node, env), true
 	}
 	return nil, false
 }
@@ -182,6 +201,16 @@ func evalExpressionNode(node ast.Node, env *object.Environment) (object.Object,
 		return evalAsyncFunctionLiteral(node, env), true
 	case *ast.AwaitExpression:
 		return evalAwaitExpression(node, env), true
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env), true
+	case *ast.SequenceExpression:
+		var result object.Object
+		for _, expr := range node.Expressions {
+			if result = Eval(expr, env); isError(result) {
+				return result, true
+			}
+		}
+		return result, true
 	}
 	return nil, false
 }
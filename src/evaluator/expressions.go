@@ -197,6 +197,7 @@ func evalAssignmentExpression(ae *ast.AssignmentExpression, env *object.Environm
 // evalMapLiteral evaluates map/object literals
 func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object {
 	pairs := make(map[string]object.Object)
+	result := &object.Map{Pairs: pairs}
 
 	for keyNode, valueNode := range node.Pairs {
 		var keyStr string
@@ -205,6 +206,10 @@ func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object
 		if ident, ok := keyNode.(*ast.Identifier); ok {
 			keyStr = ident.Value
 		} else {
+			// Computed keys: {[expr]: value} parses as a one-element array literal
+			if arr, ok := keyNode.(*ast.ArrayLiteral); ok && len(arr.Elements) == 1 {
+				keyNode = arr.Elements[0]
+			}
 			key := Eval(keyNode, env)
 			if isError(key) {
 				return key
@@ -215,6 +220,16 @@ func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object
 				keyStr = k.Value
 			case *object.Number:
 				keyStr = k.Inspect()
+			case *object.Symbol:
+				value := Eval(valueNode, env)
+				if isError(value) {
+					return value
+				}
+				if result.Symbols == nil {
+					result.Symbols = make(map[*object.Symbol]object.Object)
+				}
+				result.Symbols[k] = value
+				continue
 			default:
 				return newError("unusable as map key: %s", key.Type())
 			}
@@ -228,7 +243,24 @@ func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object
 		pairs[keyStr] = value
 	}
 
-	return &object.Map{Pairs: pairs}
+	// Accessor properties (pao/set) are enumerable and configurable, like JS literals
+	for name, getter := range node.Getters {
+		desc := &object.PropertyDescriptor{Getter: buildAccessor(getter, env), Enumerable: true, Configurable: true}
+		if setter, ok := node.Setters[name]; ok {
+			desc.Setter = buildAccessor(setter, env)
+		}
+		delete(pairs, name)
+		result.SetDescriptor(name, desc)
+	}
+	for name, setter := range node.Setters {
+		if _, ok := node.Getters[name]; ok {
+			continue
+		}
+		delete(pairs, name)
+		result.SetDescriptor(name, &object.PropertyDescriptor{Setter: buildAccessor(setter, env), Enumerable: true, Configurable: true})
+	}
+
+	return result
 }
 
 // evalSpreadElement evaluates spread expression (returns a marker for special handling)
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/evaluator/builtins/buffer"
 	"BanglaCode/src/object"
 )
 
@@ -30,6 +31,12 @@ func evalMemberAssignment(member *ast.MemberExpression, operator string, value a
 	case *object.Class:
 		return assignClassMember(o, member, operator, val)
 
+	case *object.Buffer:
+		return assignBufferMember(o, member, operator, val, env)
+
+	case *object.TypedArray:
+		return assignTypedArrayMember(o, member, operator, val, env)
+
 	default:
 		return newError("cannot assign to %s", obj.Type())
 	}
@@ -62,11 +69,23 @@ func evalMemberExpression(me *ast.MemberExpression, env *object.Environment) obj
 		return accessStreamMember(o, me)
 
 	case *object.Buffer:
-		return accessBufferMember(o, me)
+		return accessBufferMember(o, me, env)
+
+	case *object.TypedArray:
+		return accessTypedArrayMember(o, me, env)
+
+	case *object.DataView:
+		return accessDataViewMember(o, me)
 
 	case *object.Generator:
 		return accessGeneratorMember(o, me)
 
+	case *object.Symbol:
+		if ident, ok := me.Property.(*ast.Identifier); ok && !me.Computed && ident.Value == "description" {
+			return &object.String{Value: o.Description}
+		}
+		return object.NULL
+
 	default:
 		return newError("member access not supported on %s", obj.Type())
 	}
@@ -99,16 +118,37 @@ func assignArrayMember(arr *object.Array, member *ast.MemberExpression, operator
 }
 
 func assignMapMember(m *object.Map, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
-	key, errObj := resolveMapMemberKey(member, env)
+	sym, keyObj := resolveSymbolKey(member, env)
+	if isError(keyObj) {
+		return keyObj
+	}
+	if sym != nil {
+		if operator != "=" {
+			current, ok := m.Symbols[sym]
+			if !ok {
+				return newError("key '%s' not found in map", sym.Inspect())
+			}
+			val = evalBinaryExpression(string(operator[0]), current, val)
+			if isError(val) {
+				return val
+			}
+		}
+		return setMapSymbol(m, sym, val)
+	}
+
+	key, errObj := mapKeyFor(member, keyObj)
 	if errObj != nil {
 		return errObj
 	}
 
 	if operator != "=" {
-		current, ok := m.Pairs[key]
-		if !ok {
+		if !m.HasOwn(key) {
 			return newError("key '%s' not found in map", key)
 		}
+		current := getMapProperty(m, key)
+		if isError(current) {
+			return current
+		}
 		op := string(operator[0])
 		val = evalBinaryExpression(op, current, val)
 		if isError(val) {
@@ -116,8 +156,7 @@ func assignMapMember(m *object.Map, member *ast.MemberExpression, operator strin
 		}
 	}
 
-	m.Pairs[key] = val
-	return val
+	return setMapProperty(m, key, val)
 }
 
 func assignInstanceMember(inst *object.Instance, member *ast.MemberExpression, operator string, val object.Object) object.Object {
@@ -190,14 +229,22 @@ func accessArrayMember(arr *object.Array, me *ast.MemberExpression, env *object.
 }
 
 func accessMapMember(m *object.Map, me *ast.MemberExpression, env *object.Environment) object.Object {
-	key, errObj := resolveMapMemberKey(me, env)
+	sym, keyObj := resolveSymbolKey(me, env)
+	if isError(keyObj) {
+		return keyObj
+	}
+	if sym != nil {
+		if val, ok := m.Symbols[sym]; ok {
+			return val
+		}
+		return object.NULL
+	}
+
+	key, errObj := mapKeyFor(me, keyObj)
 	if errObj != nil {
 		return errObj
 	}
-	if val, ok := m.Pairs[key]; ok {
-		return val
-	}
-	return object.NULL
+	return getMapProperty(m, key)
 }
 
 func accessInstanceMember(inst *object.Instance, me *ast.MemberExpression) object.Object {
@@ -324,12 +371,10 @@ func assignClassMember(class *object.Class, member *ast.MemberExpression, operat
 	return val
 }
 
-func resolveMapMemberKey(member *ast.MemberExpression, env *object.Environment) (string, *object.Error) {
+// mapKeyFor returns the string key for a member expression; keyObj is the already
+// evaluated property for computed access (obj[expr])
+func mapKeyFor(member *ast.MemberExpression, keyObj object.Object) (string, *object.Error) {
 	if member.Computed {
-		keyObj := Eval(member.Property, env)
-		if isError(keyObj) {
-			return "", keyObj.(*object.Error)
-		}
 		return getMapKey(keyObj), nil
 	}
 	ident, ok := member.Property.(*ast.Identifier)
@@ -402,7 +447,7 @@ func accessStreamMember(stream *object.Stream, me *ast.MemberExpression) object.
 	switch prop {
 	case "Buffer":
 		// Return buffer as Buffer object
-		return &object.Buffer{Data: stream.Buffer}
+		return object.NewBuffer(stream.Buffer)
 	case "Type", "StreamType":
 		return &object.String{Value: stream.StreamType}
 	case "IsClosed":
@@ -416,18 +461,150 @@ func accessStreamMember(stream *object.Stream, me *ast.MemberExpression) object.
 	}
 }
 
-// accessBufferMember accesses Buffer object properties
-func accessBufferMember(buffer *object.Buffer, me *ast.MemberExpression) object.Object {
+// accessBufferMember accesses Buffer object properties and bytes (buf[i])
+func accessBufferMember(buf *object.Buffer, me *ast.MemberExpression, env *object.Environment) object.Object {
 	if me.Computed {
-		return newError("computed member access not supported on Buffer")
+		idx, errObj := resolveIndex(me, env)
+		if errObj != nil {
+			return errObj
+		}
+		buf.Mu.RLock()
+		defer buf.Mu.RUnlock()
+		if idx < 0 || idx >= len(buf.Data) {
+			return object.NULL
+		}
+		return &object.Number{Value: float64(buf.Data[idx])}
 	}
 
 	prop := me.Property.(*ast.Identifier).Value
 
 	switch prop {
 	case "Length", "length":
-		return &object.Number{Value: float64(len(buffer.Data))}
+		return &object.Number{Value: float64(len(buf.Data))}
 	default:
 		return newError("Buffer has no property '%s'", prop)
 	}
 }
+
+// assignBufferMember writes a single byte (buf[i] = 255)
+func assignBufferMember(buf *object.Buffer, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
+	if !member.Computed {
+		return newError("cannot assign to Buffer property")
+	}
+	idx, errObj := resolveIndex(member, env)
+	if errObj != nil {
+		return errObj
+	}
+
+	buf.Mu.Lock()
+	defer buf.Mu.Unlock()
+	if idx < 0 || idx >= len(buf.Data) {
+		return newError("buffer index out of bounds: %d", idx)
+	}
+	if operator != "=" {
+		val = evalBinaryExpression(string(operator[0]), &object.Number{Value: float64(buf.Data[idx])}, val)
+		if isError(val) {
+			return val
+		}
+	}
+	num, ok := val.(*object.Number)
+	if !ok || num.Value < 0 || num.Value > 255 {
+		return newError("byte value must be 0-255, got %s", val.Inspect())
+	}
+	buf.Data[idx] = byte(num.Value)
+	return val
+}
+
+// accessTypedArrayMember reads an element (arr[i]) or a view property
+func accessTypedArrayMember(ta *object.TypedArray, me *ast.MemberExpression, env *object.Environment) object.Object {
+	if me.Computed {
+		idx, errObj := resolveIndex(me, env)
+		if errObj != nil {
+			return errObj
+		}
+		v, ok := buffer.ReadElement(ta, idx)
+		if !ok {
+			return object.NULL
+		}
+		return &object.Number{Value: v}
+	}
+
+	prop := me.Property.(*ast.Identifier).Value
+
+	switch prop {
+	case "length":
+		return &object.Number{Value: float64(ta.Length)}
+	case "byteOffset":
+		return &object.Number{Value: float64(ta.ByteOffset)}
+	case "byteLength":
+		return &object.Number{Value: float64(ta.Length * ta.ElementSize)}
+	case "buffer":
+		return ta.Buffer
+	case "kind":
+		return &object.String{Value: ta.Kind}
+	default:
+		return newError("%sArray has no property '%s'", ta.Kind, prop)
+	}
+}
+
+// assignTypedArrayMember writes an element (arr[i] = value) with the array's numeric conversion
+func assignTypedArrayMember(ta *object.TypedArray, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
+	if !member.Computed {
+		return newError("cannot assign to %sArray property", ta.Kind)
+	}
+	idx, errObj := resolveIndex(member, env)
+	if errObj != nil {
+		return errObj
+	}
+	if operator != "=" {
+		current, ok := buffer.ReadElement(ta, idx)
+		if !ok {
+			return newError("typed array index out of bounds: %d", idx)
+		}
+		val = evalBinaryExpression(string(operator[0]), &object.Number{Value: current}, val)
+		if isError(val) {
+			return val
+		}
+	}
+	num, ok := val.(*object.Number)
+	if !ok {
+		return newError("%sArray element must be a number, got %s", ta.Kind, val.Type())
+	}
+	if !buffer.WriteElement(ta, idx, num.Value) {
+		return newError("typed array index out of bounds: %d", idx)
+	}
+	return val
+}
+
+// accessDataViewMember accesses DataView properties
+func accessDataViewMember(view *object.DataView, me *ast.MemberExpression) object.Object {
+	if me.Computed {
+		return newError("computed member access not supported on DataView")
+	}
+
+	prop := me.Property.(*ast.Identifier).Value
+
+	switch prop {
+	case "byteOffset":
+		return &object.Number{Value: float64(view.ByteOffset)}
+	case "byteLength", "length":
+		return &object.Number{Value: float64(view.ByteLength)}
+	case "buffer":
+		return view.Buffer
+	default:
+		return newError("DataView has no property '%s'", prop)
+	}
+}
+
+// resolveIndex evaluates a computed numeric index
+func resolveIndex(member *ast.MemberExpression, env *object.Environment) (int, object.Object) {
+	index := Eval(member.Property, env)
+	if isError(index) {
+		return 0, index
+	}
+	num, ok := index.(*object.Number)
+	if !ok {
+		return 0, newError("index must be a number, got %s", index.Type())
+	}
+	return int(num.Value), nil
+}
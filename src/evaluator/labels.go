@@ -0,0 +1,65 @@
+package evaluator
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+)
+
+// evalLabeledStatement runs a labelled loop (so chharo/thamo label target it) or a
+// labelled block/bikolpo, which thamo label leaves early
+func evalLabeledStatement(node *ast.LabeledStatement, env *object.Environment) object.Object {
+	label := node.Label.Value
+	switch body := node.Body.(type) {
+	case *ast.WhileStatement:
+		return evalWhileStatement(body, env, label)
+	case *ast.DoWhileStatement:
+		return evalDoWhileStatement(body, env, label)
+	case *ast.ForStatement:
+		return evalForStatement(body, env, label)
+	case *ast.ForOfStatement:
+		return evalForOfStatement(body, env, label)
+	case *ast.ForInStatement:
+		return evalForInStatement(body, env, label)
+	}
+
+	result := Eval(node.Body, env)
+	if brk, ok := result.(*object.Break); ok && brk.Label == label {
+		return object.NULL
+	}
+	return result
+}
+
+// loopControl interprets the result of one loop iteration for the loop named label.
+// It reports whether the loop stops and, if so, the value the loop evaluates to;
+// break/continue aimed at an outer label stop this loop and propagate outward.
+func loopControl(result object.Object, label string) (bool, object.Object) {
+	switch r := result.(type) {
+	case nil:
+		return false, nil
+	case *object.Break:
+		if r.Label == "" || r.Label == label {
+			return true, object.NULL
+		}
+		return true, r
+	case *object.Continue:
+		if r.Label == "" || r.Label == label {
+			return false, nil
+		}
+		return true, r
+	}
+
+	switch result.Type() {
+	case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
+		return true, result
+	}
+	return false, nil
+}
+
+// switchResult ends a bikolpo: an unlabelled thamo leaves the switch, while chharo
+// and labelled jumps propagate to the enclosing loop or labelled statement
+func switchResult(result object.Object) object.Object {
+	if brk, ok := result.(*object.Break); ok && brk.Label == "" {
+		return object.NULL
+	}
+	return result
+}
@@ -2,17 +2,37 @@ package evaluator
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/evaluator/builtins/buffer"
 	"BanglaCode/src/object"
 	"sort"
 )
 
-func evalForOfStatement(stmt *ast.ForOfStatement, env *object.Environment) object.Object {
+func evalForOfStatement(stmt *ast.ForOfStatement, env *object.Environment, label string) object.Object {
 	iterable := Eval(stmt.Iterable, env)
 	if isError(iterable) {
 		return iterable
 	}
 
 	loopEnv := object.NewEnclosedEnvironment(env)
+
+	// Generators and [CHIHNO_ITERATOR] objects are pulled lazily
+	if next, ok := iteratorNextFunc(iterable); ok {
+		for {
+			el, done, errObj := next()
+			if errObj != nil {
+				return errObj
+			}
+			if done {
+				return object.NULL
+			}
+			loopEnv.Update(stmt.VarName.Value, el)
+			result := Eval(stmt.Body, loopEnv)
+			if stop, out := loopControl(result, label); stop {
+				return out
+			}
+		}
+	}
+
 	elements, err := toForOfElements(iterable)
 	if err != nil {
 		return err
@@ -21,22 +41,15 @@ func evalForOfStatement(stmt *ast.ForOfStatement, env *object.Environment) objec
 	for _, el := range elements {
 		loopEnv.Update(stmt.VarName.Value, el)
 		result := Eval(stmt.Body, loopEnv)
-		if result != nil {
-			switch result.Type() {
-			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
-				return result
-			case object.BREAK_OBJ:
-				return object.NULL
-			case object.CONTINUE_OBJ:
-				continue
-			}
+		if stop, out := loopControl(result, label); stop {
+			return out
 		}
 	}
 
 	return object.NULL
 }
 
-func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment) object.Object {
+func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment, label string) object.Object {
 	target := Eval(stmt.Object, env)
 	if isError(target) {
 		return target
@@ -51,15 +64,8 @@ func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment) objec
 	for _, key := range keys {
 		loopEnv.Update(stmt.VarName.Value, key)
 		result := Eval(stmt.Body, loopEnv)
-		if result != nil {
-			switch result.Type() {
-			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
-				return result
-			case object.BREAK_OBJ:
-				return object.NULL
-			case object.CONTINUE_OBJ:
-				continue
-			}
+		if stop, out := loopControl(result, label); stop {
+			return out
 		}
 	}
 
@@ -82,28 +88,25 @@ func toForOfElements(iterable object.Object) ([]object.Object, *object.Error) {
 		return elements, nil
 
 	case *object.Map:
-		keys := make([]string, 0, len(it.Pairs))
-		for k := range it.Pairs {
-			keys = append(keys, k)
-		}
+		keys := it.EnumerableKeys()
 		sort.Strings(keys)
 		elements := make([]object.Object, 0, len(keys))
 		for _, k := range keys {
-			elements = append(elements, it.Pairs[k])
+			elements = append(elements, getMapProperty(it, k))
 		}
 		return elements, nil
+
+	case *object.TypedArray:
+		return buffer.Elements(it), nil
 	}
 
-	return nil, newError("for...of target must be ARRAY, STRING, or MAP, got %s", iterable.Type())
+	return nil, newError("for...of target must be ARRAY, STRING, MAP, or TYPED_ARRAY, got %s", iterable.Type())
 }
 
 func toForInKeys(target object.Object) ([]object.Object, *object.Error) {
 	switch it := target.(type) {
 	case *object.Map:
-		keys := make([]string, 0, len(it.Pairs))
-		for k := range it.Pairs {
-			keys = append(keys, k)
-		}
+		keys := it.EnumerableKeys()
 		sort.Strings(keys)
 		out := make([]object.Object, 0, len(keys))
 		for _, k := range keys {
@@ -0,0 +1,196 @@
+package evaluator
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+)
+
+// evalMatchExpression evaluates the first arm whose pattern (and guard) matches the subject.
+// Bindings live in a fresh scope per arm, so failed arms leave no variables behind.
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(node.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+
+	for _, arm := range node.Arms {
+		armEnv := object.NewEnclosedEnvironment(env)
+		matched, err := matchPattern(arm.Pattern, subject, armEnv)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		if arm.Block != nil {
+			result := Eval(arm.Block, armEnv)
+			if result == nil {
+				return object.NULL
+			}
+			return result
+		}
+		return Eval(arm.Result, armEnv)
+	}
+
+	return newError("milao: no khetre arm matched value %s", subject.Inspect())
+}
+
+// matchPattern reports whether value matches pattern, binding names into env as it goes
+func matchPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	switch pat := pattern.(type) {
+	case *ast.WildcardPattern:
+		return true, nil
+	case *ast.BindingPattern:
+		env.Set(pat.Name.Value, value)
+		return true, nil
+	case *ast.LiteralPattern:
+		expected := Eval(pat.Value, env)
+		if isError(expected) {
+			return false, expected
+		}
+		return objectsEqual(value, expected), nil
+	case *ast.OrPattern:
+		for _, alt := range pat.Alternatives {
+			matched, err := matchPattern(alt, value, env)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	case *ast.AsPattern:
+		matched, err := matchPattern(pat.Pattern, value, env)
+		if matched {
+			env.Set(pat.Name.Value, value)
+		}
+		return matched, err
+	case *ast.ArrayPattern:
+		return matchArrayPattern(pat, value, env)
+	case *ast.MapPattern:
+		return matchMapPattern(pat, value, env)
+	case *ast.ClassPattern:
+		return matchClassPattern(pat, value, env)
+	}
+	return false, newError("milao: unsupported pattern %s", pattern.String())
+}
+
+func matchArrayPattern(pat *ast.ArrayPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	arr, ok := value.(*object.Array)
+	if !ok {
+		return false, nil
+	}
+	if len(arr.Elements) < len(pat.Elements) || (!pat.HasRest && len(arr.Elements) != len(pat.Elements)) {
+		return false, nil
+	}
+
+	for i, el := range pat.Elements {
+		matched, err := matchPattern(el, arr.Elements[i], env)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if pat.Rest != nil {
+		rest := make([]object.Object, len(arr.Elements)-len(pat.Elements))
+		copy(rest, arr.Elements[len(pat.Elements):])
+		env.Set(pat.Rest.Value, &object.Array{Elements: rest})
+	}
+	return true, nil
+}
+
+// matchMapPattern matches maps and instances; every listed key must be present
+func matchMapPattern(pat *ast.MapPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	lookup, keys, ok := patternProperties(value)
+	if !ok {
+		return false, nil
+	}
+
+	for i, key := range pat.Keys {
+		prop, exists := lookup(key)
+		if !exists {
+			return false, nil
+		}
+		if isError(prop) {
+			return false, prop
+		}
+		matched, err := matchPattern(pat.Values[i], prop, env)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if pat.Rest != nil {
+		used := make(map[string]bool, len(pat.Keys))
+		for _, key := range pat.Keys {
+			used[key] = true
+		}
+		rest := &object.Map{Pairs: make(map[string]object.Object)}
+		for _, key := range keys {
+			if used[key] {
+				continue
+			}
+			rest.Pairs[key], _ = lookup(key)
+		}
+		env.Set(pat.Rest.Value, rest)
+	}
+	return true, nil
+}
+
+// patternProperties exposes the enumerable properties of maps and instances to map patterns
+func patternProperties(value object.Object) (func(string) (object.Object, bool), []string, bool) {
+	switch v := value.(type) {
+	case *object.Map:
+		lookup := func(key string) (object.Object, bool) {
+			if !v.HasOwn(key) {
+				return nil, false
+			}
+			return getMapProperty(v, key), true
+		}
+		return lookup, v.EnumerableKeys(), true
+	case *object.Instance:
+		lookup := func(key string) (object.Object, bool) {
+			if prop, ok := v.Properties[key]; ok {
+				return prop, true
+			}
+			if getter, ok := v.Class.Getters[key]; ok {
+				return callWithReceiver(getter, v, nil), true
+			}
+			return nil, false
+		}
+		keys := make([]string, 0, len(v.Properties))
+		for key := range v.Properties {
+			keys = append(keys, key)
+		}
+		return lookup, keys, true
+	}
+	return nil, nil, false
+}
+
+func matchClassPattern(pat *ast.ClassPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	classObj := evalIdentifier(pat.Class, env)
+	if isError(classObj) {
+		return false, classObj
+	}
+	class, ok := classObj.(*object.Class)
+	if !ok {
+		return false, newError("milao: %s is not a sreni, got %s", pat.Class.Value, classObj.Type())
+	}
+
+	instance, ok := value.(*object.Instance)
+	if !ok || instance.Class != class {
+		return false, nil
+	}
+	if pat.Fields == nil {
+		return true, nil
+	}
+	return matchMapPattern(pat.Fields, instance, env)
+}
@@ -0,0 +1,180 @@
+package evaluator
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+	"fmt"
+)
+
+// callWithReceiver calls fn with 'ei' bound to receiver (used by map accessors and iterators)
+func callWithReceiver(fn object.Object, receiver object.Object, args []object.Object) object.Object {
+	switch f := fn.(type) {
+	case *object.Function:
+		if f.IsAsync || f.IsGenerator {
+			bound := *f
+			bound.Env = object.NewEnclosedEnvironment(f.Env)
+			bound.Env.Set("ei", receiver)
+			return applyFunction(&bound, args, bound.Env)
+		}
+		env := extendFunctionEnv(f, args)
+		env.Set("ei", receiver)
+		return unwrapReturnValue(Eval(f.Body, env))
+	case *object.Builtin:
+		return f.Fn(args...)
+	}
+	return newError("'%s' is not a function", fn.Type())
+}
+
+// newPropertyTypeError throws a TypeError for rejected property writes/deletes
+func newPropertyTypeError(format string, a ...interface{}) *object.Exception {
+	return object.NewTypeErrorException(fmt.Sprintf(format, a...))
+}
+
+// getMapProperty reads a string-keyed property, invoking getters
+func getMapProperty(m *object.Map, key string) object.Object {
+	if desc := m.Descriptor(key); desc != nil && desc.IsAccessor() {
+		if desc.Getter == nil {
+			return object.NULL
+		}
+		return callWithReceiver(desc.Getter, m, nil)
+	}
+	if val, ok := m.Pairs[key]; ok {
+		return val
+	}
+	return object.NULL
+}
+
+// setMapProperty writes a string-keyed property, honouring setters, writable flags,
+// freeze and seal
+func setMapProperty(m *object.Map, key string, val object.Object) object.Object {
+	desc := m.Descriptor(key)
+	if desc != nil && desc.IsAccessor() {
+		if desc.Setter == nil {
+			return newPropertyTypeError("cannot set property '%s' which has only a getter", key)
+		}
+		result := callWithReceiver(desc.Setter, m, []object.Object{val})
+		if isError(result) || isException(result) {
+			return result
+		}
+		return val
+	}
+
+	if reason := m.WriteError(key); reason != "" {
+		return object.NewTypeErrorException(reason)
+	}
+
+	m.Pairs[key] = val
+	return val
+}
+
+// setMapSymbol writes a symbol-keyed property, honouring freeze and seal
+func setMapSymbol(m *object.Map, sym *object.Symbol, val object.Object) object.Object {
+	if _, exists := m.Symbols[sym]; exists {
+		if m.Frozen {
+			return newPropertyTypeError("cannot assign to read-only property '%s'", sym.Inspect())
+		}
+	} else if m.Frozen || m.Sealed {
+		return newPropertyTypeError("cannot add property '%s', object is not extensible", sym.Inspect())
+	}
+	if m.Symbols == nil {
+		m.Symbols = make(map[*object.Symbol]object.Object)
+	}
+	m.Symbols[sym] = val
+	return val
+}
+
+// deleteMapProperty removes a property unless it is non-configurable or the map is sealed
+func deleteMapProperty(m *object.Map, key string) object.Object {
+	if !m.HasOwn(key) {
+		return object.TRUE
+	}
+	desc := m.Descriptor(key)
+	if m.Frozen || m.Sealed || (desc != nil && !desc.Configurable) {
+		return newPropertyTypeError("cannot delete property '%s'", key)
+	}
+	delete(m.Pairs, key)
+	if desc != nil {
+		delete(m.Descriptors, key)
+	}
+	return object.TRUE
+}
+
+// resolveSymbolKey evaluates a computed member key and returns it if it is a symbol
+func resolveSymbolKey(member *ast.MemberExpression, env *object.Environment) (*object.Symbol, object.Object) {
+	if !member.Computed {
+		return nil, nil
+	}
+	keyObj := Eval(member.Property, env)
+	if isError(keyObj) {
+		return nil, keyObj
+	}
+	sym, _ := keyObj.(*object.Symbol)
+	return sym, keyObj
+}
+
+// buildAccessor creates the function object for a map literal getter/setter
+func buildAccessor(lit *ast.FunctionLiteral, env *object.Environment) *object.Function {
+	return &object.Function{
+		Parameters: lit.Parameters,
+		Env:        env,
+		Body:       lit.Body,
+		Name:       lit.Name.Value,
+	}
+}
+
+// iteratorNextFunc returns a pull function for objects implementing the iteration
+// protocol: generators, and maps with a [CHIHNO_ITERATOR] method returning a
+// generator or a {next} object. ok is false when the value is not such an iterable.
+func iteratorNextFunc(iterable object.Object) (next func() (object.Object, bool, object.Object), ok bool) {
+	var iterator object.Object
+	switch it := iterable.(type) {
+	case *object.Generator:
+		iterator = it
+	case *object.Map:
+		method, found := it.Symbols[object.SymbolIterator]
+		if !found {
+			return nil, false
+		}
+		iterator = callWithReceiver(method, it, nil)
+		if isError(iterator) {
+			return func() (object.Object, bool, object.Object) { return nil, true, iterator }, true
+		}
+	default:
+		return nil, false
+	}
+
+	step := func(result object.Object) (object.Object, bool, object.Object) {
+		if isError(result) || isException(result) {
+			return nil, true, result
+		}
+		res, ok := result.(*object.Map)
+		if !ok {
+			return nil, true, newError("iterator next() must return a MAP, got %s", result.Type())
+		}
+		if isTruthy(getMapProperty(res, "done")) {
+			if value := getMapProperty(res, "value"); isError(value) {
+				return nil, true, value
+			}
+			return nil, true, nil
+		}
+		return getMapProperty(res, "value"), false, nil
+	}
+
+	switch it := iterator.(type) {
+	case *object.Generator:
+		return func() (object.Object, bool, object.Object) { return step(generatorNext(it)) }, true
+	case *object.Map:
+		nextFn := getMapProperty(it, "next")
+		if nextFn.Type() != object.FUNCTION_OBJ && nextFn.Type() != object.BUILTIN_OBJ {
+			return func() (object.Object, bool, object.Object) {
+				return nil, true, newError("iterator object must have a next() method")
+			}, true
+		}
+		return func() (object.Object, bool, object.Object) {
+			return step(callWithReceiver(nextFn, it, nil))
+		}, true
+	}
+	return func() (object.Object, bool, object.Object) {
+		return nil, true, newError("[CHIHNO_ITERATOR]() must return an iterator, got %s", iterator.Type())
+	}, true
+}
@@ -18,6 +18,10 @@ func evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
 			return result.Value
 		case *object.Error:
 			return result
+		case *object.Exception:
+			if result.Fatal {
+				return result // a runtime TypeError nobody caught ends the program
+			}
 		}
 	}
 
@@ -60,8 +64,8 @@ func evalIfStatement(ie *ast.IfStatement, env *object.Environment) object.Object
 	return object.NULL
 }
 
-// evalWhileStatement evaluates while loops
-func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.Object {
+// evalWhileStatement evaluates while loops; label is the loop's label, if any
+func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment, label string) object.Object {
 	for {
 		condition := Eval(ws.Condition, env)
 		if isError(condition) {
@@ -73,28 +77,16 @@ func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.
 		}
 
 		result := Eval(ws.Body, env)
-
-		if result != nil {
-			switch result.Type() {
-			case object.RETURN_OBJ:
-				return result
-			case object.ERROR_OBJ:
-				return result
-			case object.BREAK_OBJ:
-				return object.NULL
-			case object.CONTINUE_OBJ:
-				continue
-			case object.EXCEPTION_OBJ:
-				return result
-			}
+		if stop, out := loopControl(result, label); stop {
+			return out
 		}
 	}
 
 	return object.NULL
 }
 
-// evalForStatement evaluates for loops
-func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Object {
+// evalForStatement evaluates for loops; label is the loop's label, if any
+func evalForStatement(fs *ast.ForStatement, env *object.Environment, label string) object.Object {
 	// Create new scope for loop
 	loopEnv := object.NewEnclosedEnvironment(env)
 
@@ -120,20 +112,8 @@ func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Obje
 
 		// Execute body
 		result := Eval(fs.Body, loopEnv)
-
-		if result != nil {
-			switch result.Type() {
-			case object.RETURN_OBJ:
-				return result
-			case object.ERROR_OBJ:
-				return result
-			case object.BREAK_OBJ:
-				return object.NULL
-			case object.CONTINUE_OBJ:
-				// Continue to update step
-			case object.EXCEPTION_OBJ:
-				return result
-			}
+		if stop, out := loopControl(result, label); stop {
+			return out
 		}
 
 		// Update
@@ -207,20 +187,13 @@ func evalSwitchStatement(node *ast.SwitchStatement, env *object.Environment) obj
 
 		// Check if values are equal using objectsEqual from helpers
 		if objectsEqual(switchValue, caseValue) {
-			result := Eval(caseClause.Body, env)
-
-			// If result is break, exit switch (return NULL)
-			if result == object.BREAK {
-				return object.NULL
-			}
-
-			return result
+			return switchResult(Eval(caseClause.Body, env))
 		}
 	}
 
 	// Execute default case if no match
 	if node.Default != nil {
-		return Eval(node.Default, env)
+		return switchResult(Eval(node.Default, env))
 	}
 
 	return object.NULL
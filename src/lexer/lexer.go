@@ -129,7 +129,7 @@ func (l *Lexer) readSymbolToken() (Token, bool) {
 		return l.readDotToken()
 	case 0:
 		return NewToken(EOF, "", l.line, l.column), false
-	case ',', ';', ':', '(', ')', '{', '}', '[', ']', '%':
+	case ',', ';', ':', '(', ')', '{', '}', '[', ']', '%', '|':
 		return NewToken(singleCharTokenType(l.ch), string(l.ch), l.line, l.column), true
 	default:
 		return NewToken(ILLEGAL, string(l.ch), l.line, l.column), true
@@ -225,6 +225,8 @@ func singleCharTokenType(ch byte) TokenType {
 		return LBRACKET
 	case ']':
 		return RBRACKET
+	case '|':
+		return PIPE
 	default:
 		return PERCENT
 	}
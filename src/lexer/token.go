@@ -63,6 +63,7 @@ const (
 	LBRACKET  = "["
 	RBRACKET  = "]"
 	DOTDOTDOT = "..."
+	PIPE      = "|" // union types: number | khali
 
 	// Keywords (Banglish)
 	DHORO      = "DHORO"      // variable declaration (let/var)
@@ -104,6 +105,7 @@ const (
 	PAO        = "PAO"        // get (পাও - obtain/get)
 	SET        = "SET"        // set (set - kept as-is for clarity)
 	UTPADAN    = "UTPADAN"    // yield (উৎপাদন - produce/generate)
+	MILAO      = "MILAO"      // match (মেলাও - match up)
 )
 
 // keywords maps Banglish keywords to their token types
@@ -148,6 +150,7 @@ var keywords = map[string]TokenType{
 	"pao":        PAO,
 	"set":        SET,
 	"utpadan":    UTPADAN,
+	"milao":      MILAO,
 }
 
 // LookupIdent checks if an identifier is a keyword
@@ -4,6 +4,7 @@ import (
 	"BanglaCode/src/ast"
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 )
@@ -38,6 +39,8 @@ const (
 	DB_POOL_OBJ         = "DB_POOL"
 	EVENT_EMITTER_OBJ   = "EVENT_EMITTER"
 	BUFFER_OBJ          = "BUFFER"
+	TYPED_ARRAY_OBJ     = "TYPED_ARRAY"
+	DATA_VIEW_OBJ       = "DATA_VIEW"
 	WORKER_OBJ          = "WORKER"
 	STREAM_OBJ          = "STREAM"
 	URL_OBJ             = "URL"
@@ -45,6 +48,7 @@ const (
 	SET_OBJ             = "SET"
 	ES6MAP_OBJ          = "ES6MAP"
 	GENERATOR_OBJ       = "GENERATOR"
+	SYMBOL_OBJ          = "SYMBOL"
 )
 
 // Object represents any runtime value
@@ -183,6 +187,20 @@ func NewTypeError(message string) *Error {
 	}
 }
 
+// NewTypeErrorException throws a TypeError the way `felo TypeError(message)` does. Unlike
+// a script's own felo, it ends the program unless a dhoro_bhul block catches it.
+func NewTypeErrorException(message string) *Exception {
+	return &Exception{
+		Fatal:   true,
+		Message: "TypeError: " + message,
+		Value: &Map{Pairs: map[string]Object{
+			"message": &String{Value: message},
+			"name":    &String{Value: "TypeError"},
+			"stack":   &String{Value: ""},
+		}},
+	}
+}
+
 // NewReferenceError creates a ReferenceError
 func NewReferenceError(message string) *Error {
 	return &Error{
@@ -285,7 +303,11 @@ func (a *Array) Inspect() string {
 
 // Map represents a hash map/object
 type Map struct {
-	Pairs map[string]Object
+	Pairs       map[string]Object
+	Symbols     map[*Symbol]Object             // symbol-keyed properties (never enumerated)
+	Descriptors map[string]*PropertyDescriptor // accessors and non-default attributes
+	Frozen      bool                           // no writes, additions or deletions
+	Sealed      bool                           // no additions or deletions
 }
 
 func (m *Map) Type() ObjectType { return MAP_OBJ }
@@ -293,14 +315,111 @@ func (m *Map) Inspect() string {
 	var out bytes.Buffer
 	pairs := []string{}
 	for key, value := range m.Pairs {
+		if !m.IsEnumerable(key) {
+			continue
+		}
 		pairs = append(pairs, fmt.Sprintf("%s: %s", key, value.Inspect()))
 	}
+	for key, desc := range m.Descriptors {
+		if desc.IsAccessor() && desc.Enumerable {
+			pairs = append(pairs, fmt.Sprintf("%s: [accessor]", key))
+		}
+	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
 	out.WriteString("}")
 	return out.String()
 }
 
+// PropertyDescriptor holds defineProperty-style attributes for a Map key.
+// Keys without a descriptor are plain data properties (writable, enumerable, configurable).
+type PropertyDescriptor struct {
+	Getter       Object // accessor getter (pao), nil for data properties
+	Setter       Object // accessor setter (set), nil for data properties
+	Writable     bool
+	Enumerable   bool
+	Configurable bool
+}
+
+// IsAccessor reports whether the descriptor describes a getter/setter property
+func (d *PropertyDescriptor) IsAccessor() bool {
+	return d.Getter != nil || d.Setter != nil
+}
+
+// Descriptor returns the descriptor for key, or nil for a plain data property
+func (m *Map) Descriptor(key string) *PropertyDescriptor {
+	if m.Descriptors == nil {
+		return nil
+	}
+	return m.Descriptors[key]
+}
+
+// SetDescriptor stores a descriptor for key
+func (m *Map) SetDescriptor(key string, desc *PropertyDescriptor) {
+	if m.Descriptors == nil {
+		m.Descriptors = make(map[string]*PropertyDescriptor)
+	}
+	m.Descriptors[key] = desc
+}
+
+// HasOwn reports whether key is a data or accessor property of the map
+func (m *Map) HasOwn(key string) bool {
+	if _, ok := m.Pairs[key]; ok {
+		return true
+	}
+	desc := m.Descriptor(key)
+	return desc != nil && desc.IsAccessor()
+}
+
+// IsEnumerable reports whether key shows up in key/value enumeration
+func (m *Map) IsEnumerable(key string) bool {
+	desc := m.Descriptor(key)
+	return desc == nil || desc.Enumerable
+}
+
+// EnumerableKeys returns the enumerable data and accessor keys (symbol keys are excluded)
+func (m *Map) EnumerableKeys() []string {
+	keys := make([]string, 0, len(m.Pairs))
+	for key := range m.Pairs {
+		if m.IsEnumerable(key) {
+			keys = append(keys, key)
+		}
+	}
+	for key, desc := range m.Descriptors {
+		if desc.IsAccessor() && desc.Enumerable {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// WriteError returns why a data value cannot be stored under key, or "" if the write is allowed
+func (m *Map) WriteError(key string) string {
+	if _, exists := m.Pairs[key]; exists {
+		desc := m.Descriptor(key)
+		if m.Frozen || (desc != nil && !desc.Writable) {
+			return fmt.Sprintf("cannot assign to read-only property '%s'", key)
+		}
+		return ""
+	}
+	if m.Frozen || m.Sealed {
+		return fmt.Sprintf("cannot add property '%s', object is not extensible", key)
+	}
+	return ""
+}
+
+// Symbol is a unique primitive used as a collision-free property key.
+// Two symbols are equal only if they are the same pointer.
+type Symbol struct {
+	Description string
+}
+
+func (s *Symbol) Type() ObjectType { return SYMBOL_OBJ }
+func (s *Symbol) Inspect() string  { return "Symbol(" + s.Description + ")" }
+
+// SymbolIterator is the well-known symbol used by the iteration protocol (ghuriye ... of)
+var SymbolIterator = &Symbol{Description: "CHIHNO_ITERATOR"}
+
 // Class represents a class definition
 type Class struct {
 	Name             string
@@ -323,14 +442,18 @@ type Instance struct {
 func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
 func (i *Instance) Inspect() string  { return i.Class.Name + " er udahoron" }
 
-// Break represents a break statement
-type Break struct{}
+// Break represents a break statement; Label is empty for an unlabelled thamo
+type Break struct {
+	Label string
+}
 
 func (b *Break) Type() ObjectType { return BREAK_OBJ }
 func (b *Break) Inspect() string  { return "thamo" }
 
-// Continue represents a continue statement
-type Continue struct{}
+// Continue represents a continue statement; Label is empty for an unlabelled chharo
+type Continue struct {
+	Label string
+}
 
 func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
 func (c *Continue) Inspect() string  { return "chharo" }
@@ -339,6 +462,7 @@ func (c *Continue) Inspect() string  { return "chharo" }
 type Exception struct {
 	Message string
 	Value   Object
+	Fatal   bool // raised by the runtime itself; ends the program when nothing catches it
 }
 
 func (e *Exception) Type() ObjectType { return EXCEPTION_OBJ }
@@ -508,8 +632,8 @@ func CreateEventEmitter() *EventEmitter {
 
 // Buffer represents a binary data buffer
 type Buffer struct {
-	Data []byte       // Raw binary data
-	Mu   sync.RWMutex // Thread-safe access
+	Data []byte        // Raw binary data
+	Mu   *sync.RWMutex // Thread-safe access; shared with views over the same memory
 }
 
 func (b *Buffer) Type() ObjectType { return BUFFER_OBJ }
@@ -523,9 +647,20 @@ func (b *Buffer) Inspect() string {
 func CreateBuffer(size int) *Buffer {
 	return &Buffer{
 		Data: make([]byte, size),
+		Mu:   &sync.RWMutex{},
 	}
 }
 
+// NewBuffer wraps data in a Buffer without copying it
+func NewBuffer(data []byte) *Buffer {
+	return &Buffer{Data: data, Mu: &sync.RWMutex{}}
+}
+
+// View returns a Buffer over Data[start:end] that shares this buffer's memory and lock
+func (b *Buffer) View(start, end int) *Buffer {
+	return &Buffer{Data: b.Data[start:end:end], Mu: b.Mu}
+}
+
 // CreateBufferFrom creates a new Buffer from existing data
 func CreateBufferFrom(data []byte) *Buffer {
 	// Make a copy to avoid external modifications
@@ -533,9 +668,36 @@ func CreateBufferFrom(data []byte) *Buffer {
 	copy(bufData, data)
 	return &Buffer{
 		Data: bufData,
+		Mu:   &sync.RWMutex{},
 	}
 }
 
+// TypedArray represents a fixed-width numeric view over a Buffer (Uint8Array, Float32Array, ...)
+type TypedArray struct {
+	Kind        string  // Element kind: "Int8", "Uint8", "Int16", "Uint16", "Int32", "Uint32", "Float32", "Float64"
+	ElementSize int     // Bytes per element
+	Buffer      *Buffer // Backing buffer (shared, not copied)
+	ByteOffset  int     // Start offset into Buffer.Data
+	Length      int     // Number of elements
+}
+
+func (t *TypedArray) Type() ObjectType { return TYPED_ARRAY_OBJ }
+func (t *TypedArray) Inspect() string {
+	return fmt.Sprintf("%sArray(length=%d)", t.Kind, t.Length)
+}
+
+// DataView represents an untyped, endian-aware view over a Buffer
+type DataView struct {
+	Buffer     *Buffer // Backing buffer (shared, not copied)
+	ByteOffset int     // Start offset into Buffer.Data
+	ByteLength int     // Number of bytes visible through the view
+}
+
+func (d *DataView) Type() ObjectType { return DATA_VIEW_OBJ }
+func (d *DataView) Inspect() string {
+	return fmt.Sprintf("DataView(offset=%d, length=%d)", d.ByteOffset, d.ByteLength)
+}
+
 // Worker represents a worker thread
 type Worker struct {
 	ID           int           // Unique worker ID
@@ -569,6 +731,8 @@ type Stream struct {
 	OnData        *Function    // Data event handler
 	OnEnd         *Function    // End event handler
 	OnError       *Function    // Error event handler
+	Source        io.Reader    // Backing reader pulled on demand (e.g. an HTTP request body)
+	Sink          io.Writer    // Backing writer written through (e.g. an HTTP response)
 	Mu            sync.RWMutex // Thread-safe access
 }
 
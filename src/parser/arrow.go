@@ -27,7 +27,7 @@ func (p *Parser) parseArrowFunctionExpression(left ast.Expression) ast.Expressio
 	p.nextToken()
 
 	if p.curTokenIs(lexer.LBRACE) {
-		fn.Body = p.parseBlockStatement()
+		fn.Body = p.parseFunctionBody()
 		return fn
 	}
 
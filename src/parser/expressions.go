@@ -157,7 +157,25 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 
 	for !p.peekTokenIs(lexer.RBRACE) {
 		p.nextToken()
-		key := p.parseExpression(LOWEST)
+
+		// Accessor properties: pao name() { ... } / set name(v) { ... }
+		if (p.curTokenIs(lexer.PAO) || p.curTokenIs(lexer.SET)) && !p.peekTokenIs(lexer.COLON) {
+			if !p.parseMapAccessor(mapLit) {
+				return nil
+			}
+			if !p.peekTokenIs(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
+				return nil
+			}
+			continue
+		}
+
+		var key ast.Expression
+		if p.curTokenIs(lexer.PAO) || p.curTokenIs(lexer.SET) {
+			// {pao: fn, set: fn} descriptor-style keys
+			key = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		} else {
+			key = p.parseExpression(LOWEST)
+		}
 
 		if !p.expectPeek(lexer.COLON) {
 			return nil
@@ -180,6 +198,48 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 	return mapLit
 }
 
+// parseMapAccessor parses a getter or setter inside a map literal
+func (p *Parser) parseMapAccessor(mapLit *ast.MapLiteral) bool {
+	isGetter := p.curTokenIs(lexer.PAO)
+	fn := &ast.FunctionLiteral{Token: p.curToken}
+
+	p.nextToken()
+	if !p.curTokenIs(lexer.IDENT) && !p.curTokenIs(lexer.STRING) {
+		p.errors = append(p.errors, fmt.Sprintf("expected property name after %s, got %s at line %d, column %d",
+			fn.Token.Literal, p.curToken.Type, p.curToken.Line, p.curToken.Column))
+		return false
+	}
+	name := p.curToken.Literal
+	fn.Name = &ast.Identifier{Token: p.curToken, Value: name}
+
+	if !p.expectPeek(lexer.LPAREN) {
+		return false
+	}
+	fn.Parameters = p.parseFunctionParameters()
+	if !p.expectPeek(lexer.LBRACE) {
+		return false
+	}
+	fn.Body = p.parseFunctionBody()
+
+	if isGetter {
+		if mapLit.Getters == nil {
+			mapLit.Getters = make(map[string]*ast.FunctionLiteral)
+		}
+		mapLit.Getters[name] = fn
+	} else {
+		if len(fn.Parameters) != 1 {
+			p.errors = append(p.errors, fmt.Sprintf("setter '%s' must take exactly one parameter at line %d, column %d",
+				name, fn.Token.Line, fn.Token.Column))
+			return false
+		}
+		if mapLit.Setters == nil {
+			mapLit.Setters = make(map[string]*ast.FunctionLiteral)
+		}
+		mapLit.Setters[name] = fn
+	}
+	return true
+}
+
 // parseFunctionLiteral parses kaj name(params) { body }
 func (p *Parser) parseFunctionLiteral() ast.Expression {
 	lit := &ast.FunctionLiteral{Token: p.curToken}
@@ -204,11 +264,17 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 	lit.Parameters = params
 	lit.RestParameter = restParam
 
+	returnType, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil
+	}
+	lit.ReturnType = returnType
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody()
 
 	// Check if this is a generator function by scanning for yield
 	lit.IsGenerator = lit.IsGenerator || p.containsYield(lit.Body)
@@ -241,11 +307,17 @@ func (p *Parser) parseAsyncFunctionLiteral() ast.Expression {
 	lit.Parameters = params
 	lit.RestParameter = restParam
 
+	returnType, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil
+	}
+	lit.ReturnType = returnType
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody()
 
 	return lit
 }
@@ -292,6 +364,9 @@ func (p *Parser) parseFunctionParametersWithRest() ([]*ast.Identifier, *ast.Iden
 		return p.parseRestOnlyParameters(identifiers)
 	}
 	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !p.parseParameterAnnotation(ident) {
+		return nil, nil
+	}
 	identifiers = append(identifiers, ident)
 	for p.peekTokenIs(lexer.COMMA) {
 		p.nextToken()
@@ -300,6 +375,9 @@ func (p *Parser) parseFunctionParametersWithRest() ([]*ast.Identifier, *ast.Iden
 			return p.parseRestOnlyParameters(identifiers)
 		}
 		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if !p.parseParameterAnnotation(ident) {
+			return nil, nil
+		}
 		identifiers = append(identifiers, ident)
 	}
 	if !p.expectPeek(lexer.RPAREN) {
@@ -313,6 +391,11 @@ func (p *Parser) parseRestOnlyParameters(identifiers []*ast.Identifier) ([]*ast.
 		return nil, nil
 	}
 	restParam := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	typ, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil, nil
+	}
+	restParam.Type = typ
 	if !p.expectPeek(lexer.RPAREN) {
 		return nil, nil
 	}
@@ -493,6 +576,16 @@ func (p *Parser) statementContainsYield(stmt ast.Statement) bool {
 		if p.containsYield(s.Body) {
 			return true
 		}
+	case *ast.BlockStatement:
+		return p.containsYield(s)
+	case *ast.LabeledStatement:
+		return p.statementContainsYield(s.Body)
+	case *ast.DeclarationList:
+		for _, decl := range s.Declarations {
+			if p.statementContainsYield(decl) {
+				return true
+			}
+		}
 	}
 	return false
 }
@@ -519,6 +612,12 @@ func (p *Parser) expressionContainsYield(expr ast.Expression) bool {
 		return p.expressionContainsYield(e.Left) || p.expressionContainsYield(e.Right)
 	case *ast.UnaryExpression:
 		return p.expressionContainsYield(e.Right)
+	case *ast.SequenceExpression:
+		for _, item := range e.Expressions {
+			if p.expressionContainsYield(item) {
+				return true
+			}
+		}
 	case *ast.ArrayLiteral:
 		for _, elem := range e.Elements {
 			if p.expressionContainsYield(elem) {
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"fmt"
+)
+
+// labelScope records a label visible to thamo/chharo inside its statement
+type labelScope struct {
+	name string
+	loop bool // only loop labels may be targeted by chharo
+}
+
+// parseLabeledStatement parses "label: statement"
+func (p *Parser) parseLabeledStatement() ast.Statement {
+	stmt := &ast.LabeledStatement{
+		Token: p.curToken,
+		Label: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+	}
+	for _, l := range p.labels {
+		if l.name == stmt.Label.Value {
+			p.errors = append(p.errors, fmt.Sprintf("label '%s' is already declared at line %d, column %d",
+				l.name, p.curToken.Line, p.curToken.Column))
+			break
+		}
+	}
+
+	p.nextToken() // ':'
+	p.nextToken()
+	loop := p.curTokenIs(lexer.GHURIYE) || p.curTokenIs(lexer.JOTOKKHON) || p.curTokenIs(lexer.DO)
+
+	p.labels = append(p.labels, labelScope{name: stmt.Label.Value, loop: loop})
+	if p.curTokenIs(lexer.LBRACE) {
+		stmt.Body = p.parseBlockStatement() // a labelled block, not a map literal
+	} else {
+		stmt.Body = p.parseStatement()
+	}
+	p.labels = p.labels[:len(p.labels)-1]
+
+	if stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseJumpLabel parses the optional label after thamo/chharo on the same line,
+// reporting labels that do not name an enclosing (loop) statement
+func (p *Parser) parseJumpLabel(isContinue bool) *ast.Identifier {
+	if !p.peekTokenIs(lexer.IDENT) || p.peekToken.Line != p.curToken.Line {
+		return nil
+	}
+	keyword := p.curToken.Literal
+	p.nextToken()
+	label := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	for i := len(p.labels) - 1; i >= 0; i-- {
+		if p.labels[i].name != label.Value {
+			continue
+		}
+		if isContinue && !p.labels[i].loop {
+			p.errors = append(p.errors, fmt.Sprintf("chharo target '%s' is not a loop at line %d, column %d",
+				label.Value, label.Token.Line, label.Token.Column))
+		}
+		return label
+	}
+
+	p.errors = append(p.errors, fmt.Sprintf("%s: undefined label '%s' at line %d, column %d",
+		keyword, label.Value, label.Token.Line, label.Token.Column))
+	return label
+}
+
+// parseFunctionBody parses a function block; labels never cross function boundaries
+func (p *Parser) parseFunctionBody() *ast.BlockStatement {
+	outer := p.labels
+	p.labels = nil
+	body := p.parseBlockStatement()
+	p.labels = outer
+	return body
+}
+
+// parseForInit parses a ghuriye initializer: "dhoro i = 0, j = 10" or "i = 0, j = 10"
+func (p *Parser) parseForInit() ast.Statement {
+	isDecl := p.curTokenIs(lexer.DHORO) || p.curTokenIs(lexer.STHIR) || p.curTokenIs(lexer.BISHWO)
+	if !isDecl {
+		stmt := &ast.ExpressionStatement{Token: p.curToken, Expression: p.parseSequenceExpression()}
+		if p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+		}
+		return stmt
+	}
+
+	declToken := p.curToken
+	first := p.parseStatement()
+	decl, ok := first.(*ast.VariableDeclaration)
+	if !ok || !p.peekTokenIs(lexer.COMMA) {
+		return first
+	}
+
+	list := &ast.DeclarationList{Token: declToken, Declarations: []*ast.VariableDeclaration{decl}}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		next := &ast.VariableDeclaration{
+			Token:      declToken,
+			Name:       &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+			IsConstant: decl.IsConstant,
+			IsGlobal:   decl.IsGlobal,
+		}
+		typ, ok := p.parseOptionalTypeAnnotation()
+		if !ok || !p.expectPeek(lexer.ASSIGN) {
+			return nil
+		}
+		next.Name.Type = typ
+		p.nextToken()
+		next.Value = p.parseExpression(LOWEST)
+		list.Declarations = append(list.Declarations, next)
+	}
+	if p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
+	}
+	return list
+}
+
+// parseSequenceExpression parses comma-separated expressions (the comma operator)
+func (p *Parser) parseSequenceExpression() ast.Expression {
+	first := p.parseExpression(LOWEST)
+	if !p.peekTokenIs(lexer.COMMA) {
+		return first
+	}
+
+	seq := &ast.SequenceExpression{Token: p.peekToken, Expressions: []ast.Expression{first}}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		seq.Expressions = append(seq.Expressions, p.parseExpression(LOWEST))
+	}
+	return seq
+}
@@ -0,0 +1,256 @@
+package parser
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"fmt"
+)
+
+// parseMatchExpression parses "milao (value) { khetre pattern jodi (guard) => result, ... }"
+func (p *Parser) parseMatchExpression() ast.Expression {
+	expr := &ast.MatchExpression{Token: p.curToken}
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+	p.nextToken()
+	expr.Subject = p.parseExpression(LOWEST)
+	if !p.expectPeek(lexer.RPAREN) || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACE) {
+		if !p.curTokenIs(lexer.KHETRE) {
+			p.errors = append(p.errors, fmt.Sprintf("expected khetre in milao, got %s at line %d, column %d",
+				p.curToken.Type, p.curToken.Line, p.curToken.Column))
+			return nil
+		}
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		expr.Arms = append(expr.Arms, arm)
+
+		p.nextToken()
+		if p.curTokenIs(lexer.COMMA) || p.curTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+		}
+	}
+
+	if len(expr.Arms) == 0 {
+		p.errors = append(p.errors, fmt.Sprintf("milao needs at least one khetre arm at line %d, column %d",
+			expr.Token.Line, expr.Token.Column))
+		return nil
+	}
+	return expr
+}
+
+// parseMatchArm parses "khetre pattern [jodi (guard)] => expression | { block }"
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{Token: p.curToken}
+	p.nextToken()
+	arm.Pattern = p.parsePattern()
+	if arm.Pattern == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(lexer.JODI) {
+		p.nextToken()
+		if !p.expectPeek(lexer.LPAREN) {
+			return nil
+		}
+		p.nextToken()
+		arm.Guard = p.parseExpression(LOWEST)
+		if !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(lexer.ARROW) {
+		return nil
+	}
+	p.nextToken()
+	if p.curTokenIs(lexer.LBRACE) {
+		arm.Block = p.parseBlockStatement()
+		return arm
+	}
+	arm.Result = p.parseExpression(LOWEST)
+	if arm.Result == nil {
+		return nil
+	}
+	return arm
+}
+
+// parsePattern parses alternatives and an optional "hisabe name" binding
+func (p *Parser) parsePattern() ast.Pattern {
+	first := p.parsePrimaryPattern()
+	if first == nil {
+		return nil
+	}
+	pattern := first
+	if p.peekTokenIs(lexer.PIPE) {
+		or := &ast.OrPattern{Token: p.peekToken, Alternatives: []ast.Pattern{first}}
+		for p.peekTokenIs(lexer.PIPE) {
+			p.nextToken()
+			p.nextToken()
+			alt := p.parsePrimaryPattern()
+			if alt == nil {
+				return nil
+			}
+			or.Alternatives = append(or.Alternatives, alt)
+		}
+		pattern = or
+	}
+
+	if p.peekTokenIs(lexer.HISABE) {
+		p.nextToken()
+		as := &ast.AsPattern{Token: p.curToken, Pattern: pattern}
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		as.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		pattern = as
+	}
+	return pattern
+}
+
+func (p *Parser) parsePrimaryPattern() ast.Pattern {
+	switch p.curToken.Type {
+	case lexer.IDENT:
+		if p.curToken.Literal == "_" {
+			return &ast.WildcardPattern{Token: p.curToken}
+		}
+		name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if p.peekTokenIs(lexer.LBRACE) {
+			p.nextToken()
+			cp := &ast.ClassPattern{Token: name.Token, Class: name}
+			fields := p.parseMapPattern()
+			if fields == nil {
+				return nil
+			}
+			if len(fields.Keys) > 0 || fields.Rest != nil {
+				cp.Fields = fields
+			}
+			return cp
+		}
+		return &ast.BindingPattern{Name: name}
+	case lexer.NUMBER, lexer.STRING, lexer.SOTTI, lexer.MITTHA, lexer.KHALI:
+		tok := p.curToken
+		return &ast.LiteralPattern{Token: tok, Value: p.parseExpressionAtom()}
+	case lexer.MINUS:
+		tok := p.curToken
+		if !p.expectPeek(lexer.NUMBER) {
+			return nil
+		}
+		value := &ast.UnaryExpression{Token: tok, Operator: "-", Right: p.parseNumberLiteral()}
+		return &ast.LiteralPattern{Token: tok, Value: value}
+	case lexer.LBRACKET:
+		return p.parseArrayPattern()
+	case lexer.LBRACE:
+		if mp := p.parseMapPattern(); mp != nil {
+			return mp
+		}
+		return nil
+	case lexer.LPAREN:
+		p.nextToken()
+		inner := p.parsePattern()
+		if inner == nil || !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+		return inner
+	}
+	p.errors = append(p.errors, fmt.Sprintf("invalid pattern %s at line %d, column %d",
+		p.curToken.Type, p.curToken.Line, p.curToken.Column))
+	return nil
+}
+
+// parseExpressionAtom parses a single literal token with its registered prefix function
+func (p *Parser) parseExpressionAtom() ast.Expression {
+	return p.prefixParseFns[p.curToken.Type]()
+}
+
+// parseArrayPattern parses "[p1, p2, ...rest]" starting at '['
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	ap := &ast.ArrayPattern{Token: p.curToken}
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACKET) {
+		if p.curTokenIs(lexer.DOTDOTDOT) {
+			ap.HasRest = true
+			if p.peekTokenIs(lexer.IDENT) {
+				p.nextToken()
+				ap.Rest = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			}
+			if !p.expectPeek(lexer.RBRACKET) {
+				return nil
+			}
+			break
+		}
+
+		el := p.parsePattern()
+		if el == nil {
+			return nil
+		}
+		ap.Elements = append(ap.Elements, el)
+		p.nextToken()
+		if p.curTokenIs(lexer.COMMA) {
+			p.nextToken()
+		} else if !p.curTokenIs(lexer.RBRACKET) {
+			p.errors = append(p.errors, fmt.Sprintf("expected , or ] in array pattern, got %s at line %d, column %d",
+				p.curToken.Type, p.curToken.Line, p.curToken.Column))
+			return nil
+		}
+	}
+	return ap
+}
+
+// parseMapPattern parses "{key, key: pattern, \"quoted\": pattern, ...rest}" starting at '{'
+func (p *Parser) parseMapPattern() *ast.MapPattern {
+	mp := &ast.MapPattern{Token: p.curToken}
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACE) {
+		if p.curTokenIs(lexer.DOTDOTDOT) {
+			if !p.expectPeek(lexer.IDENT) {
+				return nil
+			}
+			mp.Rest = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			if !p.expectPeek(lexer.RBRACE) {
+				return nil
+			}
+			break
+		}
+
+		if !p.curTokenIs(lexer.IDENT) && !p.curTokenIs(lexer.STRING) {
+			p.errors = append(p.errors, fmt.Sprintf("expected key in map pattern, got %s at line %d, column %d",
+				p.curToken.Type, p.curToken.Line, p.curToken.Column))
+			return nil
+		}
+		keyTok := p.curToken
+		var value ast.Pattern
+		if p.peekTokenIs(lexer.COLON) {
+			p.nextToken()
+			p.nextToken()
+			value = p.parsePattern()
+			if value == nil {
+				return nil
+			}
+		} else if keyTok.Type == lexer.IDENT {
+			value = &ast.BindingPattern{Name: &ast.Identifier{Token: keyTok, Value: keyTok.Literal}}
+		} else {
+			p.errors = append(p.errors, fmt.Sprintf("quoted key %q in map pattern needs a pattern at line %d, column %d",
+				keyTok.Literal, keyTok.Line, keyTok.Column))
+			return nil
+		}
+		mp.Keys = append(mp.Keys, keyTok.Literal)
+		mp.Values = append(mp.Values, value)
+
+		p.nextToken()
+		if p.curTokenIs(lexer.COMMA) {
+			p.nextToken()
+		} else if !p.curTokenIs(lexer.RBRACE) {
+			p.errors = append(p.errors, fmt.Sprintf("expected , or } in map pattern, got %s at line %d, column %d",
+				p.curToken.Type, p.curToken.Line, p.curToken.Column))
+			return nil
+		}
+	}
+	return mp
+}
@@ -23,6 +23,8 @@ type Parser struct {
 
 	prefixParseFns map[lexer.TokenType]prefixParseFn
 	infixParseFns  map[lexer.TokenType]infixParseFn
+
+	labels []labelScope // labelled statements enclosing the current position
 }
 
 // New creates a new parser from a lexer
@@ -58,6 +60,7 @@ func (p *Parser) registerPrefixParsers() {
 	p.registerPrefix(lexer.NOTUN, p.parseNewExpression)
 	p.registerPrefix(lexer.DOTDOTDOT, p.parseSpreadElement)
 	p.registerPrefix(lexer.DELETE, p.parseDeleteExpression)
+	p.registerPrefix(lexer.MILAO, p.parseMatchExpression)
 }
 
 func (p *Parser) registerInfixParsers() {
@@ -40,6 +40,11 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseThrowStatement()
 	case lexer.BIKOLPO:
 		return p.parseSwitchStatement()
+	case lexer.IDENT:
+		if p.peekTokenIs(lexer.COLON) {
+			return p.parseLabeledStatement()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -71,6 +76,12 @@ func (p *Parser) parseVariableDeclaration(isConstant bool, isGlobal bool) ast.St
 
 	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 
+	typ, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil
+	}
+	stmt.Name.Type = typ
+
 	if !p.expectPeek(lexer.ASSIGN) {
 		return nil
 	}
@@ -177,9 +188,12 @@ func (p *Parser) parseForStatement() ast.Statement {
 func (p *Parser) parseClassicForStatement(forToken lexer.Token) ast.Statement {
 	stmt := &ast.ForStatement{Token: forToken}
 
-	// Parse init statement
+	// Parse init statement (declarations or expressions may be comma-separated)
 	if !p.curTokenIs(lexer.SEMICOLON) {
-		stmt.Init = p.parseStatement()
+		stmt.Init = p.parseForInit()
+		if stmt.Init == nil {
+			return nil
+		}
 	}
 
 	if !p.curTokenIs(lexer.SEMICOLON) {
@@ -192,7 +206,7 @@ func (p *Parser) parseClassicForStatement(forToken lexer.Token) ast.Statement {
 
 	// Parse condition
 	if !p.curTokenIs(lexer.SEMICOLON) {
-		stmt.Condition = p.parseExpression(LOWEST)
+		stmt.Condition = p.parseSequenceExpression()
 	}
 
 	if !p.expectPeek(lexer.SEMICOLON) {
@@ -203,7 +217,7 @@ func (p *Parser) parseClassicForStatement(forToken lexer.Token) ast.Statement {
 
 	// Parse update
 	if !p.curTokenIs(lexer.RPAREN) {
-		stmt.Update = p.parseExpression(LOWEST)
+		stmt.Update = p.parseSequenceExpression()
 	}
 
 	if !p.expectPeek(lexer.RPAREN) {
@@ -287,6 +301,11 @@ func (p *Parser) parseClassDeclaration() *ast.ClassDeclaration {
 			if constructor != nil {
 				stmt.Methods = append(stmt.Methods, constructor)
 			}
+		} else if p.curTokenIs(lexer.IDENT) && p.peekTokenIs(lexer.COLON) {
+			// Parse annotated field: naam: string;
+			if field := p.parseFieldDeclaration(); field != nil {
+				stmt.Fields = append(stmt.Fields, field)
+			}
 		}
 		p.nextToken()
 	}
@@ -313,11 +332,17 @@ func (p *Parser) parseGetter() *ast.FunctionLiteral {
 		return nil
 	}
 
+	returnType, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil
+	}
+	lit.ReturnType = returnType
+
 	if !p.expectPeek(lexer.LBRACE) {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody()
 	lit.Parameters = []*ast.Identifier{}
 
 	return lit
@@ -349,7 +374,7 @@ func (p *Parser) parseSetter() *ast.FunctionLiteral {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody()
 
 	return lit
 }
@@ -394,23 +419,25 @@ func (p *Parser) parseConstructor() *ast.FunctionLiteral {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody()
 
 	return lit
 }
 
-// parseBreakStatement parses "thamo"
+// parseBreakStatement parses "thamo" or "thamo label"
 func (p *Parser) parseBreakStatement() *ast.BreakStatement {
 	stmt := &ast.BreakStatement{Token: p.curToken}
+	stmt.Label = p.parseJumpLabel(false)
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
 	}
 	return stmt
 }
 
-// parseContinueStatement parses "chharo"
+// parseContinueStatement parses "chharo" or "chharo label"
 func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
 	stmt := &ast.ContinueStatement{Token: p.curToken}
+	stmt.Label = p.parseJumpLabel(true)
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
 	}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"fmt"
+)
+
+// parseOptionalTypeAnnotation parses ": Type" if the next token is a colon.
+// ok is false when a colon was present but the type after it was malformed.
+func (p *Parser) parseOptionalTypeAnnotation() (*ast.TypeAnnotation, bool) {
+	if !p.peekTokenIs(lexer.COLON) {
+		return nil, true
+	}
+	p.nextToken() // ':'
+	p.nextToken() // first type token
+	typ := p.parseTypeAnnotation()
+	return typ, typ != nil
+}
+
+// parseTypeAnnotation parses "A | B[] | (C | D)[]" starting at the current token
+func (p *Parser) parseTypeAnnotation() *ast.TypeAnnotation {
+	first := p.parseArrayType()
+	if first == nil {
+		return nil
+	}
+	if !p.peekTokenIs(lexer.PIPE) {
+		return first
+	}
+
+	union := &ast.TypeAnnotation{Token: first.Token, Union: []*ast.TypeAnnotation{first}}
+	for p.peekTokenIs(lexer.PIPE) {
+		p.nextToken() // '|'
+		p.nextToken()
+		alt := p.parseArrayType()
+		if alt == nil {
+			return nil
+		}
+		union.Union = append(union.Union, alt)
+	}
+	return union
+}
+
+// parseArrayType parses a primary type followed by any number of "[]" suffixes
+func (p *Parser) parseArrayType() *ast.TypeAnnotation {
+	var typ *ast.TypeAnnotation
+	switch {
+	case p.curTokenIs(lexer.IDENT):
+		typ = &ast.TypeAnnotation{Token: p.curToken, Name: p.curToken.Literal}
+	case p.curTokenIs(lexer.KHALI):
+		typ = &ast.TypeAnnotation{Token: p.curToken, Name: "khali"}
+	case p.curTokenIs(lexer.KAJ):
+		typ = &ast.TypeAnnotation{Token: p.curToken, Name: "kaj"}
+	case p.curTokenIs(lexer.LPAREN):
+		p.nextToken()
+		typ = p.parseTypeAnnotation()
+		if typ == nil || !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+	default:
+		p.errors = append(p.errors, fmt.Sprintf("expected type annotation, got %s at line %d, column %d",
+			p.curToken.Type, p.curToken.Line, p.curToken.Column))
+		return nil
+	}
+
+	for p.peekTokenIs(lexer.LBRACKET) {
+		p.nextToken()
+		if !p.expectPeek(lexer.RBRACKET) {
+			return nil
+		}
+		typ = &ast.TypeAnnotation{Token: typ.Token, Elem: typ}
+	}
+	return typ
+}
+
+// parseParameterAnnotation parses the optional ": Type" after a parameter name
+func (p *Parser) parseParameterAnnotation(ident *ast.Identifier) bool {
+	typ, ok := p.parseOptionalTypeAnnotation()
+	ident.Type = typ
+	return ok
+}
+
+// parseFieldDeclaration parses an annotated class field: naam: string;
+func (p *Parser) parseFieldDeclaration() *ast.FieldDeclaration {
+	field := &ast.FieldDeclaration{
+		Token: p.curToken,
+		Name:  &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+	}
+	typ, ok := p.parseOptionalTypeAnnotation()
+	if !ok {
+		return nil
+	}
+	field.Type = typ
+	field.Name.Type = typ
+
+	if p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
+	}
+	return field
+}
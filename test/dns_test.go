@@ -0,0 +1,195 @@
+package test
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+// DNS record types answered by the stub server
+const (
+	dnsTypeA     = 1
+	dnsTypeNS    = 2
+	dnsTypeCNAME = 5
+	dnsTypePTR   = 12
+	dnsTypeMX    = 15
+	dnsTypeTXT   = 16
+	dnsTypeAAAA  = 28
+	dnsTypeSRV   = 33
+)
+
+type stubRecord struct {
+	name  string
+	qtype uint16
+	rdata []byte
+}
+
+func dnsName(name string) []byte {
+	var out []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	return append(out, 0)
+}
+
+func dnsUint16(values ...uint16) []byte {
+	out := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(out[2*i:], v)
+	}
+	return out
+}
+
+var stubZone = []stubRecord{
+	{"api.test.", dnsTypeA, net.ParseIP("192.0.2.1").To4()},
+	{"api.test.", dnsTypeA, net.ParseIP("192.0.2.2").To4()},
+	{"api.test.", dnsTypeAAAA, net.ParseIP("2001:db8::1")},
+	{"www.test.", dnsTypeCNAME, dnsName("api.test.")},
+	{"mail.test.", dnsTypeMX, append(dnsUint16(20), dnsName("mx2.test.")...)},
+	{"mail.test.", dnsTypeMX, append(dnsUint16(10), dnsName("mx1.test.")...)},
+	{"mail.test.", dnsTypeTXT, append([]byte{11}, "v=spf1 -all"...)},
+	{"_sip._tcp.test.", dnsTypeSRV, append(dnsUint16(10, 5, 5060), dnsName("sip.test.")...)},
+	{"10.2.0.192.in-addr.arpa.", dnsTypePTR, dnsName("host.test.")},
+	{"test.", dnsTypeNS, dnsName("ns1.test.")},
+}
+
+// startStubDNS answers queries for stubZone over UDP, following CNAMEs, and
+// returns NXDOMAIN for unknown names
+func startStubDNS(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := stubDNSResponse(buf[:n]); resp != nil {
+				conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func stubDNSResponse(query []byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	// question: labels, then type and class
+	var labels []string
+	i := 12
+	for i < len(query) && query[i] != 0 {
+		l := int(query[i])
+		if i+1+l > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[i+1:i+1+l]))
+		i += 1 + l
+	}
+	if i+5 > len(query) {
+		return nil
+	}
+	question := query[12 : i+5]
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	qtype := binary.BigEndian.Uint16(query[i+1:])
+
+	var answers []byte
+	count := 0
+	addAnswers := func(owner string, qtype uint16) {
+		for _, rr := range stubZone {
+			if rr.name == owner && rr.qtype == qtype {
+				answers = append(answers, dnsName(owner)...)
+				answers = append(answers, dnsUint16(qtype, 1, 0, 60, uint16(len(rr.rdata)))...)
+				answers = append(answers, rr.rdata...)
+				count++
+			}
+		}
+	}
+	if qtype != dnsTypeCNAME {
+		for _, rr := range stubZone {
+			if rr.name == name && rr.qtype == dnsTypeCNAME {
+				addAnswers(name, dnsTypeCNAME)
+				name = "api.test."
+				break
+			}
+		}
+	}
+	addAnswers(name, qtype)
+
+	rcode := uint16(0)
+	if count == 0 {
+		known := false
+		for _, rr := range stubZone {
+			known = known || rr.name == name
+		}
+		if !known {
+			rcode = 3 // NXDOMAIN
+		}
+	}
+	resp := append([]byte{}, query[:2]...)
+	resp = append(resp, dnsUint16(0x8180|rcode, 1, uint16(count), 0, 0)...)
+	resp = append(resp, question...)
+	return append(resp, answers...)
+}
+
+// TestDNSLookups tests every record type through dns_khojo against a stub server
+func TestDNSLookups(t *testing.T) {
+	server := startStubDNS(t)
+	testEval(`dns_server("` + server + `")`)
+	defer testEval(`dns_server(khali)`)
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`dns_khojo("api.test")`, []string{"192.0.2.1", "192.0.2.2"}},
+		{`dns_khojo("api.test", "AAAA")`, []string{"2001:db8::1"}},
+		{`[dns_khojo("www.test", "cname")]`, []string{"api.test"}},
+		{`dhoro mx = dns_khojo("mail.test", "MX"); [mx[0].host, lipi(mx[0].priority), mx[1].host]`, []string{"mx1.test", "10", "mx2.test"}},
+		{`dns_khojo("mail.test", "TXT")`, []string{"v=spf1 -all"}},
+		{`dhoro s = dns_khojo("_sip._tcp.test", "SRV")[0]; [s.target, lipi(s.port), lipi(s.priority), lipi(s.weight)]`, []string{"sip.test", "5060", "10", "5"}},
+		{`dns_khojo("192.0.2.10", "PTR")`, []string{"host.test"}},
+		{`dns_khojo("test", "NS")`, []string{"ns1.test"}},
+		{`dns_server()`, []string{server}},
+	}
+	for _, tt := range tests {
+		testStringArray(t, testEval(tt.input), tt.expected)
+	}
+}
+
+// TestDNSAsyncAndErrors tests dns_khojo_async, the per-call server option and error reporting
+func TestDNSAsyncAndErrors(t *testing.T) {
+	server := startStubDNS(t)
+
+	input := `
+	proyash kaj lookup() {
+		ferao opekha dns_khojo_async("api.test", "A", {server: "` + server + `"});
+	}
+	opekha lookup();
+	`
+	testStringArray(t, testEval(input), []string{"192.0.2.1", "192.0.2.2"})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dns_khojo("missing.test", "A", {server: "` + server + `"})`, "no A records for missing.test"},
+		{`dns_khojo("api.test", "SOA")`, "unsupported record type"},
+		{`dns_khojo("api.test", "PTR", {server: "` + server + `"})`, "PTR lookup needs an IP address"},
+		{`dns_khojo("api.test", "A", {timeout: 0})`, "`timeout` option"},
+		{`dns_khojo(42)`, "must be STRING"},
+		{`dns_server(5)`, "must be STRING"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
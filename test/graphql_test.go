@@ -0,0 +1,336 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const graphqlSchema = `
+dhoro sdl = '
+  type Query {
+    hello(naam: String = "World"): String!
+    user(id: ID!): User
+    users(first: Int = 10): [User!]!
+    search(text: String!): [SearchResult!]!
+    node(id: ID!): Node
+    boom: String
+    strict: String!
+  }
+  type Mutation {
+    addUser(input: NewUser!): User!
+  }
+  input NewUser {
+    naam: String!
+    role: Role = MEMBER
+  }
+  enum Role { ADMIN MEMBER }
+  interface Node { id: ID! }
+  "A person"
+  type User implements Node {
+    id: ID!
+    naam: String!
+    role: Role!
+    friends: [User!]!
+    old: String @deprecated(reason: "use naam")
+  }
+  type Post implements Node {
+    id: ID!
+    title: String!
+  }
+  union SearchResult = User | Post
+';
+
+dhoro users = [
+  {id: "1", naam: "Rahim", role: "ADMIN", __typename: "User"},
+  {id: "2", naam: "Karim", role: "MEMBER", __typename: "User"}
+];
+dhoro posts = [{id: "p1", title: "Hello", __typename: "Post"}];
+
+kaj khojo(list, id) {
+  ghuriye (dhoro i = 0; i < dorghyo(list); i = i + 1) {
+    jodi (list[i].id == id) { ferao list[i]; }
+  }
+  ferao khali;
+}
+
+dhoro resolvers = {
+  Query: {
+    hello: kaj(parent, args) { ferao "Hello, " + args.naam; },
+    user: proyash kaj(parent, args) { ferao khojo(users, args.id); },
+    users: kaj(parent, args) { ferao kato(users, 0, args.first); },
+    search: kaj(parent, args) { ferao [users[0], posts[0]]; },
+    node: kaj(parent, args) {
+      dhoro u = khojo(users, args.id);
+      jodi (u == khali) { ferao khojo(posts, args.id); }
+      ferao u;
+    },
+    boom: kaj() { felo "resolver exploded"; },
+    strict: kaj() { ferao khali; }
+  },
+  Mutation: {
+    addUser: kaj(parent, args, context) {
+      dhoro u = {id: lipi(dorghyo(users) + 1), naam: args.input.naam, role: args.input.role, __typename: "User"};
+      dhokao(users, u);
+      ferao u;
+    }
+  },
+  User: {
+    friends: kaj(u) { ferao users; }
+  }
+};
+`
+
+// TestGraphQLQueries tests resolvers, arguments, variables, fragments, aliases, directives,
+// abstract types and mutations through schema.chalao
+func TestGraphQLQueries(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`s.chalao("{ hello }")`, `{"data":{"hello":"Hello, World"}}`},
+		{`s.chalao("query ($n: String) { hello(naam: $n) }", {n: "Dhaka"})`, `{"data":{"hello":"Hello, Dhaka"}}`},
+		{`s.chalao('{ user(id: "1") { naam role } nobody: user(id: 9) { naam } }')`,
+			`{"data":{"nobody":null,"user":{"naam":"Rahim","role":"ADMIN"}}}`},
+		{`s.chalao("{ users(first: 1) { ...basic friends { naam } } } fragment basic on User { id naam }")`,
+			`{"data":{"users":[{"friends":[{"naam":"Rahim"},{"naam":"Karim"}],"id":"1","naam":"Rahim"}]}}`},
+		{`s.chalao("query ($skip: Boolean!) { users { id naam @skip(if: $skip) role @include(if: $skip) } }", {skip: sotti})`,
+			`{"data":{"users":[{"id":"1","role":"ADMIN"},{"id":"2","role":"MEMBER"}]}}`},
+		{`s.chalao('{ search(text: "x") { __typename ... on User { naam } ... on Post { title } } node(id: "p1") { id ... on Post { title } } }')`,
+			`{"data":{"node":{"id":"p1","title":"Hello"},"search":[{"__typename":"User","naam":"Rahim"},{"__typename":"Post","title":"Hello"}]}}`},
+		{`s.chalao("mutation Add($input: NewUser!) { addUser(input: $input) { id naam role } }", {input: {naam: "Salma"}})`,
+			`{"data":{"addUser":{"id":"3","naam":"Salma","role":"MEMBER"}}}`},
+		{`s.chalao("query A { hello } query B { user(id: 2) { naam } }", khali, {operationName: "B"})`,
+			`{"data":{"user":{"naam":"Karim"}}}`},
+		{`s.chalao("{ hello boom }")`,
+			`{"data":{"boom":null,"hello":"Hello, World"},"errors":[{"locations":[{"column":9,"line":1}],"message":"resolver exploded","path":["boom"]}]}`},
+		{`s.chalao("{ hello strict }")`,
+			`{"data":null,"errors":[{"locations":[{"column":9,"line":1}],"message":"Cannot return null for non-nullable field Query.strict.","path":["strict"]}]}`},
+	}
+	for _, tt := range tests {
+		result := evalServer(graphqlSchema + `dhoro s = graphql_banao(sdl, resolvers); json_banao(` + tt.input + `)`)
+		testStringObject(t, result, tt.expected)
+	}
+}
+
+// TestGraphQLValidation tests that invalid documents are rejected before any resolver runs
+func TestGraphQLValidation(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`{ hello(`, "Syntax Error"},
+		{`{ missing }`, `Cannot query field \"missing\" on type \"Query\".`},
+		{`{ user { naam } }`, `Argument \"id\" of type \"ID!\" is required on field \"Query.user\"`},
+		{`{ user(id: 1) }`, `must have a selection of subfields`},
+		{`{ hello { x } }`, `must not have a selection`},
+		{`{ users(first: "ten") { id } }`, `Expected value of type \"Int\", found \"ten\".`},
+		{`query ($n: Int) { hello(naam: $n) }`, `Variable \"$n\" of type \"Int\" used in position expecting type \"String\".`},
+		{`{ hello(naam: $x) }`, `Variable \"$x\" is not defined.`},
+		{`{ ...a } fragment a on Query { ...a }`, `Cannot spread fragment \"a\" within itself.`},
+		{`{ hello @nope }`, `Unknown directive \"@nope\".`},
+		{`subscription { hello }`, `Subscriptions are not supported.`},
+		{`mutation { addUser(input: {role: ADMIN}) { id } }`, `Field \"NewUser.naam\" of required type \"String!\" was not provided.`},
+	}
+	for _, tt := range tests {
+		result := evalServer(graphqlSchema + `dhoro s = graphql_banao(sdl, resolvers); json_banao(s.chalao('` + tt.query + `'))`)
+		str, ok := result.(*object.String)
+		if !ok || strings.Contains(str.Value, `"data"`) || !strings.Contains(str.Value, tt.expected) {
+			t.Errorf("%s: got %s, want error containing %s", tt.query, result.Inspect(), tt.expected)
+		}
+	}
+
+	result := evalServer(graphqlSchema + `dhoro s = graphql_banao(sdl, resolvers); json_banao(s.chalao("query ($id: ID!) { user(id: $id) { id } }", {id: 1.5}))`)
+	if str, ok := result.(*object.String); !ok || !strings.Contains(str.Value, `got invalid value 1.5`) {
+		t.Errorf("bad variable: got %s", result.Inspect())
+	}
+
+	errors := []struct {
+		input    string
+		expected string
+	}{
+		{`graphql_banao("type Query { a: Missing }")`, `Unknown type \"Missing\"`},
+		{`graphql_banao("type Query { a: String } type User implements Node { id: ID } interface Node { id: ID! }")`, "Interface field Node.id expects type ID!"},
+		{`graphql_banao("type Query { a: String }", {Query: {b: kaj() {}}})`, "Query.b"},
+		{`graphql_banao("type Query { a: String }", {Nope: {}})`, "unknown type"},
+		{`graphql_banao("type Query { a: String }", {}, {maxDepth: "x"})`, "`maxDepth` option"},
+		{`graphql_chalu(router_banao(), "/graphql", {})`, "not a valid GraphQL schema"},
+	}
+	for i, tt := range errors {
+		testErrorObject(t, testEval(tt.input), strings.ReplaceAll(tt.expected, `\"`, `"`), i)
+	}
+}
+
+// TestGraphQLIntrospectionAndLimits tests __schema/__type, deprecation, and depth and complexity limits
+func TestGraphQLIntrospectionAndLimits(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`s.chalao('{ __type(name: "User") { kind name description interfaces { name } fields { name type { kind ofType { name } } } } }')`,
+			`{"data":{"__type":{"description":"A person","fields":[{"name":"id","type":{"kind":"NON_NULL","ofType":{"name":"ID"}}},{"name":"naam","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},{"name":"role","type":{"kind":"NON_NULL","ofType":{"name":"Role"}}},{"name":"friends","type":{"kind":"NON_NULL","ofType":{"name":null}}}],"interfaces":[{"name":"Node"}],"kind":"OBJECT","name":"User"}}}`},
+		{`s.chalao('{ __type(name: "User") { fields(includeDeprecated: true) { name isDeprecated deprecationReason } } }').data.__type.fields[4]`,
+			`{"deprecationReason":"use naam","isDeprecated":true,"name":"old"}`},
+		{`s.chalao("{ __schema { queryType { name } mutationType { name } subscriptionType { name } } }")`,
+			`{"data":{"__schema":{"mutationType":{"name":"Mutation"},"queryType":{"name":"Query"},"subscriptionType":null}}}`},
+		{`s.chalao('{ __type(name: "Node") { possibleTypes { name } } r: __type(name: "Role") { enumValues { name } } }')`,
+			`{"data":{"__type":{"possibleTypes":[{"name":"User"},{"name":"Post"}]},"r":{"enumValues":[{"name":"ADMIN"},{"name":"MEMBER"}]}}}`},
+		{`graphql_banao(sdl, resolvers, {introspection: mittha}).chalao("{ __schema { types { name } } }").errors[0].message`,
+			`GraphQL introspection is not allowed, but the query contained "__schema".`},
+		{`graphql_banao(sdl, resolvers, {maxDepth: 2}).chalao("{ users { friends { friends { id } } } }").errors[0].message`,
+			`Query depth 4 exceeds the maximum allowed depth of 2.`},
+		{`graphql_banao(sdl, resolvers, {maxComplexity: 50}).chalao("query ($n: Int) { users(first: $n) { id naam friends { id } } }", {n: 20}).errors[0].message`,
+			`Query complexity 81 exceeds the maximum allowed complexity of 50.`},
+		{`json_banao(graphql_banao(sdl, resolvers, {maxComplexity: 50}).chalao("{ users(first: 2) { id } __schema { types { name } } }").data.users)`,
+			`[{"id":"1"},{"id":"2"}]`},
+	}
+	for _, tt := range tests {
+		input := tt.input
+		if strings.HasPrefix(input, "s.") {
+			input = "json_banao(" + input + ")"
+		}
+		result := evalServer(graphqlSchema + `dhoro s = graphql_banao(sdl, resolvers); ` + input)
+		testStringObject(t, result, tt.expected)
+	}
+}
+
+// TestGraphQLFragmentLimits tests that fragments spread repeatedly are measured once, so a
+// query that doubles its size with every fragment is rejected without being expanded
+func TestGraphQLFragmentLimits(t *testing.T) {
+	var query strings.Builder
+	query.WriteString("{ users { ...F26 } } fragment F0 on User { id }")
+	for i := 1; i <= 26; i++ {
+		fmt.Fprintf(&query, " fragment F%d on User { a: friends { ...F%d } b: friends { ...F%d } }", i, i-1, i-1)
+	}
+
+	tests := []struct {
+		options  string
+		query    string
+		expected string
+	}{
+		{`{maxDepth: 10}`, query.String(), `Query depth 28 exceeds the maximum allowed depth of 10.`},
+		{`{maxDepth: 100, maxComplexity: 1000}`, query.String(), `Query complexity 1552 exceeds the maximum allowed complexity of 1000.`},
+		{`{maxComplexity: 2}`, "{ users { ...F ...F } } fragment F on User { id }", `{"data":{"users":[{"id":"1"},{"id":"2"}]}}`},
+	}
+	for _, tt := range tests {
+		start := time.Now()
+		result := testEval(graphqlSchema + `
+		dhoro r = graphql_banao(sdl, resolvers, ` + tt.options + `).chalao("` + tt.query + `");
+		jodi (r.errors) { r.errors[0].message } nahole { json_banao(r) }`)
+		testStringObject(t, result, tt.expected)
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("checking the limits took %v", elapsed)
+		}
+	}
+}
+
+func graphqlPost(t *testing.T, endpoint, contentType, body string, headers map[string]string) (int, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest("POST", endpoint, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decodeGraphQL(t, resp)
+}
+
+func decodeGraphQL(t *testing.T, resp *http.Response) (int, map[string]interface{}) {
+	t.Helper()
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("response is not JSON: %q", raw)
+	}
+	return resp.StatusCode, out
+}
+
+// TestGraphQLHTTP tests serving a schema with graphql_chalu over GET and POST
+func TestGraphQLHTTP(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, graphqlSchema+`
+	resolvers.Query.hello = kaj(parent, args, context) { ferao "Hello, " + context.user; };
+	dhoro s = graphql_banao(sdl, resolvers);
+	dhoro pahara = kaj(req, res, agorao) {
+		jodi (req.headers["Authorization"] == "secret") {
+			agorao();
+		} nahole {
+			res.status = 401;
+			res.body = "no";
+		}
+	};
+	graphql_chalu(app, "/graphql", s, {
+		majhe: pahara,
+		context: proyash kaj(req) { ferao {user: req.headers["X-User"]}; }
+	});
+	`)
+	auth := map[string]string{"Authorization": "secret", "X-User": "Rahim"}
+	endpoint := base + "/graphql"
+
+	status, out := graphqlPost(t, endpoint, "application/json",
+		`{"query":"query Q($id: ID!) { hello user(id: $id) { naam } }","variables":{"id":"2"},"operationName":"Q"}`, auth)
+	data, _ := out["data"].(map[string]interface{})
+	if status != 200 || data["hello"] != "Hello, Rahim" || data["user"].(map[string]interface{})["naam"] != "Karim" {
+		t.Errorf("POST json: %d %v", status, out)
+	}
+
+	status, out = graphqlPost(t, endpoint, "application/graphql", `{ users { id } }`, auth)
+	if status != 200 || len(out["data"].(map[string]interface{})["users"].([]interface{})) != 2 {
+		t.Errorf("POST graphql: %d %v", status, out)
+	}
+
+	req, _ := http.NewRequest("GET", endpoint+"?query="+url.QueryEscape(`{ hello }`), nil)
+	req.Header.Set("Authorization", "secret")
+	req.Header.Set("X-User", "Karim")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, out := decodeGraphQL(t, resp); status != 200 || out["data"].(map[string]interface{})["hello"] != "Hello, Karim" {
+		t.Errorf("GET: %d %v", status, out)
+	}
+
+	req, _ = http.NewRequest("GET", endpoint+"?query="+url.QueryEscape(`mutation { addUser(input: {naam: "x"}) { id } }`), nil)
+	req.Header.Set("Authorization", "secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("Allow") != "POST" {
+		t.Errorf("mutation over GET: Allow = %q", resp.Header.Get("Allow"))
+	}
+	if status, out := decodeGraphQL(t, resp); status != http.StatusMethodNotAllowed || out["data"] != nil {
+		t.Errorf("mutation over GET: %d %v", status, out)
+	}
+
+	if status, out := graphqlPost(t, endpoint, "application/json", `{"query":"{ nope }"}`, auth); status != 400 || out["errors"] == nil {
+		t.Errorf("invalid query: %d %v", status, out)
+	}
+	if status, out := graphqlPost(t, endpoint, "application/json", `{"variables":{}}`, auth); status != 400 ||
+		!strings.Contains(out["errors"].([]interface{})[0].(map[string]interface{})["message"].(string), "Must provide query string") {
+		t.Errorf("missing query: %d %v", status, out)
+	}
+	if status, _ := graphqlPost(t, endpoint, "text/plain", `{ hello }`, auth); status != 400 {
+		t.Errorf("text/plain: %d", status)
+	}
+
+	resp, err = http.Post(endpoint, "application/json", strings.NewReader(`{"query":"{ hello }"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 401 {
+		t.Errorf("middleware: status %d", resp.StatusCode)
+	}
+}
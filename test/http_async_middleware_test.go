@@ -0,0 +1,99 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// asyncGet fetches a path and returns status, X-Trace header and body
+func asyncGet(t *testing.T, url string) (int, string, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, resp.Header.Get("X-Trace"), string(body)
+}
+
+// TestAsyncHandlersAndOnionMiddleware tests awaited proyash handlers and `opekha agorao()`
+func TestAsyncHandlersAndOnionMiddleware(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.majhe(proyash kaj(req, res, agorao) {
+		res.headers["X-Trace"] = "before";
+		opekha agorao();
+		res.headers["X-Trace"] = res.headers["X-Trace"] + ">after:" + res.body;
+	});
+	app.majhe(kaj(req, res, agorao) {
+		res.headers["X-Trace"] = res.headers["X-Trace"] + ">sync";
+		agorao();
+	});
+	app.ana("/slow", proyash kaj(req, res) {
+		opekha ghumaao(20);
+		res.status = 201;
+		res.body = "done";
+	});`)
+
+	status, trace, body := asyncGet(t, base+"/slow")
+	if status != 201 || body != "done" || trace != "before>sync>after:done" {
+		t.Errorf("got %d %q %q", status, trace, body)
+	}
+}
+
+// TestAsyncRejectionsReachErrorHandler tests rejected promises and errors in the chain
+func TestAsyncRejectionsReachErrorHandler(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	bhul_sambhalo(app, proyash kaj(err, req, res) {
+		opekha ghumaao(5);
+		json_uttor(res, {handled: req.path}, 502);
+	});
+	app.majhe(kaj(req, res, agorao) { agorao(); });
+	app.ana("/reject", proyash kaj(req, res) {
+		opekha ghumaao(5);
+		felo "boom";
+	});
+	app.ana("/sync-error", kaj(req, res) { ferao undefined_function(); });
+	app.ana("/caught", kaj(req, res) { res.body = "fine"; }, {majhe: proyash kaj(req, res, agorao) {
+		chesta {
+			opekha agorao();
+		} dhoro_bhul (e) {
+			res.body = "never";
+		}
+	}});`)
+
+	for _, path := range []string{"/reject", "/sync-error"} {
+		status, _, body := asyncGet(t, base+path)
+		if status != 502 || body != `{"handled":"`+path+`"}` {
+			t.Errorf("%s: got %d %q", path, status, body)
+		}
+	}
+	if status, _, body := asyncGet(t, base+"/caught"); status != 200 || body != "fine" {
+		t.Errorf("/caught: got %d %q", status, body)
+	}
+
+	plain := startStreamingServer(t, `
+	app.majhe(proyash kaj(req, res, agorao) { opekha agorao(); });
+	app.ana("/fail", proyash kaj(req, res) { felo "no handler"; });`)
+	if status, _, body := asyncGet(t, plain+"/fail"); status != 500 || body != `{"error":"Internal Server Error"}` {
+		t.Errorf("without bhul_sambhalo: got %d %q", status, body)
+	}
+}
+
+// TestAsyncFunctionServer tests a proyash handler passed straight to server_chalu
+func TestAsyncFunctionServer(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	input := `
+	dhoro s = server_chalu(0, proyash kaj(req, res) {
+		opekha ghumaao(10);
+		res.body = "async " + req.path;
+	});
+	anun("http://127.0.0.1:" + lipi(s.port) + "/hi").body
+	`
+	testStringObject(t, evalServer(input), "async /hi")
+}
@@ -0,0 +1,191 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newClientTestServer serves the endpoints used by the HTTP client tests
+func newClientTestServer(t *testing.T) (*httptest.Server, *int32) {
+	var flaky int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Served-By", "test")
+		fmt.Fprintf(w, `{"method":%q,"auth":%q,"type":%q,"q":%q,"body":%q}`,
+			r.Method, r.Header.Get("Authorization"), r.Header.Get("Content-Type"), r.URL.RawQuery, body)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&flaky, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "ok after %d", atomic.LoadInt32(&flaky))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/echo", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc", Path: "/"})
+	})
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("sid"); err == nil {
+			fmt.Fprint(w, c.Value)
+		}
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("y", 50000))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &flaky
+}
+
+// TestHTTPClientResponse tests the headers/url/ok/json fields of anun responses
+func TestHTTPClientResponse(t *testing.T) {
+	server, _ := newClientTestServer(t)
+	input := fmt.Sprintf(`
+	dhoro res = anun("%[1]s/redirect", {query: {a: "1 2", b: [3, 4]}});
+	dhoro posted = opekha anun_async("%[1]s/echo", {method: "put", json: {n: 1}});
+	[
+		lipi(res.status) + " " + lipi(res.ok) + " " + res.url,
+		res.headers["X-Served-By"],
+		res.json.method + " " + res.json.q,
+		posted.json.method + " " + posted.json.type + " " + posted.json.body,
+		lipi(anun("%[1]s/me").json),
+		lipi(anun("%[1]s/nope").ok)
+	]
+	`, server.URL)
+	testStringArray(t, testEval(input), []string{
+		"200 true " + server.URL + "/echo",
+		"test",
+		"GET ",
+		`PUT application/json {"n":1}`,
+		"khali",
+		"false",
+	})
+}
+
+// TestHTTPClientDefaults tests client_banao base URLs, default headers, query and per-request overrides
+func TestHTTPClientDefaults(t *testing.T) {
+	server, _ := newClientTestServer(t)
+	input := fmt.Sprintf(`
+	dhoro api = client_banao({baseURL: "%s/", headers: {Authorization: "Bearer t1"}});
+	dhoro a = api.ana("/echo", {query: {page: 2}});
+	dhoro b = api.pathano("echo", {body: "raw", headers: {Authorization: "Bearer t2"}});
+	dhoro c = api.anurodh("echo", {method: "DELETE"});
+	[a.json.method + " " + a.json.auth + " " + a.json.q, b.json.method + " " + b.json.auth + " " + b.json.body, c.json.method]
+	`, server.URL)
+	testStringArray(t, testEval(input), []string{"GET Bearer t1 page=2", "POST Bearer t2 raw", "DELETE"})
+}
+
+// TestHTTPClientTimeoutAndRetry tests timeouts and retries with backoff on retryable statuses
+func TestHTTPClientTimeoutAndRetry(t *testing.T) {
+	server, flaky := newClientTestServer(t)
+
+	start := time.Now()
+	testErrorObject(t, testEval(fmt.Sprintf(`anun("%s/slow", {timeout: 50})`, server.URL)), "deadline exceeded", 0)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timeout not applied, took %s", elapsed)
+	}
+
+	testStringObject(t, testEval(fmt.Sprintf(`anun("%s/flaky", {retry: {count: 3, delay: 5}}).body`, server.URL)), "ok after 3")
+
+	atomic.StoreInt32(flaky, 0)
+	testStringObject(t, testEval(fmt.Sprintf(`lipi(anun("%s/flaky", {retry: 1}).status)`, server.URL)), "503")
+
+	atomic.StoreInt32(flaky, 0)
+	input := fmt.Sprintf(`lipi(anun("%s/flaky", {retry: {count: 3, delay: 1, statuses: [500]}}).status)`, server.URL)
+	testStringObject(t, testEval(input), "503")
+	if n := atomic.LoadInt32(flaky); n != 1 {
+		t.Errorf("503 is not in statuses, expected 1 attempt, got %d", n)
+	}
+}
+
+// TestHTTPClientRedirects tests the follow/manual/error redirect policies and maxRedirects
+func TestHTTPClientRedirects(t *testing.T) {
+	server, _ := newClientTestServer(t)
+	input := fmt.Sprintf(`
+	dhoro manual = anun("%s/redirect", {redirect: "manual"});
+	[lipi(manual.status), manual.headers.Location]
+	`, server.URL)
+	testStringArray(t, testEval(input), []string{"302", "/echo"})
+
+	testErrorObject(t, testEval(fmt.Sprintf(`anun("%s/redirect", {redirect: "error"})`, server.URL)), "redirect not allowed", 0)
+	testErrorObject(t, testEval(fmt.Sprintf(`anun("%s/loop", {maxRedirects: 3})`, server.URL)), "stopped after 3 redirects", 1)
+}
+
+// TestHTTPClientCookiesAndStreaming tests the client cookie jar and streamed response bodies
+func TestHTTPClientCookiesAndStreaming(t *testing.T) {
+	server, _ := newClientTestServer(t)
+	input := fmt.Sprintf(`
+	dhoro api = client_banao({baseURL: "%[1]s"});
+	api.ana("/login");
+	dhoro res = anun("%[1]s/big", {stream: sotti});
+	dhoro total = 0;
+	dhoro chunk = stream_poro(res.stream, 8192);
+	jotokkhon (chunk != khali) {
+		total = total + dorghyo(chunk);
+		chunk = stream_poro(res.stream, 8192);
+	}
+	[api.ana("/me").body, api.kukis("/").sid, anun("%[1]s/me").body, lipi(total), res.body]
+	`, server.URL)
+	testStringArray(t, testEval(input), []string{"abc", "abc", "", "50000", ""})
+}
+
+// TestHTTPClientInterceptors tests age/pore request and response interceptors
+func TestHTTPClientInterceptors(t *testing.T) {
+	server, _ := newClientTestServer(t)
+	input := fmt.Sprintf(`
+	dhoro api = client_banao({baseURL: "%s"});
+	api.age(kaj(req) {
+		req.headers.Authorization = "Token " + req.method;
+		ferao req;
+	});
+	api.pore(proyash kaj(res) {
+		ferao {status: res.status, auth: res.json.auth};
+	});
+	dhoro res = api.ana("/echo");
+	dhoro later = opekha api.anurodh_async("/echo", {method: "PATCH"});
+	[lipi(res.status) + " " + res.auth, later.auth]
+	`, server.URL)
+	testStringArray(t, testEval(input), []string{"200 Token GET", "Token PATCH"})
+}
+
+// TestHTTPClientErrors tests argument validation
+func TestHTTPClientErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`anun(5)`, "first argument to `anun` must be STRING (url)"},
+		{`anun("http://127.0.0.1:1/", 5)`, "second argument to `anun` must be MAP (options)"},
+		{`anun("http://127.0.0.1:1/", {timeout: "soon"})`, "`timeout` option to `anun` must be a non-negative NUMBER"},
+		{`anun("http://127.0.0.1:1/", {redirect: "never"})`, "`redirect` option to `anun` must be"},
+		{`anun("http://127.0.0.1:1/", {retry: "x"})`, "`retry` option to `anun` must be NUMBER (count) or MAP"},
+		{`anun("http://127.0.0.1:1/")`, "HTTP error"},
+		{`client_banao(5)`, "argument to `client_banao` must be MAP (options)"},
+		{`client_banao({proxy: 5})`, "`proxy` option to `client_banao` must be STRING (url)"},
+		{`client_banao().age(5)`, "client.age() takes exactly 1 FUNCTION argument"},
+		{`client_banao().ana()`, "wrong number of arguments. got=0, want=1-2"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// metricsSuffix keeps metric names and route patterns apart between test runs, since
+// the metrics registry lives for the whole process
+func metricsSuffix() string {
+	return fmt.Sprintf("%d", time.Now().UnixNano())
+}
+
+func expectMetricLines(t *testing.T, text string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("missing line %q in:\n%s", line, text)
+		}
+	}
+}
+
+// TestMetricBuiltins tests user-defined counters, gauges and histograms and their export
+func TestMetricBuiltins(t *testing.T) {
+	id := metricsSuffix()
+	input := strings.ReplaceAll(`
+	dhoro jobs = counter_banao("jobs_ID_total", "Jobs done", ["queue"]);
+	jobs.barao({queue: "email"});
+	jobs.barao(4, {queue: "email"});
+	jobs.barao({queue: 'say "hi"'});
+	dhoro depth = gauge_banao("queue_ID_depth", "Jobs waiting");
+	depth.rakho(10);
+	depth.komao(3);
+	depth.barao();
+	dhoro latency = histogram_banao("db_ID_seconds", "Query time", ["table"], [0.1, 1]);
+	latency.mapo(0.05, {table: "users"});
+	latency.mapo(0.5, {table: "users"});
+	latency.mapo(3, {table: "users"});
+	dhoro sesh = latency.somoy({table: "posts"});
+	dhoro seconds = sesh();
+	dhoro again = counter_banao("jobs_ID_total", "Jobs done", ["queue"]);
+	[jobs.maan({queue: "email"}), depth.maan(), latency.maan({table: "users"}), latency.maan({table: "posts"}),
+	 again.maan({queue: "email"}), seconds >= 0, metrics_lekho()]
+	`, "ID", id)
+
+	arr, ok := testEval(input).(*object.Array)
+	if !ok || len(arr.Elements) != 7 {
+		t.Fatalf("expected a 7-element array, got %v", arr)
+	}
+	for i, want := range []float64{5, 8, 3, 1, 5} {
+		if n, ok := arr.Elements[i].(*object.Number); !ok || n.Value != want {
+			t.Errorf("value %d: expected %v, got %s", i, want, arr.Elements[i].Inspect())
+		}
+	}
+	if arr.Elements[5] != object.TRUE {
+		t.Errorf("somoy() should return the elapsed seconds")
+	}
+	text := arr.Elements[6].(*object.String).Value
+	expectMetricLines(t, text,
+		"# HELP jobs_"+id+"_total Jobs done",
+		"# TYPE jobs_"+id+"_total counter",
+		`jobs_`+id+`_total{queue="email"} 5`,
+		`jobs_`+id+`_total{queue="say \"hi\""} 1`,
+		"# TYPE queue_"+id+"_depth gauge",
+		"queue_"+id+"_depth 8",
+		"# TYPE db_"+id+"_seconds histogram",
+		`db_`+id+`_seconds_bucket{table="users",le="0.1"} 1`,
+		`db_`+id+`_seconds_bucket{table="users",le="1"} 2`,
+		`db_`+id+`_seconds_bucket{table="users",le="+Inf"} 3`,
+		`db_`+id+`_seconds_sum{table="users"} 3.55`,
+		`db_`+id+`_seconds_count{table="users"} 3`,
+	)
+}
+
+// TestMetricErrors tests metric declaration and label errors
+func TestMetricErrors(t *testing.T) {
+	id := metricsSuffix()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`counter_banao("bad-name", "x")`, "invalid metric name"},
+		{`counter_banao("ok_` + id + `", "x", ["__reserved"])`, "invalid label name"},
+		{`histogram_banao("h_` + id + `", "x", ["le"])`, "cannot use the label"},
+		{`histogram_banao("h2_` + id + `", "x", [], [1, 0.5])`, "increasing order"},
+		{`counter_banao("c_` + id + `", "x"); gauge_banao("c_` + id + `", "x")`, "already registered as a counter"},
+		{`counter_banao("d_` + id + `", "x").barao(-1)`, "cannot decrease"},
+		{`counter_banao("e_` + id + `", "x", ["queue"]).barao()`, "missing label \"queue\""},
+		{`counter_banao("f_` + id + `", "x", ["queue"]).barao({queue: "a", extra: 1})`, "unknown label \"extra\""},
+		{`gauge_banao("g_` + id + `", "x").rakho("ten")`, "must be NUMBER"},
+		{`metrics_chalu(router_banao(), 5)`, "must be STRING (path)"},
+		{`log_chalu(router_banao(), {format: "xml"})`, "must be \"text\" or \"json\""},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
+
+// TestRouterMetrics tests request counters, latency histograms, the in-flight gauge and
+// the /metrics route, including WebSocket routes behind the instrumented writer
+func TestRouterMetrics(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	id := metricsSuffix()
+	base := startStreamingServer(t, strings.ReplaceAll(`
+	metrics_chalu(app, "/metrics", {buckets: [0.05, 10]});
+	app.ana("/ID/users/:id", kaj(req, res) { res.body = "user " + req.params.id; });
+	app.ana("/ID/slow", proyash kaj(req, res) { opekha ghumaao(300); res.body = "slow"; });
+	app.pathano("/ID/fail", kaj(req, res) { felo "boom"; });
+	app.websocket("/ID/ws", {barta: kaj(conn, msg) { conn.lekho("echo:" + msg); }});`, "ID", id))
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		if _, body := staticGet(t, "GET", base+"/"+id+path, nil); !strings.HasPrefix(body, "user ") {
+			t.Fatalf("route failed: %q", body)
+		}
+	}
+	staticGet(t, "POST", base+"/"+id+"/fail", nil)
+	staticGet(t, "GET", base+"/"+id+"/nope", nil)
+	staticGet(t, "BREW"+id, base+"/"+id+"/users/3", nil)
+
+	conn, _, err := dialWS(t, base, "/"+id+"/ws", nil)
+	if err != nil {
+		t.Fatalf("WebSocket upgrade through the metrics writer failed: %v", err)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte("hi"))
+	if _, msg := readWS(t, conn); msg != "echo:hi" {
+		t.Errorf("WebSocket echo: got %q", msg)
+	}
+	conn.Close()
+
+	done := make(chan struct{})
+	go func() {
+		staticGet(t, "GET", base+"/"+id+"/slow", nil)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	resp, text := staticGet(t, "GET", base+"/metrics", nil)
+	<-done
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("unexpected Content-Type %q", resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(text, "\nhttp_requests_in_flight ") || strings.Contains(text, "\nhttp_requests_in_flight 0\n") {
+		t.Errorf("expected the slow request in flight:\n%s", text)
+	}
+	route := `route="/` + id + `/users/:id"`
+	expectMetricLines(t, text,
+		"# TYPE http_requests_total counter",
+		`http_requests_total{method="GET",`+route+`,status="200"} 2`,
+		`http_requests_total{method="POST",route="/`+id+`/fail",status="500"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",`+route+`,status="200",le="10"} 2`,
+		`http_request_duration_seconds_count{method="GET",`+route+`,status="200"} 2`,
+	)
+	if !strings.Contains(text, `http_requests_total{method="GET",route="unmatched",status="404"}`) {
+		t.Errorf("expected unknown paths under route=\"unmatched\":\n%s", text)
+	}
+	if strings.Contains(text, `method="BREW`+id+`"`) || !strings.Contains(text, `http_requests_total{method="OTHER",`) {
+		t.Errorf("expected unknown methods under method=\"OTHER\":\n%s", text)
+	}
+
+	_, text = staticGet(t, "GET", base+"/metrics", nil)
+	expectMetricLines(t, text,
+		`http_requests_total{method="GET",route="/`+id+`/slow",status="200"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",route="/`+id+`/slow",status="200",le="0.05"} 0`,
+	)
+	if !strings.Contains(text, `route="/`+id+`/ws",status="101"`) {
+		t.Errorf("expected the WebSocket upgrade counted as 101:\n%s", text)
+	}
+}
+
+// TestJSONAccessLog tests log_chalu with {format: "json", file}
+func TestJSONAccessLog(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	logFile := filepath.Join(t.TempDir(), "access.log")
+	base := startStreamingServer(t, `
+	log_chalu(app, {format: "json", file: "`+filepath.ToSlash(logFile)+`"});
+	goti_shima(app, 2, 60);
+	app.ana("/items/:id", kaj(req, res) { res.body = "item"; });`)
+
+	req, _ := http.NewRequest("GET", base+"/items/9?full=1", nil)
+	req.Header.Set("User-Agent", "probe/1.0")
+	req.Header.Set("X-Request-Id", "req-42")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	staticGet(t, "GET", base+"/missing", nil)
+	staticGet(t, "GET", base+"/items/1", nil) // rejected by the rate limiter
+
+	f, err := os.Open(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log lines, got %d: %v", len(entries), entries)
+	}
+	first := entries[0]
+	if first["method"] != "GET" || first["path"] != "/items/9" || first["query"] != "full=1" ||
+		first["route"] != "/items/:id" || first["status"] != float64(200) || first["bytes"] != float64(4) ||
+		first["ip"] != "127.0.0.1" || first["user_agent"] != "probe/1.0" || first["request_id"] != "req-42" {
+		t.Errorf("unexpected entry: %v", first)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, first["time"].(string)); err != nil {
+		t.Errorf("time is not RFC 3339: %v", first["time"])
+	}
+	if _, ok := first["duration_ms"].(float64); !ok {
+		t.Errorf("missing duration_ms: %v", first)
+	}
+	if entries[1]["status"] != float64(404) || entries[1]["route"] != "unmatched" {
+		t.Errorf("unexpected 404 entry: %v", entries[1])
+	}
+	if entries[2]["status"] != float64(429) {
+		t.Errorf("expected the rate-limited request logged as 429: %v", entries[2])
+	}
+}
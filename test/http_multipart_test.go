@@ -0,0 +1,131 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMultipartUploadRoundTrip tests anun's multipart builder against req.form / req.files
+func TestMultipartUploadRoundTrip(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	bigFile := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(bigFile, bytes.Repeat([]byte("b"), 3<<20), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	input := strings.ReplaceAll(`
+	dhoro app = router_banao();
+	dhoro seenPath = "";
+	app.pathano("/upload", kaj(req, res) {
+		dhoro note = req.files.note;
+		dhoro big = req.files.big;
+		seenPath = big.path;
+		json_uttor(res, {
+			title: req.form.title,
+			count: req.form.count,
+			note: [note.filename, note.content_type, note.size, buffer_text(note.data)],
+			big: [big.filename, big.size, big.data == khali, dorghyo(poro(big.path))],
+			body: req.body
+		});
+	});
+	dhoro s = server_chalu(0, app);
+	dhoro res = anun("http://127.0.0.1:" + lipi(s.port) + "/upload", {multipart: {
+		title: "report",
+		count: 3,
+		note: {filename: "note.txt", data: "hello upload", content_type: "text/plain"},
+		big: {filename: "big.bin", path: "{{BIG}}"}
+	}});
+	[res.body, lipi(seenPath != "")]
+	`, "{{BIG}}", bigFile)
+
+	testStringArray(t, evalServer(input), []string{
+		`{"big":["big.bin",3145728,true,3145728],"body":"","count":"3","note":["note.txt","text/plain",12,"hello upload"],"title":"report"}`,
+		"true",
+	})
+
+	matches, _ := filepath.Glob(filepath.Join(os.TempDir(), "banglacode-upload-*"))
+	if len(matches) != 0 {
+		t.Errorf("temp upload files were not removed: %v", matches)
+	}
+}
+
+// TestMultipartBodyLimits tests akaar_shima and the per-route {akaar_shima: n} option
+func TestMultipartBodyLimits(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	akaar_shima(app, 1024);
+	app.pathano("/small", kaj(req, res) { res.body = "ok " + req.form.name; });
+	app.pathano("/avatar", kaj(req, res) {
+		res.body = "stored " + lipi(req.files.avatar.size);
+	}, {akaar_shima: 100000});
+	app.pathano("/plain", kaj(req, res) { res.body = req.body; });`)
+
+	post := func(path string, fileSize int) (int, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("name", "ankan")
+		if fileSize > 0 {
+			part, _ := writer.CreateFormFile("avatar", "me.png")
+			part.Write(bytes.Repeat([]byte{0x89}, fileSize))
+		}
+		writer.Close()
+		resp, err := http.Post(base+path, writer.FormDataContentType(), &body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		out, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(out)
+	}
+
+	if status, body := post("/small", 0); status != 200 || body != "ok ankan" {
+		t.Errorf("small upload: %d %q", status, body)
+	}
+	if status, _ := post("/small", 5000); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 past the app limit, got %d", status)
+	}
+	if status, body := post("/avatar", 5000); status != 200 || body != "stored 5000" {
+		t.Errorf("route limit should override the app limit: %d %q", status, body)
+	}
+
+	resp, err := http.Post(base+"/plain", "text/plain", strings.NewReader(strings.Repeat("x", 2048)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for an oversized plain body, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(base+"/small", "multipart/form-data; boundary=xyz", strings.NewReader("garbage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for a malformed multipart body, got %d", resp.StatusCode)
+	}
+}
+
+// TestMultipartClientErrors tests validation of the multipart option of anun
+func TestMultipartClientErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`anun("http://127.0.0.1:1/", {multipart: {f: {data: "x"}}})`, "needs a `filename`"},
+		{`anun("http://127.0.0.1:1/", {multipart: {f: {filename: "a.txt"}}})`, "needs `data` or `path`"},
+		{`anun("http://127.0.0.1:1/", {multipart: {f: {filename: "a.txt", path: "/no/such/file"}}})`, "no such file"},
+	}
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, 0)
+	}
+}
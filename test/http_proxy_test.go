@@ -0,0 +1,292 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// echoUpstream answers name:method path?query and reports the headers it received
+func echoUpstream(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", name)
+		w.Header().Set("X-Internal", "secret")
+		w.Header().Set("X-Seen-Gateway", r.Header.Get("X-Gateway"))
+		w.Header().Set("X-Seen-Cookie", r.Header.Get("Cookie"))
+		w.Header().Set("X-Seen-Forwarded-For", r.Header.Get("X-Forwarded-For"))
+		w.Header().Set("X-Seen-Host", r.Host)
+		fmt.Fprintf(w, "%s:%s %s", name, r.Method, r.URL.RequestURI())
+		if len(body) > 0 {
+			fmt.Fprintf(w, " body=%s", body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func proxyStates(t *testing.T, url string) []map[string]interface{} {
+	t.Helper()
+	resp, body := staticGet(t, "GET", url, nil)
+	if resp.StatusCode != 200 {
+		t.Fatalf("state endpoint answered %d: %s", resp.StatusCode, body)
+	}
+	var states []map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &states); err != nil {
+		t.Fatalf("invalid state JSON %q: %v", body, err)
+	}
+	return states
+}
+
+// TestProxyForwarding tests path stripping, rewrites, header rules and round-robin balancing
+func TestProxyForwarding(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	a, b := echoUpstream(t, "a"), echoUpstream(t, "b")
+	base := startStreamingServer(t, `
+	proxy_chalu(app, "/api", {
+		upstreams: ["`+a.URL+`", "`+b.URL+`/v2"],
+		rewrite: {"^/old/(.*)$": "/new/$1"},
+		headers: {"X-Gateway": "banglacode"},
+		removeHeaders: ["Cookie"],
+		responseHeaders: {"X-Proxy": "sotti"},
+		removeResponseHeaders: ["X-Internal"],
+		majhe: kaj(req, res, agorao) {
+			jodi (req.headers["X-Block"] == "1") {
+				res.status = 403;
+				res.body = "blocked";
+				ferao;
+			}
+			res.headers["X-Mw"] = "ran";
+			agorao();
+		}
+	});
+	proxy_chalu(app, "/raw", {upstreams: "`+a.URL+`", stripPrefix: mittha, preserveHost: sotti});`)
+
+	resp, body := staticGet(t, "GET", base+"/api/users/7?x=1", map[string]string{"Cookie": "sid=1"})
+	if resp.StatusCode != 200 || body != "a:GET /users/7?x=1" {
+		t.Errorf("first request: %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-Seen-Gateway") != "banglacode" || resp.Header.Get("X-Seen-Cookie") != "" {
+		t.Errorf("request headers not rewritten: %v", resp.Header)
+	}
+	if resp.Header.Get("X-Seen-Forwarded-For") != "127.0.0.1" {
+		t.Errorf("expected X-Forwarded-For 127.0.0.1, got %q", resp.Header.Get("X-Seen-Forwarded-For"))
+	}
+	if resp.Header.Get("X-Proxy") != "sotti" || resp.Header.Get("X-Internal") != "" || resp.Header.Get("X-Mw") != "ran" {
+		t.Errorf("response headers not rewritten: %v", resp.Header)
+	}
+
+	_, body = staticGet(t, "GET", base+"/api/old/page", nil)
+	if body != "b:GET /v2/new/page" {
+		t.Errorf("expected rewrite onto the second upstream's base path, got %q", body)
+	}
+	_, body = staticGet(t, "GET", base+"/api", nil)
+	if body != "a:GET /" {
+		t.Errorf("expected the bare prefix to forward /, got %q", body)
+	}
+
+	resp, err := http.Post(base+"/api/items", "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	posted, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(posted) != "b:POST /v2/items body=payload" {
+		t.Errorf("POST body not forwarded: %q", posted)
+	}
+
+	resp, body = staticGet(t, "GET", base+"/api/x", map[string]string{"X-Block": "1"})
+	if resp.StatusCode != 403 || body != "blocked" || resp.Header.Get("X-Upstream") != "" {
+		t.Errorf("middleware should answer before forwarding: %d %q", resp.StatusCode, body)
+	}
+
+	resp, body = staticGet(t, "DELETE", base+"/raw/thing", nil)
+	if body != "a:DELETE /raw/thing" || !strings.HasPrefix(resp.Header.Get("X-Seen-Host"), "127.0.0.1:") ||
+		resp.Header.Get("X-Seen-Host") == strings.TrimPrefix(a.URL, "http://") {
+		t.Errorf("expected the full path and the client's Host: %q %q", body, resp.Header.Get("X-Seen-Host"))
+	}
+}
+
+// TestProxyLeastConnections tests that least_conn skips an upstream busy with a slow request
+func TestProxyLeastConnections(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	release, started := make(chan struct{}), make(chan struct{}, 1)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		fmt.Fprint(w, "slow")
+	}))
+	defer slow.Close()
+	fast := echoUpstream(t, "fast")
+	base := startStreamingServer(t, `
+	proxy_chalu(app, "/", {upstreams: ["`+slow.URL+`", "`+fast.URL+`"], balance: "least_conn"});`)
+
+	done := make(chan string)
+	go func() {
+		_, body := staticGet(t, "GET", base+"/first", nil)
+		done <- body
+	}()
+	<-started
+	for i := 0; i < 3; i++ {
+		if _, body := staticGet(t, "GET", base+"/next", nil); body != "fast:GET /next" {
+			t.Errorf("request %d should avoid the busy upstream, got %q", i, body)
+		}
+	}
+	close(release)
+	if body := <-done; body != "slow" {
+		t.Errorf("slow request: got %q", body)
+	}
+}
+
+// TestProxyHealthChecks tests that failing upstreams leave the rotation and come back
+func TestProxyHealthChecks(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	var sick atomic.Bool
+	sick.Store(true)
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" && sick.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "flaky")
+	}))
+	defer flaky.Close()
+	steady := echoUpstream(t, "steady")
+	base := startStreamingServer(t, `
+	dhoro p = proxy_chalu(app, "/svc", {
+		upstreams: ["`+flaky.URL+`", "`+steady.URL+`"],
+		healthCheck: {path: "health", interval: 30, timeout: 500}
+	});
+	app.ana("/state", kaj(req, res) { res.body = json_banao(p.obostha()); });
+	app.ana("/stop", kaj(req, res) { p.bondho(); res.body = "stopped"; });`)
+	defer staticGet(t, "GET", base+"/stop", nil)
+
+	waitFor := func(healthy bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if proxyStates(t, base+"/state")[0]["healthy"] == healthy {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("upstream never became healthy=%v", healthy)
+	}
+
+	waitFor(false)
+	for i := 0; i < 4; i++ {
+		if _, body := staticGet(t, "GET", base+"/svc/x", nil); body != "steady:GET /x" {
+			t.Errorf("unhealthy upstream received request %d: %q", i, body)
+		}
+	}
+	sick.Store(false)
+	waitFor(true)
+	seen := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		_, body := staticGet(t, "GET", base+"/svc/x", nil)
+		seen[strings.SplitN(body, ":", 2)[0]] = true
+	}
+	if !seen["flaky"] || !seen["steady"] {
+		t.Errorf("expected both upstreams after recovery, got %v", seen)
+	}
+	states := proxyStates(t, base+"/state")
+	if states[0]["url"] != flaky.URL || states[1]["requests"].(float64) < 6 || states[1]["active"].(float64) != 0 {
+		t.Errorf("unexpected state: %v", states)
+	}
+}
+
+// TestProxyStreamingAndWebSocket tests that responses stream and upgrades are tunnelled
+func TestProxyStreamingAndWebSocket(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	release := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ws" {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			for {
+				kind, msg, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				conn.WriteMessage(kind, append([]byte("echo:"), msg...))
+			}
+		}
+		fmt.Fprint(w, "first\n")
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprint(w, "second\n")
+	}))
+	defer upstream.Close()
+	base := startStreamingServer(t, `
+	proxy_chalu(app, "/up", {upstreams: "`+upstream.URL+`"});`)
+
+	resp, err := http.Get(base + "/up/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); line != "first\n" {
+		t.Errorf("expected the first chunk before the upstream finished, got %q", line)
+	}
+	close(release)
+	if line, _ := reader.ReadString('\n'); line != "second\n" {
+		t.Errorf("expected the second chunk, got %q", line)
+	}
+	resp.Body.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(base, "http")+"/up/ws", nil)
+	if err != nil {
+		t.Fatalf("upgrade through the proxy failed: %v", err)
+	}
+	defer conn.Close()
+	conn.WriteMessage(websocket.TextMessage, []byte("hello"))
+	if _, msg, err := conn.ReadMessage(); err != nil || string(msg) != "echo:hello" {
+		t.Errorf("expected echo:hello, got %q (%v)", msg, err)
+	}
+}
+
+// TestProxyErrors tests option validation and unreachable upstreams
+func TestProxyErrors(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`proxy_chalu(router_banao(), "/api", {})`, "`upstreams` option is required"},
+		{`proxy_chalu(router_banao(), "/api", {upstreams: []})`, "at least one URL"},
+		{`proxy_chalu(router_banao(), "/api", {upstreams: ["ftp://x"]})`, "invalid upstream URL"},
+		{`proxy_chalu(router_banao(), "/api", {upstreams: "http://x", balance: "random"})`, "round_robin"},
+		{`proxy_chalu(router_banao(), "/api", {upstreams: "http://x", rewrite: {"(": "/"}})`, "invalid rewrite pattern"},
+		{`proxy_chalu(router_banao(), "/api", {upstreams: "http://x", healthCheck: {interval: 5}})`, "needs a STRING `path`"},
+		{`proxy_chalu(router_banao(), "/api")`, "wrong number of arguments"},
+		{`proxy_chalu(router_banao(), 5, {upstreams: "http://x"})`, "must be STRING (path prefix)"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := dead.URL
+	dead.Close()
+	base := startStreamingServer(t, `
+	proxy_chalu(app, "/api", {upstreams: "`+deadURL+`"});`)
+	resp, body := staticGet(t, "GET", base+"/api/x", nil)
+	if resp.StatusCode != http.StatusBadGateway || !strings.Contains(body, "Bad Gateway") {
+		t.Errorf("expected 502 for an unreachable upstream, got %d %q", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// routerRequest sends a request to a test server and returns status, Allow header and body
+func routerRequest(t *testing.T, method, url string) (int, string, string) {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, resp.Header.Get("Allow"), string(body)
+}
+
+// TestRouterMethodNotAllowed tests 405 with Allow, automatic HEAD/OPTIONS and custom 404/405
+func TestRouterMethodNotAllowed(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.ana("/items", kaj(req, res) { res.body = "list"; });
+	app.pathano("/items", kaj(req, res) { res.body = "created"; });
+	app.mujhe_felo("/items/:id", kaj(req, res) { res.body = "deleted " + req.params.id; });`)
+
+	tests := []struct {
+		method, path string
+		status       int
+		allow, body  string
+	}{
+		{"GET", "/items", 200, "", "list"},
+		{"PUT", "/items", 405, "GET, HEAD, POST, OPTIONS", "405 method not allowed\n"},
+		{"HEAD", "/items", 200, "", ""},
+		{"OPTIONS", "/items", 204, "GET, HEAD, POST, OPTIONS", ""},
+		{"GET", "/items/7", 405, "DELETE, OPTIONS", "405 method not allowed\n"},
+		{"GET", "/nowhere", 404, "", "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		status, allow, body := routerRequest(t, tt.method, base+tt.path)
+		if status != tt.status || allow != tt.allow || body != tt.body {
+			t.Errorf("%s %s: got %d %q %q, want %d %q %q", tt.method, tt.path, status, allow, body, tt.status, tt.allow, tt.body)
+		}
+	}
+
+	custom := startStreamingServer(t, `
+	app.majhe(kaj(req, res, agorao) { res.headers["X-Seen"] = "yes"; agorao(); });
+	app.ana("/only-get", kaj(req, res) { res.body = "ok"; });
+	app.painai(kaj(req, res) { json_uttor(res, {missing: req.path}, res.status); });
+	app.onumoti_nei(kaj(req, res) { res.body = lipi(res.status) + " try " + res.headers["Allow"]; });`)
+
+	resp, err := http.Get(custom + "/ghost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 404 || string(body) != `{"missing":"/ghost"}` || resp.Header.Get("X-Seen") != "yes" {
+		t.Errorf("custom 404: %d %q seen=%q", resp.StatusCode, body, resp.Header.Get("X-Seen"))
+	}
+	if status, _, body := routerRequest(t, "POST", custom+"/only-get"); status != 405 || !strings.HasPrefix(body, "405 try") {
+		t.Errorf("custom 405: %d %q", status, body)
+	}
+}
+
+// TestRouterWildcardAndConstrainedParams tests *rest wildcards and :id(regex) params
+func TestRouterWildcardAndConstrainedParams(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.ana("/users/:id(\d+)", kaj(req, res) { res.body = "user " + req.params.id; });
+	app.ana("/users/:name", kaj(req, res) { res.body = "named " + req.params.name; });
+	app.ana("/files/*path", kaj(req, res) { res.body = "file " + req.params.path; });
+	app.ana("/posts/:year([0-9]{4})/:slug", kaj(req, res) {
+		res.body = req.params.year + "/" + req.params.slug;
+	});`)
+
+	tests := map[string]string{
+		"/users/42":              "user 42",
+		"/users/ankan":           "named ankan",
+		"/files/docs/a/b.txt":    "file docs/a/b.txt",
+		"/files/":                "file ",
+		"/posts/2024/hello-bang": "2024/hello-bang",
+	}
+	for path, expected := range tests {
+		if _, _, body := routerRequest(t, "GET", base+path); body != expected {
+			t.Errorf("GET %s: got %q, want %q", path, body, expected)
+		}
+	}
+	if status, _, _ := routerRequest(t, "GET", base+"/posts/24/x"); status != 404 {
+		t.Errorf("constraint should reject /posts/24/x, got %d", status)
+	}
+}
+
+// TestRouterGroupsAndRouteMiddleware tests app.dol groups, nested groups and {majhe: ...}
+func TestRouterGroupsAndRouteMiddleware(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	kaj tag(name) {
+		ferao kaj(req, res, agorao) {
+			res.headers["X-Trace"] = res.headers["X-Trace"] + name;
+			agorao();
+		};
+	}
+	app.majhe(kaj(req, res, agorao) { res.headers["X-Trace"] = "app"; agorao(); });
+	dhoro api = app.dol("/api");
+	api.majhe(tag(">api"));
+	dhoro v1 = api.dol("/v1");
+	v1.majhe(tag(">v1"));
+	v1.ana("/users", kaj(req, res) { res.body = "users"; }, {majhe: [tag(">route"), tag(">again")]});
+	api.ana("/", kaj(req, res) { res.body = "api root"; });
+	app.ana("/public", kaj(req, res) { res.body = "public"; });
+	app.ana("/guarded", kaj(req, res) { res.body = "secret"; }, {majhe: kaj(req, res, agorao) {
+		res.status = 401;
+		res.body = "denied";
+	}});`)
+
+	tests := []struct {
+		path, trace, body string
+	}{
+		{"/api/v1/users", "app>api>v1>route>again", "users"},
+		{"/api", "app>api", "api root"},
+		{"/public", "app", "public"},
+		{"/guarded", "app", "denied"},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(base + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.Header.Get("X-Trace") != tt.trace || string(body) != tt.body {
+			t.Errorf("GET %s: trace %q body %q, want %q %q", tt.path, resp.Header.Get("X-Trace"), body, tt.trace, tt.body)
+		}
+	}
+}
+
+// TestRouterRouteTable tests app.talika() introspection
+func TestRouterRouteTable(t *testing.T) {
+	input := `
+	dhoro app = router_banao();
+	app.majhe(kaj(req, res, agorao) { agorao(); });
+	app.pathano("/users", kaj(req, res) {}, {stream: sotti});
+	dhoro api = app.dol("/api");
+	api.majhe(kaj(req, res, agorao) { agorao(); });
+	api.ana("/users/:id(\d+)/files/*rest", kaj(req, res) {});
+	app.ana("/users", kaj(req, res) {});
+	dhoro rows = [];
+	dhoro table = app.talika();
+	ghuriye (dhoro i = 0; i < dorghyo(table); i = i + 1) {
+		dhoro r = table[i];
+		dhokao(rows, r.method + " " + r.path + " " + json_banao(r.params) + " " + lipi(r.middleware) + " " + lipi(r.stream));
+	}
+	rows
+	`
+	testStringArray(t, testEval(input), []string{
+		`GET /api/users/:id(\d+)/files/*rest ["id","rest"] 2 false`,
+		`GET /users [] 1 false`,
+		`POST /users [] 1 true`,
+	})
+}
+
+// TestRouterPatternErrors tests invalid patterns and options
+func TestRouterPatternErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`router_banao().ana("/a/:id([0-9", kaj(req, res) {})`, "unclosed constraint"},
+		{`router_banao().ana("/a/:id(+)", kaj(req, res) {})`, "invalid constraint for route param"},
+		{`router_banao().ana("/a/*rest/b", kaj(req, res) {})`, "must be the last segment"},
+		{`router_banao().dol("/x").ana("/", kaj(req, res) {}, {majhe: 5})`, "`majhe` option to group.ana() must be FUNCTION"},
+		{`router_banao().dol(5)`, "must be STRING (prefix)"},
+		{`router_banao().painai("nope")`, "argument to router.painai() must be FUNCTION"},
+	}
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, 0)
+	}
+}
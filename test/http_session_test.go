@@ -0,0 +1,289 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sessionClient returns an HTTP client that keeps cookies between requests
+func sessionClient(t *testing.T) *http.Client {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Jar: jar}
+}
+
+// doRequest sends a request and returns the response (body already read) and body
+func doRequest(t *testing.T, client *http.Client, req *http.Request) (*http.Response, string) {
+	t.Helper()
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func getBody(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	req, _ := http.NewRequest("GET", url, nil)
+	_, body := doRequest(t, client, req)
+	return body
+}
+
+const sessionRoutes = `
+	app.ana("/count", kaj(req, res) {
+		dhoro n = req.session.count;
+		jodi (n == khali) { n = 0; }
+		req.session.count = n + 1;
+		res.body = lipi(n + 1);
+	});
+	app.ana("/anon", kaj(req, res) { res.body = "hi"; });
+	app.ana("/login", kaj(req, res) {
+		dhoro old = req.session_id;
+		session_notun(req);
+		req.session.user = "ankan";
+		res.body = lipi(old != req.session_id);
+	});
+	app.ana("/whoami", kaj(req, res) { res.body = lipi(req.session.user) + " " + lipi(req.session.count); });
+	app.ana("/logout", kaj(req, res) { session_muchho(req); });
+`
+
+// TestSessionMemoryStore tests session cookies, empty sessions, tampering, regeneration and logout
+func TestSessionMemoryStore(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `session_chalu(app, {secret: "s3cret", name: "sid"});`+sessionRoutes)
+	client := sessionClient(t)
+
+	if a, b := getBody(t, client, base+"/count"), getBody(t, client, base+"/count"); a != "1" || b != "2" {
+		t.Errorf("count: got %q %q", a, b)
+	}
+	u, _ := url.Parse(base)
+	cookies := client.Jar.Cookies(u)
+	if len(cookies) != 1 || cookies[0].Name != "sid" || !strings.Contains(cookies[0].Value, ".") {
+		t.Fatalf("expected one signed sid cookie, got %v", cookies)
+	}
+
+	resp, _ := doRequest(t, http.DefaultClient, mustRequest("GET", base+"/anon"))
+	if len(resp.Header.Values("Set-Cookie")) != 0 {
+		t.Errorf("empty new session should not set a cookie, got %v", resp.Header.Values("Set-Cookie"))
+	}
+
+	tampered := mustRequest("GET", base+"/count")
+	tampered.AddCookie(&http.Cookie{Name: "sid", Value: cookies[0].Value + "x"})
+	if _, body := doRequest(t, http.DefaultClient, tampered); body != "1" {
+		t.Errorf("tampered cookie should start a new session, got %q", body)
+	}
+
+	if body := getBody(t, client, base+"/login"); body != "true" {
+		t.Errorf("session_notun should change the id, got %q", body)
+	}
+	if body := getBody(t, client, base+"/whoami"); body != "ankan 2" {
+		t.Errorf("regenerated session lost data: %q", body)
+	}
+	stale := mustRequest("GET", base+"/whoami")
+	stale.AddCookie(cookies[0])
+	if _, body := doRequest(t, http.DefaultClient, stale); body != "khali khali" {
+		t.Errorf("old session id should be destroyed, got %q", body)
+	}
+
+	resp, _ = doRequest(t, client, mustRequest("GET", base+"/logout"))
+	if !strings.Contains(resp.Header.Get("Set-Cookie"), "Max-Age=0") {
+		t.Errorf("logout should expire the cookie, got %q", resp.Header.Get("Set-Cookie"))
+	}
+	if body := getBody(t, client, base+"/whoami"); body != "khali khali" {
+		t.Errorf("after logout: %q", body)
+	}
+}
+
+// TestSessionStreamedResponse tests that a new session sticks when the handler streams,
+// since its cookie is issued just before the headers go out
+func TestSessionStreamedResponse(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `session_chalu(app, {secret: "s3cret"});`+sessionRoutes+`
+	app.ana("/stream", kaj(req, res) {
+		req.session.user = "streamer";
+		res.lekho("part1 ");
+		res.samapti("part2");
+	});`)
+	client := sessionClient(t)
+
+	resp, body := doRequest(t, client, mustRequest("GET", base+"/stream"))
+	if body != "part1 part2" || len(resp.Header.Values("Set-Cookie")) != 1 {
+		t.Fatalf("expected the streamed body with one Set-Cookie, got %q %v", body, resp.Header.Values("Set-Cookie"))
+	}
+	if body := getBody(t, client, base+"/whoami"); body != "streamer khali" {
+		t.Errorf("streamed session did not stick: %q", body)
+	}
+}
+
+// TestSessionFileStore tests sessions persisted as files
+func TestSessionFileStore(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	dir := t.TempDir()
+	base := startStreamingServer(t, `session_chalu(app, {secret: "s3cret", store: "file", dir: "`+dir+`"});`+sessionRoutes)
+	client := sessionClient(t)
+
+	getBody(t, client, base+"/count")
+	if body := getBody(t, client, base+"/count"); body != "2" {
+		t.Errorf("count: got %q", body)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), ".json") {
+		t.Fatalf("expected one session file, got %v", entries)
+	}
+	data, _ := os.ReadFile(dir + "/" + entries[0].Name())
+	if string(data) != `{"count":2}` {
+		t.Errorf("unexpected session file contents %s", data)
+	}
+	getBody(t, client, base+"/logout")
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("logout should remove the session file, got %v", entries)
+	}
+}
+
+// TestSignedCookies tests kuki_rakho {secret} and kuki_pora, plus multiple Set-Cookie headers
+func TestSignedCookies(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.ana("/set", kaj(req, res) {
+		kuki_rakho(res, "theme", "dark", {secret: "k"});
+		kuki_rakho(res, "plain", "1");
+	});
+	app.ana("/read", kaj(req, res) { res.body = lipi(kuki_pora(req, "theme", "k")); });`)
+
+	resp, _ := doRequest(t, http.DefaultClient, mustRequest("GET", base+"/set"))
+	setCookies := resp.Cookies()
+	if len(setCookies) != 2 || setCookies[1].String() != "plain=1; Path=/" {
+		t.Fatalf("expected two Set-Cookie headers, got %v", resp.Header.Values("Set-Cookie"))
+	}
+	signed := setCookies[0]
+	if !strings.HasPrefix(signed.Value, "dark.") {
+		t.Errorf("unexpected signed value %q", signed.Value)
+	}
+
+	for value, expected := range map[string]string{
+		signed.Value:                       "dark",
+		"light" + signed.Value[4:]:         "khali",
+		"dark":                             "khali",
+		signed.Value[:len(signed.Value)-1]: "khali",
+	} {
+		req := mustRequest("GET", base+"/read")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: value})
+		if _, body := doRequest(t, http.DefaultClient, req); body != expected {
+			t.Errorf("kuki_pora(%q): got %q, want %q", value, body, expected)
+		}
+	}
+}
+
+// TestCSRFProtection tests tokens from req.csrf_token via header and form field
+func TestCSRFProtection(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	session_chalu(app, {secret: "s3cret"});
+	csrf_chalu(app);
+	app.ana("/form", kaj(req, res) { res.body = req.csrf_token; });
+	app.pathano("/submit", kaj(req, res) { res.body = "saved"; });`)
+	client := sessionClient(t)
+	token := getBody(t, client, base+"/form")
+	if len(token) != 64 || getBody(t, client, base+"/form") != token {
+		t.Fatalf("expected a stable 64-char token, got %q", token)
+	}
+
+	resp, body := doRequest(t, client, mustRequest("POST", base+"/submit"))
+	if resp.StatusCode != 403 || body != `{"error":"invalid CSRF token"}` {
+		t.Errorf("missing token: %d %s", resp.StatusCode, body)
+	}
+	withHeader := mustRequest("POST", base+"/submit")
+	withHeader.Header.Set("X-CSRF-Token", token)
+	if resp, body := doRequest(t, client, withHeader); resp.StatusCode != 200 || body != "saved" {
+		t.Errorf("header token: %d %s", resp.StatusCode, body)
+	}
+	form, _ := http.NewRequest("POST", base+"/submit", strings.NewReader("_csrf="+token))
+	form.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if resp, body := doRequest(t, client, form); resp.StatusCode != 200 || body != "saved" {
+		t.Errorf("form token: %d %s", resp.StatusCode, body)
+	}
+	foreign := mustRequest("POST", base+"/submit")
+	foreign.Header.Set("X-CSRF-Token", token)
+	if resp, _ := doRequest(t, http.DefaultClient, foreign); resp.StatusCode != 403 {
+		t.Errorf("token without its session should be rejected, got %d", resp.StatusCode)
+	}
+}
+
+// TestBasicAndBearerAuth tests basic_pahara and bearer_pahara with maps, arrays and verifiers
+func TestBasicAndBearerAuth(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	dhoro admin = app.dol("/admin");
+	admin.majhe(basic_pahara({ankan: "pass123"}, {realm: "Admin"}));
+	admin.ana("/", kaj(req, res) { res.body = "hello " + req.user; });
+	app.ana("/api/me", kaj(req, res) { res.body = req.user.name; }, {majhe: bearer_pahara(proyash kaj(token) {
+		opekha ghumaao(1);
+		jodi (token == "good-token") { ferao {name: "bot"}; }
+		ferao mittha;
+	})});
+	app.ana("/api/list", kaj(req, res) { res.body = req.user; }, {majhe: bearer_pahara(["t1", "t2"])});`)
+
+	tests := []struct {
+		path, auth string
+		status     int
+		challenge  string
+		body       string
+	}{
+		{"/admin", "", 401, `Basic realm="Admin"`, `{"error":"Unauthorized"}`},
+		{"/admin", "Basic YW5rYW46d3Jvbmc=", 401, `Basic realm="Admin"`, `{"error":"Unauthorized"}`},
+		{"/admin", "Basic YW5rYW46cGFzczEyMw==", 200, "", "hello ankan"},
+		{"/api/me", "Bearer good-token", 200, "", "bot"},
+		{"/api/me", "Bearer bad", 401, `Bearer realm="BanglaCode", error="invalid_token"`, `{"error":"Unauthorized"}`},
+		{"/api/me", "", 401, `Bearer realm="BanglaCode"`, `{"error":"Unauthorized"}`},
+		{"/api/list", "bearer t2", 200, "", "t2"},
+	}
+	for _, tt := range tests {
+		req := mustRequest("GET", base+tt.path)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		resp, body := doRequest(t, http.DefaultClient, req)
+		if resp.StatusCode != tt.status || resp.Header.Get("WWW-Authenticate") != tt.challenge || body != tt.body {
+			t.Errorf("%s %q: got %d %q %q", tt.path, tt.auth, resp.StatusCode, resp.Header.Get("WWW-Authenticate"), body)
+		}
+	}
+}
+
+// TestSessionAuthErrors tests argument validation
+func TestSessionAuthErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`session_chalu(router_banao(), {})`, "`secret` option is required"},
+		{`session_chalu(router_banao(), {secret: "x", store: "disk"})`, `unknown store "disk"`},
+		{`session_chalu(router_banao(), {secret: "x", store: 5})`, "must be STRING or Redis connection"},
+		{`session_muchho({})`, "no session on this request"},
+		{`basic_pahara(5)`, "must be MAP (users) or FUNCTION (verifier)"},
+		{`bearer_pahara({})`, "must be ARRAY (tokens) or FUNCTION (verifier)"},
+		{`kuki_pora({}, "a")`, "want=3 (req, name, secret)"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
+
+func mustRequest(method, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
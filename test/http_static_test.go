@@ -0,0 +1,179 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// staticGet sends a request with the given headers; compression is left to the server
+func staticGet(t *testing.T, method, url string, headers map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	client := &http.Client{
+		Transport:     &http.Transport{DisableCompression: true},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func staticFixture(t *testing.T) (public, spa string) {
+	t.Helper()
+	public = writeViews(t, map[string]string{
+		"hello.txt":       "hello static world",
+		"app.js":          "console.log('plain');",
+		"app.js.gz":       "pretend-gzip",
+		"page.html":       "<p>page</p>",
+		"docs/index.html": "docs home",
+		"files/a<b>.txt":  "a",
+		"files/.hidden":   "h",
+		".env":            "SECRET=1",
+	})
+	spa = writeViews(t, map[string]string{"index.html": "spa shell", "main.js": "js"})
+
+	outside := writeViews(t, map[string]string{"secret.txt": "outside"})
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(public, "link.txt")); err != nil {
+		t.Skip("symlinks not available")
+	}
+	return public, spa
+}
+
+// TestStaticCachingAndRanges tests content types, cache headers, conditional and range requests
+// and precompressed assets
+func TestStaticCachingAndRanges(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	public, _ := staticFixture(t)
+	base := startStreamingServer(t, `
+	file_dao(app, "/static", "`+public+`", {maxAge: 3600, immutable: sotti, cacheControl: {".html": "no-cache"}});
+	`)
+
+	resp, body := staticGet(t, "GET", base+"/static/hello.txt", nil)
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != 200 || body != "hello static world" || etag == "" || resp.Header.Get("Last-Modified") == "" {
+		t.Fatalf("GET hello.txt: %d %q etag=%q", resp.StatusCode, body, etag)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "public, max-age=3600, immutable" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if resp, _ := staticGet(t, "GET", base+"/static/page.html", nil); resp.Header.Get("Cache-Control") != "no-cache" {
+		t.Errorf("html Cache-Control = %q", resp.Header.Get("Cache-Control"))
+	}
+
+	if resp, _ := staticGet(t, "GET", base+"/static/hello.txt", map[string]string{"If-None-Match": etag}); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match status = %d", resp.StatusCode)
+	}
+	resp, body = staticGet(t, "GET", base+"/static/hello.txt", map[string]string{"Range": "bytes=6-11"})
+	if resp.StatusCode != http.StatusPartialContent || body != "static" || resp.Header.Get("Content-Range") != "bytes 6-11/18" {
+		t.Errorf("Range: %d %q %q", resp.StatusCode, body, resp.Header.Get("Content-Range"))
+	}
+	if resp, body := staticGet(t, "HEAD", base+"/static/hello.txt", nil); resp.StatusCode != 200 || body != "" || resp.ContentLength != 18 {
+		t.Errorf("HEAD: %d %q %d", resp.StatusCode, body, resp.ContentLength)
+	}
+
+	resp, body = staticGet(t, "GET", base+"/static/app.js", map[string]string{"Accept-Encoding": "gzip, deflate"})
+	if body != "pretend-gzip" || resp.Header.Get("Content-Encoding") != "gzip" || !strings.Contains(resp.Header.Get("Content-Type"), "javascript") {
+		t.Errorf("precompressed: %q %q %q", body, resp.Header.Get("Content-Encoding"), resp.Header.Get("Content-Type"))
+	}
+	resp, body = staticGet(t, "GET", base+"/static/app.js", nil)
+	if body != "console.log('plain');" || resp.Header.Get("Content-Encoding") != "" || resp.Header.Get("Vary") != "Accept-Encoding" {
+		t.Errorf("uncompressed: %q %q vary=%q", body, resp.Header.Get("Content-Encoding"), resp.Header.Get("Vary"))
+	}
+}
+
+// TestStaticIndexesAndSafety tests directory indexes, listings, SPA fallback, dotfiles and
+// paths that try to leave the directory
+func TestStaticIndexesAndSafety(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	public, spa := staticFixture(t)
+	base := startStreamingServer(t, `
+	app.ana("/api/ping", kaj(req, res) { res.body = "pong"; });
+	file_dao(app, "/static", "`+public+`");
+	file_dao(app, "/browse", "`+public+`", {index: mittha, listing: sotti, dotfiles: "deny"});
+	file_dao(app, "/", "`+spa+`", {spa: sotti});
+	`)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/static/docs/", 200, "docs home"},
+		{"/static/docs", 301, ""},
+		{"/static/.env", 404, ""},
+		{"/browse/.env", 403, ""},
+		{"/static/files/.hidden", 404, ""},
+		{"/static/../../etc/passwd", 404, ""},
+		{"/static/%2e%2e/%2e%2e/etc/passwd", 404, ""},
+		{"/static/link.txt", 404, ""},
+		{"/static/missing.txt", 404, ""},
+		{"/api/ping", 200, "pong"},
+		{"/dashboard/settings", 200, "spa shell"},
+		{"/main.js", 200, "js"},
+		{"/missing.js", 404, ""},
+	}
+	for _, tt := range tests {
+		resp, body := staticGet(t, "GET", base+tt.path, nil)
+		if resp.StatusCode != tt.status || (tt.body != "" && body != tt.body) {
+			t.Errorf("%s: got %d %q, want %d %q", tt.path, resp.StatusCode, body, tt.status, tt.body)
+		}
+	}
+
+	resp, body := staticGet(t, "GET", base+"/browse/files/", nil)
+	if resp.StatusCode != 200 || !strings.Contains(body, `<a href="a%3Cb%3E.txt">a&lt;b&gt;.txt</a>`) || strings.Contains(body, ".hidden") {
+		t.Errorf("listing: %d %q", resp.StatusCode, body)
+	}
+	if resp, _ := staticGet(t, "POST", base+"/static/hello.txt", nil); resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d", resp.StatusCode)
+	}
+}
+
+// TestStaticMiddleware tests that static mounts run global and per-mount middleware
+func TestStaticMiddleware(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	public, _ := staticFixture(t)
+	base := startStreamingServer(t, `
+	app.majhe(kaj(req, res, agorao) {
+		res.headers["X-Seen"] = req.params.path;
+		agorao();
+	});
+	dhoro pahara = kaj(req, res, agorao) {
+		jodi (req.headers["X-Key"] == "khola") {
+			agorao();
+		} nahole {
+			res.status = 401;
+			res.body = "login";
+		}
+	};
+	file_dao(app, "/private", "`+public+`", {majhe: pahara, cacheControl: "private, no-store"});
+	`)
+
+	resp, body := staticGet(t, "GET", base+"/private/hello.txt", nil)
+	if resp.StatusCode != 401 || body != "login" || resp.Header.Get("X-Seen") != "hello.txt" {
+		t.Errorf("blocked: %d %q seen=%q", resp.StatusCode, body, resp.Header.Get("X-Seen"))
+	}
+	resp, body = staticGet(t, "GET", base+"/private/hello.txt", map[string]string{"X-Key": "khola"})
+	if resp.StatusCode != 200 || body != "hello static world" || resp.Header.Get("Cache-Control") != "private, no-store" {
+		t.Errorf("allowed: %d %q cc=%q", resp.StatusCode, body, resp.Header.Get("Cache-Control"))
+	}
+
+	testErrorObject(t, testEval(`dhoro app = router_banao(); file_dao(app, "/x", "`+public+`/nope")`), "not found", 0)
+	testErrorObject(t, testEval(`dhoro app = router_banao(); file_dao(app, "/x", "`+public+`", {dotfiles: "show"})`), "`dotfiles` option", 1)
+	testErrorObject(t, testEval(`dhoro app = router_banao(); file_dao(app, "/x", "`+public+`", {spa: sotti, index: mittha})`), "needs an `index`", 2)
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startStreamingServer runs a script that builds `app` and serves it on a free port
+func startStreamingServer(t *testing.T, routes string) string {
+	t.Helper()
+	result := evalServer(`
+	dhoro app = router_banao();
+	` + routes + `
+	server_chalu(0, app).port
+	`)
+	port, ok := result.(*object.Number)
+	if !ok {
+		t.Fatalf("server did not start: %s", result.Inspect())
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", int(port.Value))
+}
+
+// TestResponseIncrementalWrites tests res.lekho/res.samapti with chunked transfer encoding
+func TestResponseIncrementalWrites(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.ana("/export", kaj(req, res) {
+		res.status = 201;
+		res.headers["X-Export"] = "rows";
+		ghuriye (dhoro i = 1; i <= 3; i = i + 1) {
+			res.lekho("row" + lipi(i) + ";");
+		}
+		res.samapti({done: sotti});
+		res.body = "ignored";
+	});`)
+
+	resp, err := http.Get(base + "/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != 201 || resp.Header.Get("X-Export") != "rows" {
+		t.Errorf("unexpected status/headers: %d %v", resp.StatusCode, resp.Header)
+	}
+	if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Errorf("expected chunked transfer encoding, got %v", resp.TransferEncoding)
+	}
+	if string(body) != "row1;row2;row3;{\"done\":true}" {
+		t.Errorf("unexpected body %q", string(body))
+	}
+}
+
+// TestStreamingRequestBody tests {stream: sotti} routes reading req.stream in chunks
+func TestStreamingRequestBody(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.pathano("/upload", kaj(req, res) {
+		dhoro total = 0;
+		dhoro chunks = 0;
+		dhoro chunk = stream_poro(req.stream, 4096);
+		jotokkhon (chunk != khali) {
+			total = total + dorghyo(chunk);
+			chunks = chunks + 1;
+			chunk = stream_poro(req.stream, 4096);
+		}
+		res.body = lipi(total) + " bytes in " + lipi(chunks >= 25) + " chunks, body='" + req.body + "'";
+	}, {stream: sotti});
+	app.pathano("/buffered", kaj(req, res) {
+		res.body = req.body + "|" + stream_poro(req.stream);
+	});`)
+
+	resp, err := http.Post(base+"/upload", "application/octet-stream", strings.NewReader(strings.Repeat("x", 100000)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "100000 bytes in true chunks, body=''" {
+		t.Errorf("unexpected upload response %q", string(body))
+	}
+
+	resp, err = http.Post(base+"/buffered", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hello|hello" {
+		t.Errorf("unexpected buffered response %q", string(body))
+	}
+}
+
+// TestStreamPipeRequestToResponse tests stream_pipe from req.stream into res.stream
+func TestStreamPipeRequestToResponse(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.bodlano("/echo", kaj(req, res) {
+		res.headers["Content-Type"] = "text/plain";
+		stream_pipe(req.stream, res.stream);
+	}, {stream: sotti});`)
+
+	payload := strings.Repeat("banglacode ", 10000)
+	req, _ := http.NewRequest("PUT", base+"/echo", strings.NewReader(payload))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != payload {
+		t.Errorf("echo mismatch: got %d bytes, want %d", len(body), len(payload))
+	}
+}
+
+// TestServerSentEvents tests sse_shuru event framing and headers
+func TestServerSentEvents(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.ana("/events", kaj(req, res) {
+		dhoro sse = sse_shuru(res);
+		sse.pathano("hello");
+		sse.ping();
+		sse.pathano({count: 2}, {event: "tick", id: 7});
+		sse.pathano("line1`+"\n"+`line2");
+		sse.bondho();
+	});`)
+
+	resp, err := http.Get(base + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	first, _ := reader.ReadString('\n')
+	if first != "data: hello\n" {
+		t.Errorf("first event should arrive before the stream ends, got %q", first)
+	}
+	rest, _ := io.ReadAll(reader)
+	expected := "\n: ping\n\nevent: tick\nid: 7\ndata: {\"count\":2}\n\ndata: line1\ndata: line2\n\n"
+	if string(rest) != expected {
+		t.Errorf("unexpected event stream %q", string(rest))
+	}
+}
+
+// TestStreamingErrors tests misuse of the streaming helpers
+func TestStreamingErrors(t *testing.T) {
+	testErrorObject(t, testEval(`sse_shuru({status: 200})`), "still being handled", 0)
+	testErrorObject(t, testEval(`dhoro app = router_banao(); app.ana("/", kaj(req, res) {}, "stream")`),
+		"must be MAP (options)", 0)
+}
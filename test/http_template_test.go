@@ -0,0 +1,153 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeViews creates template files under a temporary views directory
+func writeViews(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+var testViews = map[string]string{
+	"layouts/main.html": `<title>{{block "title" .}}App{{end}}</title>{{template "partials/nav" .}}<main>{{template "content" .}}</main>`,
+	"partials/nav.html": `<nav>{{.user}}</nav>`,
+	"users.html":        `{{define "title"}}Users ({{len .users}}){{end}}<ul>{{range $i, $u := .users}}<li>{{$i}}:{{$u.naam}}{{if $u.admin}}*{{end}}</li>{{else}}none{{end}}</ul>`,
+	"bare.html":         `<p>{{.msg}}</p><a href="/search?q={{.msg}}">x</a>`,
+}
+
+// TestTemplateRendering tests autoescaping, loops, conditionals, layouts, blocks and partials
+func TestTemplateRendering(t *testing.T) {
+	dir := writeViews(t, testViews)
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`template_bhoro("<b>{{.naam}}</b> {{.n}} {{kacha .html}}", {naam: "<script>", n: 1000000, html: "<i>ok</i>"})`,
+			"<b>&lt;script&gt;</b> 1000000 <i>ok</i>"},
+		{`template_bhoro("{{if .list}}{{range .list}}[{{.}}]{{end}}{{else}}empty{{end}}", {list: [1, 2.5, "a"]})`,
+			"[1][2.5][a]"},
+		{`template_bhoro("{{if eq .count 0}}zero{{else}}{{.count}}{{end}}", {count: 0})`, "zero"},
+		{`template_banao("` + dir + `").likho("bare", {msg: "a&b"})`,
+			`<p>a&amp;b</p><a href="/search?q=a%26b">x</a>`},
+		{`dhoro v = template_banao("` + dir + `", {layout: "layouts/main"});
+		v.likho("users", {user: "<ankan>", users: [{naam: "Rahim", admin: sotti}, {naam: "Karim"}]})`,
+			`<title>Users (2)</title><nav>&lt;ankan&gt;</nav><main><ul><li>0:Rahim*</li><li>1:Karim</li></ul></main>`},
+		{`dhoro v = template_banao("` + dir + `", {layout: "layouts/main"});
+		v.likho("bare.html", {msg: "hi", user: "x"})`,
+			`<title>App</title><nav>x</nav><main><p>hi</p><a href="/search?q=hi">x</a></main>`},
+		{`dhoro v = template_banao("` + dir + `", {layout: "layouts/main"});
+		v.likho("users", {users: []}, {layout: khali})`,
+			`<ul>none</ul>`},
+	}
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+
+	errors := []struct {
+		input    string
+		expected string
+	}{
+		{`template_banao("` + dir + `").likho("missing")`, `template "missing" not found`},
+		{`template_banao("` + dir + `").likho("../secret")`, "invalid template name"},
+		{`template_banao("` + dir + `/nope")`, "template directory"},
+		{`template_banao("` + dir + `", {dev: "yes"})`, "`dev` option"},
+		{`template_bhoro("{{.a")`, "template error"},
+		{`template_bhoro(5)`, "must be STRING"},
+		{`template_chalu({}, "` + dir + `")`, "template_chalu"},
+	}
+	for i, tt := range errors {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
+
+// TestTemplateDevReload tests that dev mode picks up edited files and the default mode keeps its cache
+func TestTemplateDevReload(t *testing.T) {
+	dir := writeViews(t, map[string]string{"page.html": "v1"})
+	render := func(engine object.Object) object.Object {
+		likho := engine.(*object.Map).Pairs["likho"].(*object.Builtin)
+		return likho.Fn(&object.String{Value: "page"})
+	}
+	dev := testEval(`template_banao("` + dir + `", {dev: sotti})`)
+	prod := testEval(`template_banao("` + dir + `")`)
+	testStringObject(t, render(dev), "v1")
+	testStringObject(t, render(prod), "v1")
+
+	later := time.Now().Add(time.Minute)
+	os.WriteFile(filepath.Join(dir, "page.html"), []byte("v2"), 0o644)
+	os.Chtimes(filepath.Join(dir, "page.html"), later, later)
+
+	testStringObject(t, render(dev), "v2")
+	testStringObject(t, render(prod), "v1")
+}
+
+// TestTemplateRouterRender tests res.dekhao with the app's layout, status and content type
+func TestTemplateRouterRender(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	dir := writeViews(t, testViews)
+	base := startStreamingServer(t, `
+	template_chalu(app, "`+dir+`", {layout: "layouts/main"});
+	app.ana("/users", kaj(req, res) {
+		res.dekhao("users", {user: req.query.user, users: [{naam: "Rahim"}]});
+	});
+	app.ana("/gone", kaj(req, res) {
+		res.dekhao("bare", {msg: "gone"}, {layout: khali, status: 410});
+	});
+	app.ana("/broken", kaj(req, res) {
+		res.dekhao("missing");
+	});
+	app.painai(kaj(req, res) { res.dekhao("bare", {msg: req.path}, {layout: khali}); });
+	`)
+
+	resp, err := http.Get(base + "/users?user=%3Cb%3E")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if body := getBody(t, http.DefaultClient, base+"/users?user=%3Cb%3E"); !strings.Contains(body, "<nav>&lt;b&gt;</nav><main><ul><li>0:Rahim</li></ul>") {
+		t.Errorf("/users body = %q", body)
+	}
+
+	resp, err = http.Get(base + "/gone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusGone {
+		t.Errorf("/gone status = %d", resp.StatusCode)
+	}
+
+	if body := getBody(t, http.DefaultClient, base+"/nowhere"); !strings.HasPrefix(body, "<p>/nowhere</p>") {
+		t.Errorf("404 body = %q", body)
+	}
+
+	resp, err = http.Get(base + "/broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("/broken status = %d", resp.StatusCode)
+	}
+}
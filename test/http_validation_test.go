@@ -0,0 +1,163 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const validatedRoutes = `
+	dhoro userSchema = {
+		summary: "Create a user",
+		tags: ["users"],
+		params: {type: "object", properties: {org: {type: "integer", minimum: 1}}},
+		query: {type: "object", required: ["dry"], properties: {dry: {type: "boolean"}}},
+		headers: {type: "object", required: ["x-api-key"], properties: {"x-api-key": {type: "string", minLength: 4}}},
+		body: {
+			type: "object",
+			required: ["name", "email"],
+			additionalProperties: mittha,
+			properties: {
+				name: {type: "string", minLength: 2},
+				email: {type: "string", format: "email"},
+				age: {type: "integer", minimum: 0, maximum: 150},
+				role: {enum: ["admin", "member"]},
+				tags: {type: "array", items: {type: "string"}, maxItems: 2}
+			}
+		},
+		responses: {"201": {description: "Created", schema: {type: "object"}}}
+	};
+	app.pathano("/orgs/:org(\d+)/users", kaj(req, res) {
+		json_uttor(res, {org: req.params.org + 1, dry: req.query.dry, name: req.json.name}, 201);
+	}, {schema: userSchema});
+	app.ana("/files/*path", kaj(req, res) { res.body = req.params.path; });
+	app.ana("/internal", kaj(req, res) {}, {openapi: mittha});
+	openapi_chalu(app, "/docs/openapi.json", {title: "Users API", version: "2.1.0"});
+`
+
+// postJSON sends a JSON body with an API key header
+func postJSON(t *testing.T, url, key, body string) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest("POST", url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("X-Api-Key", key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	out, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(out)
+}
+
+// TestRouteSchemaValidation tests coercion, structured 400 responses and valid requests
+func TestRouteSchemaValidation(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, validatedRoutes)
+
+	status, body := postJSON(t, base+"/orgs/41/users?dry=true", "secret", `{"name":"Ankan","email":"ankan@example.com","age":30,"role":"admin"}`)
+	if status != 201 || body != `{"dry":true,"name":"Ankan","org":42}` {
+		t.Errorf("valid request: %d %s", status, body)
+	}
+
+	status, body = postJSON(t, base+"/orgs/0/users", "abc", `{"name":"A","email":"nope","age":1.5,"role":"root","tags":["a",2,"c"],"extra":1}`)
+	if status != 400 {
+		t.Fatalf("invalid request: expected 400, got %d %s", status, body)
+	}
+	var result struct {
+		Error   string `json:"error"`
+		Details []struct {
+			In, Path, Message string
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(result.Details))
+	for i, d := range result.Details {
+		got[i] = d.In + " " + d.Path + ": " + d.Message
+	}
+	expected := []string{
+		"params params.org: must be >= 1",
+		"query query.dry: is required",
+		"headers headers.x-api-key: must be at least 4 characters",
+		"body body.age: must be integer",
+		"body body.email: must be a valid email",
+		"body body.extra: is not allowed",
+		"body body.name: must be at least 2 characters",
+		`body body.role: must be one of "admin", "member"`,
+		"body body.tags: must have at most 2 items",
+		"body body.tags[1]: must be string",
+	}
+	if result.Error != "validation failed" || strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected validation details:\n%s", strings.Join(got, "\n"))
+	}
+
+	if status, body := postJSON(t, base+"/orgs/3/users?dry=1", "secret", `not json`); status != 400 || !strings.Contains(body, "must be valid JSON") {
+		t.Errorf("malformed JSON: %d %s", status, body)
+	}
+}
+
+// TestOpenAPIDocument tests openapi_chalu output generated from routes and schemas
+func TestOpenAPIDocument(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, validatedRoutes)
+
+	resp, err := http.Get(base + "/docs/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var doc map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc["openapi"] != "3.0.3" || doc["info"].(map[string]interface{})["title"] != "Users API" {
+		t.Errorf("unexpected header: %v %v", doc["openapi"], doc["info"])
+	}
+	paths := doc["paths"].(map[string]interface{})
+	if len(paths) != 2 || paths["/files/{path}"] == nil {
+		t.Fatalf("expected only the two public routes, got %v", paths)
+	}
+	op := paths["/orgs/{org}/users"].(map[string]interface{})["post"].(map[string]interface{})
+	if op["summary"] != "Create a user" {
+		t.Errorf("missing summary: %v", op)
+	}
+	var params []string
+	for _, p := range op["parameters"].([]interface{}) {
+		param := p.(map[string]interface{})
+		params = append(params, param["in"].(string)+":"+param["name"].(string)+":"+map[bool]string{true: "required", false: "optional"}[param["required"].(bool)])
+	}
+	if strings.Join(params, ",") != "path:org:required,query:dry:required,header:x-api-key:required" {
+		t.Errorf("unexpected parameters %v", params)
+	}
+	schema := op["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	if schema["required"].([]interface{})[0] != "name" {
+		t.Errorf("unexpected body schema %v", schema)
+	}
+	responses := op["responses"].(map[string]interface{})
+	if responses["201"].(map[string]interface{})["description"] != "Created" || responses["400"] == nil {
+		t.Errorf("unexpected responses %v", responses)
+	}
+}
+
+// TestSchemaJachai tests standalone validation and schema option errors
+func TestSchemaJachai(t *testing.T) {
+	input := `
+	dhoro schema = {type: "object", required: ["id"], properties: {id: {type: "string", format: "uuid"}}};
+	dhoro ok = schema_jachai({id: "123e4567-e89b-12d3-a456-426614174000"}, schema);
+	dhoro bad = schema_jachai({id: "x"}, schema);
+	[lipi(dorghyo(ok)), bad[0].path + " " + bad[0].message, lipi(dorghyo(schema_jachai({}, schema)))]
+	`
+	testStringArray(t, testEval(input), []string{"0", "value.id must be a valid uuid", "1"})
+
+	testErrorObject(t, testEval(`router_banao().ana("/", kaj(req, res) {}, {schema: "strict"})`), "`schema` option to router.ana() must be MAP", 0)
+	testErrorObject(t, testEval(`schema_jachai(1, "number")`), "must be MAP (schema)", 0)
+}
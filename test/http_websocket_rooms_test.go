@@ -0,0 +1,186 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const chatRoutes = `
+	app.websocket("/chat/:room", {
+		khola: kaj(conn) {
+			conn.meta.name = conn.req.query.name;
+			websocket_jog(conn, conn.req.params.room);
+			websocket_somprochar(conn.req.params.room, conn.meta.name + " joined", conn);
+		},
+		barta: kaj(conn, msg) {
+			jodi (msg == "rooms") {
+				websocket_jog(conn, "extra");
+				conn.lekho(lipi(websocket_ghor(conn)));
+				ferao;
+			}
+			websocket_somprochar(conn.req.params.room, conn.meta.name + ": " + msg, conn);
+		},
+		bondho: kaj(conn, code, reason) {
+			websocket_somprochar(conn.req.params.room, conn.meta.name + " left");
+		}
+	});
+	app.ana("/presence/:room", kaj(req, res) {
+		dhoro names = [];
+		ghuriye (dhoro i = 0; i < dorghyo(websocket_sodossho(req.params.room)); i = i + 1) {
+			dhokao(names, websocket_sodossho(req.params.room)[i].meta.name);
+		}
+		res.body = lipi(names);
+	});
+	app.ana("/announce", kaj(req, res) { res.body = lipi(websocket_sobaike("announcement")); });
+`
+
+func waitForBody(t *testing.T, url, expected string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		body := getBody(t, http.DefaultClient, url)
+		if body == expected {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: got %q, want %q", url, body, expected)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestWebSocketRooms tests join, broadcast except the sender, presence, membership
+// queries and leaving on close
+func TestWebSocketRooms(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, chatRoutes)
+
+	a, _, err := dialWS(t, base, "/chat/lobby?name=ankan", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForBody(t, base+"/presence/lobby", "[ankan]")
+	b, _, err := dialWS(t, base, "/chat/lobby?name=bina", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	c, _, err := dialWS(t, base, "/chat/other?name=chandra", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	waitForBody(t, base+"/presence/lobby", "[ankan, bina]")
+
+	if _, msg := readWS(t, a); msg != "bina joined" {
+		t.Errorf("a: got %q", msg)
+	}
+	a.WriteMessage(websocket.TextMessage, []byte("hello"))
+	if _, msg := readWS(t, b); msg != "ankan: hello" {
+		t.Errorf("b: got %q", msg)
+	}
+
+	c.WriteMessage(websocket.TextMessage, []byte("rooms"))
+	if _, msg := readWS(t, c); msg != "[extra, other]" {
+		t.Errorf("websocket_ghor: got %q", msg)
+	}
+	waitForBody(t, base+"/presence/extra", "[chandra]")
+
+	// other tests' connections may still be closing, so count at least ours
+	if sent, _ := strconv.Atoi(getBody(t, http.DefaultClient, base+"/announce")); sent < 3 {
+		t.Errorf("websocket_sobaike: sent to %d connections", sent)
+	}
+	for name, conn := range map[string]*websocket.Conn{"a": a, "b": b, "c": c} {
+		if _, msg := readWS(t, conn); msg != "announcement" {
+			t.Errorf("%s: expected the announcement, got %q", name, msg)
+		}
+	}
+
+	a.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	a.Close()
+	if _, msg := readWS(t, b); msg != "ankan left" {
+		t.Errorf("b: got %q", msg)
+	}
+	waitForBody(t, base+"/presence/lobby", "[bina]")
+}
+
+// TestWebSocketSlowConsumer tests that a client that stops reading is dropped instead
+// of blocking broadcasts to the others
+func TestWebSocketSlowConsumer(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	dhoro join = kaj(conn) { websocket_jog(conn, "all"); };
+	app.websocket("/slow", {khola: join}, {sendQueue: 4});
+	app.websocket("/fast", {khola: join});
+	app.ana("/count", kaj(req, res) { res.body = lipi(dorghyo(websocket_sodossho("all"))); });
+	app.ana("/flood", kaj(req, res) {
+		dhoro chunk = "x";
+		ghuriye (dhoro i = 0; i < 16; i = i + 1) { chunk = chunk + chunk; }
+		ghuriye (dhoro i = 0; i < 200; i = i + 1) { websocket_somprochar("all", chunk); }
+		res.body = "done";
+	});`)
+
+	slow, _, err := dialWS(t, base, "/slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slow.Close()
+	fast, _, err := dialWS(t, base, "/fast", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fast.Close()
+	waitForBody(t, base+"/count", "2")
+
+	received := make(chan int)
+	go func() {
+		n := 0
+		fast.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for n < 200 {
+			if _, data, err := fast.ReadMessage(); err != nil || len(data) != 65536 {
+				break
+			}
+			n++
+		}
+		received <- n
+	}()
+
+	start := time.Now()
+	if body := getBody(t, http.DefaultClient, base+"/flood"); body != "done" {
+		t.Fatalf("flood: %q", body)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("broadcast blocked on the slow client for %s", elapsed)
+	}
+	if n := <-received; n != 200 {
+		t.Errorf("fast client got %d of 200 messages", n)
+	}
+	waitForBody(t, base+"/count", "1")
+}
+
+// TestWebSocketRoomErrors tests argument validation
+func TestWebSocketRoomErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`websocket_jog({id: "ws_conn_0"}, "a")`, "WebSocket connection not found or closed"},
+		{`websocket_jog("conn", "a")`, "first argument to `websocket_jog` must be MAP (connection)"},
+		{`websocket_jog({})`, "want=2 (conn, room)"},
+		{`websocket_chharo({})`, "connection object missing 'id' field"},
+		{`websocket_somprochar(5, "hi")`, "first argument to `websocket_somprochar` must be STRING (room)"},
+		{`websocket_somprochar("a", 5)`, "message to `websocket_somprochar` must be STRING or BUFFER"},
+		{`websocket_sobaike("hi", "x")`, "`except` argument to `websocket_sobaike` must be MAP (connection) or ARRAY"},
+		{`websocket_sodossho("")`, "must be a non-empty STRING (room)"},
+		{`router_banao().websocket("/ws", {}, {sendQueue: 0})`, "`sendQueue` option to router.websocket() must be a positive NUMBER"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+	testStringObject(t, testEval(`lipi(websocket_somprochar("nobody-here", "hi"))`), "0")
+}
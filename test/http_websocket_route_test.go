@@ -0,0 +1,247 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialWS connects to a router WebSocket route
+func dialWS(t *testing.T, base, path string, header http.Header, protocols ...string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	dialer := websocket.Dialer{Subprotocols: protocols, HandshakeTimeout: 2 * time.Second}
+	return dialer.Dial("ws"+strings.TrimPrefix(base, "http")+path, header)
+}
+
+func readWS(t *testing.T, conn *websocket.Conn) (int, string) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return messageType, string(data)
+}
+
+// TestWebSocketRouteLifecycle tests open/message/close events, params, binary Buffers
+// and sharing a port with HTTP routes
+func TestWebSocketRouteLifecycle(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	dhoro closed = [];
+	app.ana("/closed", kaj(req, res) { res.body = lipi(closed); });
+	app.dol("/rooms").websocket("/:room", {
+		khola: kaj(conn) { conn.lekho("welcome to " + conn.req.params.room + " " + lipi(conn.connected)); },
+		barta: proyash kaj(conn, msg) {
+			jodi (dhoron(msg) == "BUFFER") {
+				conn.lekho(msg);
+			} nahole {
+				websocket_pathao(conn, "echo:" + msg);
+			}
+		},
+		bondho: kaj(conn, code, reason) { closed = [code, reason, conn.connected]; }
+	});`)
+
+	conn, _, err := dialWS(t, base, "/rooms/lobby", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, msg := readWS(t, conn); msg != "welcome to lobby true" {
+		t.Errorf("open: got %q", msg)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte("hi"))
+	if _, msg := readWS(t, conn); msg != "echo:hi" {
+		t.Errorf("text: got %q", msg)
+	}
+	conn.WriteMessage(websocket.BinaryMessage, []byte{0, 1, 2})
+	if messageType, msg := readWS(t, conn); messageType != websocket.BinaryMessage || msg != "\x00\x01\x02" {
+		t.Errorf("binary: got %d %q", messageType, msg)
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4000, "bye"))
+	conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for getBody(t, http.DefaultClient, base+"/closed") != `[4000, bye, false]` {
+		if time.Now().After(deadline) {
+			t.Fatalf("close event not delivered, got %q", getBody(t, http.DefaultClient, base+"/closed"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp, _ := doRequest(t, http.DefaultClient, mustRequest("GET", base+"/rooms/lobby"))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain GET on a WebSocket route: got %d", resp.StatusCode)
+	}
+}
+
+// TestWebSocketRouteAuthAndOrigins tests middleware auth on the upgrade request, origin
+// checks and subprotocol negotiation
+func TestWebSocketRouteAuthAndOrigins(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.websocket("/secure", {
+		khola: kaj(conn) { conn.lekho(conn.req.user + " via " + conn.protocol); }
+	}, {majhe: bearer_pahara(["t1"]), origins: ["https://app.example.com"], protocols: ["v2", "v1"]});
+	app.websocket("/same", {khola: kaj(conn) { conn.bondho(4001, "done"); }});`)
+
+	if _, resp, err := dialWS(t, base, "/secure", nil); err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %v %v", resp, err)
+	}
+
+	header := http.Header{"Authorization": {"Bearer t1"}, "Origin": {"https://evil.example.com"}}
+	if _, resp, err := dialWS(t, base, "/secure", header); err == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for a foreign origin, got %v %v", resp, err)
+	}
+
+	header.Set("Origin", "https://app.example.com")
+	conn, _, err := dialWS(t, base, "/secure", header, "v1", "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != "v2" {
+		t.Errorf("expected server preference v2, got %q", conn.Subprotocol())
+	}
+	if _, msg := readWS(t, conn); msg != "t1 via v2" {
+		t.Errorf("got %q", msg)
+	}
+
+	if _, resp, err := dialWS(t, base, "/same", http.Header{"Origin": {"https://elsewhere.test"}}); err == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("default policy should reject cross-origin upgrades, got %v %v", resp, err)
+	}
+	same, _, err := dialWS(t, base, "/same", http.Header{"Origin": {base}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer same.Close()
+	same.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = same.ReadMessage()
+	if closeErr, ok := err.(*websocket.CloseError); !ok || closeErr.Code != 4001 || closeErr.Text != "done" {
+		t.Errorf("expected close 4001 done, got %v", err)
+	}
+}
+
+// TestWebSocketRouteKeepaliveAndErrors tests pings, dropping silent peers and bhul
+func TestWebSocketRouteKeepaliveAndErrors(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	app.websocket("/ping", {
+		barta: proyash kaj(conn, msg) {
+			jodi (msg != "wait") { felo "bad message"; }
+			opekha ghumaao(100);
+			conn.lekho("waited");
+		},
+		bhul: kaj(conn, err) { conn.lekho("error: " + err); }
+	}, {pingInterval: 30, pongTimeout: 50, maxMessage: 8});`)
+
+	conn, _, err := dialWS(t, base, "/ping", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pings int32
+	conn.SetPingHandler(func(data string) error {
+		atomic.AddInt32(&pings, 1)
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	conn.WriteMessage(websocket.TextMessage, []byte("x"))
+	if _, msg := readWS(t, conn); msg != "error: bad message" {
+		t.Errorf("got %q", msg)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte("wait"))
+	if _, msg := readWS(t, conn); msg != "waited" || atomic.LoadInt32(&pings) == 0 {
+		t.Errorf("expected keepalive pings while waiting, got %q after %d pings", msg, atomic.LoadInt32(&pings))
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte("this is longer than eight bytes"))
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			// 1009 unless the unread rest of the message resets the connection first
+			if !websocket.IsCloseError(err, websocket.CloseMessageTooBig, websocket.CloseAbnormalClosure) {
+				t.Errorf("expected the oversized message to close the connection, got %v", err)
+			}
+			break
+		}
+	}
+
+	// A peer that never answers pings is dropped after pingInterval + pongTimeout
+	silent, _, err := dialWS(t, base, "/ping", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	silent.SetPingHandler(func(string) error { return nil })
+	start := time.Now()
+	silent.SetReadDeadline(start.Add(2 * time.Second))
+	for {
+		_, _, err := silent.ReadMessage()
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			t.Fatalf("silent peer was not dropped")
+		}
+		if err != nil {
+			break
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("silent peer dropped after %v, before the pong timeout", elapsed)
+	}
+}
+
+// TestWebSocketRouteDetachesResponse tests that an upgraded request's res is no longer a
+// live response: it leaves the stream registry and res.lekho fails
+func TestWebSocketRouteDetachesResponse(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	captured := make(chan *object.Map, 1)
+	builtins.Builtins["test_ws_res"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			captured <- args[0].(*object.Map)
+			return object.NULL
+		},
+	}
+	defer delete(builtins.Builtins, "test_ws_res")
+
+	base := startStreamingServer(t, `
+	app.websocket("/ws", {barta: kaj(conn, msg) { conn.lekho(msg); }},
+		{majhe: kaj(req, res, next) { test_ws_res(res); next(); }});`)
+
+	conn, _, err := dialWS(t, base, "/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte("hi"))
+	if _, msg := readWS(t, conn); msg != "hi" {
+		t.Errorf("got %q", msg)
+	}
+	conn.Close()
+
+	res := <-captured
+	result := builtins.Builtins["sse_shuru"].Fn(res)
+	testErrorObject(t, result, "still being handled", 0)
+	result = res.Pairs["lekho"].(*object.Builtin).Fn(&object.String{Value: "late"})
+	testErrorObject(t, result, "response already finished", 1)
+}
+
+// TestWebSocketRouteErrors tests argument validation
+func TestWebSocketRouteErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`router_banao().websocket("/ws")`, "wrong number of arguments to router.websocket(). got=1, want=2-3"},
+		{`router_banao().websocket("/ws", kaj(c) {})`, "must be MAP ({khola, barta, bondho, bhul})"},
+		{`router_banao().websocket("/ws", {barta: 5})`, "`barta` handler to router.websocket() must be FUNCTION"},
+		{`router_banao().websocket("/ws", {}, {origins: 5})`, "`origins` option to router.websocket() must be STRING or ARRAY"},
+		{`router_banao().websocket("/ws", {}, {pingInterval: -1})`, "`pingInterval` option to router.websocket() must be a non-negative NUMBER"},
+		{`router_banao().dol("/a").websocket("/ws", {}, {maxMessage: 0})`, "`maxMessage` option to group.websocket() must be a positive NUMBER"},
+		{`websocket_pathao({id: "x"}, 5)`, "must be STRING or BUFFER"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestJWTSignAndVerify tests HS/RS/ES round trips and tampered tokens
+func TestJWTSignAndVerify(t *testing.T) {
+	input := `
+	dhoro hs = jwt_banao({sub: "u1", roles: ["admin"]}, "secret", {algorithm: "HS512", expiresIn: 60});
+	dhoro claims = jwt_jachai(hs, "secret");
+	dhoro rsa = crypto_generate_keypair();
+	dhoro rs = jwt_banao({sub: "u2"}, rsa.privateKey, {algorithm: "RS256"});
+	dhoro ec = crypto_generate_keypair("ec");
+	dhoro es = jwt_banao({sub: "u3"}, ec.privateKey, {algorithm: "ES256", kid: "ec-1"});
+	dhoro parts = jwt_khulo(es);
+	[
+		claims.sub + " " + claims.roles[0] + " " + lipi(claims.exp - claims.iat),
+		jwt_jachai(rs, rsa.publicKey).sub,
+		jwt_jachai(es, ec.publicKey).sub,
+		parts.header.alg + " " + parts.header.kid + " " + parts.claims.sub
+	]
+	`
+	testStringArray(t, testEval(input), []string{"u1 admin 60", "u2", "u3", "ES256 ec-1 u3"})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`jwt_jachai(jwt_banao({sub: "a"}, "secret"), "other")`, "jwt_jachai: invalid signature"},
+		{`dhoro t = jwt_banao({sub: "a"}, "secret"); jwt_jachai(t + "x", "secret")`, "invalid signature"},
+		{`jwt_jachai("abc.def", "secret")`, "malformed token"},
+		{`jwt_jachai(jwt_banao({sub: "a"}, "secret", {algorithm: "HS384"}), "secret", {algorithms: ["HS256"]})`, "algorithm HS384 not allowed"},
+		{`dhoro k = crypto_generate_keypair("ec"); jwt_jachai(jwt_banao({}, "secret"), k.publicKey)`, "algorithm HS256 not allowed"},
+		{`jwt_banao({}, crypto_generate_keypair("ec").privateKey, {algorithm: "RS256"})`, "RS256 needs an RSA private key"},
+		{`jwt_banao({}, "secret", {algorithm: "none"})`, `unsupported algorithm "none"`},
+		{`jwt_banao("claims", "secret")`, "must be MAP (claims)"},
+		{`jwt_jachai("a.b.c", 5)`, "must be STRING (secret / public key) or MAP (jwks_poro keys)"},
+		{`crypto_generate_keypair("dsa")`, `key type must be "rsa" or "ec"`},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+}
+
+// TestJWTPublicKeyAsHMACSecret tests that an HS256 token signed with a public key PEM is rejected
+func TestJWTPublicKeyAsHMACSecret(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	signingInput := b64(`{"alg":"HS256","typ":"JWT"}`) + "." + b64(`{"sub":"attacker"}`)
+	mac := hmac.New(sha256.New, []byte(publicPEM))
+	mac.Write([]byte(signingInput))
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	testErrorObject(t, testEval(fmt.Sprintf(`jwt_jachai("%s", "%s")`, token, publicPEM)), "algorithm HS256 not allowed", 0)
+}
+
+// TestJWTClaimValidation tests exp/nbf/iat/aud/iss checks and leeway
+func TestJWTClaimValidation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`jwt_jachai(jwt_banao({exp: 1000}, "s"), "s")`, "token expired"},
+		{`jwt_jachai(jwt_banao({nbf: 99999999999}, "s"), "s")`, "token not yet valid"},
+		{`jwt_jachai(jwt_banao({iat: 99999999999}, "s"), "s")`, "token issued in the future"},
+		{`jwt_jachai(jwt_banao({}, "s", {expiresIn: -5}), "s", {leeway: 2})`, "token expired"},
+		{`jwt_jachai(jwt_banao({aud: "web"}, "s"), "s", {audience: "api"})`, "invalid audience"},
+		{`jwt_jachai(jwt_banao({}, "s"), "s", {audience: "api"})`, "invalid audience"},
+		{`jwt_jachai(jwt_banao({iss: "evil"}, "s"), "s", {issuer: ["auth", "sso"]})`, "invalid issuer"},
+		{`jwt_jachai(jwt_banao({}, "s"), "s", {audience: 5})`, "`audience` option to `jwt_jachai` must be STRING or ARRAY"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected, i)
+	}
+
+	input := `
+	dhoro opts = {leeway: 30, audience: "api", issuer: ["auth", "sso"]};
+	dhoro c = jwt_jachai(jwt_banao({aud: ["web", "api"], iss: "sso", sub: "ok"}, "s", {expiresIn: -5}), "s", opts);
+	c.sub
+	`
+	testStringObject(t, testEval(input), "ok")
+}
+
+// TestJWKS tests verifying tokens against keys parsed from a JWKS document
+func TestJWKS(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"rsa-1","n":"%s","e":"%s"},{"kty":"oct","kid":"hmac-1","k":"%s"}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString([]byte("shared")))
+
+	input := fmt.Sprintf(`
+	dhoro keys = jwks_poro('%s');
+	dhoro rs = jwt_banao({sub: "rsa-user"}, "%s", {algorithm: "RS256", kid: "rsa-1"});
+	dhoro hs = jwt_banao({sub: "hmac-user"}, "shared", {kid: "hmac-1"});
+	[jwt_jachai(rs, keys).sub, jwt_jachai(hs, keys).sub, lipi(dorghyo(chabi(keys)))]
+	`, jwks, privatePEM)
+	testStringArray(t, testEval(input), []string{"rsa-user", "hmac-user", "2"})
+
+	testErrorObject(t, testEval(fmt.Sprintf(`jwt_jachai(jwt_banao({}, "shared", {kid: "gone"}), jwks_poro('%s'))`, jwks)), `no key for kid "gone"`, 0)
+	testErrorObject(t, testEval(`jwks_poro('{"keys":[{"kty":"EC","crv":"P-521"}]}')`), `unsupported curve "P-521"`, 1)
+	testErrorObject(t, testEval(`jwks_poro("nope")`), "invalid JWKS JSON", 2)
+}
+
+// TestJWTMiddleware tests jwt_pahara populating req.user
+func TestJWTMiddleware(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	base := startStreamingServer(t, `
+	dhoro api = app.dol("/api");
+	api.majhe(jwt_pahara("s3cret", {audience: "api"}));
+	api.ana("/me", kaj(req, res) { res.body = req.user.sub; });
+	app.ana("/token/:sub", kaj(req, res) {
+		res.body = jwt_banao({sub: req.params.sub, aud: "api"}, "s3cret", {expiresIn: 60});
+	});
+	app.ana("/expired", kaj(req, res) { res.body = jwt_banao({sub: "old", aud: "api", exp: 1000}, "s3cret"); });`)
+
+	token := getBody(t, http.DefaultClient, base+"/token/ankan")
+	expired := getBody(t, http.DefaultClient, base+"/expired")
+	tests := []struct {
+		auth   string
+		status int
+		body   string
+	}{
+		{"Bearer " + token, 200, "ankan"},
+		{"Bearer " + expired, 401, `{"error":"Unauthorized"}`},
+		{"Bearer " + strings.TrimSuffix(token, token[len(token)-2:]), 401, `{"error":"Unauthorized"}`},
+		{"", 401, `{"error":"Unauthorized"}`},
+	}
+	for i, tt := range tests {
+		req := mustRequest("GET", base+"/api/me")
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		resp, body := doRequest(t, http.DefaultClient, req)
+		if resp.StatusCode != tt.status || body != tt.body {
+			t.Errorf("test[%d]: got %d %q", i, resp.StatusCode, body)
+		}
+	}
+	testErrorObject(t, testEval(`jwt_pahara([1])`), "must be STRING (secret / public key) or MAP", 0)
+}
+
+func b64(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
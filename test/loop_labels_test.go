@@ -0,0 +1,182 @@
+package test
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"testing"
+)
+
+// TestLabelledBreakAndContinue tests thamo/chharo targeting an outer loop
+func TestLabelledBreakAndContinue(t *testing.T) {
+	input := `
+	dhoro found = [];
+	outer: ghuriye (dhoro i = 0; i < 3; i = i + 1) {
+		ghuriye (dhoro j = 0; j < 3; j = j + 1) {
+			jodi (j == 1) { chharo outer; }
+			jodi (i == 2) { thamo outer; }
+			dhokao(found, i * 10 + j);
+		}
+	}
+	found
+	`
+
+	testArrayObject(t, testEval(input), []float64{0, 10}, 0)
+}
+
+// TestLabelledLoopKinds tests labels on jotokkhon, do-while, for-of and for-in loops
+func TestLabelledLoopKinds(t *testing.T) {
+	input := `
+	dhoro log = [];
+	w: jotokkhon (sotti) {
+		ghuriye (x of [1, 2, 3]) {
+			jodi (x == 2) { thamo w; }
+			dhokao(log, x);
+		}
+	}
+	dhoro n = 0;
+	d: do {
+		n = n + 1;
+		ghuriye (k in {a: 1, b: 2}) {
+			jodi (n < 3) { chharo d; }
+			dhokao(log, n);
+		}
+	} jotokkhon (n < 3);
+	items: ghuriye (x of [10, 20, 30]) {
+		ghuriye (y of [1, 2]) {
+			jodi (y == 2) { chharo items; }
+			dhokao(log, x + y);
+		}
+	}
+	log
+	`
+
+	testArrayObject(t, testEval(input), []float64{1, 3, 3, 11, 21, 31}, 0)
+}
+
+// TestLabelledBlock tests leaving a labelled block early
+func TestLabelledBlock(t *testing.T) {
+	input := `
+	dhoro steps = [];
+	setup: {
+		dhokao(steps, 1);
+		jodi (sotti) { thamo setup; }
+		dhokao(steps, 2);
+	}
+	dhokao(steps, 3);
+	steps
+	`
+
+	testArrayObject(t, testEval(input), []float64{1, 3}, 0)
+}
+
+// TestBreakContinueInsideSwitch tests that thamo leaves only bikolpo and chharo continues the loop
+func TestBreakContinueInsideSwitch(t *testing.T) {
+	input := `
+	dhoro out = [];
+	ghuriye (dhoro k = 0; k < 6; k = k + 1) {
+		bikolpo (k) {
+			khetre 1 { chharo; }
+			khetre 3 { thamo; }
+			manchito { jodi (k == 4) { thamo; } }
+		}
+		dhokao(out, k);
+	}
+	dhoro hits = 0;
+	loop: jotokkhon (sotti) {
+		hits = hits + 1;
+		bikolpo (hits) { khetre 3 { thamo loop; } }
+	}
+	dhokao(out, hits);
+	out
+	`
+
+	testArrayObject(t, testEval(input), []float64{0, 2, 3, 4, 5, 3}, 0)
+}
+
+// TestCommaOperatorInForHeader tests multiple declarations and comma-separated updates
+func TestCommaOperatorInForHeader(t *testing.T) {
+	input := `
+	dhoro pairs = [];
+	ghuriye (dhoro a = 0, b = 5; a < b; a = a + 1, b = b - 1) {
+		dhokao(pairs, a * 10 + b);
+	}
+	dhoro i = 0;
+	dhoro j = 0;
+	ghuriye (i = 1, j = 2; i < 4; i = i + 1, j = j + 2) {}
+	dhokao(pairs, i);
+	dhokao(pairs, j);
+	pairs
+	`
+
+	testArrayObject(t, testEval(input), []float64{5, 14, 23, 4, 8}, 0)
+}
+
+// TestLabelParseErrors tests undefined labels, chharo to non-loops and labels across functions
+func TestLabelParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`ghuriye (dhoro i = 0; i < 1; i = i + 1) { thamo nope; }`, "thamo: undefined label 'nope'"},
+		{`blk: { chharo blk; }`, "chharo target 'blk' is not a loop"},
+		{`a: jotokkhon (sotti) { dhoro f = kaj() { thamo a; }; }`, "thamo: undefined label 'a'"},
+		{`a: jotokkhon (sotti) { a: jotokkhon (sotti) { thamo; } }`, "label 'a' is already declared"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+		errs := p.Errors()
+		if len(errs) != 1 || errs[0][:len(tt.expected)] != tt.expected {
+			t.Errorf("input %q: expected single error %q, got %v", tt.input, tt.expected, errs)
+		}
+	}
+}
+
+// TestLabelledStatementString tests printing labels, labelled jumps and declaration lists
+func TestLabelledStatementString(t *testing.T) {
+	input := `outer: ghuriye (dhoro i = 0, j = 1; i < j; i = i + 1, j = j - 1) { chharo outer; }`
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	expected := "outer: ghuriye (dhoro i = 0, j = 1;; (i < j); i = (i + 1), j = (j - 1)) chharo outer;"
+	if got := program.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestYieldInsideLabelsAndLists tests that utpadan under a label, in a declaration list or
+// in a comma sequence still makes the function a generator
+func TestYieldInsideLabelsAndLists(t *testing.T) {
+	input := `
+	kaj gen() {
+		outer: ghuriye (dhoro i = 0; i < 3; i = i + 1) { utpadan i; }
+	}
+	gen()
+	`
+	if result := testEval(input); result.Type() != object.GENERATOR_OBJ {
+		t.Errorf("expected calling gen() to return a generator, got %s", result.Inspect())
+	}
+
+	tests := []string{
+		`dhoro f = kaj() { outer: ghuriye (dhoro i = 0; i < 3; i = i + 1) { utpadan i; } };`,
+		`dhoro f = kaj() { outer: { utpadan 1; } };`,
+		`dhoro f = kaj() { ghuriye (dhoro i = 0, j = utpadan 1; i < j; i = i + 1) {} };`,
+		`dhoro f = kaj() { ghuriye (dhoro i = 0; i < 3; i = i + 1, utpadan i) {} };`,
+	}
+	for _, input := range tests {
+		p := parser.New(lexer.New(input))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			t.Fatalf("input %q: parser errors: %v", input, p.Errors())
+		}
+		fn := program.Statements[0].(*ast.VariableDeclaration).Value.(*ast.FunctionLiteral)
+		if !fn.IsGenerator {
+			t.Errorf("input %q: expected a generator function", input)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"BanglaCode/src/checker"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"strings"
+	"testing"
+)
+
+// TestMatchLiteralAndAlternatives tests literal, negative and | patterns
+func TestMatchLiteralAndAlternatives(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`milao (2) { khetre 1 | 2 => "small", khetre _ => "big" }`, "small"},
+		{`milao (-5) { khetre -5 => "minus", khetre _ => "other" }`, "minus"},
+		{`milao ("ok") { khetre "fail" => "no", khetre "ok" => "yes" }`, "yes"},
+		{`milao (khali) { khetre khali => "empty", khetre _ => "full" }`, "empty"},
+		{`milao (sotti) { khetre mittha => "f"; khetre sotti => "t" }`, "t"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+// TestMatchArrayPatterns tests element-wise matching, length checks and rest bindings
+func TestMatchArrayPatterns(t *testing.T) {
+	input := `
+	kaj describe(v) {
+		ferao milao (v) {
+			khetre [] => 0,
+			khetre [x] => x,
+			khetre [1, y] => 100 + y,
+			khetre [first, ...rest] => first * 1000 + dorghyo(rest),
+			khetre _ => -1
+		};
+	}
+	dhoro results = [describe([]), describe([7]), describe([1, 5]), describe([2, 3, 4]), describe("x")];
+	results
+	`
+
+	testArrayObject(t, testEval(input), []float64{0, 7, 105, 2002, -1}, 0)
+}
+
+// TestMatchMapPatterns tests key presence, nested patterns and rest maps
+func TestMatchMapPatterns(t *testing.T) {
+	input := `
+	kaj handle(res) {
+		ferao milao (res) {
+			khetre {status: 200, body: {items: [first, ...others]}} => "first " + first,
+			khetre {status: 200, body} => "ok " + body,
+			khetre {status: s, ...extra} jodi (s >= 500) => "server " + dorghyo(chabi(extra)),
+			khetre {error} => "error " + error,
+			khetre _ => "unknown"
+		};
+	}
+	dhoro results = [handle({status: 200, body: {items: ["a", "b"]}}), handle({status: 200, body: "hi"}),
+		handle({status: 503, retry: 1, trace: 2}), handle({error: "boom"}), handle({status: 404})];
+	results
+	`
+
+	expected := []string{"first a", "ok hi", "server 2", "error boom", "unknown"}
+	arr := testEval(input)
+	testStringArray(t, arr, expected)
+}
+
+// TestMatchClassPatterns tests sreni type patterns, property destructuring and hisabe bindings
+func TestMatchClassPatterns(t *testing.T) {
+	input := `
+	sreni Person { shuru(naam, boyosh) { ei.naam = naam; ei.boyosh = boyosh; } }
+	sreni Robot { shuru(id) { ei.id = id; } }
+	kaj who(v) {
+		ferao milao (v) {
+			khetre Person {naam, boyosh: 30} => naam + " is thirty",
+			khetre Person {} hisabe p => "person " + p.naam,
+			khetre Robot {id} => "robot " + id,
+			khetre _ => "nobody"
+		};
+	}
+	dhoro results = [who(notun Person("Ankan", 30)), who(notun Person("Rahim", 20)), who(notun Robot(7)), who({naam: "x"})];
+	results
+	`
+
+	testStringArray(t, testEval(input), []string{"Ankan is thirty", "person Rahim", "robot 7", "nobody"})
+}
+
+// TestMatchGuardsAndBlocks tests guards, block arms and per-arm binding scopes
+func TestMatchGuardsAndBlocks(t *testing.T) {
+	input := `
+	dhoro n = 1;
+	dhoro result = milao (21) {
+		khetre n jodi (n > 100) => "huge",
+		khetre n => {
+			dhoro doubled = n * 2;
+			doubled
+		}
+	};
+	[result, n]
+	`
+
+	testArrayObject(t, testEval(input), []float64{42, 1}, 0)
+}
+
+// TestMatchReturnFromBlockArm tests that ferao inside a block arm returns from the enclosing function
+func TestMatchReturnFromBlockArm(t *testing.T) {
+	input := `
+	kaj f(v) {
+		milao (v) {
+			khetre 1 => { ferao "early"; },
+			khetre _ => khali
+		};
+		ferao "late";
+	}
+	f(1) + " " + f(2)
+	`
+
+	testStringObject(t, testEval(input), "early late")
+}
+
+// TestMatchNoArmMatched tests the runtime error when nothing matches
+func TestMatchNoArmMatched(t *testing.T) {
+	testErrorObject(t, testEval(`milao (3) { khetre 1 => "one" }`), "no khetre arm matched value 3", 0)
+}
+
+// TestMatchParseErrors tests malformed milao expressions
+func TestMatchParseErrors(t *testing.T) {
+	inputs := []string{
+		`milao (1) { }`,
+		`milao (1) { khetre 1 "one" }`,
+		`milao (1) { khetre {"quoted"} => 1 }`,
+		`milao (1) { dekho(1) }`,
+	}
+
+	for _, input := range inputs {
+		p := parser.New(lexer.New(input))
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+// TestMatchCheckerWarnings tests exhaustiveness and reachability warnings from the checker
+func TestMatchCheckerWarnings(t *testing.T) {
+	input := `dhoro ok: boolean = sotti;
+dhoro a = milao (ok) { khetre sotti => 1, khetre mittha => 2 };
+dhoro b = milao (ok) { khetre sotti => 1 };
+dhoro c = milao (3) { khetre 1 | 2 => "x" };
+dhoro d = milao (3) { khetre _ => 1, khetre 2 => 3 };
+dhoro e: string = milao (3) { khetre 1 => "a", khetre _ => 2 };
+dhoro f = milao ([1]) { khetre [x] => x, khetre {y} => y };`
+
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	diags := checker.Check(program)
+
+	expected := []struct {
+		line    int
+		warning bool
+		text    string
+	}{
+		{3, true, "mittha not handled"},
+		{4, true, "other number values not handled"},
+		{5, true, "unreachable khetre arm"},
+		{6, false, "cannot assign number | string to 'e' of type string"},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diags)
+	}
+	for i, want := range expected {
+		d := diags[i]
+		if d.Line != want.line || d.Warning != want.warning || !strings.Contains(d.Message, want.text) {
+			t.Errorf("diagnostic %d: expected line %d warning=%v %q, got %s", i, want.line, want.warning, want.text, d)
+		}
+	}
+}
+
+func testStringArray(t *testing.T, obj object.Object, expected []string) {
+	t.Helper()
+	arr, ok := obj.(*object.Array)
+	if !ok {
+		t.Fatalf("expected array, got %s", obj.Inspect())
+	}
+	if len(arr.Elements) != len(expected) {
+		t.Fatalf("expected %d elements, got %d (%s)", len(expected), len(arr.Elements), obj.Inspect())
+	}
+	for i, want := range expected {
+		testStringObject(t, arr.Elements[i], want)
+	}
+}
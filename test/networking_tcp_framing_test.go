@@ -0,0 +1,197 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTCPScript runs a script whose last value is a TCP server handle and dials it
+func startTCPScript(t *testing.T, input string) *net.TCPConn {
+	t.Helper()
+	result := evalServer(input)
+	server, ok := result.(*object.Map)
+	if !ok {
+		t.Fatalf("server did not start: %s", result.Inspect())
+	}
+	port := int(server.Pairs["port"].(*object.Number).Value)
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(3 * time.Second))
+	return conn.(*net.TCPConn)
+}
+
+// TestTCPLineFramingAndHalfClose tests that lines split across writes reach the handler
+// whole, and that the samapti callback can still reply after the client half-closes
+func TestTCPLineFramingAndHalfClose(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	conn := startTCPScript(t, `
+	dhoro count = 0;
+	tcp_server_chalu(0, kaj(conn) {
+		count = count + 1;
+		tcp_lekho(conn, "got " + conn.data);
+	}, {framing: "line", samapti: kaj(conn) { tcp_lekho(conn, "bye after " + lipi(count)); }})
+	`)
+	defer conn.Close()
+
+	for _, part := range []string{"hel", "lo\r\nwor", "ld\n", "tail"} {
+		conn.Write([]byte(part))
+		time.Sleep(20 * time.Millisecond)
+	}
+	conn.CloseWrite()
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "got hello\ngot world\ngot tail\nbye after 3\n"
+	if string(reply) != expected {
+		t.Errorf("got %q, want %q", reply, expected)
+	}
+}
+
+// TestTCPLengthPrefixBinary tests 2-byte little-endian length prefixes with Buffer payloads
+func TestTCPLengthPrefixBinary(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	conn := startTCPScript(t, `
+	tcp_server_chalu(0, kaj(conn) {
+		tcp_lekho(conn, buffer_hex(conn.data));
+	}, {framing: {type: "length", size: 2, endian: "little"}, binary: sotti})
+	`)
+	defer conn.Close()
+
+	// one frame split inside its prefix, then two frames in one write
+	conn.Write([]byte{3})
+	time.Sleep(20 * time.Millisecond)
+	conn.Write([]byte{0, 0xde, 0xad})
+	time.Sleep(20 * time.Millisecond)
+	conn.Write([]byte{0xbe, 1, 0, 0xff, 0, 0})
+
+	r := bufio.NewReader(conn)
+	for _, expected := range []string{"deadbe", "ff", ""} {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(r, header); err != nil {
+			t.Fatal(err)
+		}
+		body := make([]byte, int(header[0])|int(header[1])<<8)
+		if _, err := io.ReadFull(r, body); err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != expected {
+			t.Errorf("got %q, want %q", body, expected)
+		}
+	}
+}
+
+// TestTCPClientFraming tests framed tcp_shuno/tcp_lekho on tcp_jukto connections, tcp_samapti,
+// and that tcp_shuno resolves khali at end of stream
+func TestTCPClientFraming(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- string(data)
+		conn.Write([]byte("one|two|three"))
+	}()
+
+	input := fmt.Sprintf(`
+	dhoro conn = opekha tcp_jukto("127.0.0.1", %d, {framing: {type: "delimiter", delimiter: "|"}});
+	tcp_lekho(conn, "ping");
+	tcp_lekho(conn, buffer_theke("pong"));
+	tcp_samapti(conn);
+	dhoro frames = [];
+	dhoro frame = opekha tcp_shuno(conn);
+	jotokkhon (frame != khali) {
+		dhokao(frames, frame);
+		frame = opekha tcp_shuno(conn);
+	}
+	tcp_bondho(conn);
+	frames
+	`, listener.Addr().(*net.TCPAddr).Port)
+
+	testStringArray(t, evalServer(input), []string{"one", "two", "three"})
+	if got := <-received; got != "ping|pong|" {
+		t.Errorf("server received %q", got)
+	}
+}
+
+// TestTCPTimeouts tests that idleTimeout closes silent connections and readTimeout
+// closes connections that stall in the middle of a frame
+func TestTCPTimeouts(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	tests := []struct {
+		name    string
+		options string
+		send    string
+	}{
+		{"idle", `{idleTimeout: 100}`, ""},
+		{"read", `{framing: "line", readTimeout: 100, idleTimeout: 5000}`, "partial"},
+	}
+
+	for _, tt := range tests {
+		conn := startTCPScript(t, `tcp_server_chalu(0, kaj(conn) {}, `+tt.options+`)`)
+		conn.Write([]byte(tt.send))
+		start := time.Now()
+		if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+			t.Errorf("%s: expected the server to close the connection, got %v", tt.name, err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: closed after %s", tt.name, elapsed)
+		}
+		conn.Close()
+	}
+}
+
+// TestTCPFramingErrors tests option validation and oversized frames
+func TestTCPFramingErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`tcp_server_chalu(0, kaj(c) {}, {framing: "json"})`, "unknown framing"},
+		{`tcp_server_chalu(0, kaj(c) {}, {framing: {type: "length", size: 3}})`, "must be 1, 2 or 4"},
+		{`tcp_server_chalu(0, kaj(c) {}, {framing: {type: "length", endian: "middle"}})`, "must be \"big\" or \"little\""},
+		{`tcp_server_chalu(0, kaj(c) {}, {framing: {type: "delimiter"}})`, "non-empty `delimiter`"},
+		{`tcp_server_chalu(0, kaj(c) {}, {idleTimeout: -1})`, "`idleTimeout` option"},
+		{`tcp_jukto("127.0.0.1", 1, {maxFrame: 0})`, "`maxFrame` option"},
+		{`tcp_server_chalu(0, kaj(c) {}, {samapti: "done"})`, "`samapti` option"},
+	}
+
+	for i, tt := range tests {
+		testErrorObject(t, evalServer(tt.input), tt.expected, i)
+	}
+
+	defer builtins.CloseAllServers(time.Second)
+	conn := startTCPScript(t, `tcp_server_chalu(0, kaj(conn) {}, {framing: "line", maxFrame: 8})`)
+	defer conn.Close()
+	conn.Write([]byte(strings.Repeat("x", 64)))
+	if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("expected an oversized frame to close the connection, got %v", err)
+	}
+
+	result := evalServer(`
+	dhoro s = tcp_server_chalu(0, kaj(conn) {}, {framing: {type: "length", size: 1}});
+	dhoro c = opekha tcp_jukto("127.0.0.1", s.port, {framing: {type: "length", size: 1}});
+	tcp_lekho(c, "` + strings.Repeat("y", 300) + `")
+	`)
+	testErrorObject(t, result, "do not fit a 1-byte length prefix", 0)
+}
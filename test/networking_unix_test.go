@@ -0,0 +1,188 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestUnixStreamSocket tests unix_server_chalu/unix_jukto with the tcp_* connection builtins,
+// and that a stale socket file does not block a restart
+func TestUnixStreamSocket(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	// a socket file nobody listens on, as left by a crashed process
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	input := `
+	dhoro s = unix_server_chalu("` + path + `", kaj(conn) {
+		tcp_lekho(conn, "echo " + conn.data);
+	}, {framing: "line"});
+	dhoro conn = opekha unix_jukto(s.address, {framing: "line"});
+	tcp_lekho(conn, "hi");
+	dhoro reply = opekha tcp_shuno(conn);
+	tcp_bondho(conn);
+	opekha s.bondho(1000);
+	[reply, lipi(conn.path == s.address), lipi(s.port)]
+	`
+	testStringArray(t, evalServer(input), []string{"echo hi", "true", "0"})
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket file to be removed on close, got %v", err)
+	}
+}
+
+// TestUnixDatagramSocket tests unixgram_server_chalu, unixgram_pathao and udp_uttor replies
+func TestUnixDatagramSocket(t *testing.T) {
+	dir := t.TempDir()
+	serverPath := filepath.Join(dir, "server.sock")
+	clientPath := filepath.Join(dir, "client.sock")
+
+	client, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: clientPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	result := evalServer(`
+	dhoro s = unixgram_server_chalu("` + serverPath + `", kaj(packet) {
+		jodi (packet.remote_addr != "") {
+			udp_uttor(packet, "ack " + packet.data);
+		}
+	});
+	opekha unixgram_pathao("` + serverPath + `", "one");
+	s
+	`)
+	server, ok := result.(*object.Map)
+	if !ok {
+		t.Fatalf("server did not start: %s", result.Inspect())
+	}
+	closeServer := server.Pairs["bondho"].(*object.Builtin).Fn
+	defer closeServer()
+
+	client.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := client.WriteToUnix([]byte("two"), &net.UnixAddr{Name: serverPath, Net: "unixgram"}); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 64)
+	n, _, err := client.ReadFromUnix(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf[:n]) != "ack two" {
+		t.Errorf("got reply %q", buf[:n])
+	}
+
+	closeServer()
+	if _, err := os.Stat(serverPath); !os.IsNotExist(err) {
+		t.Errorf("expected bondho() to remove the socket file, got %v", err)
+	}
+}
+
+// TestUDPServerHandleAndIPv6 tests that udp_server_chalu returns a handle, binds IPv6 hosts,
+// and that udp_pathao accepts bracketed IPv6 literals
+func TestUDPServerHandleAndIPv6(t *testing.T) {
+	if ln, err := net.ListenPacket("udp6", "[::1]:0"); err != nil {
+		t.Skip("IPv6 loopback not available")
+	} else {
+		ln.Close()
+	}
+
+	received := make(chan string, 1)
+	builtins.Builtins["test_udp_received"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			received <- args[0].Inspect()
+			return object.NULL
+		},
+	}
+	defer delete(builtins.Builtins, "test_udp_received")
+
+	result := evalServer(`
+	dhoro s = udp_server_chalu(0, kaj(packet) {
+		test_udp_received(packet.data + " from " + thikana_poro(packet.remote_addr).family);
+	}, {host: "::1"});
+	opekha udp_pathao("[::1]", s.port, "ping");
+	s
+	`)
+	server, ok := result.(*object.Map)
+	if !ok {
+		t.Fatalf("server did not start: %s", result.Inspect())
+	}
+	defer server.Pairs["bondho"].(*object.Builtin).Fn()
+
+	select {
+	case got := <-received:
+		if got != "ping from ipv6" {
+			t.Errorf("got %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no packet received")
+	}
+	if !strings.HasPrefix(server.Pairs["address"].Inspect(), "[::1]:") {
+		t.Errorf("address = %s", server.Pairs["address"].Inspect())
+	}
+}
+
+// TestUDPMulticastAndBroadcast tests joining and leaving groups and the broadcast options
+func TestUDPMulticastAndBroadcast(t *testing.T) {
+	result := evalServer(`
+	dhoro s = udp_server_chalu(0, kaj(packet) {}, {multicast: "239.77.0.1", broadcast: sotti});
+	udp_multicast_jog(s, "239.77.0.2");
+	udp_multicast_chharo(s, "239.77.0.2");
+	udp_multicast_chharo(s, "239.77.0.1");
+	opekha udp_pathao("127.255.255.255", s.port, "hello", {broadcast: sotti});
+	opekha s.bondho();
+	s.port > 0
+	`)
+	if errObj, ok := result.(*object.Error); ok && strings.Contains(errObj.Message, "multicast group") {
+		t.Skipf("multicast not available: %s", errObj.Message)
+	}
+	testBooleanObject(t, result, true)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`udp_server_chalu(0, kaj(p) {}, {multicast: "10.0.0.1"})`, "is not a multicast group address"},
+		{`udp_server_chalu(0, kaj(p) {}, {interface: "no-such-if0"})`, "unknown interface"},
+		{`udp_multicast_jog({id: "udp_conn_0"}, "239.77.0.3")`, "UDP server not found"},
+		{`udp_pathao("127.0.0.1", 1, "x", "broadcast")`, "options to `udp_pathao` must be MAP"},
+	}
+	for i, tt := range tests {
+		testErrorObject(t, evalServer(tt.input), tt.expected, i)
+	}
+}
+
+// TestThikanaPoro tests address parsing for IPv4, IPv6, host names and Unix paths
+func TestThikanaPoro(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`"127.0.0.1:8080"`, []string{"ipv4", "127.0.0.1", "8080"}},
+		{`"[::1]:443"`, []string{"ipv6", "::1", "443"}},
+		{`"fe80::1%eth0"`, []string{"ipv6", "fe80::1", "khali"}},
+		{`"example.com:80"`, []string{"hostname", "example.com", "80"}},
+		{`"[2001:db8::2]"`, []string{"ipv6", "2001:db8::2", "khali"}},
+	}
+	for _, tt := range tests {
+		result := evalServer(`dhoro a = thikana_poro(` + tt.input + `); [a.family, a.host, lipi(a.port)]`)
+		testStringArray(t, result, tt.expected)
+	}
+
+	testStringArray(t, evalServer(`dhoro a = thikana_poro("/run/app.sock"); [a.family, a.path]`),
+		[]string{"unix", "/run/app.sock"})
+	testErrorObject(t, evalServer(`thikana_poro("host:99999")`), "invalid port", 0)
+	testErrorObject(t, evalServer(`thikana_poro(":80")`), "has no host", 0)
+}
@@ -0,0 +1,313 @@
+package test
+
+import (
+	"BanglaCode/src/object"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testTypeErrorException checks that obj is a thrown TypeError whose message contains substr
+func testTypeErrorException(t *testing.T, obj object.Object, substr string, testNum int) bool {
+	t.Helper()
+	exc, ok := obj.(*object.Exception)
+	if !ok {
+		t.Errorf("test[%d]: expected a thrown TypeError, got %T (%+v)", testNum, obj, obj)
+		return false
+	}
+	if !strings.HasPrefix(exc.Message, "TypeError: ") || !strings.Contains(exc.Message, substr) {
+		t.Errorf("test[%d]: expected TypeError containing %q, got %q", testNum, substr, exc.Message)
+		return false
+	}
+	return true
+}
+
+// TestMapLiteralAccessors tests pao/set accessors on map literals
+func TestMapLiteralAccessors(t *testing.T) {
+	input := `
+	dhoro temp = {
+		celsius: 20,
+		pao fahrenheit() { ferao ei.celsius * 9 / 5 + 32; },
+		set fahrenheit(f) { ei.celsius = (f - 32) * 5 / 9; }
+	};
+	dhoro before = temp.fahrenheit;
+	temp.fahrenheit = 212;
+	[before, temp.celsius, temp["fahrenheit"]]
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{68, 100, 212}, 0)
+}
+
+// TestGetterOnlyAccessor tests that assigning a getter-only property is a TypeError
+func TestGetterOnlyAccessor(t *testing.T) {
+	input := `
+	dhoro obj = {pao naam() { ferao "Ankan"; }};
+	obj.naam = "Rahim";
+	`
+
+	testTypeErrorException(t, testEval(input), "only a getter", 0)
+}
+
+// TestAccessorsInEnumeration tests that accessors show up in chabi, maan and JSON
+func TestAccessorsInEnumeration(t *testing.T) {
+	input := `
+	dhoro user = {first: "A", pao full() { ferao ei.first + "B"; }};
+	[dorghyo(chabi(user)), maan(user)[0] + maan(user)[1], json_banao(user)]
+	`
+
+	result := testEval(input)
+	arr, ok := result.(*object.Array)
+	if !ok || len(arr.Elements) != 3 {
+		t.Fatalf("expected 3-element array, got %s", result.Inspect())
+	}
+	testNumberObject(t, arr.Elements[0], 2)
+	values := arr.Elements[1].(*object.String).Value
+	if values != "AAB" && values != "ABA" {
+		t.Errorf("expected getter value in maan, got %q", values)
+	}
+	jsonStr := arr.Elements[2].(*object.String).Value
+	if jsonStr != `{"first":"A","full":"AB"}` {
+		t.Errorf("unexpected JSON %q", jsonStr)
+	}
+}
+
+// TestDefinePropertyReadOnly tests non-writable and non-enumerable properties
+func TestDefinePropertyReadOnly(t *testing.T) {
+	input := `
+	dhoro obj = {naam: "x"};
+	boishishto_nirdharon(obj, "id", {value: 7});
+	[obj.id, dorghyo(chabi(obj)), nijer_ache(obj, "id")]
+	`
+
+	result := testEval(input)
+	arr := result.(*object.Array)
+	testNumberObject(t, arr.Elements[0], 7)
+	testNumberObject(t, arr.Elements[1], 1)
+	testBooleanObject(t, arr.Elements[2], true)
+
+	input = `
+	dhoro obj = {};
+	boishishto_nirdharon(obj, "id", {value: 7, enumerable: sotti});
+	obj.id = 8;
+	`
+	testTypeErrorException(t, testEval(input), "read-only property 'id'", 0)
+
+	input = `
+	dhoro obj = {};
+	boishishto_nirdharon(obj, "id", {value: 7});
+	boishishto_nirdharon(obj, "id", {value: 8});
+	`
+	testTypeErrorException(t, testEval(input), "cannot redefine property 'id'", 0)
+}
+
+// TestDefinePropertyAccessor tests defining getters via descriptors
+func TestDefinePropertyAccessor(t *testing.T) {
+	input := `
+	dhoro counter = {hits: 0};
+	boishishto_nirdharon(counter, "next", {pao: kaj() { ei.hits = ei.hits + 1; ferao ei.hits; }, enumerable: sotti});
+	counter.next;
+	counter.next
+	`
+
+	testNumberObject(t, testEval(input), 2)
+}
+
+// TestGetOwnPropertyDescriptor tests reading descriptors back
+func TestGetOwnPropertyDescriptor(t *testing.T) {
+	input := `
+	dhoro obj = {a: 1};
+	dhoro d = boishishto_bornona(obj, "a");
+	[d.value, d.writable, d.enumerable, d.configurable, boishishto_bornona(obj, "nai")]
+	`
+
+	result := testEval(input)
+	arr := result.(*object.Array)
+	testNumberObject(t, arr.Elements[0], 1)
+	testBooleanObject(t, arr.Elements[1], true)
+	testBooleanObject(t, arr.Elements[2], true)
+	testBooleanObject(t, arr.Elements[3], true)
+	testNullObject(t, arr.Elements[4])
+}
+
+// TestFreezeEnforced tests that joma rejects writes, additions and deletes
+func TestFreezeEnforced(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dhoro o = joma({a: 1}); o.a = 2;`, "read-only property 'a'"},
+		{`dhoro o = joma({a: 1}); o.b = 2;`, "not extensible"},
+		{`dhoro o = joma({a: 1}); delete o.a;`, "cannot delete property 'a'"},
+		{`dhoro o = joma({a: 1}); mishra(o, {a: 5});`, "read-only property 'a'"},
+	}
+
+	for i, tt := range tests {
+		testTypeErrorException(t, testEval(tt.input), tt.expected, i)
+	}
+
+	testBooleanObject(t, testEval(`joma_ki(joma({}))`), true)
+	testBooleanObject(t, testEval(`joma_ki({})`), false)
+}
+
+// TestSealEnforced tests that mohor allows writes but not additions or deletes
+func TestSealEnforced(t *testing.T) {
+	input := `
+	dhoro o = mohor({a: 1});
+	o.a = 2;
+	[o.a, mohor_ki(o), joma_ki(o)]
+	`
+	result := testEval(input)
+	arr := result.(*object.Array)
+	testNumberObject(t, arr.Elements[0], 2)
+	testBooleanObject(t, arr.Elements[1], true)
+	testBooleanObject(t, arr.Elements[2], false)
+
+	testTypeErrorException(t, testEval(`dhoro o = mohor({a: 1}); o.b = 1;`), "not extensible", 0)
+	testTypeErrorException(t, testEval(`dhoro o = mohor({a: 1}); delete o["a"];`), "cannot delete", 0)
+}
+
+// TestSymbolKeys tests that symbols are unique, hidden from enumeration and usable with 'in'
+func TestSymbolKeys(t *testing.T) {
+	input := `
+	dhoro a = chihno("id");
+	dhoro b = chihno("id");
+	dhoro obj = {naam: "x", [a]: 1};
+	obj[b] = 2;
+	[obj[a], obj[b], dorghyo(chabi(obj)), dorghyo(chihno_chabi(obj)), a == b, a == a, a in obj]
+	`
+
+	result := testEval(input)
+	arr, ok := result.(*object.Array)
+	if !ok || len(arr.Elements) != 7 {
+		t.Fatalf("expected 7-element array, got %s", result.Inspect())
+	}
+	testNumberObject(t, arr.Elements[0], 1)
+	testNumberObject(t, arr.Elements[1], 2)
+	testNumberObject(t, arr.Elements[2], 1)
+	testNumberObject(t, arr.Elements[3], 2)
+	testBooleanObject(t, arr.Elements[4], false)
+	testBooleanObject(t, arr.Elements[5], true)
+	testBooleanObject(t, arr.Elements[6], true)
+
+	testStringObject(t, testEval(`chihno("token").description`), "token")
+}
+
+// TestSymbolIteratorProtocol tests for-of over maps implementing [CHIHNO_ITERATOR]
+func TestSymbolIteratorProtocol(t *testing.T) {
+	input := `
+	dhoro range = {
+		from: 1,
+		to: 4,
+		[CHIHNO_ITERATOR]: kaj() {
+			dhoro current = ei.from;
+			dhoro last = ei.to;
+			ferao {next: kaj() {
+				jodi (current > last) { ferao {done: sotti}; }
+				current = current + 1;
+				ferao {value: current - 1, done: mittha};
+			}};
+		}
+	};
+	dhoro total = 0;
+	ghuriye (n of range) {
+		total = total + n;
+	}
+	total
+	`
+	testNumberObject(t, evalOOPInput(input), 10)
+
+	input = `
+	dhoro bag = {
+		base: 3,
+		[CHIHNO_ITERATOR]: kaj*() {
+			utpadan (ei.base * 2);
+			utpadan (ei.base * 2 + 2);
+			utpadan (ei.base * 2 + 4);
+		}
+	};
+	dhoro out = [];
+	ghuriye (v of bag) {
+		jodi (v > 8) { thamo; }
+		dhokao(out, v);
+	}
+	out
+	`
+	testArrayObject(t, evalOOPInput(input), []float64{6, 8}, 0)
+}
+
+// TestFrozenWriteStopsScript tests that a rejected write ends the block it is in and can
+// be caught with chesta/dhoro_bhul like any thrown TypeError
+func TestFrozenWriteStopsScript(t *testing.T) {
+	input := `
+	dhoro fr = joma({a: 1});
+	jodi (sotti) { fr.a = 3; }
+	"continued"
+	`
+	testTypeErrorException(t, testEval(input), "read-only property 'a'", 0)
+
+	input = `
+	dhoro fr = joma({a: 1});
+	kaj update() { fr.a = 3; ferao "updated"; }
+	update();
+	"continued"
+	`
+	testTypeErrorException(t, testEval(input), "read-only property 'a'", 1)
+
+	// a script's own uncaught felo keeps its old behaviour and does not end the program
+	testStringObject(t, testEval(`felo TypeError("mine"); "continued"`), "continued")
+
+	input = `
+	dhoro fr = mohor({a: 1});
+	dhoro log = [];
+	chesta {
+		jodi (sotti) { fr.b = 1; dhokao(log, "continued"); }
+	} dhoro_bhul (e) {
+		dhokao(log, e.name);
+		dhokao(log, e.message);
+	}
+	dhokao(log, "end");
+	log
+	`
+	result := testEval(input)
+	arr, ok := result.(*object.Array)
+	if !ok || len(arr.Elements) != 3 {
+		t.Fatalf("expected 3-element log, got %s", result.Inspect())
+	}
+	if name := arr.Elements[0].Inspect(); name != "TypeError" {
+		t.Errorf("expected e.name TypeError, got %q", name)
+	}
+	if msg := arr.Elements[1].Inspect(); !strings.Contains(msg, "not extensible") {
+		t.Errorf("unexpected e.message %q", msg)
+	}
+	if end := arr.Elements[2].Inspect(); end != "end" {
+		t.Errorf("expected the script to continue after dhoro_bhul, got %q", end)
+	}
+}
+
+// TestFrozenWriteExitStatus tests that an uncaught TypeError makes the interpreter exit 1
+func TestFrozenWriteExitStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the interpreter")
+	}
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "banglacode")
+	if out, err := exec.Command("go", "build", "-o", binary, "..").CombinedOutput(); err != nil {
+		t.Fatalf("build failed: %v: %s", err, out)
+	}
+	script := filepath.Join(dir, "frozen.bang")
+	src := `dhoro fr = joma({a: 1}); fr.a = 3; dekho("after");`
+	if err := os.WriteFile(script, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(binary, script).CombinedOutput()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit status 1, got %v: %s", err, out)
+	}
+	if strings.Contains(string(out), "after") || !strings.Contains(string(out), "read-only property 'a'") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
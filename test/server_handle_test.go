@@ -0,0 +1,148 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/object"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// evaluatorCallback is the evaluator's builtin callback, captured before the networking
+// tests replace builtins.EvalFunc with a version that ignores arguments
+var evaluatorCallback = builtins.EvalFunc
+
+// evalServer evaluates input with the evaluator's callback installed. EvalFunc is only
+// written when a networking test replaced it, never while it is already right, since
+// servers started by earlier tests may still be reading it.
+func evalServer(input string) object.Object {
+	if reflect.ValueOf(builtins.EvalFunc).Pointer() != reflect.ValueOf(evaluatorCallback).Pointer() {
+		builtins.EvalFunc = evaluatorCallback
+	}
+	return testEval(input)
+}
+
+// TestServerChaluReturnsHandle tests that server_chalu returns immediately with the bound port
+func TestServerChaluReturnsHandle(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	input := `
+	dhoro a = server_chalu(0, kaj(req, res) { res.body = "a"; });
+	dhoro b = server_chalu(0, kaj(req, res) { res.body = "b"; });
+	dhoro first = anun("http://127.0.0.1:" + lipi(a.port) + "/").body;
+	dhoro second = anun("http://127.0.0.1:" + lipi(b.port) + "/").body;
+	dhoro closed = opekha a.bondho(1000);
+	opekha b.bondho(1000);
+	[first, second, lipi(closed), lipi(a.port != b.port ebong a.port > 0)]
+	`
+
+	testStringArray(t, evalServer(input), []string{"a", "b", "true", "true"})
+}
+
+// TestServerCloseDrainsInFlightRequests tests that bondho waits for running handlers
+func TestServerCloseDrainsInFlightRequests(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	input := `
+	dhoro s = server_chalu(0, kaj(req, res) {
+		opekha ghumaao(200);
+		res.body = "slow";
+	});
+	dhoro pending = anun_async("http://127.0.0.1:" + lipi(s.port) + "/");
+	opekha ghumaao(50);
+	dhoro drained = opekha s.bondho(2000);
+	dhoro res = opekha pending;
+	[lipi(drained), res.body]
+	`
+
+	testStringArray(t, evalServer(input), []string{"true", "slow"})
+}
+
+// TestServerCloseDeadline tests that bondho force-closes once the deadline passes
+func TestServerCloseDeadline(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	input := `
+	dhoro s = server_chalu(0, kaj(req, res) {
+		opekha ghumaao(1000);
+		res.body = "late";
+	});
+	anun_async("http://127.0.0.1:" + lipi(s.port) + "/");
+	opekha ghumaao(50);
+	opekha s.bondho(50)
+	`
+
+	testBooleanObject(t, evalServer(input), false)
+}
+
+// TestServerClosedStopsAccepting tests that closed TCP and WebSocket servers release their ports
+func TestServerClosedStopsAccepting(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	for _, starter := range []string{"tcp_server_chalu", "websocket_server_chalu"} {
+		result := evalServer(`
+		dhoro s = ` + starter + `(0, kaj(conn) {});
+		opekha s.bondho(100);
+		s.port
+		`)
+		port, ok := result.(*object.Number)
+		if !ok || port.Value <= 0 {
+			t.Fatalf("%s: expected bound port, got %s", starter, result.Inspect())
+		}
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", int(port.Value)), 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			t.Errorf("%s: expected connection to closed server on port %d to fail", starter, int(port.Value))
+		}
+	}
+}
+
+// TestTCPServerCloseLetsHandlerReply tests that a TCP handler running during bondho can still reply
+func TestTCPServerCloseLetsHandlerReply(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+
+	input := `
+	dhoro s = tcp_server_chalu(0, kaj(conn) {
+		opekha ghumaao(100);
+		tcp_lekho(conn, "bye");
+	});
+	dhoro client = opekha tcp_jukto("127.0.0.1", s.port);
+	tcp_lekho(client, "hi");
+	opekha ghumaao(30);
+	dhoro closing = s.bondho(1000);
+	dhoro reply = opekha tcp_shuno(client);
+	[reply, lipi(opekha closing)]
+	`
+
+	testStringArray(t, evalServer(input), []string{"bye", "true"})
+}
+
+// TestServerPortInUse tests that bind errors are reported synchronously
+func TestServerPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	result := evalServer(fmt.Sprintf(`server_chalu(%d, kaj(req, res) {})`, port))
+	testErrorObject(t, result, "server error", 0)
+}
+
+// TestProcessSignalHook tests running a script handler when the process receives a signal
+func TestProcessSignalHook(t *testing.T) {
+	input := `
+	bishwo received = "";
+	process_signal("SIGHUP", kaj(sig) { received = sig; });
+	process_signal(process_id(), 1);
+	opekha ghumaao(100);
+	received
+	`
+	testStringObject(t, evalServer(input), "SIGHUP")
+
+	testErrorObject(t, evalServer(`process_signal("SIGNOPE", kaj(s) {})`), "unknown signal", 0)
+	testErrorObject(t, evalServer(`process_signal("SIGTERM", 5)`), "must be FUNCTION", 0)
+}
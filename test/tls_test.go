@@ -0,0 +1,197 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPKI holds PEM files for a throwaway CA, a server cert for 127.0.0.1 and a client cert
+type testPKI struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+	serverCertPEM, serverKeyPEM                          string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "BanglaCode Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage) (string, string) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, _ := x509.MarshalECPrivateKey(key)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	}
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	pki := &testPKI{}
+	pki.caFile = write("ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})))
+	pki.serverCertPEM, pki.serverKeyPEM = issue(2, "localhost", x509.ExtKeyUsageServerAuth)
+	pki.serverCert = write("server.pem", pki.serverCertPEM)
+	pki.serverKey = write("server-key.pem", pki.serverKeyPEM)
+	clientCert, clientKey := issue(3, "ankan-client", x509.ExtKeyUsageClientAuth)
+	pki.clientCert = write("client.pem", clientCert)
+	pki.clientKey = write("client-key.pem", clientKey)
+	return pki
+}
+
+// expand fills {{NAME}} placeholders in a script with PKI paths and PEM contents
+func (p *testPKI) expand(input string) string {
+	return strings.NewReplacer(
+		"{{CA}}", p.caFile,
+		"{{CERT}}", p.serverCert,
+		"{{KEY}}", p.serverKey,
+		"{{CLIENT_CERT}}", p.clientCert,
+		"{{CLIENT_KEY}}", p.clientKey,
+		"{{CERT_PEM}}", p.serverCertPEM,
+		"{{KEY_PEM}}", p.serverKeyPEM,
+	).Replace(input)
+}
+
+// TestHTTPSServerAndClient tests HTTPS with a private CA, untrusted certs and insecure mode
+func TestHTTPSServerAndClient(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	pki := newTestPKI(t)
+
+	input := pki.expand(`
+	dhoro s = server_chalu(0, kaj(req, res) { res.body = "secure " + req.tls.version; },
+		{tls: {cert: "{{CERT}}", key: "{{KEY}}"}});
+	dhoro url = "https://127.0.0.1:" + lipi(s.port) + "/";
+	dhoro trusted = anun(url, {tls: {ca: "{{CA}}"}}).body;
+	dhoro skipped = (opekha anun_async(url, {tls: {insecure: sotti}})).body;
+	[trusted, skipped]
+	`)
+	testStringArray(t, evalServer(input), []string{"secure 1.3", "secure 1.3"})
+
+	untrusted := pki.expand(`
+	dhoro s = server_chalu(0, kaj(req, res) {}, {tls: {cert: "{{CERT}}", key: "{{KEY}}"}});
+	anun("https://127.0.0.1:" + lipi(s.port) + "/")
+	`)
+	testErrorObject(t, evalServer(untrusted), "certificate signed by unknown authority", 0)
+}
+
+// TestHTTPSInlinePEMAndMinVersion tests PEM strings instead of files and min_version checks
+func TestHTTPSInlinePEMAndMinVersion(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	pki := newTestPKI(t)
+
+	input := pki.expand("dhoro s = server_chalu(0, kaj(req, res) { res.body = req.tls.version; }," +
+		" {tls: {cert: `{{CERT_PEM}}`, key: `{{KEY_PEM}}`, min_version: \"1.3\"}});" + `
+	dhoro url = "https://127.0.0.1:" + lipi(s.port) + "/";
+	dhoro ok = anun(url, {tls: {ca: "{{CA}}"}}).body;
+	ok
+	`)
+
+	testStringObject(t, evalServer(input), "1.3")
+}
+
+// TestHTTPSClientCertificates tests mutual TLS with client_ca on the server
+func TestHTTPSClientCertificates(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	pki := newTestPKI(t)
+
+	input := pki.expand(`
+	dhoro s = server_chalu(0, kaj(req, res) { res.body = "hello " + req.tls.client_cert.common_name; },
+		{tls: {cert: "{{CERT}}", key: "{{KEY}}", client_ca: "{{CA}}"}});
+	dhoro url = "https://127.0.0.1:" + lipi(s.port) + "/";
+	anun(url, {tls: {ca: "{{CA}}", cert: "{{CLIENT_CERT}}", key: "{{CLIENT_KEY}}"}}).body
+	`)
+	testStringObject(t, evalServer(input), "hello ankan-client")
+
+	withoutCert := pki.expand(`
+	dhoro s = server_chalu(0, kaj(req, res) {}, {tls: {cert: "{{CERT}}", key: "{{KEY}}", client_ca: "{{CA}}"}});
+	anun("https://127.0.0.1:" + lipi(s.port) + "/", {tls: {ca: "{{CA}}"}})
+	`)
+	testErrorObject(t, evalServer(withoutCert), "certificate required", 0)
+}
+
+// TestTLSOverTCPAndWebSocket tests tls options on tcp_server_chalu/tcp_jukto and wss:// servers
+func TestTLSOverTCPAndWebSocket(t *testing.T) {
+	defer builtins.CloseAllServers(time.Second)
+	pki := newTestPKI(t)
+
+	input := pki.expand(`
+	dhoro opts = {tls: {cert: "{{CERT}}", key: "{{KEY}}"}};
+	dhoro tcp = tcp_server_chalu(0, kaj(conn) { tcp_lekho(conn, "echo " + conn.data); }, opts);
+	dhoro client = opekha tcp_jukto("127.0.0.1", tcp.port, {tls: {ca: "{{CA}}"}});
+	tcp_lekho(client, "hi");
+	dhoro reply = opekha tcp_shuno(client);
+
+	dhoro ws = websocket_server_chalu(0, kaj(conn) {}, opts);
+	dhoro wsClient = opekha websocket_jukto("wss://127.0.0.1:" + lipi(ws.port) + "/", {tls: {ca: "{{CA}}"}});
+	dhoro connected = wsClient.connected;
+	tcp_bondho(client);
+	websocket_bondho(wsClient);
+	[reply, lipi(connected)]
+	`)
+
+	testStringArray(t, evalServer(input), []string{"echo hi", "true"})
+}
+
+// TestTLSOptionErrors tests validation of tls option maps
+func TestTLSOptionErrors(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`server_chalu(0, kaj(req, res) {}, {tls: {cert: "{{CERT}}"}})`, "needs both `cert` and `key`"},
+		{`server_chalu(0, kaj(req, res) {}, {tls: "yes"})`, "`tls` option to `server_chalu` must be MAP"},
+		{`tcp_server_chalu(0, kaj(c) {}, {tls: {cert: "{{CERT}}", key: "{{KEY}}", min_version: "2.0"}})`, "unknown tls min_version"},
+		{`websocket_server_chalu(0, kaj(c) {}, {tls: {cert: "{{CERT}}", key: "{{KEY}}", client_auth: "maybe"}})`, "unknown client_auth"},
+		{`tcp_jukto("127.0.0.1", 1, {tls: {ca: "/no/such/ca.pem"}})`, "cannot read tls ca"},
+		{`websocket_jukto("wss://127.0.0.1:1/", {tls: {cert: "{{CLIENT_CERT}}"}})`, "needs both `cert` and `key`"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, evalServer(pki.expand(tt.input)), tt.expected, 0)
+	}
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"BanglaCode/src/checker"
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"strings"
+	"testing"
+)
+
+func checkInput(t *testing.T, input string) []checker.Diagnostic {
+	t.Helper()
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	return checker.Check(program)
+}
+
+func expectDiagnostic(t *testing.T, diags []checker.Diagnostic, line int, contains string) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Line == line && strings.Contains(d.Message, contains) {
+			return
+		}
+	}
+	t.Errorf("expected diagnostic on line %d containing %q, got %v", line, contains, diags)
+}
+
+// TestTypeAnnotationParsing tests annotations on dhoro, parameters, returns and class fields
+func TestTypeAnnotationParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dhoro x: number = 5;`, "dhoro x: number = 5;"},
+		{`dhoro xs: (string | khali)[] = [];`, "dhoro xs: (string | khali)[] = [];"},
+		{`kaj add(a: number, b: number): number { ferao a + b; }`, "kaj add(a: number, b: number): number "},
+		{`kaj sum(...nums: number[]): number { ferao 0; }`, "kaj sum(...nums: number[]): number "},
+		{`sreni P { naam: string; }`, "naam: string;"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			t.Fatalf("parser errors for %q: %v", tt.input, p.Errors())
+		}
+		if got := program.String(); !strings.Contains(got, tt.expected) {
+			t.Errorf("expected %q in %q", tt.expected, got)
+		}
+	}
+}
+
+// TestTypeAnnotationsIgnoredAtRuntime tests that the evaluator runs annotated code unchanged
+func TestTypeAnnotationsIgnoredAtRuntime(t *testing.T) {
+	input := `
+	sreni Counter {
+		count: number;
+		shuru(start: number) { ei.count = start; }
+		kaj next(): number { ei.count = ei.count + 1; ferao ei.count; }
+	}
+	kaj twice(n: number): number { ferao n * 2; }
+	dhoro c: Counter = notun Counter(1);
+	dhoro total: number = twice(c.next());
+	total
+	`
+
+	testNumberObject(t, testEval(input), 4)
+}
+
+// TestTypeCheckerMismatches tests that annotated mismatches are reported with positions
+func TestTypeCheckerMismatches(t *testing.T) {
+	input := `dhoro x: number = "hi";
+kaj add(a: number, b: number): number { ferao a + b; }
+add(1, "2");
+add(1);
+kaj naam(): string { ferao 5; }
+dhoro y: Foo = 1;
+dhoro z = 1 - "a";
+dhoro names: string[] = ["a"];
+names = [1];`
+
+	diags := checkInput(t, input)
+	expectDiagnostic(t, diags, 1, "cannot assign string to 'x' of type number")
+	expectDiagnostic(t, diags, 3, "argument 2 to 'add' must be number, got string")
+	expectDiagnostic(t, diags, 4, "expects 2 argument(s) but got 1")
+	expectDiagnostic(t, diags, 5, "cannot return number from a function declared to return string")
+	expectDiagnostic(t, diags, 6, "unknown type 'Foo'")
+	expectDiagnostic(t, diags, 7, "operator '-' cannot be applied to number and string")
+	expectDiagnostic(t, diags, 9, "cannot assign number[] to 'names' of type string[]")
+	if len(diags) != 7 {
+		t.Errorf("expected 7 diagnostics, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Column != 19 {
+		t.Errorf("expected column 19 for first diagnostic, got %d", diags[0].Column)
+	}
+}
+
+// TestTypeCheckerClasses tests constructor arguments, field assignments and method results
+func TestTypeCheckerClasses(t *testing.T) {
+	input := `sreni Person {
+	naam: string;
+	shuru(naam: string) { ei.naam = naam; }
+	kaj boyosh(): number { ferao 30; }
+}
+dhoro p = notun Person(4);
+p.naam = 4;
+dhoro s: string = p.boyosh();
+dhoro ok: Person = notun Person("Ankan");`
+
+	diags := checkInput(t, input)
+	expectDiagnostic(t, diags, 6, "argument 1 to 'Person' must be string, got number")
+	expectDiagnostic(t, diags, 7, "cannot assign number to field 'Person.naam' of type string")
+	expectDiagnostic(t, diags, 8, "cannot assign number to 's' of type string")
+	if len(diags) != 3 {
+		t.Errorf("expected 3 diagnostics, got %v", diags)
+	}
+}
+
+// TestTypeCheckerBuiltinStubs tests builtin calls against the declaration stubs
+func TestTypeCheckerBuiltinStubs(t *testing.T) {
+	input := `dorghyo(1, 2);
+anun(5);
+dhoro rows: number = db_query_postgres(khali, 42);
+dhoro res = anun("https://example.com", {method: "GET"});
+dhoro n: number = dorghyo("abc");`
+
+	diags := checkInput(t, input)
+	expectDiagnostic(t, diags, 1, "function 'dorghyo' expects 1 argument(s) but got 2")
+	expectDiagnostic(t, diags, 2, "no signature of 'anun' accepts (number)")
+	expectDiagnostic(t, diags, 3, "argument 2 to 'db_query_postgres' must be string, got number")
+	if len(diags) != 3 {
+		t.Errorf("expected 3 diagnostics, got %v", diags)
+	}
+}
+
+// TestTypeCheckerAsync tests that opekha unwraps the result of an annotated proyash kaj
+func TestTypeCheckerAsync(t *testing.T) {
+	input := `proyash kaj load(): number { ferao 1; }
+proyash kaj main() {
+	dhoro n: number = opekha load();
+	dhoro s: string = opekha load();
+}`
+
+	diags := checkInput(t, input)
+	expectDiagnostic(t, diags, 4, "cannot assign number to 's' of type string")
+	if len(diags) != 1 {
+		t.Errorf("expected 1 diagnostic, got %v", diags)
+	}
+}
+
+// TestTypeCheckerUntypedCode tests that unannotated programs check cleanly
+func TestTypeCheckerUntypedCode(t *testing.T) {
+	input := `dhoro total = 0;
+dhoro items = [1, "two", {three: 3}];
+ghuriye (dhoro i = 0; i < dorghyo(items); i = i + 1) {
+	total = total + i;
+}
+kaj make(kind) {
+	jodi (kind == "a") { ferao {name: kind}; }
+	ferao khali;
+}
+dhoro label = "count: " + total;
+dhoro later = khali;
+later = make("a").name;
+sreni Box { shuru(v) { ei.v = v; } }
+dhoro b = notun Box(1);
+b.v = "anything";`
+
+	if diags := checkInput(t, input); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for untyped code, got %v", diags)
+	}
+}
+
+// TestBuiltinStubsExist tests that every declaration stub names a registered builtin
+func TestBuiltinStubsExist(t *testing.T) {
+	for name := range checker.BuiltinStubs() {
+		if _, ok := builtins.Builtins[name]; !ok {
+			t.Errorf("stub %q does not match any builtin", name)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package test
+
+import (
+	"BanglaCode/src/object"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTypedArrayFromLength tests creating zeroed typed arrays
+func TestTypedArrayFromLength(t *testing.T) {
+	input := `
+	dhoro arr = typed_array_banao("Uint16", 4);
+	arr.length + arr.byteLength
+	`
+
+	result := testEval(input)
+	testNumberObject(t, result, 12)
+}
+
+// TestTypedArrayFromArray tests creating typed arrays from number arrays
+func TestTypedArrayFromArray(t *testing.T) {
+	input := `
+	dhoro arr = typed_array_banao("Float32", [1.5, 2.5, 3]);
+	typed_array_list(arr)
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{1.5, 2.5, 3}, 0)
+}
+
+// TestTypedArrayWrapping tests integer wrap-around and clamping on write
+func TestTypedArrayWrapping(t *testing.T) {
+	tests := []struct {
+		kind     string
+		value    string
+		expected float64
+	}{
+		{"Uint8", "256", 0},
+		{"Uint8", "-1", 255},
+		{"Int8", "200", -56},
+		{"Uint8Clamped", "300", 255},
+		{"Uint8Clamped", "-5", 0},
+		{"Int16", "40000", -25536},
+		{"Uint32", "-1", 4294967295},
+	}
+
+	for _, tt := range tests {
+		input := `
+		dhoro arr = typed_array_banao("` + tt.kind + `", 1);
+		arr[0] = ` + tt.value + `;
+		arr[0]
+		`
+		result := testEval(input)
+		if !testNumberObject(t, result, tt.expected) {
+			t.Errorf("kind %s, value %s", tt.kind, tt.value)
+		}
+	}
+}
+
+// TestTypedArraySharesBuffer tests that typed arrays over a buffer do not copy
+func TestTypedArraySharesBuffer(t *testing.T) {
+	input := `
+	dhoro buf = buffer_banao(8);
+	dhoro words = typed_array_banao("Uint16", buf);
+	words[1] = 513;
+	[words.length, buf[2], buf[3]]
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{4, 1, 2}, 0)
+}
+
+// TestTypedArraySubarray tests zero-copy sub-views
+func TestTypedArraySubarray(t *testing.T) {
+	input := `
+	dhoro arr = typed_array_banao("Int32", [10, 20, 30, 40]);
+	dhoro mid = typed_array_angsho(arr, 1, 3);
+	mid[0] += 5;
+	[mid.length, mid.byteOffset, arr[1]]
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{2, 4, 25}, 0)
+}
+
+// TestTypedArrayForOf tests iterating typed arrays
+func TestTypedArrayForOf(t *testing.T) {
+	input := `
+	dhoro total = 0;
+	ghuriye (x of typed_array_banao("Uint8", [1, 2, 3])) {
+		total = total + x;
+	}
+	total
+	`
+
+	result := testEval(input)
+	testNumberObject(t, result, 6)
+}
+
+// TestTypedArrayMisalignedOffset tests that typed array offsets must be aligned
+func TestTypedArrayMisalignedOffset(t *testing.T) {
+	input := `typed_array_banao("Uint32", buffer_banao(8), 2);`
+
+	result := testEval(input)
+	testErrorObject(t, result, "multiple of 4", 0)
+}
+
+// TestDataViewEndianness tests big- and little-endian reads and writes
+func TestDataViewEndianness(t *testing.T) {
+	input := `
+	dhoro buf = buffer_banao(8);
+	dhoro view = dataview_banao(buf);
+	dataview_lekho(view, "Uint16", 0, 258);
+	dataview_lekho(view, "Uint16", 2, 258, sotti);
+	dataview_lekho(view, "Int32", 4, -2);
+	[buf[0], buf[1], buf[2], buf[3], dataview_poro(view, "Uint16", 2, sotti), dataview_poro(view, "Int32", 4), dataview_poro(view, "Uint8", 7)]
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{1, 2, 2, 1, 258, -2, 254}, 0)
+}
+
+// TestDataViewFloats tests float reads and writes
+func TestDataViewFloats(t *testing.T) {
+	input := `
+	dhoro view = dataview_banao(buffer_banao(12));
+	dataview_lekho(view, "Float32", 0, 1.5);
+	dataview_lekho(view, "Float64", 4, 3.25, sotti);
+	[dataview_poro(view, "Float32", 0), dataview_poro(view, "Float64", 4, sotti)]
+	`
+
+	result := testEval(input)
+	testArrayObject(t, result, []float64{1.5, 3.25}, 0)
+}
+
+// TestDataViewOffsetWindow tests that views are bounded by their window
+func TestDataViewOffsetWindow(t *testing.T) {
+	input := `
+	dhoro buf = buffer_theke([0, 0, 0, 42, 0]);
+	dhoro view = dataview_banao(buf, 3, 2);
+	dataview_poro(view, "Uint8", 0)
+	`
+	testNumberObject(t, testEval(input), 42)
+
+	input = `
+	dhoro view = dataview_banao(buffer_banao(4), 2);
+	dataview_poro(view, "Uint32", 0)
+	`
+	testErrorObject(t, testEval(input), "out of range", 0)
+}
+
+// TestBufferBase64Encodings tests base64 and base64url round trips
+func TestBufferBase64Encodings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`buffer_text(buffer_theke("Hello"), "base64")`, "SGVsbG8="},
+		{`buffer_text(buffer_theke([251, 255]), "base64url")`, "-_8"},
+		{`buffer_text(buffer_theke("SGVsbG8=", "base64"))`, "Hello"},
+		{`buffer_hex(buffer_theke("-_8", "base64url"))`, "fbff"},
+		{`buffer_text(buffer_theke("48656c6c6f", "hex"))`, "Hello"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		str, ok := result.(*object.String)
+		if !ok || str.Value != tt.expected {
+			t.Errorf("%s: expected %q, got %s", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+// TestBufferViewSharesMemory tests zero-copy buffer views
+func TestBufferViewSharesMemory(t *testing.T) {
+	input := `
+	dhoro buf = buffer_theke("Hello World");
+	dhoro view = buffer_view(buf, 6);
+	view[0] = 119;
+	buffer_text(buf)
+	`
+
+	result := testEval(input)
+	str, ok := result.(*object.String)
+	if !ok || !strings.HasSuffix(str.Value, "world") {
+		t.Errorf("Expected view write to be visible in original, got %s", result.Inspect())
+	}
+}
+
+// TestBufferViewSharesLock tests that views lock the same mutex as their parent, and that
+// copying or comparing between a buffer and its own view does not deadlock
+func TestBufferViewSharesLock(t *testing.T) {
+	result := testEval(`dhoro buf = buffer_theke("abcdef"); [buf, buffer_view(buf, 2, 4)]`)
+	arr, ok := result.(*object.Array)
+	if !ok || len(arr.Elements) != 2 {
+		t.Fatalf("expected a 2-element array, got %s", result.Inspect())
+	}
+	parent, view := arr.Elements[0].(*object.Buffer), arr.Elements[1].(*object.Buffer)
+	if parent.Mu != view.Mu {
+		t.Errorf("expected the view to share its parent's lock")
+	}
+
+	input := `
+	dhoro buf = buffer_theke("abcdef");
+	dhoro view = buffer_view(buf, 2, 4);
+	buffer_copy(buf, view);
+	buffer_copy(view, buf, 0, 4);
+	[buffer_text(buf), buffer_tulona(buf, buf)]
+	`
+	done := make(chan object.Object, 1)
+	go func() { done <- testEval(input) }()
+	select {
+	case result := <-done:
+		if result.Inspect() != "[cdefef, 0]" {
+			t.Errorf("unexpected result %s", result.Inspect())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("copying within one buffer deadlocked")
+	}
+}